@@ -2,6 +2,7 @@ package arwen
 
 import (
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/config"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto"
 	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
 )
 
@@ -122,6 +123,44 @@ type VMHostParameters struct {
 	FixOOGReturnCodeEnableEpoch               uint32
 	RemoveNonUpdatedStorageEnableEpoch        uint32
 	CreateNFTThroughExecByCallerEnableEpoch   uint32
+	ExecutionObserver                         ExecutionObserver
+	WASMModulePolicy                          *WASMModulePolicy
+	WASMBackend                               WASMBackend
+	Crypto                                    crypto.VMCrypto
+}
+
+// WASMBackend selects the engine which executes the WASM code of the contracts
+type WASMBackend string
+
+const (
+	// WASMBackendWasmer executes contracts with the native Wasmer library; it
+	// is also selected by an empty WASMBackend
+	WASMBackendWasmer WASMBackend = "wasmer"
+
+	// WASMBackendGo executes contracts with the pure-Go interpreter. Only the
+	// interpreter itself (wasmer/gowasm) is free of cgo: the host still links
+	// the native Wasmer library and registers the EI functions through cgo.
+	// Selecting this backend therefore does not yet allow building the host
+	// with CGO_ENABLED=0, linking it statically or running it under the race
+	// detector without libwasmer; that requires moving the registration of the
+	// EI functions behind the executor interface
+	WASMBackendGo WASMBackend = "go"
+)
+
+// WASMModulePolicy holds the limits enforced on the WASM module of a contract
+// when it is deployed or upgraded. A zero value disables the respective limit.
+// A nil CustomSectionsWhitelist allows any custom section, while a non-nil one
+// allows only the custom sections it names.
+type WASMModulePolicy struct {
+	MaxFunctions            uint32
+	MaxGlobals              uint32
+	MaxTableSize            uint32
+	MaxDataSegmentsSize     uint64
+	MaxInitialMemoryPages   uint32
+	MaxMemoryPages          uint32
+	MaxImports              uint32
+	ForbidFloatingPoint     bool
+	CustomSectionsWhitelist []string
 }
 
 // AsyncCallInfo contains the information required to handle the asynchronous call of another SmartContract
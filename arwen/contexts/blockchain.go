@@ -4,13 +4,23 @@ import (
 	"math/big"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/elrond-go-core/core"
 	"github.com/ElrondNetwork/elrond-go-core/data/esdt"
 	logger "github.com/ElrondNetwork/elrond-go-logger"
 	"github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/builtInFunctions"
 )
 
 var log = logger.GetOrCreate("arwen/blockchainContext")
 
+// esdtTokenKeyPrefix prefixes the storage keys under which the accounts hold
+// their ESDT tokens, and the system account holds the global token settings
+var esdtTokenKeyPrefix = []byte(core.ElrondProtectedKeyPrefix + core.ESDTKeyIdentifier)
+
+// esdtRoleKeyPrefix prefixes the storage keys under which the accounts hold
+// their local ESDT roles
+var esdtRoleKeyPrefix = []byte(core.ElrondProtectedKeyPrefix + core.ESDTRoleIdentifier + core.ESDTKeyIdentifier)
+
 type blockchainContext struct {
 	host           arwen.VMHost
 	blockChainHook vmcommon.BlockchainHook
@@ -127,6 +137,67 @@ func (context *blockchainContext) GetESDTToken(address []byte, tokenID []byte, n
 	return context.blockChainHook.GetESDTToken(address, tokenID, nonce)
 }
 
+// GetESDTLocalRoles returns the local roles the given address has for the given token
+func (context *blockchainContext) GetESDTLocalRoles(address []byte, tokenID []byte) ([][]byte, error) {
+	key := append(append([]byte{}, esdtRoleKeyPrefix...), tokenID...)
+	marshaledRoles, err := context.blockChainHook.GetStorageData(address, key)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := &esdt.ESDTRoles{}
+	err = roles.Unmarshal(marshaledRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	return roles.Roles, nil
+}
+
+// IsESDTFrozen returns whether the given token is frozen for the given address
+func (context *blockchainContext) IsESDTFrozen(address []byte, tokenID []byte) (bool, error) {
+	esdtToken, err := context.blockChainHook.GetESDTToken(address, tokenID, 0)
+	if err != nil {
+		return false, err
+	}
+
+	userMetadata := builtInFunctions.ESDTUserMetadataFromBytes(esdtToken.Properties)
+	return userMetadata.Frozen, nil
+}
+
+// IsESDTPaused returns whether the transfers of the given token are paused
+func (context *blockchainContext) IsESDTPaused(tokenID []byte) (bool, error) {
+	globalMetadata, err := context.getESDTGlobalMetadata(tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	return globalMetadata.Paused, nil
+}
+
+// IsESDTLimitedTransfer returns whether the given token can only be
+// transferred by or to the addresses holding the transfer role
+func (context *blockchainContext) IsESDTLimitedTransfer(tokenID []byte) (bool, error) {
+	globalMetadata, err := context.getESDTGlobalMetadata(tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	return globalMetadata.LimitedTransfer, nil
+}
+
+// getESDTGlobalMetadata reads the global settings of the given token, held by
+// the system account of the shard
+func (context *blockchainContext) getESDTGlobalMetadata(tokenID []byte) (builtInFunctions.ESDTGlobalMetadata, error) {
+	key := append(append([]byte{}, esdtTokenKeyPrefix...), tokenID...)
+	metadata, err := context.blockChainHook.GetStorageData(vmcommon.SystemAccountAddress, key)
+	if err != nil {
+		return builtInFunctions.ESDTGlobalMetadata{}, err
+	}
+
+	return builtInFunctions.ESDTGlobalMetadataFromBytes(metadata), nil
+}
+
 // GetCodeHash returns the code hash that is set tho the given account
 func (context *blockchainContext) GetCodeHash(address []byte) []byte {
 	account, err := context.blockChainHook.GetUserAccount(address)
@@ -274,6 +345,31 @@ func (context *blockchainContext) IsPayable(addr []byte) (bool, error) {
 	return context.blockChainHook.IsPayable(addr)
 }
 
+// GetCodeMetadata returns the code metadata of the account at the given address,
+// taking into account the code deployed or upgraded during the current execution.
+// Accounts without code have all the code metadata flags unset.
+func (context *blockchainContext) GetCodeMetadata(address []byte) ([]byte, error) {
+	outputAccount, ok := context.host.Output().GetOutputAccounts()[string(address)]
+	if ok && len(outputAccount.CodeMetadata) == arwen.CodeMetadataLen {
+		return outputAccount.CodeMetadata, nil
+	}
+
+	account, err := context.blockChainHook.GetUserAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	if arwen.IfNil(account) {
+		return nil, arwen.ErrInvalidAccount
+	}
+
+	codeMetadata := account.GetCodeMetadata()
+	if len(codeMetadata) != arwen.CodeMetadataLen {
+		return make([]byte, arwen.CodeMetadataLen), nil
+	}
+
+	return codeMetadata, nil
+}
+
 // SaveCompiledCode saves the compiled code to cache and storage.
 func (context *blockchainContext) SaveCompiledCode(codeHash []byte, code []byte) {
 	context.blockChainHook.SaveCompiledCode(codeHash, code)
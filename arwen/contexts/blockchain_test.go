@@ -1,6 +1,7 @@
 package contexts
 
 import (
+	"bytes"
 	"errors"
 	"math/big"
 	"testing"
@@ -8,7 +9,10 @@ import (
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
 	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
 	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data/esdt"
 	"github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/builtInFunctions"
 	"github.com/stretchr/testify/require"
 )
 
@@ -395,6 +399,43 @@ func TestBlockchainContext_IsPayable(t *testing.T) {
 	require.True(t, isPayable)
 }
 
+func TestBlockchainContext_GetCodeMetadata(t *testing.T) {
+	t.Parallel()
+
+	mockWorld := worldmock.NewMockWorld()
+	mockWorld.AcctMap.PutAccounts([]*worldmock.Account{
+		{Address: []byte("contract"), CodeMetadata: []byte{vmcommon.MetadataUpgradeable, vmcommon.MetadataPayable}},
+		{Address: []byte("user")},
+	})
+
+	outputContext := &contextmock.OutputContextMock{
+		OutputAccounts: make(map[string]*vmcommon.OutputAccount),
+	}
+	host := &contextmock.VMHostMock{OutputContext: outputContext}
+	blockchainContext, _ := NewBlockchainContext(host, mockWorld)
+
+	codeMetadata, err := blockchainContext.GetCodeMetadata([]byte("contract"))
+	require.Nil(t, err)
+	require.Equal(t, []byte{vmcommon.MetadataUpgradeable, vmcommon.MetadataPayable}, codeMetadata)
+
+	codeMetadata, err = blockchainContext.GetCodeMetadata([]byte("user"))
+	require.Nil(t, err)
+	require.Equal(t, []byte{0, 0}, codeMetadata)
+
+	outputContext.OutputAccounts["contract"] = &vmcommon.OutputAccount{
+		Address:      []byte("contract"),
+		CodeMetadata: []byte{vmcommon.MetadataReadable, 0},
+	}
+	codeMetadata, err = blockchainContext.GetCodeMetadata([]byte("contract"))
+	require.Nil(t, err)
+	require.Equal(t, []byte{vmcommon.MetadataReadable, 0}, codeMetadata)
+
+	mockWorld.Err = errTestError
+	codeMetadata, err = blockchainContext.GetCodeMetadata([]byte("user"))
+	require.Equal(t, errTestError, err)
+	require.Nil(t, codeMetadata)
+}
+
 func TestBlockchainContext_Getters(t *testing.T) {
 	t.Parallel()
 
@@ -440,3 +481,63 @@ func TestBlockchainContext_Getters(t *testing.T) {
 	require.Equal(t, randomSeed1[:], blockchainContext.LastRandomSeed())
 	require.Equal(t, randomSeed2[:], blockchainContext.CurrentRandomSeed())
 }
+
+func TestBlockchainContext_ESDTRolesAndSettings(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account_with_roles")
+	tokenID := []byte("TKN-abcdef")
+	marshaledRoles, err := (&esdt.ESDTRoles{
+		Roles: [][]byte{[]byte(core.ESDTRoleLocalMint), []byte(core.ESDTRoleTransfer)},
+	}).Marshal()
+	require.Nil(t, err)
+
+	blockchainHook := &contextmock.BlockchainHookStub{
+		GetStorageDataCalled: func(accountAddress []byte, index []byte) ([]byte, error) {
+			switch {
+			case bytes.Equal(accountAddress, address) && string(index) == "ELRONDroleesdtTKN-abcdef":
+				return marshaledRoles, nil
+			case bytes.Equal(accountAddress, vmcommon.SystemAccountAddress) && string(index) == "ELRONDesdtTKN-abcdef":
+				return []byte{builtInFunctions.MetadataLimitedTransfer, 0}, nil
+			}
+			return nil, nil
+		},
+		GetESDTTokenCalled: func(accountAddress []byte, token []byte, nonce uint64) (*esdt.ESDigitalToken, error) {
+			require.Equal(t, uint64(0), nonce)
+			properties := []byte{0, 0}
+			if bytes.Equal(accountAddress, address) {
+				properties[0] = builtInFunctions.MetadataFrozen
+			}
+			return &esdt.ESDigitalToken{Value: big.NewInt(0), Properties: properties}, nil
+		},
+	}
+	blockchainContext, _ := NewBlockchainContext(&contextmock.VMHostStub{}, blockchainHook)
+
+	roles, err := blockchainContext.GetESDTLocalRoles(address, tokenID)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte(core.ESDTRoleLocalMint), []byte(core.ESDTRoleTransfer)}, roles)
+
+	roles, err = blockchainContext.GetESDTLocalRoles([]byte("other"), tokenID)
+	require.Nil(t, err)
+	require.Empty(t, roles)
+
+	frozen, err := blockchainContext.IsESDTFrozen(address, tokenID)
+	require.Nil(t, err)
+	require.True(t, frozen)
+
+	frozen, err = blockchainContext.IsESDTFrozen([]byte("other"), tokenID)
+	require.Nil(t, err)
+	require.False(t, frozen)
+
+	paused, err := blockchainContext.IsESDTPaused(tokenID)
+	require.Nil(t, err)
+	require.False(t, paused)
+
+	limitedTransfer, err := blockchainContext.IsESDTLimitedTransfer(tokenID)
+	require.Nil(t, err)
+	require.True(t, limitedTransfer)
+
+	limitedTransfer, err = blockchainContext.IsESDTLimitedTransfer([]byte("OTHER-abcdef"))
+	require.Nil(t, err)
+	require.False(t, limitedTransfer)
+}
@@ -0,0 +1,89 @@
+package contexts
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer"
+)
+
+var _ arwen.ExecutionObserver = (*disabledExecutionObserver)(nil)
+var _ wasmer.FunctionCallObserver = (*functionCallNotifier)(nil)
+
+type disabledExecutionObserver struct {
+}
+
+// NewDisabledExecutionObserver creates a new disabledExecutionObserver, which ignores all events
+func NewDisabledExecutionObserver() *disabledExecutionObserver {
+	return &disabledExecutionObserver{}
+}
+
+// OnCallEnter does nothing
+func (deo *disabledExecutionObserver) OnCallEnter(_ *arwen.CallEnterEvent) {
+}
+
+// OnCallExit does nothing
+func (deo *disabledExecutionObserver) OnCallExit(_ *arwen.CallExitEvent) {
+}
+
+// OnStorageAccess does nothing
+func (deo *disabledExecutionObserver) OnStorageAccess(_ *arwen.StorageAccessEvent) {
+}
+
+// OnTransfer does nothing
+func (deo *disabledExecutionObserver) OnTransfer(_ *arwen.TransferEvent) {
+}
+
+// OnLog does nothing
+func (deo *disabledExecutionObserver) OnLog(_ *arwen.LogEvent) {
+}
+
+// OnAsyncCall does nothing
+func (deo *disabledExecutionObserver) OnAsyncCall(_ *arwen.AsyncCallEvent) {
+}
+
+// OnCallback does nothing
+func (deo *disabledExecutionObserver) OnCallback(_ *arwen.CallbackEvent) {
+}
+
+// OnGasUsed does nothing
+func (deo *disabledExecutionObserver) OnGasUsed(_ *arwen.GasUsedEvent) {
+}
+
+// OnFunctionEnter does nothing
+func (deo *disabledExecutionObserver) OnFunctionEnter(_ *arwen.FunctionCallEvent) {
+}
+
+// OnFunctionExit does nothing
+func (deo *disabledExecutionObserver) OnFunctionExit(_ *arwen.FunctionCallEvent) {
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (deo *disabledExecutionObserver) IsInterfaceNil() bool {
+	return deo == nil
+}
+
+// functionCallNotifier forwards the function calls of an instance to the
+// ExecutionObserver of the host, as events of the contract at address
+type functionCallNotifier struct {
+	observer arwen.ExecutionObserver
+	address  []byte
+}
+
+// OnFunctionEnter emits a FunctionCallEvent to OnFunctionEnter
+func (fcn *functionCallNotifier) OnFunctionEnter(functionName string, imported bool, pointsUsed uint64) {
+	fcn.observer.OnFunctionEnter(&arwen.FunctionCallEvent{
+		Address:    fcn.address,
+		Function:   functionName,
+		Imported:   imported,
+		PointsUsed: pointsUsed,
+	})
+}
+
+// OnFunctionExit emits a FunctionCallEvent to OnFunctionExit
+func (fcn *functionCallNotifier) OnFunctionExit(functionName string, imported bool, pointsUsed uint64) {
+	fcn.observer.OnFunctionExit(&arwen.FunctionCallEvent{
+		Address:    fcn.address,
+		Function:   functionName,
+		Imported:   imported,
+		PointsUsed: pointsUsed,
+	})
+}
@@ -1,9 +1,26 @@
 package contexts
 
 import (
+	"fmt"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/gowasm"
 )
 
+// NewInstanceBuilder creates the builder of the instances executed by the
+// given WASM backend
+func NewInstanceBuilder(host arwen.VMHost, wasmBackend arwen.WASMBackend) (arwen.InstanceBuilder, error) {
+	switch wasmBackend {
+	case "", arwen.WASMBackendWasmer:
+		return &wasmerInstanceBuilder{}, nil
+	case arwen.WASMBackendGo:
+		return &goInstanceBuilder{host: host}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", arwen.ErrUnknownWASMBackend, wasmBackend)
+	}
+}
+
 type wasmerInstanceBuilder struct {
 }
 
@@ -24,3 +41,33 @@ func (builder *wasmerInstanceBuilder) NewInstanceFromCompiledCodeWithOptions(
 ) (wasmer.InstanceHandler, error) {
 	return wasmer.NewInstanceFromCompiledCodeWithOptions(compiledCode, options)
 }
+
+// goInstanceBuilder creates instances of the pure-Go interpreter, which
+// import the functions of the host and are metered with its current gas schedule
+type goInstanceBuilder struct {
+	host arwen.VMHost
+}
+
+// NewInstanceWithOptions creates a new instance of the pure-Go interpreter
+// from WASM bytecode, respecting the provided options
+func (builder *goInstanceBuilder) NewInstanceWithOptions(
+	contractCode []byte,
+	options wasmer.CompilationOptions,
+) (wasmer.InstanceHandler, error) {
+	opcodeCosts := builder.opcodeCosts()
+	return gowasm.NewInstanceWithOptions(contractCode, options, builder.host.GetAPIMethods(), &opcodeCosts)
+}
+
+// NewInstanceFromCompiledCodeWithOptions creates a new instance of the
+// pure-Go interpreter from the code returned by its Cache method
+func (builder *goInstanceBuilder) NewInstanceFromCompiledCodeWithOptions(
+	compiledCode []byte,
+	options wasmer.CompilationOptions,
+) (wasmer.InstanceHandler, error) {
+	opcodeCosts := builder.opcodeCosts()
+	return gowasm.NewInstanceFromCompiledCodeWithOptions(compiledCode, options, builder.host.GetAPIMethods(), &opcodeCosts)
+}
+
+func (builder *goInstanceBuilder) opcodeCosts() [wasmer.OPCODE_COUNT]uint32 {
+	return builder.host.Metering().GasSchedule().WASMOpcodeCost.ToOpcodeCostsArray()
+}
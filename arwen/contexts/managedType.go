@@ -28,6 +28,8 @@ const p384CurveUnmarshalCompressedMultiplier = 200
 const p521CurveUnmarshalCompressedMultiplier = 400
 
 type managedBufferMap map[int32][]byte
+type managedVecMap map[int32][][]byte
+type managedMapMap map[int32]map[string][]byte
 type bigIntMap map[int32]*big.Int
 type ellipticCurveMap map[int32]*elliptic.CurveParams
 
@@ -42,6 +44,8 @@ type managedTypesState struct {
 	bigIntValues  bigIntMap
 	ecValues      ellipticCurveMap
 	mBufferValues managedBufferMap
+	mVecValues    managedVecMap
+	mMapValues    managedMapMap
 }
 
 // NewManagedTypesContext creates a new managedTypesContext
@@ -52,6 +56,8 @@ func NewManagedTypesContext(host arwen.VMHost) (*managedTypesContext, error) {
 			bigIntValues:  make(bigIntMap),
 			ecValues:      make(ellipticCurveMap),
 			mBufferValues: make(managedBufferMap),
+			mVecValues:    make(managedVecMap),
+			mMapValues:    make(managedMapMap),
 		},
 		managedTypesStack:   make([]managedTypesState, 0),
 		randomnessGenerator: nil,
@@ -86,17 +92,14 @@ func (context *managedTypesContext) InitState() {
 	context.managedTypesValues = managedTypesState{
 		bigIntValues:  make(bigIntMap),
 		ecValues:      make(ellipticCurveMap),
-		mBufferValues: make(managedBufferMap)}
+		mBufferValues: make(managedBufferMap),
+		mVecValues:    make(managedVecMap),
+		mMapValues:    make(managedMapMap)}
 }
 
 // PushState appends the values map to the state stack
 func (context *managedTypesContext) PushState() {
-	newBigIntState, newEcState, newmBufferState := context.clone()
-	context.managedTypesStack = append(context.managedTypesStack, managedTypesState{
-		bigIntValues:  newBigIntState,
-		ecValues:      newEcState,
-		mBufferValues: newmBufferState,
-	})
+	context.managedTypesStack = append(context.managedTypesStack, context.clone())
 }
 
 // PopSetActiveState removes the latest entry from the state stack and sets it as the current values map
@@ -105,13 +108,7 @@ func (context *managedTypesContext) PopSetActiveState() {
 	if managedTypesStackLen == 0 {
 		return
 	}
-	prevState := context.managedTypesStack[managedTypesStackLen-1]
-	prevBigIntValues := prevState.bigIntValues
-	prevEcValues := prevState.ecValues
-	prevmBufferValues := prevState.mBufferValues
-	context.managedTypesValues.bigIntValues = prevBigIntValues
-	context.managedTypesValues.ecValues = prevEcValues
-	context.managedTypesValues.mBufferValues = prevmBufferValues
+	context.managedTypesValues = context.managedTypesStack[managedTypesStackLen-1]
 	context.managedTypesStack = context.managedTypesStack[:managedTypesStackLen-1]
 }
 
@@ -130,10 +127,12 @@ func (context *managedTypesContext) ClearStateStack() {
 	context.randomnessGenerator = nil
 }
 
-func (context *managedTypesContext) clone() (bigIntMap, ellipticCurveMap, managedBufferMap) {
+func (context *managedTypesContext) clone() managedTypesState {
 	newBigIntState := make(bigIntMap, len(context.managedTypesValues.bigIntValues))
 	newEcState := make(ellipticCurveMap, len(context.managedTypesValues.ecValues))
 	newmBufferState := make(managedBufferMap, len(context.managedTypesValues.mBufferValues))
+	newmVecState := make(managedVecMap, len(context.managedTypesValues.mVecValues))
+	newmMapState := make(managedMapMap, len(context.managedTypesValues.mMapValues))
 	for bigIntHandle, bigInt := range context.managedTypesValues.bigIntValues {
 		newBigIntState[bigIntHandle] = big.NewInt(0).Set(bigInt)
 	}
@@ -143,7 +142,24 @@ func (context *managedTypesContext) clone() (bigIntMap, ellipticCurveMap, manage
 	for mBufferHandle, mBuffer := range context.managedTypesValues.mBufferValues {
 		newmBufferState[mBufferHandle] = mBuffer
 	}
-	return newBigIntState, newEcState, newmBufferState
+	// the items are never modified in place, so only the collections are copied
+	for mVecHandle, mVec := range context.managedTypesValues.mVecValues {
+		newmVecState[mVecHandle] = append(make([][]byte, 0, len(mVec)), mVec...)
+	}
+	for mMapHandle, mMap := range context.managedTypesValues.mMapValues {
+		newmMap := make(map[string][]byte, len(mMap))
+		for key, value := range mMap {
+			newmMap[key] = value
+		}
+		newmMapState[mMapHandle] = newmMap
+	}
+	return managedTypesState{
+		bigIntValues:  newBigIntState,
+		ecValues:      newEcState,
+		mBufferValues: newmBufferState,
+		mVecValues:    newmVecState,
+		mMapValues:    newmMapState,
+	}
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
@@ -454,3 +470,136 @@ func (context *managedTypesContext) InsertSlice(mBufferHandle int32, startPositi
 	context.managedTypesValues.mBufferValues[mBufferHandle] = mBuffer
 	return context.managedTypesValues.mBufferValues[mBufferHandle], nil
 }
+
+// MANAGED VECTORS
+
+// NewManagedVec creates a new empty vector in the managed vectors map and returns the handle
+func (context *managedTypesContext) NewManagedVec() int32 {
+	newHandle := int32(len(context.managedTypesValues.mVecValues))
+	for {
+		if _, ok := context.managedTypesValues.mVecValues[newHandle]; !ok {
+			break
+		}
+		newHandle++
+	}
+	context.managedTypesValues.mVecValues[newHandle] = make([][]byte, 0)
+	return newHandle
+}
+
+// GetManagedVecLength returns the number of items of the managed vector, or -1 if the vector is non-existent
+func (context *managedTypesContext) GetManagedVecLength(mVecHandle int32) int32 {
+	mVec, ok := context.managedTypesValues.mVecValues[mVecHandle]
+	if !ok {
+		return -1
+	}
+	return int32(len(mVec))
+}
+
+// PushManagedVecItem appends a copy of the given bytes at the end of the managed vector
+func (context *managedTypesContext) PushManagedVecItem(mVecHandle int32, item []byte) error {
+	mVec, ok := context.managedTypesValues.mVecValues[mVecHandle]
+	if !ok {
+		return arwen.ErrNoManagedVecUnderThisHandle
+	}
+	context.managedTypesValues.mVecValues[mVecHandle] = append(mVec, copyBytes(item))
+	return nil
+}
+
+// GetManagedVecItem returns the item at the given index of the managed vector
+func (context *managedTypesContext) GetManagedVecItem(mVecHandle int32, index int32) ([]byte, error) {
+	mVec, ok := context.managedTypesValues.mVecValues[mVecHandle]
+	if !ok {
+		return nil, arwen.ErrNoManagedVecUnderThisHandle
+	}
+	if index < 0 || int(index) >= len(mVec) {
+		return nil, arwen.ErrBadBounds
+	}
+	return mVec[index], nil
+}
+
+// SetManagedVecItem replaces the item at the given index of the managed vector with a copy of the given bytes
+func (context *managedTypesContext) SetManagedVecItem(mVecHandle int32, index int32, item []byte) error {
+	mVec, ok := context.managedTypesValues.mVecValues[mVecHandle]
+	if !ok {
+		return arwen.ErrNoManagedVecUnderThisHandle
+	}
+	if index < 0 || int(index) >= len(mVec) {
+		return arwen.ErrBadBounds
+	}
+	mVec[index] = copyBytes(item)
+	return nil
+}
+
+// RemoveManagedVecItem removes the item at the given index of the managed vector, preserving the order of the others
+func (context *managedTypesContext) RemoveManagedVecItem(mVecHandle int32, index int32) error {
+	mVec, ok := context.managedTypesValues.mVecValues[mVecHandle]
+	if !ok {
+		return arwen.ErrNoManagedVecUnderThisHandle
+	}
+	if index < 0 || int(index) >= len(mVec) {
+		return arwen.ErrBadBounds
+	}
+	context.managedTypesValues.mVecValues[mVecHandle] = append(mVec[:index:index], mVec[index+1:]...)
+	return nil
+}
+
+// MANAGED MAPS
+
+// NewManagedMap creates a new empty map in the managed maps map and returns the handle
+func (context *managedTypesContext) NewManagedMap() int32 {
+	newHandle := int32(len(context.managedTypesValues.mMapValues))
+	for {
+		if _, ok := context.managedTypesValues.mMapValues[newHandle]; !ok {
+			break
+		}
+		newHandle++
+	}
+	context.managedTypesValues.mMapValues[newHandle] = make(map[string][]byte)
+	return newHandle
+}
+
+// GetManagedMapLength returns the number of entries of the managed map, or -1 if the map is non-existent
+func (context *managedTypesContext) GetManagedMapLength(mMapHandle int32) int32 {
+	mMap, ok := context.managedTypesValues.mMapValues[mMapHandle]
+	if !ok {
+		return -1
+	}
+	return int32(len(mMap))
+}
+
+// PutManagedMapValue sets a copy of the given value under the given key of the managed map
+func (context *managedTypesContext) PutManagedMapValue(mMapHandle int32, key []byte, value []byte) error {
+	mMap, ok := context.managedTypesValues.mMapValues[mMapHandle]
+	if !ok {
+		return arwen.ErrNoManagedMapUnderThisHandle
+	}
+	mMap[string(key)] = copyBytes(value)
+	return nil
+}
+
+// GetManagedMapValue returns the value under the given key of the managed map, and whether the key exists
+func (context *managedTypesContext) GetManagedMapValue(mMapHandle int32, key []byte) ([]byte, bool, error) {
+	mMap, ok := context.managedTypesValues.mMapValues[mMapHandle]
+	if !ok {
+		return nil, false, arwen.ErrNoManagedMapUnderThisHandle
+	}
+	value, exists := mMap[string(key)]
+	return value, exists, nil
+}
+
+// RemoveManagedMapValue removes the given key from the managed map and returns its former value, and whether the key existed
+func (context *managedTypesContext) RemoveManagedMapValue(mMapHandle int32, key []byte) ([]byte, bool, error) {
+	mMap, ok := context.managedTypesValues.mMapValues[mMapHandle]
+	if !ok {
+		return nil, false, arwen.ErrNoManagedMapUnderThisHandle
+	}
+	value, exists := mMap[string(key)]
+	delete(mMap, string(key))
+	return value, exists, nil
+}
+
+func copyBytes(bytes []byte) []byte {
+	bytesCopy := make([]byte, len(bytes))
+	copy(bytesCopy, bytes)
+	return bytesCopy
+}
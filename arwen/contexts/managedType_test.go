@@ -26,6 +26,8 @@ func TestNewManagedTypes(t *testing.T) {
 	require.NotNil(t, managedTypesContext.managedTypesValues.bigIntValues)
 	require.NotNil(t, managedTypesContext.managedTypesValues.ecValues)
 	require.NotNil(t, managedTypesContext.managedTypesValues.mBufferValues)
+	require.NotNil(t, managedTypesContext.managedTypesValues.mVecValues)
+	require.NotNil(t, managedTypesContext.managedTypesValues.mMapValues)
 	require.NotNil(t, managedTypesContext.managedTypesStack)
 	require.Equal(t, 0, len(managedTypesContext.managedTypesValues.bigIntValues))
 	require.Equal(t, 0, len(managedTypesContext.managedTypesValues.ecValues))
@@ -543,6 +545,129 @@ func TestManagedTypesContext_ManagedBuffersFunctionalities(t *testing.T) {
 	require.Equal(t, bytesWithNewSlice, mBufferBytes)
 }
 
+func TestManagedTypesContext_ManagedVecFunctionalities(t *testing.T) {
+	t.Parallel()
+	host := &contextmock.VMHostStub{}
+	managedTypesContext, _ := NewManagedTypesContext(host)
+	item1 := []byte{1, 2, 3}
+	item2 := []byte{4, 5}
+	item3 := []byte{6}
+
+	// Calls for non-existent vectors
+	noVecHandle := int32(379)
+	require.Equal(t, int32(-1), managedTypesContext.GetManagedVecLength(noVecHandle))
+	require.Equal(t, arwen.ErrNoManagedVecUnderThisHandle, managedTypesContext.PushManagedVecItem(noVecHandle, item1))
+	item, err := managedTypesContext.GetManagedVecItem(noVecHandle, 0)
+	require.Nil(t, item)
+	require.Equal(t, arwen.ErrNoManagedVecUnderThisHandle, err)
+	require.Equal(t, arwen.ErrNoManagedVecUnderThisHandle, managedTypesContext.SetManagedVecItem(noVecHandle, 0, item1))
+	require.Equal(t, arwen.ErrNoManagedVecUnderThisHandle, managedTypesContext.RemoveManagedVecItem(noVecHandle, 0))
+
+	// New/Push/Get
+	mVecHandle := managedTypesContext.NewManagedVec()
+	require.Equal(t, int32(0), mVecHandle)
+	require.Equal(t, int32(0), managedTypesContext.GetManagedVecLength(mVecHandle))
+	require.Nil(t, managedTypesContext.PushManagedVecItem(mVecHandle, item1))
+	require.Nil(t, managedTypesContext.PushManagedVecItem(mVecHandle, item2))
+	require.Nil(t, managedTypesContext.PushManagedVecItem(mVecHandle, item3))
+	require.Equal(t, int32(3), managedTypesContext.GetManagedVecLength(mVecHandle))
+	item, err = managedTypesContext.GetManagedVecItem(mVecHandle, 1)
+	require.Nil(t, err)
+	require.Equal(t, item2, item)
+	_, err = managedTypesContext.GetManagedVecItem(mVecHandle, 3)
+	require.Equal(t, arwen.ErrBadBounds, err)
+	_, err = managedTypesContext.GetManagedVecItem(mVecHandle, -1)
+	require.Equal(t, arwen.ErrBadBounds, err)
+
+	// the items are copies of the pushed bytes
+	item1[0] = 100
+	item, _ = managedTypesContext.GetManagedVecItem(mVecHandle, 0)
+	require.Equal(t, []byte{1, 2, 3}, item)
+
+	// Set
+	require.Nil(t, managedTypesContext.SetManagedVecItem(mVecHandle, 0, item3))
+	item, _ = managedTypesContext.GetManagedVecItem(mVecHandle, 0)
+	require.Equal(t, item3, item)
+	require.Equal(t, arwen.ErrBadBounds, managedTypesContext.SetManagedVecItem(mVecHandle, 3, item3))
+
+	// Remove
+	require.Nil(t, managedTypesContext.RemoveManagedVecItem(mVecHandle, 0))
+	require.Equal(t, int32(2), managedTypesContext.GetManagedVecLength(mVecHandle))
+	item, _ = managedTypesContext.GetManagedVecItem(mVecHandle, 0)
+	require.Equal(t, item2, item)
+	item, _ = managedTypesContext.GetManagedVecItem(mVecHandle, 1)
+	require.Equal(t, item3, item)
+	require.Equal(t, arwen.ErrBadBounds, managedTypesContext.RemoveManagedVecItem(mVecHandle, 2))
+
+	// Push/PopSetActiveState
+	managedTypesContext.PushState()
+	require.Nil(t, managedTypesContext.PushManagedVecItem(mVecHandle, item1))
+	require.Nil(t, managedTypesContext.SetManagedVecItem(mVecHandle, 0, item1))
+	require.Equal(t, int32(1), managedTypesContext.NewManagedVec())
+	managedTypesContext.PopSetActiveState()
+	require.Equal(t, int32(2), managedTypesContext.GetManagedVecLength(mVecHandle))
+	item, _ = managedTypesContext.GetManagedVecItem(mVecHandle, 0)
+	require.Equal(t, item2, item)
+	require.Equal(t, int32(-1), managedTypesContext.GetManagedVecLength(1))
+}
+
+func TestManagedTypesContext_ManagedMapFunctionalities(t *testing.T) {
+	t.Parallel()
+	host := &contextmock.VMHostStub{}
+	managedTypesContext, _ := NewManagedTypesContext(host)
+	key1 := []byte("key1")
+	key2 := []byte("key2")
+	value1 := []byte{1, 2, 3}
+	value2 := []byte{4, 5}
+
+	// Calls for non-existent maps
+	noMapHandle := int32(379)
+	require.Equal(t, int32(-1), managedTypesContext.GetManagedMapLength(noMapHandle))
+	require.Equal(t, arwen.ErrNoManagedMapUnderThisHandle, managedTypesContext.PutManagedMapValue(noMapHandle, key1, value1))
+	value, exists, err := managedTypesContext.GetManagedMapValue(noMapHandle, key1)
+	require.Nil(t, value)
+	require.False(t, exists)
+	require.Equal(t, arwen.ErrNoManagedMapUnderThisHandle, err)
+	_, _, err = managedTypesContext.RemoveManagedMapValue(noMapHandle, key1)
+	require.Equal(t, arwen.ErrNoManagedMapUnderThisHandle, err)
+
+	// New/Put/Get
+	mMapHandle := managedTypesContext.NewManagedMap()
+	require.Equal(t, int32(0), mMapHandle)
+	require.Equal(t, int32(0), managedTypesContext.GetManagedMapLength(mMapHandle))
+	require.Nil(t, managedTypesContext.PutManagedMapValue(mMapHandle, key1, value1))
+	require.Nil(t, managedTypesContext.PutManagedMapValue(mMapHandle, key2, value2))
+	require.Nil(t, managedTypesContext.PutManagedMapValue(mMapHandle, key2, value1))
+	require.Equal(t, int32(2), managedTypesContext.GetManagedMapLength(mMapHandle))
+	value, exists, err = managedTypesContext.GetManagedMapValue(mMapHandle, key2)
+	require.Nil(t, err)
+	require.True(t, exists)
+	require.Equal(t, value1, value)
+	value, exists, err = managedTypesContext.GetManagedMapValue(mMapHandle, []byte("missing"))
+	require.Nil(t, err)
+	require.False(t, exists)
+	require.Nil(t, value)
+
+	// Remove
+	value, exists, err = managedTypesContext.RemoveManagedMapValue(mMapHandle, key1)
+	require.Nil(t, err)
+	require.True(t, exists)
+	require.Equal(t, value1, value)
+	require.Equal(t, int32(1), managedTypesContext.GetManagedMapLength(mMapHandle))
+	_, exists, _ = managedTypesContext.RemoveManagedMapValue(mMapHandle, key1)
+	require.False(t, exists)
+
+	// Push/PopSetActiveState
+	managedTypesContext.PushState()
+	require.Nil(t, managedTypesContext.PutManagedMapValue(mMapHandle, key1, value2))
+	_, _, _ = managedTypesContext.RemoveManagedMapValue(mMapHandle, key2)
+	managedTypesContext.PopSetActiveState()
+	require.Equal(t, int32(1), managedTypesContext.GetManagedMapLength(mMapHandle))
+	value, exists, _ = managedTypesContext.GetManagedMapValue(mMapHandle, key2)
+	require.True(t, exists)
+	require.Equal(t, value1, value)
+}
+
 func TestManagedTypesContext_PopSetActiveStateIfStackIsEmptyShouldNotPanic(t *testing.T) {
 	t.Parallel()
 	host := &contextmock.VMHostStub{}
@@ -21,10 +21,13 @@ type meteringContext struct {
 	initialGasProvided uint64
 	initialCost        uint64
 	gasForExecution    uint64
+	gasRequired        uint64
 	gasUsedByAccounts  map[string]uint64
 
-	gasTracer       arwen.GasTracing
-	traceGasEnabled bool
+	gasTracer          arwen.GasTracing
+	traceGasEnabled    bool
+	tracedFunctionName string
+	tracedCallNotified bool
 }
 
 // NewMeteringContext creates a new meteringContext
@@ -58,6 +61,9 @@ func (context *meteringContext) InitState() {
 	context.initialGasProvided = 0
 	context.initialCost = 0
 	context.gasForExecution = 0
+	context.gasRequired = 0
+	context.tracedFunctionName = ""
+	context.tracedCallNotified = false
 	context.gasUsedByAccounts = make(map[string]uint64)
 
 	var newGasTracer arwen.GasTracing
@@ -84,6 +90,7 @@ func (context *meteringContext) PushState() {
 		initialGasProvided: context.initialGasProvided,
 		initialCost:        context.initialCost,
 		gasForExecution:    context.gasForExecution,
+		gasRequired:        context.gasRequired,
 		gasUsedByAccounts:  context.cloneGasUsedByAccounts(),
 	}
 
@@ -104,6 +111,7 @@ func (context *meteringContext) PopSetActiveState() {
 	context.initialGasProvided = prevState.initialGasProvided
 	context.initialCost = prevState.initialCost
 	context.gasForExecution = prevState.gasForExecution
+	context.gasRequired = prevState.gasRequired
 	context.gasUsedByAccounts = prevState.gasUsedByAccounts
 }
 
@@ -131,6 +139,7 @@ func (context *meteringContext) PopMergeActiveState() {
 	context.initialGasProvided = prevState.initialGasProvided
 	context.initialCost = prevState.initialCost
 	context.gasForExecution = prevState.gasForExecution
+	context.gasRequired = prevState.gasRequired
 
 	context.addToGasUsedByAccounts(prevState.gasUsedByAccounts)
 }
@@ -328,12 +337,15 @@ func (context *meteringContext) UseGas(gas uint64) {
 func (context *meteringContext) UseAndTraceGas(gas uint64) {
 	context.UseGas(gas)
 	context.traceGas(gas)
+	context.notifyTracedGasUsed(gas)
 }
 
 // UseAndTraceGas sets in the runtime context the given gas as gas used and adds to current trace
 func (context *meteringContext) UseGasAndAddTracedGas(functionName string, gas uint64) {
 	context.UseGas(gas)
 	context.addToGasTrace(functionName, gas)
+	context.tracedCallNotified = true
+	context.notifyGasUsed(functionName, gas, true)
 }
 
 // GetGasTrace returns the gasTrace map
@@ -385,6 +397,28 @@ func (context *meteringContext) GasUsedForExecution() uint64 {
 	return gasUsed
 }
 
+// GasRequired returns the minimal gas that must be provided to the current
+// contract for it to reach its current point of execution: the gas it spent
+// so far, or more, if a nested call required more gas than it consumed.
+func (context *meteringContext) GasRequired() uint64 {
+	gasSpent := context.GasSpentByContract()
+	if context.gasRequired > gasSpent {
+		return context.gasRequired
+	}
+	return gasSpent
+}
+
+// TrackGasRequiredByNestedCall records the gas required by the current
+// contract to perform a nested call which has just completed. It must be
+// called before the gas remaining from the nested call is restored.
+func (context *meteringContext) TrackGasRequiredByNestedCall(gasProvidedToCall uint64, gasRequiredByCall uint64) {
+	gasSpentBeforeCall := math.SubUint64(context.GasSpentByContract(), gasProvidedToCall)
+	gasRequired := math.AddUint64(gasSpentBeforeCall, gasRequiredByCall)
+	if gasRequired > context.gasRequired {
+		context.gasRequired = gasRequired
+	}
+}
+
 // GetGasForExecution returns the gas left after the deduction of the initial gas from the provided gas
 func (context *meteringContext) GetGasForExecution() uint64 {
 	return context.gasForExecution
@@ -420,13 +454,16 @@ func (context *meteringContext) UseGasForAsyncStep() error {
 }
 
 // UseGasBounded returns an error if the given gasToUse is less than the available gas,
-// otherwise it uses the given gas
+// otherwise it uses the given gas. The observer is notified in both cases, so
+// that the calls failing for lack of gas are observed as well.
 func (context *meteringContext) UseGasBounded(gasToUse uint64) error {
 	if context.GasLeft() <= gasToUse {
+		context.notifyTracedGasUsed(0)
 		return arwen.ErrNotEnoughGas
 	}
 	context.UseGas(gasToUse)
 	context.traceGas(gasToUse)
+	context.notifyTracedGasUsed(gasToUse)
 	return nil
 }
 
@@ -519,6 +556,8 @@ func (context *meteringContext) SetGasTracing(enableGasTracing bool) {
 
 // StartGasTracing sets initial trace for the upcoming gas usage.
 func (context *meteringContext) StartGasTracing(functionName string) {
+	context.tracedFunctionName = functionName
+	context.tracedCallNotified = false
 	if context.traceGasEnabled {
 		scAddress := context.getSCAddress()
 		if len(scAddress) != 0 {
@@ -536,6 +575,23 @@ func (context *meteringContext) addToGasTrace(functionName string, usedGas uint6
 	context.gasTracer.AddTracedGas(scAddress, functionName, usedGas)
 }
 
+// notifyTracedGasUsed notifies the gas used by the function passed to the
+// last StartGasTracing, marking only its first notification as a new call
+func (context *meteringContext) notifyTracedGasUsed(usedGas uint64) {
+	newCall := !context.tracedCallNotified
+	context.tracedCallNotified = true
+	context.notifyGasUsed(context.tracedFunctionName, usedGas, newCall)
+}
+
+func (context *meteringContext) notifyGasUsed(functionName string, usedGas uint64, newCall bool) {
+	context.host.Observer().OnGasUsed(&arwen.GasUsedEvent{
+		Address:  context.host.Runtime().GetSCAddress(),
+		Function: functionName,
+		GasUsed:  usedGas,
+		NewCall:  newCall,
+	})
+}
+
 func (context *meteringContext) getSCAddress() string {
 	return string(context.host.Runtime().GetSCAddress())
 }
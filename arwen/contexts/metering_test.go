@@ -294,6 +294,42 @@ func TestMeteringContext_GasUsed_NoStacking(t *testing.T) {
 	require.Equal(t, totalGasUsed, gasUsedByContract)
 }
 
+func TestMeteringContext_GasRequired_NestedCalls(t *testing.T) {
+	t.Parallel()
+
+	mockRuntime := &contextmock.RuntimeContextMock{}
+	host := &contextmock.VMHostMock{
+		RuntimeContext: mockRuntime,
+	}
+	input := &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{GasProvided: 10000}}
+	mockRuntime.SetVMInput(&input.VMInput)
+	mockRuntime.SetPointsUsed(0)
+
+	metering, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	metering.InitStateFromContractCallInput(&input.VMInput)
+
+	metering.UseGas(100)
+	require.Equal(t, uint64(100), metering.GasRequired())
+
+	// a nested call is provided 5000 gas, requires 3000 and uses 2000
+	metering.PushState()
+	metering.UseGas(5000)
+	metering.PopMergeActiveState()
+	metering.TrackGasRequiredByNestedCall(5000, 3000)
+	metering.RestoreGas(3000)
+	require.Equal(t, uint64(2100), metering.GasSpentByContract())
+	require.Equal(t, uint64(3100), metering.GasRequired())
+
+	// the gas spent after the nested call may exceed the gas it required
+	metering.UseGas(1500)
+	require.Equal(t, uint64(3600), metering.GasRequired())
+
+	// the required gas is reset with the state of the context
+	metering.InitState()
+	mockRuntime.SetPointsUsed(0)
+	require.Equal(t, uint64(0), metering.GasRequired())
+}
+
 func setUpStackOneLevel(t *testing.T, parentInput *vmcommon.ContractCallInput, childInput *vmcommon.ContractCallInput) (*contextmock.VMHostMock, *contextmock.RuntimeContextMock, uint64) {
 	t.Parallel()
 
@@ -570,3 +606,64 @@ func TestMeteringContext_GasTracer(t *testing.T) {
 	require.Equal(t, gasUsed2, gasTrace["scAddress2"]["function2"][0])
 
 }
+
+func TestMeteringContext_GasUsedEventsMarkNewCalls(t *testing.T) {
+	t.Parallel()
+
+	events := make([]*arwen.GasUsedEvent, 0)
+	host := &contextmock.VMHostMock{
+		RuntimeContext: &contextmock.RuntimeContextMock{SCAddress: []byte("scAddress")},
+		ExecutionObserver: &contextmock.ExecutionObserverStub{
+			OnGasUsedCalled: func(event *arwen.GasUsedEvent) {
+				events = append(events, event)
+			},
+		},
+	}
+
+	meteringContext, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringContext.gasForExecution = 10000
+
+	meteringContext.StartGasTracing("function1")
+	meteringContext.UseAndTraceGas(10)
+	meteringContext.UseAndTraceGas(20)
+	meteringContext.UseGasAndAddTracedGas("function2", 30)
+	meteringContext.StartGasTracing("function1")
+	meteringContext.UseAndTraceGas(40)
+
+	require.Len(t, events, 4)
+	require.Equal(t, "function1", events[0].Function)
+	require.True(t, events[0].NewCall)
+	require.Equal(t, "function1", events[1].Function)
+	require.False(t, events[1].NewCall)
+	require.Equal(t, "function2", events[2].Function)
+	require.True(t, events[2].NewCall)
+	require.Equal(t, "function1", events[3].Function)
+	require.True(t, events[3].NewCall)
+}
+
+func TestMeteringContext_GasUsedEventOnUseGasBoundedFailure(t *testing.T) {
+	t.Parallel()
+
+	events := make([]*arwen.GasUsedEvent, 0)
+	host := &contextmock.VMHostMock{
+		RuntimeContext: &contextmock.RuntimeContextMock{SCAddress: []byte("scAddress")},
+		ExecutionObserver: &contextmock.ExecutionObserverStub{
+			OnGasUsedCalled: func(event *arwen.GasUsedEvent) {
+				events = append(events, event)
+			},
+		},
+	}
+
+	meteringContext, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringContext.gasForExecution = 100
+
+	// a breakpoint on the EI function must fire even if it runs out of gas
+	meteringContext.StartGasTracing("signalError")
+	err := meteringContext.UseGasBounded(1000)
+	require.Equal(t, arwen.ErrNotEnoughGas, err)
+
+	require.Len(t, events, 1)
+	require.Equal(t, "signalError", events[0].Function)
+	require.True(t, events[0].NewCall)
+	require.Equal(t, uint64(0), events[0].GasUsed)
+}
@@ -1,6 +1,7 @@
 package contexts
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"math/big"
@@ -21,13 +22,36 @@ type outputContext struct {
 	outputState *vmcommon.VMOutput
 	stateStack  []*vmcommon.VMOutput
 	codeUpdates map[string]struct{}
+
+	backTransfers      *backTransfersState
+	backTransfersStack []*backTransfersState
+}
+
+// backTransfersState holds the transfers received by the running contract
+// from the contracts it called synchronously, as well as the transfers the
+// running contract sent back to its own caller.
+type backTransfersState struct {
+	receivedESDT  []*vmcommon.ESDTTransfer
+	receivedValue *big.Int
+	sentESDT      []*vmcommon.ESDTTransfer
+	sentValue     *big.Int
+}
+
+func newBackTransfersState() *backTransfersState {
+	return &backTransfersState{
+		receivedESDT:  make([]*vmcommon.ESDTTransfer, 0),
+		receivedValue: big.NewInt(0),
+		sentESDT:      make([]*vmcommon.ESDTTransfer, 0),
+		sentValue:     big.NewInt(0),
+	}
 }
 
 // NewOutputContext creates a new outputContext
 func NewOutputContext(host arwen.VMHost) (*outputContext, error) {
 	context := &outputContext{
-		host:       host,
-		stateStack: make([]*vmcommon.VMOutput, 0),
+		host:               host,
+		stateStack:         make([]*vmcommon.VMOutput, 0),
+		backTransfersStack: make([]*backTransfersState, 0),
 	}
 
 	context.InitState()
@@ -39,6 +63,7 @@ func NewOutputContext(host arwen.VMHost) (*outputContext, error) {
 func (context *outputContext) InitState() {
 	context.outputState = newVMOutput()
 	context.codeUpdates = make(map[string]struct{})
+	context.backTransfers = newBackTransfersState()
 }
 
 func newVMOutput() *vmcommon.VMOutput {
@@ -71,6 +96,10 @@ func (context *outputContext) PushState() {
 	newState := newVMOutput()
 	mergeVMOutputs(newState, context.outputState)
 	context.stateStack = append(context.stateStack, newState)
+
+	// the called contract starts without back transfers of its own
+	context.backTransfersStack = append(context.backTransfersStack, context.backTransfers)
+	context.backTransfers = newBackTransfersState()
 }
 
 // PopSetActiveState removes the latest entry from the state stack and sets it as the current vm output
@@ -83,6 +112,8 @@ func (context *outputContext) PopSetActiveState() {
 	prevState := context.stateStack[stateStackLen-1]
 	context.stateStack = context.stateStack[:stateStackLen-1]
 	context.outputState = prevState
+
+	context.backTransfers = context.popBackTransfers()
 }
 
 // PopMergeActiveState merges the current state into the head of the stateStack,
@@ -102,10 +133,13 @@ func (context *outputContext) PopMergeActiveState() {
 	mergeVMOutputs(prevState, context.outputState)
 	context.outputState = newVMOutput()
 	mergeVMOutputs(context.outputState, prevState)
+
+	context.popMergeBackTransfers()
 }
 
 // PopDiscard removes the latest entry from the state stack, but maintaining
-// all GasUsed values.
+// all GasUsed values. The transfers sent back by the finished contract become
+// back transfers received by its caller.
 func (context *outputContext) PopDiscard() {
 	stateStackLen := len(context.stateStack)
 	if stateStackLen == 0 {
@@ -113,11 +147,33 @@ func (context *outputContext) PopDiscard() {
 	}
 
 	context.stateStack = context.stateStack[:stateStackLen-1]
+	context.popMergeBackTransfers()
 }
 
 // ClearStateStack reinitializes the state stack.
 func (context *outputContext) ClearStateStack() {
 	context.stateStack = make([]*vmcommon.VMOutput, 0)
+	context.backTransfersStack = make([]*backTransfersState, 0)
+}
+
+// popMergeBackTransfers restores the back transfers of the caller, to which
+// the transfers sent back by the finished contract are added as received
+func (context *outputContext) popMergeBackTransfers() {
+	prevBackTransfers := context.popBackTransfers()
+	prevBackTransfers.receivedESDT = append(prevBackTransfers.receivedESDT, context.backTransfers.sentESDT...)
+	prevBackTransfers.receivedValue.Add(prevBackTransfers.receivedValue, context.backTransfers.sentValue)
+	context.backTransfers = prevBackTransfers
+}
+
+func (context *outputContext) popBackTransfers() *backTransfersState {
+	stackLen := len(context.backTransfersStack)
+	if stackLen == 0 {
+		return newBackTransfersState()
+	}
+
+	prevBackTransfers := context.backTransfersStack[stackLen-1]
+	context.backTransfersStack = context.backTransfersStack[:stackLen-1]
+	return prevBackTransfers
 }
 
 // CensorVMOutput will cause the next executed SC to appear isolated, as if
@@ -131,6 +187,7 @@ func (context *outputContext) CensorVMOutput() {
 	context.outputState.GasRemaining = 0
 	context.outputState.GasRefund = big.NewInt(0)
 	context.outputState.Logs = make([]*vmcommon.LogEntry, 0)
+	context.backTransfers = newBackTransfersState()
 
 	logOutput.Trace("state content censored")
 }
@@ -237,6 +294,13 @@ func (context *outputContext) WriteLog(address []byte, topics [][]byte, data []b
 	}
 	logOutput.Trace("log entry", "address", address, "data", data)
 
+	context.host.Observer().OnLog(&arwen.LogEvent{
+		Address:    address,
+		Identifier: newLogEntry.Identifier,
+		Topics:     topics,
+		Data:       data,
+	})
+
 	if len(topics) == 0 {
 		context.outputState.Logs = append(context.outputState.Logs, newLogEntry)
 		return
@@ -305,6 +369,20 @@ func (context *outputContext) Transfer(destination []byte, sender []byte, gasLim
 	}
 	destAcc.OutputTransfers = append(destAcc.OutputTransfers, outputTransfer)
 
+	if callType == vm.DirectCall && context.isBackTransfer(destination, sender) {
+		context.backTransfers.sentValue.Add(context.backTransfers.sentValue, value)
+	}
+
+	context.host.Observer().OnTransfer(&arwen.TransferEvent{
+		Sender:      sender,
+		Destination: destination,
+		Value:       outputTransfer.Value,
+		Data:        input,
+		GasLimit:    gasLimit,
+		GasLocked:   gasLocked,
+		CallType:    callType,
+	})
+
 	logOutput.Trace("transfer value added")
 	return nil
 }
@@ -380,10 +458,52 @@ func (context *outputContext) TransferESDT(
 
 	destAcc.OutputTransfers = append(destAcc.OutputTransfers, outputTransfer)
 
+	if context.isBackTransfer(destination, sender) {
+		context.backTransfers.sentESDT = append(context.backTransfers.sentESDT, transfers...)
+	}
+
+	context.host.Observer().OnTransfer(&arwen.TransferEvent{
+		Sender:        sender,
+		Destination:   destination,
+		Value:         outputTransfer.Value,
+		ESDTTransfers: transfers,
+		Data:          outputTransfer.Data,
+		GasLimit:      outputTransfer.GasLimit,
+		CallType:      callType,
+	})
+
 	context.outputState.Logs = append(context.outputState.Logs, vmOutput.Logs...)
 	return gasRemaining, nil
 }
 
+// isBackTransfer returns true if the running contract was called synchronously
+// and the transfer goes from it back to its caller
+func (context *outputContext) isBackTransfer(destination []byte, sender []byte) bool {
+	if len(context.stateStack) == 0 {
+		return false
+	}
+
+	runtime := context.host.Runtime()
+	vmInput := runtime.GetVMInput()
+	if vmInput.CallType != vm.DirectCall {
+		return false
+	}
+
+	return bytes.Equal(sender, runtime.GetSCAddress()) && bytes.Equal(destination, vmInput.CallerAddr)
+}
+
+// GetBackTransfers returns the ESDT and EGLD transfers received by the running
+// contract from the contracts it called synchronously.
+func (context *outputContext) GetBackTransfers() ([]*vmcommon.ESDTTransfer, *big.Int) {
+	return context.backTransfers.receivedESDT, context.backTransfers.receivedValue
+}
+
+// ClearBackTransfers forgets the back transfers received so far by the running contract.
+func (context *outputContext) ClearBackTransfers() {
+	context.backTransfers.receivedESDT = make([]*vmcommon.ESDTTransfer, 0)
+	context.backTransfers.receivedValue = big.NewInt(0)
+}
+
 func (context *outputContext) getOutputTransferDataFromESDTTransfer(
 	transfers []*vmcommon.ESDTTransfer,
 	vmOutput *vmcommon.VMOutput,
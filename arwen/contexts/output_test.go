@@ -7,6 +7,7 @@ import (
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
 	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
 	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
 	"github.com/ElrondNetwork/elrond-vm-common"
 	"github.com/stretchr/testify/require"
 )
@@ -423,6 +424,70 @@ func TestOutputContext_Transfer(t *testing.T) {
 	require.Equal(t, []byte("txdata"), destAccount.OutputTransfers[0].Data)
 }
 
+func TestOutputContext_BackTransfers(t *testing.T) {
+	t.Parallel()
+
+	caller := []byte("caller")
+	callee := []byte("callee")
+
+	host := &contextmock.VMHostMock{}
+	host.RuntimeContext = &contextmock.RuntimeContextMock{
+		SCAddress: callee,
+		VMInput: &vmcommon.VMInput{
+			CallerAddr: caller,
+			CallType:   vm.DirectCall,
+		},
+	}
+	mockWorld := worldmock.NewMockWorld()
+	mockWorld.AcctMap.PutAccount(&worldmock.Account{
+		Address: callee,
+		Balance: big.NewInt(10000),
+	})
+
+	blockchainContext, _ := NewBlockchainContext(host, mockWorld)
+	outputContext, _ := NewOutputContext(host)
+
+	host.OutputContext = outputContext
+	host.BlockchainContext = blockchainContext
+
+	// transfers outside a nested call are not back transfers
+	err := outputContext.Transfer(caller, callee, 0, 0, big.NewInt(10), nil, vm.DirectCall)
+	require.Nil(t, err)
+	esdtTransfers, value := outputContext.GetBackTransfers()
+	require.Empty(t, esdtTransfers)
+	require.Equal(t, big.NewInt(0), value)
+
+	// successful nested call
+	outputContext.PushState()
+	outputContext.CensorVMOutput()
+	err = outputContext.Transfer(caller, callee, 0, 0, big.NewInt(100), nil, vm.DirectCall)
+	require.Nil(t, err)
+	err = outputContext.Transfer([]byte("other"), callee, 0, 0, big.NewInt(1000), nil, vm.DirectCall)
+	require.Nil(t, err)
+	esdtTransfers, value = outputContext.GetBackTransfers()
+	require.Empty(t, esdtTransfers)
+	require.Equal(t, big.NewInt(0), value)
+	outputContext.PopMergeActiveState()
+
+	_, value = outputContext.GetBackTransfers()
+	require.Equal(t, big.NewInt(100), value)
+
+	// failed nested call
+	outputContext.PushState()
+	outputContext.CensorVMOutput()
+	err = outputContext.Transfer(caller, callee, 0, 0, big.NewInt(200), nil, vm.DirectCall)
+	require.Nil(t, err)
+	outputContext.PopSetActiveState()
+
+	_, value = outputContext.GetBackTransfers()
+	require.Equal(t, big.NewInt(100), value)
+
+	outputContext.ClearBackTransfers()
+	esdtTransfers, value = outputContext.GetBackTransfers()
+	require.Empty(t, esdtTransfers)
+	require.Equal(t, big.NewInt(0), value)
+}
+
 func TestOutputContext_Transfer_Errors_And_Checks(t *testing.T) {
 	t.Parallel()
 
@@ -561,6 +626,35 @@ func TestOutputContext_WriteLog(t *testing.T) {
 	require.Equal(t, outputContext.outputState.Logs[2].Topics, [][]byte{topic})
 }
 
+func TestOutputContext_WriteLogNotifiesObserver(t *testing.T) {
+	t.Parallel()
+
+	var observedLogs []*arwen.LogEvent
+	observer := &contextmock.ExecutionObserverStub{
+		OnLogCalled: func(event *arwen.LogEvent) {
+			observedLogs = append(observedLogs, event)
+		},
+	}
+	host := &contextmock.VMHostMock{
+		RuntimeContext: &contextmock.RuntimeContextMock{
+			CallFunction: "function",
+		},
+		ExecutionObserver: observer,
+	}
+	outputContext, _ := NewOutputContext(host)
+
+	address := []byte("address")
+	data := []byte("data")
+	topics := [][]byte{[]byte("topic")}
+
+	outputContext.WriteLog(address, topics, data)
+	require.Len(t, observedLogs, 1)
+	require.Equal(t, address, observedLogs[0].Address)
+	require.Equal(t, []byte("function"), observedLogs[0].Identifier)
+	require.Equal(t, topics, observedLogs[0].Topics)
+	require.Equal(t, data, observedLogs[0].Data)
+}
+
 func TestOutputContext_PopSetActiveStateIfStackIsEmptyShouldNotPanic(t *testing.T) {
 	t.Parallel()
 
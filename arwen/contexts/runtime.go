@@ -47,6 +47,8 @@ func NewRuntimeContext(
 	host arwen.VMHost,
 	vmType []byte,
 	builtInFuncContainer vmcommon.BuiltInFunctionContainer,
+	wasmModulePolicy *arwen.WASMModulePolicy,
+	wasmBackend arwen.WASMBackend,
 ) (*runtimeContext, error) {
 	scAPINames := host.GetAPIMethods().Names()
 
@@ -58,8 +60,13 @@ func NewRuntimeContext(
 		validator:     newWASMValidator(scAPINames, builtInFuncContainer),
 		errors:        nil,
 	}
+	context.validator.setModulePolicy(wasmModulePolicy)
 
-	context.instanceBuilder = &wasmerInstanceBuilder{}
+	instanceBuilder, err := NewInstanceBuilder(host, wasmBackend)
+	if err != nil {
+		return nil, err
+	}
+	context.instanceBuilder = instanceBuilder
 	context.InitState()
 
 	return context, nil
@@ -139,6 +146,7 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(codeHash []byte, gas
 
 	hostReference := uintptr(unsafe.Pointer(&context.host))
 	context.instance.SetContextData(hostReference)
+	context.observeFunctionCalls()
 	context.verifyCode = false
 
 	logRuntime.Trace("new instance created", "code", "cached compilation")
@@ -146,6 +154,15 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(codeHash []byte, gas
 }
 
 func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte, codeHash []byte, gasLimit uint64, newCode bool) error {
+	if newCode && context.verifyCode {
+		err := context.validator.verifyModulePolicy(contract)
+		if err != nil {
+			context.instance = nil
+			logRuntime.Trace("instance creation", "code", "bytecode", "error", err)
+			return err
+		}
+	}
+
 	gasSchedule := context.host.Metering().GasSchedule()
 	options := wasmer.CompilationOptions{
 		GasLimit:           gasLimit,
@@ -178,6 +195,7 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 
 	hostReference := uintptr(unsafe.Pointer(&context.host))
 	context.instance.SetContextData(hostReference)
+	context.observeFunctionCalls()
 
 	if newCode {
 		err = context.VerifyContractCode()
@@ -193,6 +211,26 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 	return nil
 }
 
+// observeFunctionCalls forwards the function calls of the new instance to the
+// ExecutionObserver, if one is registered and the WASM backend supports it
+func (context *runtimeContext) observeFunctionCalls() {
+	observable, ok := context.instance.(wasmer.FunctionCallObservable)
+	if !ok {
+		return
+	}
+
+	observer := context.host.Observer()
+	_, isDisabled := observer.(*disabledExecutionObserver)
+	if isDisabled {
+		return
+	}
+
+	observable.SetFunctionCallObserver(&functionCallNotifier{
+		observer: observer,
+		address:  context.GetSCAddress(),
+	})
+}
+
 // GetSCCode returns the SC code of the current SC.
 func (context *runtimeContext) GetSCCode() ([]byte, error) {
 	blockchain := context.host.Blockchain()
@@ -675,6 +713,15 @@ func (context *runtimeContext) ExecuteAsyncCall(address []byte, data []byte, val
 	})
 	context.SetRuntimeBreakpointValue(arwen.BreakpointAsyncCall)
 
+	context.host.Observer().OnAsyncCall(&arwen.AsyncCallEvent{
+		CallerAddr:  context.GetSCAddress(),
+		Destination: address,
+		Data:        data,
+		ValueBytes:  value,
+		GasLimit:    context.asyncCallInfo.GasLimit,
+		GasLocked:   gasToLock,
+	})
+
 	logRuntime.Trace("prepare async call",
 		"caller", context.GetSCAddress(),
 		"dest", address,
@@ -701,6 +748,17 @@ func (context *runtimeContext) AddAsyncContextCall(contextIdentifier []byte, asy
 	currentContextMap[string(contextIdentifier)].AsyncCalls =
 		append(currentContextMap[string(contextIdentifier)].AsyncCalls, asyncCall)
 
+	context.host.Observer().OnAsyncCall(&arwen.AsyncCallEvent{
+		CallerAddr:        context.GetSCAddress(),
+		Destination:       asyncCall.Destination,
+		Data:              asyncCall.Data,
+		ValueBytes:        asyncCall.ValueBytes,
+		GasLimit:          asyncCall.GasLimit,
+		ContextIdentifier: contextIdentifier,
+		SuccessCallback:   asyncCall.SuccessCallback,
+		ErrorCallback:     asyncCall.ErrorCallback,
+	})
+
 	return nil
 }
 
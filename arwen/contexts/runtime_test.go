@@ -51,7 +51,7 @@ func InitializeArwenAndWasmer() *contextmock.VMHostMock {
 	host.MeteringContext = mockMetering
 	host.BlockchainContext, _ = NewBlockchainContext(host, worldmock.NewMockWorld())
 	host.OutputContext, _ = NewOutputContext(host)
-	host.CryptoHook = factory.NewVMCrypto()
+	host.CryptoHook, _ = factory.NewVMCrypto()
 	return host
 }
 
@@ -60,6 +60,8 @@ func makeDefaultRuntimeContext(t *testing.T, host arwen.VMHost) *runtimeContext
 		host,
 		vmType,
 		builtInFunctions.NewBuiltInFunctionContainer(),
+		nil,
+		arwen.WASMBackendWasmer,
 	)
 	require.Nil(t, err)
 	require.NotNil(t, runtimeContext)
@@ -78,6 +80,23 @@ func TestNewRuntimeContext(t *testing.T) {
 	require.Nil(t, runtimeContext.asyncCallInfo)
 }
 
+func TestNewRuntimeContext_WASMBackend(t *testing.T) {
+	host := InitializeArwenAndWasmer()
+	builtInFuncContainer := builtInFunctions.NewBuiltInFunctionContainer()
+
+	runtimeContext, err := NewRuntimeContext(host, vmType, builtInFuncContainer, nil, "")
+	require.Nil(t, err)
+	require.IsType(t, &wasmerInstanceBuilder{}, runtimeContext.instanceBuilder)
+
+	runtimeContext, err = NewRuntimeContext(host, vmType, builtInFuncContainer, nil, arwen.WASMBackendGo)
+	require.Nil(t, err)
+	require.IsType(t, &goInstanceBuilder{}, runtimeContext.instanceBuilder)
+
+	runtimeContext, err = NewRuntimeContext(host, vmType, builtInFuncContainer, nil, "unknown")
+	require.ErrorIs(t, err, arwen.ErrUnknownWASMBackend)
+	require.Nil(t, runtimeContext)
+}
+
 func TestRuntimeContext_InitState(t *testing.T) {
 	host := InitializeArwenAndWasmer()
 	runtimeContext := makeDefaultRuntimeContext(t, host)
@@ -107,6 +107,7 @@ func (context *storageContext) GetStorage(key []byte) []byte {
 	metering.UseGas(gasToUse)
 
 	logStorage.Trace("get", "key", key, "value", value)
+	context.notifyStorageRead(context.address, key, value)
 
 	return value
 }
@@ -150,6 +151,7 @@ func (context *storageContext) GetStorageFromAddress(address []byte, key []byte)
 	metering.UseGas(gasToUse)
 
 	logStorage.Trace("get from address", "address", address, "key", key, "value", value)
+	context.notifyStorageRead(address, key, value)
 	return value
 }
 
@@ -175,6 +177,16 @@ func (context *storageContext) GetStorageUnmetered(key []byte) []byte {
 	return context.getStorageFromAddressUnmetered(context.address, key)
 }
 
+func (context *storageContext) notifyStorageRead(address []byte, key []byte, value []byte) {
+	context.host.Observer().OnStorageAccess(&arwen.StorageAccessEvent{
+		Address: address,
+		Key:     key,
+		Value:   value,
+		Write:   false,
+		Status:  arwen.StorageUnchanged,
+	})
+}
+
 // enableStorageProtection will prevent writing to protected keys
 func (context *storageContext) enableStorageProtection() {
 	context.arwenStorageProtectionEnabled = true
@@ -203,6 +215,20 @@ func (context *storageContext) SetProtectedStorage(key []byte, value []byte) (ar
 
 // SetStorage sets the given value at the given key.
 func (context *storageContext) SetStorage(key []byte, value []byte) (arwen.StorageStatus, error) {
+	status, err := context.setStorage(key, value)
+	context.host.Observer().OnStorageAccess(&arwen.StorageAccessEvent{
+		Address: context.address,
+		Key:     key,
+		Value:   value,
+		Write:   true,
+		Status:  status,
+		Err:     err,
+	})
+
+	return status, err
+}
+
+func (context *storageContext) setStorage(key []byte, value []byte) (arwen.StorageStatus, error) {
 	if context.host.Runtime().ReadOnly() {
 		logStorage.Trace("storage set", "error", "cannot set storage in readonly mode")
 		return arwen.StorageUnchanged, nil
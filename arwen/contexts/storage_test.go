@@ -94,6 +94,60 @@ func TestStorageContext_SetAddress(t *testing.T) {
 	require.Equal(t, []byte(nil), storageContext.GetStorage(keyA))
 }
 
+func TestStorageContext_NotifiesObserver(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account")
+	mockOutput := &contextmock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(address)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	mockRuntime := &contextmock.RuntimeContextMock{}
+	mockMetering := &contextmock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(config.MakeGasMapForTests())
+	mockMetering.BlockGasLimitMock = uint64(15000)
+
+	var observedEvents []*arwen.StorageAccessEvent
+	observer := &contextmock.ExecutionObserverStub{
+		OnStorageAccessCalled: func(event *arwen.StorageAccessEvent) {
+			observedEvents = append(observedEvents, event)
+		},
+	}
+
+	host := &contextmock.VMHostMock{
+		OutputContext:     mockOutput,
+		MeteringContext:   mockMetering,
+		RuntimeContext:    mockRuntime,
+		ExecutionObserver: observer,
+	}
+	bcHook := &contextmock.BlockchainHookStub{}
+
+	storageContext, _ := NewStorageContext(host, bcHook, elrondReservedTestPrefix)
+	storageContext.SetAddress(address)
+
+	key := []byte("key")
+	value := []byte("value")
+	storageStatus, err := storageContext.SetStorage(key, value)
+	require.Nil(t, err)
+	require.Equal(t, arwen.StorageAdded, storageStatus)
+	require.Equal(t, value, storageContext.GetStorage(key))
+
+	require.Len(t, observedEvents, 2)
+	require.True(t, observedEvents[0].Write)
+	require.Equal(t, address, observedEvents[0].Address)
+	require.Equal(t, key, observedEvents[0].Key)
+	require.Equal(t, value, observedEvents[0].Value)
+	require.Equal(t, arwen.StorageAdded, observedEvents[0].Status)
+	require.False(t, observedEvents[1].Write)
+	require.Equal(t, value, observedEvents[1].Value)
+
+	_, err = storageContext.SetStorage(elrondReservedTestPrefix, value)
+	require.Equal(t, arwen.ErrStoreElrondReservedKey, err)
+	require.Len(t, observedEvents, 3)
+	require.Equal(t, arwen.ErrStoreElrondReservedKey, observedEvents[2].Err)
+}
+
 func TestStorageContext_GetStorageUpdates(t *testing.T) {
 	t.Parallel()
 
@@ -14,6 +14,7 @@ const noArity = -1
 // wasmValidator is a validator for WASM SmartContracts
 type wasmValidator struct {
 	reserved *reservedFunctions
+	policy   *arwen.WASMModulePolicy
 }
 
 // newWASMValidator creates a new WASMValidator
@@ -23,6 +24,88 @@ func newWASMValidator(scAPINames vmcommon.FunctionNames, builtInFuncContainer vm
 	}
 }
 
+// setModulePolicy sets the policy to be enforced on the WASM module of new
+// contracts; a nil policy disables the verification
+func (validator *wasmValidator) setModulePolicy(policy *arwen.WASMModulePolicy) {
+	validator.policy = policy
+}
+
+// verifyModulePolicy checks the WASM module of a new contract against the
+// configured policy, before the module is compiled
+func (validator *wasmValidator) verifyModulePolicy(code []byte) error {
+	policy := validator.policy
+	if policy == nil {
+		return nil
+	}
+
+	module, err := parseWASMModule(code, policy.ForbidFloatingPoint)
+	if err != nil {
+		return err
+	}
+
+	if exceedsLimit(uint64(module.numImports), uint64(policy.MaxImports)) {
+		return arwen.ErrTooManyImports
+	}
+	if exceedsLimit(uint64(module.numFunctions), uint64(policy.MaxFunctions)) {
+		return arwen.ErrTooManyFunctions
+	}
+	if exceedsLimit(uint64(module.numGlobals), uint64(policy.MaxGlobals)) {
+		return arwen.ErrTooManyGlobals
+	}
+	if exceedsLimit(module.dataSegmentsSize, policy.MaxDataSegmentsSize) {
+		return arwen.ErrDataSegmentsTooLarge
+	}
+
+	for _, table := range module.tables {
+		if exceedsLimit(uint64(table.Min), uint64(policy.MaxTableSize)) {
+			return arwen.ErrTableTooLarge
+		}
+		if table.HasMax && exceedsLimit(uint64(table.Max), uint64(policy.MaxTableSize)) {
+			return arwen.ErrTableTooLarge
+		}
+	}
+
+	for _, memory := range module.memories {
+		if exceedsLimit(uint64(memory.Min), uint64(policy.MaxInitialMemoryPages)) {
+			return arwen.ErrInitialMemoryTooLarge
+		}
+		if exceedsLimit(uint64(memory.Min), uint64(policy.MaxMemoryPages)) {
+			return arwen.ErrMaxMemoryTooLarge
+		}
+		if memory.HasMax && exceedsLimit(uint64(memory.Max), uint64(policy.MaxMemoryPages)) {
+			return arwen.ErrMaxMemoryTooLarge
+		}
+	}
+
+	if policy.ForbidFloatingPoint && module.usesFloatingPoint {
+		return arwen.ErrFloatingPointInstruction
+	}
+
+	if policy.CustomSectionsWhitelist != nil {
+		for _, section := range module.customSections {
+			if !isWhitelisted(section, policy.CustomSectionsWhitelist) {
+				return fmt.Errorf("%w: %s", arwen.ErrCustomSectionNotAllowed, section)
+			}
+		}
+	}
+
+	return nil
+}
+
+func exceedsLimit(value uint64, limit uint64) bool {
+	return limit > 0 && value > limit
+}
+
+func isWhitelisted(name string, whitelist []string) bool {
+	for _, allowed := range whitelist {
+		if name == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (validator *wasmValidator) verifyMemoryDeclaration(instance wasmer.InstanceHandler) error {
 	if !instance.HasMemory() {
 		return arwen.ErrMemoryDeclarationMissing
@@ -1,6 +1,7 @@
 package contexts
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -95,3 +96,130 @@ func TestFunctionsGuard_Arity(t *testing.T) {
 	err = validator.verifyVoidFunction(instance, "wrongParamsAndReturn")
 	require.NotNil(t, err)
 }
+
+func TestFunctionsGuard_ModulePolicy_NilPolicy(t *testing.T) {
+	validator := newWASMValidator(MakeAPIImports().Names(), builtInFunctions.NewBuiltInFunctionContainer())
+
+	require.Nil(t, validator.verifyModulePolicy(makeTestWASMModule(false)))
+	require.Nil(t, validator.verifyModulePolicy([]byte("not a WASM module")))
+}
+
+func TestFunctionsGuard_ModulePolicy_MalformedModule(t *testing.T) {
+	validator := newWASMValidator(MakeAPIImports().Names(), builtInFunctions.NewBuiltInFunctionContainer())
+	validator.setModulePolicy(&arwen.WASMModulePolicy{})
+
+	require.Nil(t, validator.verifyModulePolicy(makeTestWASMModule(false)))
+
+	err := validator.verifyModulePolicy([]byte("not a WASM module"))
+	require.Equal(t, arwen.ErrInvalidWASMModule, err)
+	require.True(t, errors.Is(err, arwen.ErrContractInvalid))
+
+	module := makeTestWASMModule(false)
+	err = validator.verifyModulePolicy(module[:len(module)-3])
+	require.Equal(t, arwen.ErrInvalidWASMModule, err)
+}
+
+func TestFunctionsGuard_ModulePolicy_Limits(t *testing.T) {
+	module := makeTestWASMModule(false)
+
+	testCases := []struct {
+		policy      arwen.WASMModulePolicy
+		expectedErr error
+	}{
+		{arwen.WASMModulePolicy{MaxImports: 2}, nil},
+		{arwen.WASMModulePolicy{MaxImports: 1}, arwen.ErrTooManyImports},
+		{arwen.WASMModulePolicy{MaxFunctions: 2}, nil},
+		{arwen.WASMModulePolicy{MaxFunctions: 1}, arwen.ErrTooManyFunctions},
+		{arwen.WASMModulePolicy{MaxGlobals: 1}, nil},
+		{arwen.WASMModulePolicy{MaxGlobals: 1, MaxFunctions: 1}, arwen.ErrTooManyFunctions},
+		{arwen.WASMModulePolicy{MaxTableSize: 10}, nil},
+		{arwen.WASMModulePolicy{MaxTableSize: 9}, arwen.ErrTableTooLarge},
+		{arwen.WASMModulePolicy{MaxDataSegmentsSize: 5}, nil},
+		{arwen.WASMModulePolicy{MaxDataSegmentsSize: 4}, arwen.ErrDataSegmentsTooLarge},
+		{arwen.WASMModulePolicy{MaxInitialMemoryPages: 2}, nil},
+		{arwen.WASMModulePolicy{MaxInitialMemoryPages: 1}, arwen.ErrInitialMemoryTooLarge},
+		{arwen.WASMModulePolicy{MaxMemoryPages: 16}, nil},
+		{arwen.WASMModulePolicy{MaxMemoryPages: 15}, arwen.ErrMaxMemoryTooLarge},
+		{arwen.WASMModulePolicy{CustomSectionsWhitelist: []string{"name"}}, nil},
+	}
+
+	for _, testCase := range testCases {
+		policy := testCase.policy
+		validator := newWASMValidator(MakeAPIImports().Names(), builtInFunctions.NewBuiltInFunctionContainer())
+		validator.setModulePolicy(&policy)
+
+		err := validator.verifyModulePolicy(module)
+		require.Equal(t, testCase.expectedErr, err, "policy %+v", policy)
+	}
+}
+
+func TestFunctionsGuard_ModulePolicy_CustomSections(t *testing.T) {
+	validator := newWASMValidator(MakeAPIImports().Names(), builtInFunctions.NewBuiltInFunctionContainer())
+	validator.setModulePolicy(&arwen.WASMModulePolicy{CustomSectionsWhitelist: []string{}})
+
+	err := validator.verifyModulePolicy(makeTestWASMModule(false))
+	require.True(t, errors.Is(err, arwen.ErrCustomSectionNotAllowed))
+	require.True(t, errors.Is(err, arwen.ErrContractInvalid))
+}
+
+func TestFunctionsGuard_ModulePolicy_FloatingPoint(t *testing.T) {
+	validator := newWASMValidator(MakeAPIImports().Names(), builtInFunctions.NewBuiltInFunctionContainer())
+
+	validator.setModulePolicy(&arwen.WASMModulePolicy{ForbidFloatingPoint: false})
+	require.Nil(t, validator.verifyModulePolicy(makeTestWASMModule(true)))
+
+	validator.setModulePolicy(&arwen.WASMModulePolicy{ForbidFloatingPoint: true})
+	require.Nil(t, validator.verifyModulePolicy(makeTestWASMModule(false)))
+	require.Equal(t, arwen.ErrFloatingPointInstruction, validator.verifyModulePolicy(makeTestWASMModule(true)))
+}
+
+// makeTestWASMModule builds a module with 2 imported and 2 defined functions,
+// 1 global, a table of 10 elements, a memory of 2 to 16 pages, a data segment
+// of 5 bytes and a "name" custom section; the second function uses a floating
+// point constant if useFloats is set
+func makeTestWASMModule(useFloats bool) []byte {
+	secondBody := []byte{0x00, 0x41, 0x05, 0x1A, 0x0B}
+	if useFloats {
+		secondBody = []byte{0x00, 0x43, 0x00, 0x00, 0x80, 0x3F, 0x1A, 0x0B}
+	}
+
+	codeSection := []byte{0x02, 0x03, 0x00, 0x01, 0x0B, byte(len(secondBody))}
+	codeSection = append(codeSection, secondBody...)
+
+	module := []byte{0x00, 0x61, 0x73, 0x6D, 0x01, 0x00, 0x00, 0x00}
+	module = appendTestWASMSection(module, 1, []byte{0x01, 0x60, 0x00, 0x00})
+	module = appendTestWASMSection(module, 2, []byte{
+		0x02,
+		0x03, 'e', 'n', 'v', 0x01, 'f', 0x00, 0x00,
+		0x03, 'e', 'n', 'v', 0x01, 'g', 0x00, 0x00,
+	})
+	module = appendTestWASMSection(module, 3, []byte{0x02, 0x00, 0x00})
+	module = appendTestWASMSection(module, 4, []byte{0x01, 0x70, 0x00, 0x0A})
+	module = appendTestWASMSection(module, 5, []byte{0x01, 0x01, 0x02, 0x10})
+	module = appendTestWASMSection(module, 6, []byte{0x01, 0x7F, 0x01, 0x41, 0x00, 0x0B})
+	module = appendTestWASMSection(module, 10, codeSection)
+	module = appendTestWASMSection(module, 11, []byte{0x01, 0x00, 0x41, 0x00, 0x0B, 0x05, 'h', 'e', 'l', 'l', 'o'})
+	module = appendTestWASMSection(module, 0, []byte{0x04, 'n', 'a', 'm', 'e', 0x00})
+
+	return module
+}
+
+func appendTestWASMSection(module []byte, sectionID byte, content []byte) []byte {
+	module = append(module, sectionID, byte(len(content)))
+	return append(module, content...)
+}
+
+func TestParseWASMModuleSummary(t *testing.T) {
+	summary, err := ParseWASMModuleSummary(makeTestWASMModule(false))
+	require.Nil(t, err)
+
+	require.Equal(t, []WASMImport{{Module: "env", Name: "f"}, {Module: "env", Name: "g"}}, summary.FunctionImports)
+	require.Equal(t, uint32(2), summary.NumFunctions)
+	require.Equal(t, uint32(1), summary.NumGlobals)
+	require.Equal(t, []WASMMemory{{InitialPages: 2, MaxPages: 16, HasMax: true}}, summary.Memories)
+	require.Equal(t, uint64(5), summary.DataSegmentsSize)
+	require.Equal(t, []string{"name"}, summary.CustomSections)
+
+	_, err = ParseWASMModuleSummary([]byte{0x00, 0x61, 0x73})
+	require.Equal(t, arwen.ErrInvalidWASMModule, err)
+}
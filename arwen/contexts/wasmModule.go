@@ -0,0 +1,217 @@
+package contexts
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/wasmparser"
+)
+
+// WASMImport is a function imported by a WASM module
+type WASMImport struct {
+	Module string
+	Name   string
+}
+
+// WASMMemory describes a memory declared or imported by a WASM module, in pages
+type WASMMemory struct {
+	InitialPages uint32
+	MaxPages     uint32
+	HasMax       bool
+}
+
+// WASMModuleSummary holds the declarations of a WASM module which can be read
+// without compiling it
+type WASMModuleSummary struct {
+	FunctionImports  []WASMImport
+	NumFunctions     uint32
+	NumGlobals       uint32
+	Memories         []WASMMemory
+	DataSegmentsSize uint64
+	CustomSections   []string
+}
+
+// ParseWASMModuleSummary reads the declarations of the given WASM module
+func ParseWASMModuleSummary(code []byte) (*WASMModuleSummary, error) {
+	info, err := parseWASMModule(code, false)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &WASMModuleSummary{
+		FunctionImports:  info.functionImports,
+		NumFunctions:     info.numFunctions,
+		NumGlobals:       info.numGlobals,
+		Memories:         make([]WASMMemory, 0, len(info.memories)),
+		DataSegmentsSize: info.dataSegmentsSize,
+		CustomSections:   info.customSections,
+	}
+	for _, memory := range info.memories {
+		summary.Memories = append(summary.Memories, WASMMemory{
+			InitialPages: memory.Min,
+			MaxPages:     memory.Max,
+			HasMax:       memory.HasMax,
+		})
+	}
+
+	return summary, nil
+}
+
+// wasmModuleInfo holds the properties of a WASM module which are relevant to
+// the WASMModulePolicy
+type wasmModuleInfo struct {
+	numImports        uint32
+	functionImports   []WASMImport
+	numFunctions      uint32
+	numGlobals        uint32
+	tables            []wasmparser.Limits
+	memories          []wasmparser.Limits
+	dataSegmentsSize  uint64
+	customSections    []string
+	usesFloatingPoint bool
+}
+
+// parseWASMModule reads the sections of a WASM module; the instructions of the
+// functions are decoded only if decodeCode is set
+func parseWASMModule(code []byte, decodeCode bool) (*wasmModuleInfo, error) {
+	sections, err := wasmparser.ReadSections(code)
+	if err != nil {
+		return nil, arwen.ErrInvalidWASMModule
+	}
+
+	info := &wasmModuleInfo{}
+	for _, section := range sections {
+		err = info.readSection(section, decodeCode)
+		if err != nil {
+			return nil, arwen.ErrInvalidWASMModule
+		}
+	}
+
+	return info, nil
+}
+
+func (info *wasmModuleInfo) readSection(section wasmparser.Section, decodeCode bool) error {
+	switch section.ID {
+	case wasmparser.SectionCustom:
+		name, _, err := wasmparser.DecodeCustomSection(section.Data)
+		info.customSections = append(info.customSections, name)
+		return err
+	case wasmparser.SectionImport:
+		return info.readImportSection(section.Data)
+	case wasmparser.SectionFunction:
+		functions, err := wasmparser.DecodeFunctionSection(section.Data)
+		info.numFunctions = uint32(len(functions))
+		return err
+	case wasmparser.SectionTable:
+		tables, err := wasmparser.DecodeTableSection(section.Data)
+		for _, table := range tables {
+			info.tables = append(info.tables, table.Limits)
+		}
+		return err
+	case wasmparser.SectionMemory:
+		memories, err := wasmparser.DecodeMemorySection(section.Data)
+		info.memories = append(info.memories, memories...)
+		return err
+	case wasmparser.SectionGlobal:
+		return info.readGlobalSection(section.Data)
+	case wasmparser.SectionCode:
+		if !decodeCode {
+			return nil
+		}
+		return info.readCodeSection(section.Data)
+	case wasmparser.SectionData:
+		return info.readDataSection(section.Data)
+	default:
+		return nil
+	}
+}
+
+func (info *wasmModuleInfo) readImportSection(data []byte) error {
+	imports, err := wasmparser.DecodeImportSection(data)
+	if err != nil {
+		return err
+	}
+
+	info.numImports = uint32(len(imports))
+	for _, imported := range imports {
+		switch imported.Kind {
+		case wasmparser.ExternalFunction:
+			info.functionImports = append(info.functionImports, WASMImport{Module: imported.Module, Name: imported.Name})
+		case wasmparser.ExternalTable:
+			info.tables = append(info.tables, imported.Table.Limits)
+		case wasmparser.ExternalMemory:
+			info.memories = append(info.memories, imported.Memory)
+		case wasmparser.ExternalGlobal:
+			info.numGlobals++
+		}
+	}
+
+	return nil
+}
+
+func (info *wasmModuleInfo) readGlobalSection(data []byte) error {
+	globals, err := wasmparser.DecodeGlobalSection(data)
+	if err != nil {
+		return err
+	}
+
+	info.numGlobals += uint32(len(globals))
+	for _, global := range globals {
+		err = info.scanInstructions(global.Init)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (info *wasmModuleInfo) readCodeSection(data []byte) error {
+	bodies, err := wasmparser.DecodeCodeSection(data)
+	if err != nil {
+		return err
+	}
+
+	for _, body := range bodies {
+		err = info.scanInstructions(body.Code)
+		if err != nil || info.usesFloatingPoint {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (info *wasmModuleInfo) readDataSection(data []byte) error {
+	segments, err := wasmparser.DecodeDataSection(data)
+	if err != nil {
+		return err
+	}
+
+	for _, segment := range segments {
+		err = info.scanInstructions(segment.Offset)
+		if err != nil {
+			return err
+		}
+
+		info.dataSegmentsSize += uint64(len(segment.Data))
+	}
+
+	return nil
+}
+
+// scanInstructions reads the given instructions, looking for floating point
+// ones; the scan stops at the first one found
+func (info *wasmModuleInfo) scanInstructions(code []byte) error {
+	reader := wasmparser.NewReader(code)
+	for !reader.IsAtEnd() {
+		instruction, err := reader.ReadInstruction()
+		if instruction.IsFloatingPoint() {
+			info.usesFloatingPoint = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
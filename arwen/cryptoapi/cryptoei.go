@@ -9,10 +9,18 @@ package cryptoapi
 // extern int32_t v1_4_sha256(void* context, int32_t dataOffset, int32_t length, int32_t resultOffset);
 // extern int32_t v1_4_keccak256(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
 // extern int32_t v1_4_ripemd160(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
+// extern int32_t v1_4_sha512(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
+// extern int32_t v1_4_sha3256(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
+// extern int32_t v1_4_blake2b256(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
+// extern int32_t v1_4_blake2s256(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
 // extern int32_t v1_4_verifyBLS(void *context, int32_t keyOffset, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
+// extern int32_t v1_4_verifyBLSMultiSig(void *context, int32_t numKeys, int32_t keysOffset, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
+// extern int32_t v1_4_verifyBLSAggregated(void *context, int32_t numKeys, int32_t keysOffset, int32_t messagesLengthOffset, int32_t messagesOffset, int32_t sigOffset);
 // extern int32_t v1_4_verifyEd25519(void *context, int32_t keyOffset, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
 // extern int32_t v1_4_verifySecp256k1(void *context, int32_t keyOffset, int32_t keyLength, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
 // extern int32_t v1_4_verifyCustomSecp256k1(void *context, int32_t keyOffset, int32_t keyLength, int32_t messageOffset, int32_t messageLength, int32_t sigOffset, int32_t hashType);
+// extern int32_t v1_4_verifySecp256r1(void *context, int32_t keyOffset, int32_t keyLength, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
+// extern int32_t v1_4_verifyMerkleProof(void *context, int32_t leafOffset, int32_t leafLength, int32_t proofOffset, int32_t proofLength, long long index, int32_t rootOffset, int32_t flags);
 // extern int32_t v1_4_encodeSecp256k1DerSignature(void *context, int32_t rOffset, int32_t rLength, int32_t sOffset, int32_t sLength, int32_t sigOffset);
 // extern void v1_4_addEC(void *context, int32_t xResultHandle, int32_t yResultHandle, int32_t ecHandle, int32_t fstPointXHandle, int32_t fstPointYHandle, int32_t sndPointXHandle, int32_t sndPointYHandle);
 // extern void v1_4_doubleEC(void *context, int32_t xResultHandle, int32_t yResultHandle, int32_t ecHandle, int32_t pointXHandle, int32_t pointYHandle);
@@ -32,9 +40,13 @@ import "C"
 
 import (
 	"crypto/elliptic"
+	"encoding/binary"
+	basicMath "math"
 	"unsafe"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/merkle"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing/secp256k1"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/math"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer"
@@ -47,16 +59,47 @@ const ed25519SignatureLength = 64
 const secp256k1CompressedPublicKeyLength = 33
 const secp256k1UncompressedPublicKeyLength = 65
 const secp256k1SignatureLength = 64
+const secp256r1CompressedPublicKeyLength = 33
+const secp256r1UncompressedPublicKeyLength = 65
 const curveNameLength = 4
+const merkleProofHashLength = 32
+
+// The flags of a binary Merkle proof verification, configuring how the tree
+// was built; with no flag set, the leaf is a SHA-256 digest and each pair of
+// digests is hashed in the order given by the leaf index
+const (
+	// merkleProofKeccak256 selects Keccak-256 instead of SHA-256
+	merkleProofKeccak256 = 1 << iota
+
+	// merkleProofHashLeaf hashes the leaf before combining it with its sibling
+	merkleProofHashLeaf
+
+	// merkleProofSortPairs orders each pair of digests bytewise before hashing
+	merkleProofSortPairs
+
+	// merkleProofDomainSeparation prepends 0x00 to the leaf and 0x01 to each
+	// pair of digests when hashing them, as in RFC 6962
+	merkleProofDomainSeparation
+
+	merkleProofAllFlags = merkleProofKeccak256 | merkleProofHashLeaf | merkleProofSortPairs | merkleProofDomainSeparation
+)
 
 const (
 	sha256Name                      = "sha256"
 	keccak256Name                   = "keccak256"
 	ripemd160Name                   = "ripemd160"
+	sha512Name                      = "sha512"
+	sha3256Name                     = "sha3256"
+	blake2b256Name                  = "blake2b256"
+	blake2s256Name                  = "blake2s256"
 	verifyBLSName                   = "verifyBLS"
+	verifyBLSMultiSigName           = "verifyBLSMultiSig"
+	verifyBLSAggregatedName         = "verifyBLSAggregated"
 	verifyEd25519Name               = "verifyEd25519"
 	verifySecp256k1Name             = "verifySecp256k1"
 	verifyCustomSecp256k1Name       = "verifyCustomSecp256k1"
+	verifySecp256r1Name             = "verifySecp256r1"
+	verifyMerkleProofName           = "verifyMerkleProof"
 	encodeSecp256k1DerSignatureName = "encodeSecp256k1DerSignature"
 	addECName                       = "addEC"
 	doubleECName                    = "doubleEC"
@@ -92,11 +135,41 @@ func CryptoImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("sha512", v1_4_sha512, C.v1_4_sha512)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("sha3256", v1_4_sha3256, C.v1_4_sha3256)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("blake2b256", v1_4_blake2b256, C.v1_4_blake2b256)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("blake2s256", v1_4_blake2s256, C.v1_4_blake2s256)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("verifyBLS", v1_4_verifyBLS, C.v1_4_verifyBLS)
 	if err != nil {
 		return nil, err
 	}
 
+	imports, err = imports.Append("verifyBLSMultiSig", v1_4_verifyBLSMultiSig, C.v1_4_verifyBLSMultiSig)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("verifyBLSAggregated", v1_4_verifyBLSAggregated, C.v1_4_verifyBLSAggregated)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("verifyEd25519", v1_4_verifyEd25519, C.v1_4_verifyEd25519)
 	if err != nil {
 		return nil, err
@@ -112,6 +185,16 @@ func CryptoImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("verifySecp256r1", v1_4_verifySecp256r1, C.v1_4_verifySecp256r1)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("verifyMerkleProof", v1_4_verifyMerkleProof, C.v1_4_verifyMerkleProof)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("encodeSecp256k1DerSignature", v1_4_encodeSecp256k1DerSignature, C.v1_4_encodeSecp256k1DerSignature)
 	if err != nil {
 		return nil, err
@@ -273,6 +356,80 @@ func v1_4_ripemd160(context unsafe.Pointer, dataOffset int32, length int32, resu
 	return 0
 }
 
+//export v1_4_sha512
+func v1_4_sha512(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	cryptoCosts := metering.GasSchedule().CryptoAPICost
+
+	return hashRawData(context, sha512Name, cryptoCosts.SHA512, cryptoCosts.SHA512PerByte, crypto.Sha512, dataOffset, length, resultOffset)
+}
+
+//export v1_4_sha3256
+func v1_4_sha3256(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	cryptoCosts := metering.GasSchedule().CryptoAPICost
+
+	return hashRawData(context, sha3256Name, cryptoCosts.SHA3256, cryptoCosts.SHA3256PerByte, crypto.Sha3256, dataOffset, length, resultOffset)
+}
+
+//export v1_4_blake2b256
+func v1_4_blake2b256(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	cryptoCosts := metering.GasSchedule().CryptoAPICost
+
+	return hashRawData(context, blake2b256Name, cryptoCosts.Blake2b256, cryptoCosts.Blake2b256PerByte, crypto.Blake2b256, dataOffset, length, resultOffset)
+}
+
+//export v1_4_blake2s256
+func v1_4_blake2s256(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	cryptoCosts := metering.GasSchedule().CryptoAPICost
+
+	return hashRawData(context, blake2s256Name, cryptoCosts.Blake2s256, cryptoCosts.Blake2s256PerByte, crypto.Blake2s256, dataOffset, length, resultOffset)
+}
+
+// hashRawData hashes data from the WASM memory and stores the result back in
+// the memory, charging a base cost and a cost per hashed byte
+func hashRawData(
+	context unsafe.Pointer,
+	functionName string,
+	baseCost uint64,
+	costPerByte uint64,
+	hash func(data []byte) ([]byte, error),
+	dataOffset int32,
+	length int32,
+	resultOffset int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(functionName)
+
+	costPerByte = math.AddUint64(costPerByte, metering.GasSchedule().BaseOperationCost.DataCopyPerByte)
+	gasToUse := math.AddUint64(baseCost, math.MulUint64(costPerByte, uint64(length)))
+	metering.UseAndTraceGas(gasToUse)
+
+	data, err := runtime.MemLoad(dataOffset, length)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	result, err := hash(data)
+	if err != nil {
+		return 1
+	}
+
+	err = runtime.MemStore(resultOffset, result)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
 //export v1_4_verifyBLS
 func v1_4_verifyBLS(
 	context unsafe.Pointer,
@@ -315,6 +472,155 @@ func v1_4_verifyBLS(
 	return 0
 }
 
+//export v1_4_verifyBLSMultiSig
+func v1_4_verifyBLSMultiSig(
+	context unsafe.Pointer,
+	numKeys int32,
+	keysOffset int32,
+	messageOffset int32,
+	messageLength int32,
+	sigOffset int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(verifyBLSMultiSigName)
+
+	if numKeys < 0 {
+		_ = arwen.WithFault(arwen.ErrNegativeLength, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse := math.MulUint64(metering.GasSchedule().CryptoAPICost.VerifyBLSMultiSigPerKey, uint64(numKeys))
+	gasToUse = math.AddUint64(metering.GasSchedule().CryptoAPICost.VerifyBLSMultiSig, gasToUse)
+	metering.UseAndTraceGas(gasToUse)
+
+	keys, err := loadFixedLengthItems(runtime, numKeys, blsPublicKeyLength, keysOffset)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(messageLength))
+	metering.UseAndTraceGas(gasToUse)
+
+	message, err := runtime.MemLoad(messageOffset, messageLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	sig, err := runtime.MemLoad(sigOffset, blsSignatureLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	invalidSigErr := crypto.VerifyBLSMultiSig(keys, message, sig)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_4_verifyBLSAggregated
+func v1_4_verifyBLSAggregated(
+	context unsafe.Pointer,
+	numKeys int32,
+	keysOffset int32,
+	messagesLengthOffset int32,
+	messagesOffset int32,
+	sigOffset int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(verifyBLSAggregatedName)
+
+	if numKeys < 0 {
+		_ = arwen.WithFault(arwen.ErrNegativeLength, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse := math.MulUint64(metering.GasSchedule().CryptoAPICost.VerifyBLSAggregatedPerKey, uint64(numKeys))
+	gasToUse = math.AddUint64(metering.GasSchedule().CryptoAPICost.VerifyBLSAggregated, gasToUse)
+	metering.UseAndTraceGas(gasToUse)
+
+	keys, err := loadFixedLengthItems(runtime, numKeys, blsPublicKeyLength, keysOffset)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	messageLengths, totalLength, err := loadMessageLengths(runtime, numKeys, messagesLengthOffset)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, totalLength)
+	metering.UseAndTraceGas(gasToUse)
+
+	messages, err := runtime.MemLoadMultiple(messagesOffset, messageLengths)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	sig, err := runtime.MemLoad(sigOffset, blsSignatureLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	invalidSigErr := crypto.VerifyBLSAggregated(keys, messages, sig)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
+// loadFixedLengthItems loads the given number of items of the same length,
+// stored contiguously in the WASM memory, such as BLS public keys
+func loadFixedLengthItems(runtime arwen.RuntimeContext, numItems int32, itemLength int32, offset int32) ([][]byte, error) {
+	if int64(numItems)*int64(itemLength) > basicMath.MaxInt32 {
+		return nil, arwen.ErrArgOutOfRange
+	}
+
+	data, err := runtime.MemLoad(offset, numItems*itemLength)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([][]byte, numItems)
+	for i := range items {
+		items[i] = data[int32(i)*itemLength : int32(i+1)*itemLength]
+	}
+
+	return items, nil
+}
+
+// loadMessageLengths loads the given number of message lengths, stored in the
+// WASM memory as little endian int32 values, and returns them together with
+// their sum
+func loadMessageLengths(runtime arwen.RuntimeContext, numMessages int32, lengthsOffset int32) ([]int32, uint64, error) {
+	if int64(numMessages)*4 > basicMath.MaxInt32 {
+		return nil, 0, arwen.ErrArgOutOfRange
+	}
+
+	lengthsData, err := runtime.MemLoad(lengthsOffset, numMessages*4)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	lengths := make([]int32, numMessages)
+	totalLength := uint64(0)
+	for i := range lengths {
+		lengths[i] = int32(binary.LittleEndian.Uint32(lengthsData[i*4 : (i+1)*4]))
+		if lengths[i] < 0 {
+			return nil, 0, arwen.ErrNegativeLength
+		}
+		totalLength = math.AddUint64(totalLength, uint64(lengths[i]))
+	}
+
+	return lengths, totalLength, nil
+}
+
 //export v1_4_verifyEd25519
 func v1_4_verifyEd25519(
 	context unsafe.Pointer,
@@ -435,6 +741,140 @@ func v1_4_verifySecp256k1(
 	)
 }
 
+//export v1_4_verifySecp256r1
+func v1_4_verifySecp256r1(
+	context unsafe.Pointer,
+	keyOffset int32,
+	keyLength int32,
+	messageOffset int32,
+	messageLength int32,
+	sigOffset int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(verifySecp256r1Name)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.VerifySecp256r1
+	metering.UseAndTraceGas(gasToUse)
+
+	if keyLength != secp256r1CompressedPublicKeyLength && keyLength != secp256r1UncompressedPublicKeyLength {
+		_ = arwen.WithFault(arwen.ErrInvalidPublicKeySize, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(messageLength))
+	metering.UseAndTraceGas(gasToUse)
+
+	message, err := runtime.MemLoad(messageOffset, messageLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	// the signature is DER encoded, like the secp256k1 ones
+	const sigHeaderLength = 2
+	sigHeader, err := runtime.MemLoad(sigOffset, sigHeaderLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	sigLength := int32(sigHeader[1]) + sigHeaderLength
+	sig, err := runtime.MemLoad(sigOffset, sigLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	invalidSigErr := crypto.VerifySecp256r1(key, message, sig)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_4_verifyMerkleProof
+func v1_4_verifyMerkleProof(
+	context unsafe.Pointer,
+	leafOffset int32,
+	leafLength int32,
+	proofOffset int32,
+	proofLength int32,
+	index int64,
+	rootOffset int32,
+	flags int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(verifyMerkleProofName)
+
+	if proofLength < 0 {
+		_ = arwen.WithFault(arwen.ErrNegativeLength, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse := math.MulUint64(metering.GasSchedule().CryptoAPICost.MerkleProofPerStep, uint64(proofLength))
+	gasToUse = math.AddUint64(metering.GasSchedule().CryptoAPICost.MerkleProof, gasToUse)
+	metering.UseAndTraceGas(gasToUse)
+
+	hashFunc, options, err := getMerkleProofOptions(crypto, flags)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(leafLength))
+	metering.UseAndTraceGas(gasToUse)
+
+	leaf, err := runtime.MemLoad(leafOffset, leafLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	proof, err := loadFixedLengthItems(runtime, proofLength, merkleProofHashLength, proofOffset)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	root, err := runtime.MemLoad(rootOffset, merkleProofHashLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	invalidProofErr := merkle.VerifyBinaryProof(hashFunc, leaf, proof, uint64(index), root, options)
+	if invalidProofErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
+// getMerkleProofOptions translates the flags of a binary Merkle proof
+// verification into the hash function and the options of the tree
+func getMerkleProofOptions(crypto crypto.VMCrypto, flags int32) (merkle.HashFunc, merkle.BinaryProofOptions, error) {
+	options := merkle.BinaryProofOptions{}
+	if flags&^merkleProofAllFlags != 0 {
+		return nil, options, arwen.ErrInvalidMerkleProofFlags
+	}
+
+	hashFunc := crypto.Sha256
+	if flags&merkleProofKeccak256 != 0 {
+		hashFunc = crypto.Keccak256
+	}
+
+	options.HashLeaf = flags&merkleProofHashLeaf != 0
+	options.SortPairs = flags&merkleProofSortPairs != 0
+	if flags&merkleProofDomainSeparation != 0 {
+		options.LeafPrefix = []byte{0}
+		options.NodePrefix = []byte{1}
+	}
+
+	return hashFunc, options, nil
+}
+
 //export v1_4_encodeSecp256k1DerSignature
 func v1_4_encodeSecp256k1DerSignature(
 	context unsafe.Pointer,
@@ -1005,22 +1445,27 @@ func v1_4_createEC(context unsafe.Pointer, dataOffset int32, dataLength int32) i
 	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
 		return -1
 	}
-	curveChoice := string(data[:])
+	curveParams := getCurveParams(string(data))
+	if curveParams == nil {
+		return -1
+	}
+	return managedType.PutEllipticCurve(curveParams)
+}
+
+// getCurveParams returns the parameters of the curve with the given name, or
+// nil if no such curve is supported
+func getCurveParams(curveChoice string) *elliptic.CurveParams {
 	switch curveChoice {
 	case "p224":
-		curveParams := elliptic.P224().Params()
-		return managedType.PutEllipticCurve(curveParams)
+		return elliptic.P224().Params()
 	case "p256":
-		curveParams := elliptic.P256().Params()
-		return managedType.PutEllipticCurve(curveParams)
+		return elliptic.P256().Params()
 	case "p384":
-		curveParams := elliptic.P384().Params()
-		return managedType.PutEllipticCurve(curveParams)
+		return elliptic.P384().Params()
 	case "p521":
-		curveParams := elliptic.P521().Params()
-		return managedType.PutEllipticCurve(curveParams)
+		return elliptic.P521().Params()
 	}
-	return -1
+	return nil
 }
 
 //export v1_4_getCurveLengthEC
@@ -0,0 +1,262 @@
+package cryptoapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context/eicontext"
+	"github.com/ElrondNetwork/elrond-go-core/hashing/blake2b"
+	"github.com/ElrondNetwork/elrond-go-crypto"
+	"github.com/ElrondNetwork/elrond-go-crypto/signing"
+	"github.com/ElrondNetwork/elrond-go-crypto/signing/mcl"
+	"github.com/ElrondNetwork/elrond-go-crypto/signing/mcl/multisig"
+	"github.com/ElrondNetwork/elrond-go-crypto/signing/mcl/singlesig"
+	herumi "github.com/herumi/bls-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+type blsSigners struct {
+	suite       crypto.Suite
+	privateKeys []crypto.PrivateKey
+	publicKeys  []crypto.PublicKey
+	keys        []byte
+}
+
+func newBLSSigners(t *testing.T, numSigners int) *blsSigners {
+	signers := &blsSigners{suite: mcl.NewSuiteBLS12()}
+	keyGenerator := signing.NewKeyGenerator(signers.suite)
+	for i := 0; i < numSigners; i++ {
+		privateKey, publicKey := keyGenerator.GeneratePair()
+		key, err := publicKey.ToByteArray()
+		require.Nil(t, err)
+
+		signers.privateKeys = append(signers.privateKeys, privateKey)
+		signers.publicKeys = append(signers.publicKeys, publicKey)
+		signers.keys = append(signers.keys, key...)
+	}
+
+	return signers
+}
+
+func TestVerifyBLSMultiSig(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	signers := newBLSSigners(t, 3)
+	message := []byte("message signed by all")
+
+	hasher, err := blake2b.NewBlake2bWithSize(16)
+	require.Nil(t, err)
+	multiSigner := &multisig.BlsMultiSigner{Hasher: hasher}
+
+	shares := make([][]byte, len(signers.privateKeys))
+	for i, privateKey := range signers.privateKeys {
+		shares[i], err = multiSigner.SignShare(privateKey, message)
+		require.Nil(t, err)
+	}
+	aggSig, err := multiSigner.AggregateSignatures(signers.suite, shares, signers.publicKeys)
+	require.Nil(t, err)
+
+	keysOffset := context.Runtime.Store(signers.keys)
+	messageOffset := context.Runtime.Store(message)
+	sigOffset := context.Runtime.Store(aggSig)
+	otherSigOffset := context.Runtime.Store(shares[0])
+
+	result := v1_4_verifyBLSMultiSig(context.Pointer, 3, keysOffset, messageOffset, int32(len(message)), sigOffset)
+	require.Equal(t, int32(0), result)
+
+	result = v1_4_verifyBLSMultiSig(context.Pointer, 2, keysOffset, messageOffset, int32(len(message)), sigOffset)
+	require.Equal(t, int32(-1), result)
+
+	result = v1_4_verifyBLSMultiSig(context.Pointer, 3, keysOffset, messageOffset, int32(len(message)), otherSigOffset)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+
+	result = v1_4_verifyBLSMultiSig(context.Pointer, -1, keysOffset, messageOffset, int32(len(message)), sigOffset)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrNegativeLength, context.Runtime.Err)
+
+	context.Runtime.Err = nil
+	result = v1_4_verifyBLSMultiSig(context.Pointer, 100, keysOffset, messageOffset, int32(len(message)), sigOffset)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrBadBounds, context.Runtime.Err)
+}
+
+func TestVerifyBLSAggregated(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	signers := newBLSSigners(t, 3)
+	signer := singlesig.NewBlsSigner()
+
+	messages := make([]byte, 0)
+	messageLengths := make([]byte, 0)
+	sigs := make([]herumi.Sign, len(signers.privateKeys))
+	for i, privateKey := range signers.privateKeys {
+		message := []byte(fmt.Sprintf("message of signer %d", i))
+		messages = append(messages, message...)
+		messageLengths = appendInt32(messageLengths, int32(len(message)))
+
+		sig, err := signer.Sign(privateKey, message)
+		require.Nil(t, err)
+		require.Nil(t, sigs[i].Deserialize(sig))
+	}
+	aggSig := &herumi.Sign{}
+	aggSig.Aggregate(sigs)
+
+	keysOffset := context.Runtime.Store(signers.keys)
+	lengthsOffset := context.Runtime.Store(messageLengths)
+	messagesOffset := context.Runtime.Store(messages)
+	sigOffset := context.Runtime.Store(aggSig.Serialize())
+	otherSigOffset := context.Runtime.Store(sigs[0].Serialize())
+	negativeLengthOffset := context.Runtime.Store(appendInt32(nil, -1))
+
+	result := v1_4_verifyBLSAggregated(context.Pointer, 3, keysOffset, lengthsOffset, messagesOffset, sigOffset)
+	require.Equal(t, int32(0), result)
+
+	result = v1_4_verifyBLSAggregated(context.Pointer, 1, keysOffset, lengthsOffset, messagesOffset, otherSigOffset)
+	require.Equal(t, int32(0), result)
+
+	result = v1_4_verifyBLSAggregated(context.Pointer, 2, keysOffset, lengthsOffset, messagesOffset, sigOffset)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+
+	result = v1_4_verifyBLSAggregated(context.Pointer, 1, keysOffset, negativeLengthOffset, messagesOffset, sigOffset)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrNegativeLength, context.Runtime.Err)
+}
+
+func TestVerifySecp256r1(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	key, message, sig := signSecp256r1(t, []byte("webauthn assertion"))
+
+	keyOffset := context.Runtime.Store(key)
+	messageOffset := context.Runtime.Store(message)
+	sigOffset := context.Runtime.Store(sig)
+
+	result := v1_4_verifySecp256r1(context.Pointer, keyOffset, int32(len(key)), messageOffset, int32(len(message)), sigOffset)
+	require.Equal(t, int32(0), result)
+
+	result = v1_4_verifySecp256r1(context.Pointer, keyOffset, int32(len(key)), messageOffset, int32(len(message))-1, sigOffset)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+
+	result = v1_4_verifySecp256r1(context.Pointer, keyOffset, 32, messageOffset, int32(len(message)), sigOffset)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrInvalidPublicKeySize, context.Runtime.Err)
+}
+
+func TestVerifyMerkleProof(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	leaves := [][]byte{[]byte("alice"), []byte("bob"), []byte("carol")}
+	hashes := make([][]byte, 0)
+	for _, leaf := range leaves {
+		hash := sha256.Sum256(append([]byte{0}, leaf...))
+		hashes = append(hashes, hash[:])
+	}
+	node := sha256.Sum256(append(append([]byte{1}, hashes[0]...), hashes[1]...))
+	root := sha256.Sum256(append(append([]byte{1}, node[:]...), hashes[2]...))
+
+	leafOffset := context.Runtime.Store(leaves[2])
+	proofOffset := context.Runtime.Store(node[:])
+	rootOffset := context.Runtime.Store(root[:])
+	flags := int32(merkleProofHashLeaf | merkleProofDomainSeparation)
+
+	result := v1_4_verifyMerkleProof(context.Pointer, leafOffset, int32(len(leaves[2])), proofOffset, 1, 1, rootOffset, flags)
+	require.Equal(t, int32(0), result)
+
+	result = v1_4_verifyMerkleProof(context.Pointer, leafOffset, int32(len(leaves[2])), proofOffset, 1, 0, rootOffset, flags)
+	require.Equal(t, int32(-1), result)
+
+	result = v1_4_verifyMerkleProof(context.Pointer, leafOffset, int32(len(leaves[2])), proofOffset, 1, 1, rootOffset, flags|merkleProofKeccak256)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+
+	result = v1_4_verifyMerkleProof(context.Pointer, leafOffset, int32(len(leaves[2])), proofOffset, 1, 1, rootOffset, 1<<10)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrInvalidMerkleProofFlags, context.Runtime.Err)
+
+	result = v1_4_verifyMerkleProof(context.Pointer, leafOffset, int32(len(leaves[2])), proofOffset, -1, 1, rootOffset, flags)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrNegativeLength, context.Runtime.Err)
+}
+
+func TestHashFunctions(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	data := []byte("abc")
+
+	testCases := []struct {
+		name     string
+		raw      func(unsafe.Pointer, int32, int32, int32) int32
+		managed  func(unsafe.Pointer, int32, int32) int32
+		expected string
+	}{
+		{"sha512", v1_4_sha512, v1_4_managedSha512, "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+		{"sha3256", v1_4_sha3256, v1_4_managedSha3256, "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532"},
+		{"blake2b256", v1_4_blake2b256, v1_4_managedBlake2b256, "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319"},
+		{"blake2s256", v1_4_blake2s256, v1_4_managedBlake2s256, "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982"},
+	}
+
+	for _, testCase := range testCases {
+		dataOffset := context.Runtime.Store(data)
+		resultOffset := context.Runtime.Store(make([]byte, len(testCase.expected)/2))
+
+		result := testCase.raw(context.Pointer, dataOffset, int32(len(data)), resultOffset)
+		require.Equal(t, int32(0), result, testCase.name)
+		hash, err := context.Runtime.MemLoad(resultOffset, int32(len(testCase.expected)/2))
+		require.Nil(t, err)
+		require.Equal(t, testCase.expected, hex.EncodeToString(hash), testCase.name)
+
+		inputHandle := context.ManagedTypes.NewManagedBufferFromBytes(data)
+		outputHandle := context.ManagedTypes.NewManagedBuffer()
+		result = testCase.managed(context.Pointer, inputHandle, outputHandle)
+		require.Equal(t, int32(0), result, testCase.name)
+		hash, err = context.ManagedTypes.GetBytes(outputHandle)
+		require.Nil(t, err)
+		require.Equal(t, testCase.expected, hex.EncodeToString(hash), testCase.name)
+
+		result = testCase.managed(context.Pointer, int32(1000), outputHandle)
+		require.Equal(t, int32(1), result, testCase.name)
+		require.Equal(t, arwen.ErrNoManagedBufferUnderThisHandle, context.Runtime.Err)
+		context.Runtime.Err = nil
+	}
+}
+
+// signSecp256r1 signs the message with a new P-256 key, returning the
+// compressed public key and the DER encoded signature
+func signSecp256r1(t *testing.T, message []byte) ([]byte, []byte, []byte) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	hash := sha256.Sum256(message)
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hash[:])
+	require.Nil(t, err)
+	sig, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	require.Nil(t, err)
+
+	key := elliptic.MarshalCompressed(elliptic.P256(), privateKey.X, privateKey.Y)
+	return key, message, sig
+}
+
+func aggregateBLSSignatures(t *testing.T, sigs [][]byte) []byte {
+	points := make([]herumi.Sign, len(sigs))
+	for i, sig := range sigs {
+		require.Nil(t, points[i].Deserialize(sig))
+	}
+
+	aggSig := &herumi.Sign{}
+	aggSig.Aggregate(points)
+	return aggSig.Serialize()
+}
+
+func appendInt32(data []byte, value int32) []byte {
+	encoded := make([]byte, 4)
+	binary.LittleEndian.PutUint32(encoded, uint32(value))
+	return append(data, encoded...)
+}
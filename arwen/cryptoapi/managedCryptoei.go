@@ -0,0 +1,1256 @@
+package cryptoapi
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef unsigned char uint8_t;
+// typedef int int32_t;
+//
+// extern int32_t v1_4_managedSha256(void *context, int32_t inputHandle, int32_t outputHandle);
+// extern int32_t v1_4_managedKeccak256(void *context, int32_t inputHandle, int32_t outputHandle);
+// extern int32_t v1_4_managedRipemd160(void *context, int32_t inputHandle, int32_t outputHandle);
+// extern int32_t v1_4_managedSha512(void *context, int32_t inputHandle, int32_t outputHandle);
+// extern int32_t v1_4_managedSha3256(void *context, int32_t inputHandle, int32_t outputHandle);
+// extern int32_t v1_4_managedBlake2b256(void *context, int32_t inputHandle, int32_t outputHandle);
+// extern int32_t v1_4_managedBlake2s256(void *context, int32_t inputHandle, int32_t outputHandle);
+// extern int32_t v1_4_managedVerifyBLS(void *context, int32_t keyHandle, int32_t messageHandle, int32_t sigHandle);
+// extern int32_t v1_4_managedVerifyBLSMultiSig(void *context, int32_t keysHandle, int32_t messageHandle, int32_t sigHandle);
+// extern int32_t v1_4_managedVerifyBLSAggregated(void *context, int32_t keysHandle, int32_t messagesHandle, int32_t sigHandle);
+// extern int32_t v1_4_managedVerifyEd25519(void *context, int32_t keyHandle, int32_t messageHandle, int32_t sigHandle);
+// extern int32_t v1_4_managedVerifySecp256k1(void *context, int32_t keyHandle, int32_t messageHandle, int32_t sigHandle);
+// extern int32_t v1_4_managedVerifyCustomSecp256k1(void *context, int32_t keyHandle, int32_t messageHandle, int32_t sigHandle, int32_t hashType);
+// extern int32_t v1_4_managedEncodeSecp256k1DerSignature(void *context, int32_t rHandle, int32_t sHandle, int32_t sigHandle);
+// extern int32_t v1_4_managedVerifySecp256r1(void *context, int32_t keyHandle, int32_t messageHandle, int32_t sigHandle);
+// extern int32_t v1_4_managedVerifyEd25519Batch(void *context, int32_t keysHandle, int32_t messagesHandle, int32_t sigsHandle, int32_t resultHandle);
+// extern int32_t v1_4_managedVerifySecp256k1Batch(void *context, int32_t keysHandle, int32_t messagesHandle, int32_t sigsHandle, int32_t resultHandle);
+// extern int32_t v1_4_managedVerifyCustomSecp256k1Batch(void *context, int32_t keysHandle, int32_t messagesHandle, int32_t sigsHandle, int32_t hashType, int32_t resultHandle);
+// extern int32_t v1_4_managedVerifyMerkleProof(void *context, int32_t leafHandle, int32_t proofHandle, long long index, int32_t rootHandle, int32_t flags);
+// extern int32_t v1_4_managedVerifyPatriciaMerkleProof(void *context, int32_t rootHashHandle, int32_t keyHandle, int32_t proofHandle, int32_t valueHandle);
+// extern int32_t v1_4_managedScalarBaseMultEC(void *context, int32_t xResultHandle, int32_t yResultHandle, int32_t ecHandle, int32_t dataHandle);
+// extern int32_t v1_4_managedScalarMultEC(void *context, int32_t xResultHandle, int32_t yResultHandle, int32_t ecHandle, int32_t pointXHandle, int32_t pointYHandle, int32_t dataHandle);
+// extern int32_t v1_4_managedMarshalEC(void *context, int32_t xPairHandle, int32_t yPairHandle, int32_t ecHandle, int32_t resultHandle);
+// extern int32_t v1_4_managedUnmarshalEC(void *context, int32_t xResultHandle, int32_t yResultHandle, int32_t ecHandle, int32_t dataHandle);
+// extern int32_t v1_4_managedMarshalCompressedEC(void *context, int32_t xPairHandle, int32_t yPairHandle, int32_t ecHandle, int32_t resultHandle);
+// extern int32_t v1_4_managedUnmarshalCompressedEC(void *context, int32_t xResultHandle, int32_t yResultHandle, int32_t ecHandle, int32_t dataHandle);
+// extern int32_t v1_4_managedGenerateKeyEC(void *context, int32_t xPubKeyHandle, int32_t yPubKeyHandle, int32_t ecHandle, int32_t resultHandle);
+// extern int32_t v1_4_managedCreateEC(void *context, int32_t dataHandle);
+import "C"
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/merkle"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing/secp256k1"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/math"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer"
+)
+
+const (
+	managedSha256Name                      = "managedSha256"
+	managedKeccak256Name                   = "managedKeccak256"
+	managedRipemd160Name                   = "managedRipemd160"
+	managedSha512Name                      = "managedSha512"
+	managedSha3256Name                     = "managedSha3256"
+	managedBlake2b256Name                  = "managedBlake2b256"
+	managedBlake2s256Name                  = "managedBlake2s256"
+	managedVerifyBLSName                   = "managedVerifyBLS"
+	managedVerifyBLSMultiSigName           = "managedVerifyBLSMultiSig"
+	managedVerifyBLSAggregatedName         = "managedVerifyBLSAggregated"
+	managedVerifyEd25519Name               = "managedVerifyEd25519"
+	managedVerifySecp256k1Name             = "managedVerifySecp256k1"
+	managedVerifyCustomSecp256k1Name       = "managedVerifyCustomSecp256k1"
+	managedEncodeSecp256k1DerSignatureName = "managedEncodeSecp256k1DerSignature"
+	managedVerifySecp256r1Name             = "managedVerifySecp256r1"
+	managedVerifyEd25519BatchName          = "managedVerifyEd25519Batch"
+	managedVerifySecp256k1BatchName        = "managedVerifySecp256k1Batch"
+	managedVerifyCustomSecp256k1BatchName  = "managedVerifyCustomSecp256k1Batch"
+	managedVerifyMerkleProofName           = "managedVerifyMerkleProof"
+	managedVerifyPatriciaMerkleProofName   = "managedVerifyPatriciaMerkleProof"
+	managedScalarBaseMultECName            = "managedScalarBaseMultEC"
+	managedScalarMultECName                = "managedScalarMultEC"
+	managedMarshalECName                   = "managedMarshalEC"
+	managedUnmarshalECName                 = "managedUnmarshalEC"
+	managedMarshalCompressedECName         = "managedMarshalCompressedEC"
+	managedUnmarshalCompressedECName       = "managedUnmarshalCompressedEC"
+	managedGenerateKeyECName               = "managedGenerateKeyEC"
+	managedCreateECName                    = "managedCreateEC"
+)
+
+// ManagedCryptoImports adds the crypto imports working with managed buffers to the Wasmer Imports map
+func ManagedCryptoImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
+	imports = imports.Namespace("env")
+
+	imports, err := imports.Append("managedSha256", v1_4_managedSha256, C.v1_4_managedSha256)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedKeccak256", v1_4_managedKeccak256, C.v1_4_managedKeccak256)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedRipemd160", v1_4_managedRipemd160, C.v1_4_managedRipemd160)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedSha512", v1_4_managedSha512, C.v1_4_managedSha512)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedSha3256", v1_4_managedSha3256, C.v1_4_managedSha3256)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedBlake2b256", v1_4_managedBlake2b256, C.v1_4_managedBlake2b256)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedBlake2s256", v1_4_managedBlake2s256, C.v1_4_managedBlake2s256)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifyBLS", v1_4_managedVerifyBLS, C.v1_4_managedVerifyBLS)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifyBLSMultiSig", v1_4_managedVerifyBLSMultiSig, C.v1_4_managedVerifyBLSMultiSig)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifyBLSAggregated", v1_4_managedVerifyBLSAggregated, C.v1_4_managedVerifyBLSAggregated)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifyEd25519", v1_4_managedVerifyEd25519, C.v1_4_managedVerifyEd25519)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifySecp256k1", v1_4_managedVerifySecp256k1, C.v1_4_managedVerifySecp256k1)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifyCustomSecp256k1", v1_4_managedVerifyCustomSecp256k1, C.v1_4_managedVerifyCustomSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedEncodeSecp256k1DerSignature", v1_4_managedEncodeSecp256k1DerSignature, C.v1_4_managedEncodeSecp256k1DerSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifySecp256r1", v1_4_managedVerifySecp256r1, C.v1_4_managedVerifySecp256r1)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifyEd25519Batch", v1_4_managedVerifyEd25519Batch, C.v1_4_managedVerifyEd25519Batch)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifySecp256k1Batch", v1_4_managedVerifySecp256k1Batch, C.v1_4_managedVerifySecp256k1Batch)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifyCustomSecp256k1Batch", v1_4_managedVerifyCustomSecp256k1Batch, C.v1_4_managedVerifyCustomSecp256k1Batch)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifyMerkleProof", v1_4_managedVerifyMerkleProof, C.v1_4_managedVerifyMerkleProof)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedVerifyPatriciaMerkleProof", v1_4_managedVerifyPatriciaMerkleProof, C.v1_4_managedVerifyPatriciaMerkleProof)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedScalarBaseMultEC", v1_4_managedScalarBaseMultEC, C.v1_4_managedScalarBaseMultEC)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedScalarMultEC", v1_4_managedScalarMultEC, C.v1_4_managedScalarMultEC)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedMarshalEC", v1_4_managedMarshalEC, C.v1_4_managedMarshalEC)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedUnmarshalEC", v1_4_managedUnmarshalEC, C.v1_4_managedUnmarshalEC)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedMarshalCompressedEC", v1_4_managedMarshalCompressedEC, C.v1_4_managedMarshalCompressedEC)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedUnmarshalCompressedEC", v1_4_managedUnmarshalCompressedEC, C.v1_4_managedUnmarshalCompressedEC)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedGenerateKeyEC", v1_4_managedGenerateKeyEC, C.v1_4_managedGenerateKeyEC)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedCreateEC", v1_4_managedCreateEC, C.v1_4_managedCreateEC)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+//export v1_4_managedSha256
+func v1_4_managedSha256(context unsafe.Pointer, inputHandle int32, outputHandle int32) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	cryptoCosts := metering.GasSchedule().CryptoAPICost
+
+	return hashManagedBuffer(context, managedSha256Name, cryptoCosts.SHA256, 0, crypto.Sha256, inputHandle, outputHandle)
+}
+
+//export v1_4_managedKeccak256
+func v1_4_managedKeccak256(context unsafe.Pointer, inputHandle int32, outputHandle int32) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	cryptoCosts := metering.GasSchedule().CryptoAPICost
+
+	return hashManagedBuffer(context, managedKeccak256Name, cryptoCosts.Keccak256, 0, crypto.Keccak256, inputHandle, outputHandle)
+}
+
+//export v1_4_managedRipemd160
+func v1_4_managedRipemd160(context unsafe.Pointer, inputHandle int32, outputHandle int32) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	cryptoCosts := metering.GasSchedule().CryptoAPICost
+
+	return hashManagedBuffer(context, managedRipemd160Name, cryptoCosts.Ripemd160, 0, crypto.Ripemd160, inputHandle, outputHandle)
+}
+
+//export v1_4_managedSha512
+func v1_4_managedSha512(context unsafe.Pointer, inputHandle int32, outputHandle int32) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	cryptoCosts := metering.GasSchedule().CryptoAPICost
+
+	return hashManagedBuffer(context, managedSha512Name, cryptoCosts.SHA512, cryptoCosts.SHA512PerByte, crypto.Sha512, inputHandle, outputHandle)
+}
+
+//export v1_4_managedSha3256
+func v1_4_managedSha3256(context unsafe.Pointer, inputHandle int32, outputHandle int32) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	cryptoCosts := metering.GasSchedule().CryptoAPICost
+
+	return hashManagedBuffer(context, managedSha3256Name, cryptoCosts.SHA3256, cryptoCosts.SHA3256PerByte, crypto.Sha3256, inputHandle, outputHandle)
+}
+
+//export v1_4_managedBlake2b256
+func v1_4_managedBlake2b256(context unsafe.Pointer, inputHandle int32, outputHandle int32) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	cryptoCosts := metering.GasSchedule().CryptoAPICost
+
+	return hashManagedBuffer(context, managedBlake2b256Name, cryptoCosts.Blake2b256, cryptoCosts.Blake2b256PerByte, crypto.Blake2b256, inputHandle, outputHandle)
+}
+
+//export v1_4_managedBlake2s256
+func v1_4_managedBlake2s256(context unsafe.Pointer, inputHandle int32, outputHandle int32) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	cryptoCosts := metering.GasSchedule().CryptoAPICost
+
+	return hashManagedBuffer(context, managedBlake2s256Name, cryptoCosts.Blake2s256, cryptoCosts.Blake2s256PerByte, crypto.Blake2s256, inputHandle, outputHandle)
+}
+
+// hashManagedBuffer hashes the contents of a managed buffer into another one,
+// charging a base cost and a cost per hashed byte
+func hashManagedBuffer(
+	context unsafe.Pointer,
+	functionName string,
+	baseCost uint64,
+	costPerByte uint64,
+	hash func(data []byte) ([]byte, error),
+	inputHandle int32,
+	outputHandle int32,
+) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(functionName)
+
+	metering.UseAndTraceGas(baseCost)
+
+	data, err := managedType.GetBytes(inputHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	managedType.ConsumeGasForBytes(data)
+	metering.UseAndTraceGas(math.MulUint64(costPerByte, uint64(len(data))))
+
+	result, err := hash(data)
+	if err != nil {
+		return 1
+	}
+
+	managedType.SetBytes(outputHandle, result)
+	return 0
+}
+
+//export v1_4_managedVerifyBLS
+func v1_4_managedVerifyBLS(
+	context unsafe.Pointer,
+	keyHandle int32,
+	messageHandle int32,
+	sigHandle int32,
+) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(managedVerifyBLSName)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.VerifyBLS
+	metering.UseAndTraceGas(gasToUse)
+
+	key, message, sig, err := getSignatureArguments(managedType, keyHandle, messageHandle, sigHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	invalidSigErr := crypto.VerifyBLS(key, message, sig)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_4_managedVerifyBLSMultiSig
+func v1_4_managedVerifyBLSMultiSig(
+	context unsafe.Pointer,
+	keysHandle int32,
+	messageHandle int32,
+	sigHandle int32,
+) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(managedVerifyBLSMultiSigName)
+
+	numKeys := managedType.GetManagedVecLength(keysHandle)
+	if numKeys < 0 {
+		_ = arwen.WithFault(arwen.ErrNoManagedVecUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse := math.MulUint64(metering.GasSchedule().CryptoAPICost.VerifyBLSMultiSigPerKey, uint64(numKeys))
+	gasToUse = math.AddUint64(metering.GasSchedule().CryptoAPICost.VerifyBLSMultiSig, gasToUse)
+	metering.UseAndTraceGas(gasToUse)
+
+	keys, err := getManagedVecItems(managedType, keysHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	message, err := managedType.GetBytes(messageHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	managedType.ConsumeGasForBytes(message)
+
+	sig, err := managedType.GetBytes(sigHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	invalidSigErr := crypto.VerifyBLSMultiSig(keys, message, sig)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_4_managedVerifyBLSAggregated
+func v1_4_managedVerifyBLSAggregated(
+	context unsafe.Pointer,
+	keysHandle int32,
+	messagesHandle int32,
+	sigHandle int32,
+) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(managedVerifyBLSAggregatedName)
+
+	numKeys := managedType.GetManagedVecLength(keysHandle)
+	if numKeys < 0 {
+		_ = arwen.WithFault(arwen.ErrNoManagedVecUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse := math.MulUint64(metering.GasSchedule().CryptoAPICost.VerifyBLSAggregatedPerKey, uint64(numKeys))
+	gasToUse = math.AddUint64(metering.GasSchedule().CryptoAPICost.VerifyBLSAggregated, gasToUse)
+	metering.UseAndTraceGas(gasToUse)
+
+	keys, err := getManagedVecItems(managedType, keysHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	messages, err := getManagedVecItems(managedType, messagesHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	for _, message := range messages {
+		managedType.ConsumeGasForBytes(message)
+	}
+
+	sig, err := managedType.GetBytes(sigHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	invalidSigErr := crypto.VerifyBLSAggregated(keys, messages, sig)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_4_managedVerifyEd25519
+func v1_4_managedVerifyEd25519(
+	context unsafe.Pointer,
+	keyHandle int32,
+	messageHandle int32,
+	sigHandle int32,
+) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(managedVerifyEd25519Name)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.VerifyEd25519
+	metering.UseAndTraceGas(gasToUse)
+
+	key, message, sig, err := getSignatureArguments(managedType, keyHandle, messageHandle, sigHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	invalidSigErr := crypto.VerifyEd25519(key, message, sig)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_4_managedVerifySecp256k1
+func v1_4_managedVerifySecp256k1(
+	context unsafe.Pointer,
+	keyHandle int32,
+	messageHandle int32,
+	sigHandle int32,
+) int32 {
+	return v1_4_managedVerifyCustomSecp256k1(
+		context,
+		keyHandle,
+		messageHandle,
+		sigHandle,
+		int32(secp256k1.ECDSADoubleSha256),
+	)
+}
+
+//export v1_4_managedVerifyCustomSecp256k1
+func v1_4_managedVerifyCustomSecp256k1(
+	context unsafe.Pointer,
+	keyHandle int32,
+	messageHandle int32,
+	sigHandle int32,
+	hashType int32,
+) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(managedVerifyCustomSecp256k1Name)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.VerifySecp256k1
+	metering.UseAndTraceGas(gasToUse)
+
+	key, message, sig, err := getSignatureArguments(managedType, keyHandle, messageHandle, sigHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	if len(key) != secp256k1CompressedPublicKeyLength && len(key) != secp256k1UncompressedPublicKeyLength {
+		_ = arwen.WithFault(arwen.ErrInvalidPublicKeySize, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	invalidSigErr := crypto.VerifySecp256k1(key, message, sig, uint8(hashType))
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_4_managedEncodeSecp256k1DerSignature
+func v1_4_managedEncodeSecp256k1DerSignature(
+	context unsafe.Pointer,
+	rHandle int32,
+	sHandle int32,
+	sigHandle int32,
+) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.EncodeDERSig
+	metering.UseGasAndAddTracedGas(managedEncodeSecp256k1DerSignatureName, gasToUse)
+
+	r, err := managedType.GetBytes(rHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	s, err := managedType.GetBytes(sHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	derSig := crypto.EncodeSecp256k1DERSignature(r, s)
+	managedType.SetBytes(sigHandle, derSig)
+
+	return 0
+}
+
+//export v1_4_managedVerifySecp256r1
+func v1_4_managedVerifySecp256r1(
+	context unsafe.Pointer,
+	keyHandle int32,
+	messageHandle int32,
+	sigHandle int32,
+) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(managedVerifySecp256r1Name)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.VerifySecp256r1
+	metering.UseAndTraceGas(gasToUse)
+
+	key, message, sig, err := getSignatureArguments(managedType, keyHandle, messageHandle, sigHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	if len(key) != secp256r1CompressedPublicKeyLength && len(key) != secp256r1UncompressedPublicKeyLength {
+		_ = arwen.WithFault(arwen.ErrInvalidPublicKeySize, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	invalidSigErr := crypto.VerifySecp256r1(key, message, sig)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_4_managedVerifyEd25519Batch
+func v1_4_managedVerifyEd25519Batch(
+	context unsafe.Pointer,
+	keysHandle int32,
+	messagesHandle int32,
+	sigsHandle int32,
+	resultHandle int32,
+) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(managedVerifyEd25519BatchName)
+
+	return verifySignatureBatch(
+		context,
+		metering.GasSchedule().CryptoAPICost.VerifyEd25519Batch,
+		metering.GasSchedule().CryptoAPICost.VerifyEd25519BatchPerItem,
+		keysHandle,
+		messagesHandle,
+		sigsHandle,
+		resultHandle,
+		crypto.VerifyEd25519,
+	)
+}
+
+//export v1_4_managedVerifySecp256k1Batch
+func v1_4_managedVerifySecp256k1Batch(
+	context unsafe.Pointer,
+	keysHandle int32,
+	messagesHandle int32,
+	sigsHandle int32,
+	resultHandle int32,
+) int32 {
+	return v1_4_managedVerifyCustomSecp256k1Batch(
+		context,
+		keysHandle,
+		messagesHandle,
+		sigsHandle,
+		int32(secp256k1.ECDSADoubleSha256),
+		resultHandle,
+	)
+}
+
+//export v1_4_managedVerifyCustomSecp256k1Batch
+func v1_4_managedVerifyCustomSecp256k1Batch(
+	context unsafe.Pointer,
+	keysHandle int32,
+	messagesHandle int32,
+	sigsHandle int32,
+	hashType int32,
+	resultHandle int32,
+) int32 {
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(managedVerifyCustomSecp256k1BatchName)
+
+	verifyFunc := func(key []byte, message []byte, sig []byte) error {
+		if len(key) != secp256k1CompressedPublicKeyLength && len(key) != secp256k1UncompressedPublicKeyLength {
+			return arwen.ErrInvalidPublicKeySize
+		}
+		return crypto.VerifySecp256k1(key, message, sig, uint8(hashType))
+	}
+
+	return verifySignatureBatch(
+		context,
+		metering.GasSchedule().CryptoAPICost.VerifySecp256k1Batch,
+		metering.GasSchedule().CryptoAPICost.VerifySecp256k1BatchPerItem,
+		keysHandle,
+		messagesHandle,
+		sigsHandle,
+		resultHandle,
+		verifyFunc,
+	)
+}
+
+// verifySignatureBatch verifies the signatures held by the managed vectors of
+// keys, messages and signatures, the items with the same index forming an
+// entry of the batch. The bitmap of the valid entries is stored in the result
+// buffer, the validity of entry i being given by the bit i%8 of the byte i/8.
+// An invalid entry does not interrupt the verification of the batch.
+func verifySignatureBatch(
+	context unsafe.Pointer,
+	baseCost uint64,
+	costPerItem uint64,
+	keysHandle int32,
+	messagesHandle int32,
+	sigsHandle int32,
+	resultHandle int32,
+	verifyFunc func(key []byte, message []byte, sig []byte) error,
+) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	numItems := managedType.GetManagedVecLength(keysHandle)
+	if numItems < 0 {
+		_ = arwen.WithFault(arwen.ErrNoManagedVecUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse := math.MulUint64(costPerItem, uint64(numItems))
+	gasToUse = math.AddUint64(baseCost, gasToUse)
+	metering.UseAndTraceGas(gasToUse)
+
+	keys, err := getManagedVecItems(managedType, keysHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	messages, err := getManagedVecItems(managedType, messagesHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	for _, message := range messages {
+		managedType.ConsumeGasForBytes(message)
+	}
+
+	sigs, err := getManagedVecItems(managedType, sigsHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	if len(messages) != len(keys) || len(sigs) != len(keys) {
+		_ = arwen.WithFault(arwen.ErrSignatureBatchLengthMismatch, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	allValid := true
+	bitmap := make([]byte, (len(keys)+7)/8)
+	for i := range keys {
+		invalidSigErr := verifyFunc(keys[i], messages[i], sigs[i])
+		if invalidSigErr != nil {
+			allValid = false
+			continue
+		}
+		bitmap[i/8] |= 1 << uint(i%8)
+	}
+
+	managedType.SetBytes(resultHandle, bitmap)
+	if !allValid {
+		return -1
+	}
+
+	return 0
+}
+
+// getSignatureArguments returns the contents of the managed buffers holding
+// the key, the message and the signature to be verified, using gas for the message
+func getSignatureArguments(
+	managedType arwen.ManagedTypesContext,
+	keyHandle int32,
+	messageHandle int32,
+	sigHandle int32,
+) ([]byte, []byte, []byte, error) {
+	key, err := managedType.GetBytes(keyHandle)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	message, err := managedType.GetBytes(messageHandle)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	managedType.ConsumeGasForBytes(message)
+
+	sig, err := managedType.GetBytes(sigHandle)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return key, message, sig, nil
+}
+
+// getManagedVecItems returns all the items of a managed vector
+func getManagedVecItems(managedType arwen.ManagedTypesContext, mVecHandle int32) ([][]byte, error) {
+	length := managedType.GetManagedVecLength(mVecHandle)
+	if length < 0 {
+		return nil, arwen.ErrNoManagedVecUnderThisHandle
+	}
+
+	items := make([][]byte, length)
+	for i := range items {
+		item, err := managedType.GetManagedVecItem(mVecHandle, int32(i))
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+
+	return items, nil
+}
+
+//export v1_4_managedVerifyMerkleProof
+func v1_4_managedVerifyMerkleProof(
+	context unsafe.Pointer,
+	leafHandle int32,
+	proofHandle int32,
+	index int64,
+	rootHandle int32,
+	flags int32,
+) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(managedVerifyMerkleProofName)
+
+	proofLength := managedType.GetManagedVecLength(proofHandle)
+	if proofLength < 0 {
+		_ = arwen.WithFault(arwen.ErrNoManagedVecUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse := math.MulUint64(metering.GasSchedule().CryptoAPICost.MerkleProofPerStep, uint64(proofLength))
+	gasToUse = math.AddUint64(metering.GasSchedule().CryptoAPICost.MerkleProof, gasToUse)
+	metering.UseAndTraceGas(gasToUse)
+
+	hashFunc, options, err := getMerkleProofOptions(crypto, flags)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	leaf, err := managedType.GetBytes(leafHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	managedType.ConsumeGasForBytes(leaf)
+
+	proof, err := getManagedVecItems(managedType, proofHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	root, err := managedType.GetBytes(rootHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	invalidProofErr := merkle.VerifyBinaryProof(hashFunc, leaf, proof, uint64(index), root, options)
+	if invalidProofErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_4_managedVerifyPatriciaMerkleProof
+func v1_4_managedVerifyPatriciaMerkleProof(
+	context unsafe.Pointer,
+	rootHashHandle int32,
+	keyHandle int32,
+	proofHandle int32,
+	valueHandle int32,
+) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(managedVerifyPatriciaMerkleProofName)
+
+	numNodes := managedType.GetManagedVecLength(proofHandle)
+	if numNodes < 0 {
+		_ = arwen.WithFault(arwen.ErrNoManagedVecUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse := math.MulUint64(metering.GasSchedule().CryptoAPICost.PatriciaMerkleProofPerNode, uint64(numNodes))
+	gasToUse = math.AddUint64(metering.GasSchedule().CryptoAPICost.PatriciaMerkleProof, gasToUse)
+	metering.UseAndTraceGas(gasToUse)
+
+	rootHash, err := managedType.GetBytes(rootHashHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	key, err := managedType.GetBytes(keyHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	proof, err := getManagedVecItems(managedType, proofHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	for _, node := range proof {
+		managedType.ConsumeGasForBytes(node)
+	}
+
+	// the nodes of the state trie are hashed with Blake2b-256
+	value, invalidProofErr := merkle.VerifyPatriciaProof(crypto.Blake2b256, rootHash, key, proof)
+	if invalidProofErr != nil {
+		return -1
+	}
+
+	managedType.SetBytes(valueHandle, value)
+	return 0
+}
+
+//export v1_4_managedScalarBaseMultEC
+func v1_4_managedScalarBaseMultEC(
+	context unsafe.Pointer,
+	xResultHandle int32,
+	yResultHandle int32,
+	ecHandle int32,
+	dataHandle int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(managedScalarBaseMultECName)
+
+	curveMultiplier := managedType.GetScalarMult100xCurveGasCostMultiplier(ecHandle)
+	if curveMultiplier < 0 {
+		_ = arwen.WithFault(arwen.ErrNoEllipticCurveUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	data, err := managedType.GetBytes(dataHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	oneByteScalarGasCost := metering.GasSchedule().CryptoAPICost.ScalarMultECC * uint64(curveMultiplier) / 100
+	gasToUse := oneByteScalarGasCost + uint64(len(data))*oneByteScalarGasCost
+	metering.UseAndTraceGas(gasToUse)
+
+	ec, err := managedType.GetEllipticCurve(ecHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	xResult, yResult, err := managedType.GetTwoBigInt(xResultHandle, yResultHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	managedType.ConsumeGasForBigIntCopy(ec.P, ec.N, ec.B, ec.Gx, ec.Gy, xResult, yResult)
+
+	xResultSBM, yResultSBM := ec.ScalarBaseMult(data)
+	if !ec.IsOnCurve(xResultSBM, yResultSBM) {
+		_ = arwen.WithFault(arwen.ErrPointNotOnCurve, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+	xResult.Set(xResultSBM)
+	yResult.Set(yResultSBM)
+
+	return 0
+}
+
+//export v1_4_managedScalarMultEC
+func v1_4_managedScalarMultEC(
+	context unsafe.Pointer,
+	xResultHandle int32,
+	yResultHandle int32,
+	ecHandle int32,
+	pointXHandle int32,
+	pointYHandle int32,
+	dataHandle int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(managedScalarMultECName)
+
+	curveMultiplier := managedType.GetScalarMult100xCurveGasCostMultiplier(ecHandle)
+	if curveMultiplier < 0 {
+		_ = arwen.WithFault(arwen.ErrNoEllipticCurveUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	data, err := managedType.GetBytes(dataHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	oneByteScalarGasCost := metering.GasSchedule().CryptoAPICost.ScalarMultECC * uint64(curveMultiplier) / 100
+	gasToUse := oneByteScalarGasCost + uint64(len(data))*oneByteScalarGasCost
+	metering.UseAndTraceGas(gasToUse)
+
+	ec, err := managedType.GetEllipticCurve(ecHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	xResult, yResult, err1 := managedType.GetTwoBigInt(xResultHandle, yResultHandle)
+	x, y, err2 := managedType.GetTwoBigInt(pointXHandle, pointYHandle)
+	if err1 != nil || err2 != nil {
+		_ = arwen.WithFault(arwen.ErrNoBigIntUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+	if !ec.IsOnCurve(x, y) {
+		_ = arwen.WithFault(arwen.ErrPointNotOnCurve, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	managedType.ConsumeGasForBigIntCopy(xResult, yResult, ec.P, ec.N, ec.B, ec.Gx, ec.Gy, x, y)
+	xResultSM, yResultSM := ec.ScalarMult(x, y, data)
+	if !ec.IsOnCurve(xResultSM, yResultSM) {
+		_ = arwen.WithFault(arwen.ErrPointNotOnCurve, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+	xResult.Set(xResultSM)
+	yResult.Set(yResultSM)
+
+	return 0
+}
+
+//export v1_4_managedMarshalEC
+func v1_4_managedMarshalEC(
+	context unsafe.Pointer,
+	xPairHandle int32,
+	yPairHandle int32,
+	ecHandle int32,
+	resultHandle int32,
+) int32 {
+	metering := arwen.GetMeteringContext(context)
+	gasCost := metering.GasSchedule().CryptoAPICost.MarshalECC
+
+	return managedMarshalPoint(context, managedMarshalECName, gasCost, elliptic.Marshal, xPairHandle, yPairHandle, ecHandle, resultHandle)
+}
+
+//export v1_4_managedMarshalCompressedEC
+func v1_4_managedMarshalCompressedEC(
+	context unsafe.Pointer,
+	xPairHandle int32,
+	yPairHandle int32,
+	ecHandle int32,
+	resultHandle int32,
+) int32 {
+	metering := arwen.GetMeteringContext(context)
+	gasCost := metering.GasSchedule().CryptoAPICost.MarshalCompressedECC
+
+	return managedMarshalPoint(context, managedMarshalCompressedECName, gasCost, elliptic.MarshalCompressed, xPairHandle, yPairHandle, ecHandle, resultHandle)
+}
+
+// managedMarshalPoint encodes a point of an elliptic curve into a managed
+// buffer and returns the length of the encoding, or -1 on failure
+func managedMarshalPoint(
+	context unsafe.Pointer,
+	functionName string,
+	gasCost uint64,
+	marshal func(curve elliptic.Curve, x, y *big.Int) []byte,
+	xPairHandle int32,
+	yPairHandle int32,
+	ecHandle int32,
+	resultHandle int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(functionName)
+
+	curveMultiplier := managedType.Get100xCurveGasCostMultiplier(ecHandle)
+	if curveMultiplier < 0 {
+		_ = arwen.WithFault(arwen.ErrNoEllipticCurveUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return -1
+	}
+	gasToUse := gasCost * uint64(curveMultiplier) / 100
+	metering.UseAndTraceGas(gasToUse)
+
+	ec, err := managedType.GetEllipticCurve(ecHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	x, y, err := managedType.GetTwoBigInt(xPairHandle, yPairHandle)
+	if err != nil || x == nil || y == nil {
+		_ = arwen.WithFault(arwen.ErrNoBigIntUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return -1
+	}
+	if !ec.IsOnCurve(x, y) {
+		_ = arwen.WithFault(arwen.ErrPointNotOnCurve, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return -1
+	}
+	if x.BitLen() > int(ec.BitSize) || y.BitLen() > int(ec.BitSize) {
+		_ = arwen.WithFault(arwen.ErrLengthOfBufferNotCorrect, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return -1
+	}
+
+	managedType.ConsumeGasForBigIntCopy(ec.P, ec.N, ec.B, ec.Gx, ec.Gy, x, y)
+
+	result := marshal(ec, x, y)
+	managedType.SetBytes(resultHandle, result)
+	return int32(len(result))
+}
+
+//export v1_4_managedUnmarshalEC
+func v1_4_managedUnmarshalEC(
+	context unsafe.Pointer,
+	xResultHandle int32,
+	yResultHandle int32,
+	ecHandle int32,
+	dataHandle int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(managedUnmarshalECName)
+
+	curveMultiplier := managedType.Get100xCurveGasCostMultiplier(ecHandle)
+	if curveMultiplier < 0 {
+		_ = arwen.WithFault(arwen.ErrNoEllipticCurveUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+	gasToUse := metering.GasSchedule().CryptoAPICost.UnmarshalECC * uint64(curveMultiplier) / 100
+	metering.UseAndTraceGas(gasToUse)
+
+	data, err := managedType.GetBytes(dataHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	ec, err := managedType.GetEllipticCurve(ecHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	byteLen := (ec.BitSize + 7) / 8
+	if len(data) != 1+2*byteLen {
+		_ = arwen.WithFault(arwen.ErrLengthOfBufferNotCorrect, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	xResult, yResult, err := managedType.GetTwoBigInt(xResultHandle, yResultHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	managedType.ConsumeGasForBigIntCopy(ec.P, ec.N, ec.B, ec.Gx, ec.Gy, xResult, yResult)
+
+	xResultU, yResultU := elliptic.Unmarshal(ec, data)
+	if xResultU == nil || yResultU == nil || !ec.IsOnCurve(xResultU, yResultU) {
+		_ = arwen.WithFault(arwen.ErrPointNotOnCurve, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+	xResult.Set(xResultU)
+	yResult.Set(yResultU)
+
+	return 0
+}
+
+//export v1_4_managedUnmarshalCompressedEC
+func v1_4_managedUnmarshalCompressedEC(
+	context unsafe.Pointer,
+	xResultHandle int32,
+	yResultHandle int32,
+	ecHandle int32,
+	dataHandle int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(managedUnmarshalCompressedECName)
+
+	curveMultiplier := managedType.GetUCompressed100xCurveGasCostMultiplier(ecHandle)
+	if curveMultiplier < 0 {
+		_ = arwen.WithFault(arwen.ErrNoEllipticCurveUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+	gasToUse := metering.GasSchedule().CryptoAPICost.UnmarshalCompressedECC * uint64(curveMultiplier) / 100
+	metering.UseAndTraceGas(gasToUse)
+
+	data, err := managedType.GetBytes(dataHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	ec, err := managedType.GetEllipticCurve(ecHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	byteLen := (ec.BitSize+7)/8 + 1
+	if len(data) != byteLen {
+		_ = arwen.WithFault(arwen.ErrLengthOfBufferNotCorrect, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	xResult, yResult, err := managedType.GetTwoBigInt(xResultHandle, yResultHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	managedType.ConsumeGasForBigIntCopy(ec.P, ec.N, ec.B, ec.Gx, ec.Gy, xResult, yResult)
+
+	xResultUC, yResultUC := elliptic.UnmarshalCompressed(ec, data)
+	if xResultUC == nil || yResultUC == nil || !ec.IsOnCurve(xResultUC, yResultUC) {
+		_ = arwen.WithFault(arwen.ErrPointNotOnCurve, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+	xResult.Set(xResultUC)
+	yResult.Set(yResultUC)
+	return 0
+}
+
+//export v1_4_managedGenerateKeyEC
+func v1_4_managedGenerateKeyEC(
+	context unsafe.Pointer,
+	xPubKeyHandle int32,
+	yPubKeyHandle int32,
+	ecHandle int32,
+	resultHandle int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(managedGenerateKeyECName)
+
+	curveMultiplier := managedType.Get100xCurveGasCostMultiplier(ecHandle)
+	if curveMultiplier < 0 {
+		_ = arwen.WithFault(arwen.ErrNoEllipticCurveUnderThisHandle, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+	if curveMultiplier == 250 {
+		curveMultiplier = 500
+	}
+	gasToUse := metering.GasSchedule().CryptoAPICost.GenerateKeyECC * uint64(curveMultiplier) / 100
+	metering.UseAndTraceGas(gasToUse)
+
+	ec, err := managedType.GetEllipticCurve(ecHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	xPubKey, yPubKey, err := managedType.GetTwoBigInt(xPubKeyHandle, yPubKeyHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	managedType.ConsumeGasForBigIntCopy(ec.P, ec.N, ec.B, ec.Gx, ec.Gy, xPubKey, yPubKey)
+
+	ioReader := managedType.GetRandReader()
+	result, xPubKeyGK, yPubKeyGK, err := elliptic.GenerateKey(ec, ioReader)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	managedType.SetBytes(resultHandle, result)
+	xPubKey.Set(xPubKeyGK)
+	yPubKey.Set(yPubKeyGK)
+	return 0
+}
+
+//export v1_4_managedCreateEC
+func v1_4_managedCreateEC(context unsafe.Pointer, dataHandle int32) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.EllipticCurveNew
+	metering.UseGasAndAddTracedGas(managedCreateECName, gasToUse)
+
+	data, err := managedType.GetBytes(dataHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+	if len(data) != curveNameLength {
+		_ = arwen.WithFault(arwen.ErrBadBounds, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return -1
+	}
+
+	curveParams := getCurveParams(string(data))
+	if curveParams == nil {
+		return -1
+	}
+	return managedType.PutEllipticCurve(curveParams)
+}
@@ -0,0 +1,356 @@
+package cryptoapi
+
+import (
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing/secp256k1"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context/eicontext"
+	"github.com/ElrondNetwork/elrond-go-crypto/signing/mcl/singlesig"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestManagedHashes(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	inputHandle := context.ManagedTypes.NewManagedBufferFromBytes([]byte("abc"))
+	outputHandle := context.ManagedTypes.NewManagedBuffer()
+
+	result := v1_4_managedSha256(context.Pointer, inputHandle, outputHandle)
+	require.Equal(t, int32(0), result)
+	context.RequireBufferHex(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", outputHandle)
+
+	result = v1_4_managedKeccak256(context.Pointer, inputHandle, outputHandle)
+	require.Equal(t, int32(0), result)
+	context.RequireBufferHex(t, "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45", outputHandle)
+
+	result = v1_4_managedRipemd160(context.Pointer, inputHandle, outputHandle)
+	require.Equal(t, int32(0), result)
+	context.RequireBufferHex(t, "8eb208f7e05d987a9b044a8e98c6b087f15a0bfc", outputHandle)
+}
+
+func TestManagedVerifyEd25519(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err)
+	message := []byte("oracle price")
+
+	keyHandle := context.ManagedTypes.NewManagedBufferFromBytes(publicKey)
+	messageHandle := context.ManagedTypes.NewManagedBufferFromBytes(message)
+	sigHandle := context.ManagedTypes.NewManagedBufferFromBytes(ed25519.Sign(privateKey, message))
+
+	result := v1_4_managedVerifyEd25519(context.Pointer, keyHandle, messageHandle, sigHandle)
+	require.Equal(t, int32(0), result)
+
+	result = v1_4_managedVerifyEd25519(context.Pointer, keyHandle, sigHandle, sigHandle)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+
+	result = v1_4_managedVerifyEd25519(context.Pointer, keyHandle, messageHandle, int32(1000))
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrNoManagedBufferUnderThisHandle, context.Runtime.Err)
+}
+
+func TestManagedVerifyBLS(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	signers := newBLSSigners(t, 2)
+	message := []byte("message")
+
+	sig, err := singlesig.NewBlsSigner().Sign(signers.privateKeys[0], message)
+	require.Nil(t, err)
+
+	keyHandle := context.ManagedTypes.NewManagedBufferFromBytes(signers.keys[:blsPublicKeyLength])
+	otherKeyHandle := context.ManagedTypes.NewManagedBufferFromBytes(signers.keys[blsPublicKeyLength:])
+	messageHandle := context.ManagedTypes.NewManagedBufferFromBytes(message)
+	sigHandle := context.ManagedTypes.NewManagedBufferFromBytes(sig)
+
+	result := v1_4_managedVerifyBLS(context.Pointer, keyHandle, messageHandle, sigHandle)
+	require.Equal(t, int32(0), result)
+
+	result = v1_4_managedVerifyBLS(context.Pointer, otherKeyHandle, messageHandle, sigHandle)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+}
+
+func TestManagedVerifyBLSAggregated(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	signers := newBLSSigners(t, 2)
+	signer := singlesig.NewBlsSigner()
+
+	keysHandle := context.ManagedTypes.NewManagedVec()
+	messagesHandle := context.ManagedTypes.NewManagedVec()
+	sigs := make([][]byte, 0)
+	for i, privateKey := range signers.privateKeys {
+		message := []byte{byte(i + 1)}
+		sig, err := signer.Sign(privateKey, message)
+		require.Nil(t, err)
+		sigs = append(sigs, sig)
+
+		require.Nil(t, context.ManagedTypes.PushManagedVecItem(keysHandle, signers.keys[i*blsPublicKeyLength:(i+1)*blsPublicKeyLength]))
+		require.Nil(t, context.ManagedTypes.PushManagedVecItem(messagesHandle, message))
+	}
+
+	sigHandle := context.ManagedTypes.NewManagedBufferFromBytes(aggregateBLSSignatures(t, sigs))
+	result := v1_4_managedVerifyBLSAggregated(context.Pointer, keysHandle, messagesHandle, sigHandle)
+	require.Equal(t, int32(0), result)
+
+	sigHandle = context.ManagedTypes.NewManagedBufferFromBytes(sigs[0])
+	result = v1_4_managedVerifyBLSAggregated(context.Pointer, keysHandle, messagesHandle, sigHandle)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+
+	result = v1_4_managedVerifyBLSAggregated(context.Pointer, int32(1000), messagesHandle, sigHandle)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrNoManagedVecUnderThisHandle, context.Runtime.Err)
+}
+
+func TestManagedVerifyCustomSecp256k1(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	key, _ := hex.DecodeString("044338845e8308b819bf33a43dc7f47713f92d8d377dfde399831e9d8da23446be32cef60a7c923332ab06c768242d11017a6bcf419c17b8b184fc19ea603b07d6")
+	sig, _ := hex.DecodeString("3046022100da0db89620513df9a90cf8c97edf227e07182d1c91b3cab55a472122d639daee022100d5b9cf4a02274cf5b606df7b4fa73bff1190f54e0c6ef8cd362e63dc1dbecce1")
+
+	keyHandle := context.ManagedTypes.NewManagedBufferFromBytes(key)
+	messageHandle := context.ManagedTypes.NewManagedBufferFromBytes([]byte("aaa"))
+	sigHandle := context.ManagedTypes.NewManagedBufferFromBytes(sig)
+
+	result := v1_4_managedVerifyCustomSecp256k1(context.Pointer, keyHandle, messageHandle, sigHandle, int32(secp256k1.ECDSASha256))
+	require.Equal(t, int32(0), result)
+
+	result = v1_4_managedVerifySecp256k1(context.Pointer, keyHandle, messageHandle, sigHandle)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+
+	result = v1_4_managedVerifySecp256k1(context.Pointer, messageHandle, messageHandle, sigHandle)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrInvalidPublicKeySize, context.Runtime.Err)
+}
+
+func TestManagedVerifyEd25519Batch(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	keysHandle := context.ManagedTypes.NewManagedVec()
+	messagesHandle := context.ManagedTypes.NewManagedVec()
+	sigsHandle := context.ManagedTypes.NewManagedVec()
+
+	numItems := 10
+	for i := 0; i < numItems; i++ {
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.Nil(t, err)
+
+		message := []byte{byte(i)}
+		sig := ed25519.Sign(privateKey, message)
+		if i == 3 || i == 8 {
+			sig[0]++
+		}
+
+		require.Nil(t, context.ManagedTypes.PushManagedVecItem(keysHandle, publicKey))
+		require.Nil(t, context.ManagedTypes.PushManagedVecItem(messagesHandle, message))
+		require.Nil(t, context.ManagedTypes.PushManagedVecItem(sigsHandle, sig))
+	}
+
+	resultHandle := context.ManagedTypes.NewManagedBuffer()
+	result := v1_4_managedVerifyEd25519Batch(context.Pointer, keysHandle, messagesHandle, sigsHandle, resultHandle)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+	context.RequireBufferHex(t, "f702", resultHandle)
+
+	emptyHandle := context.ManagedTypes.NewManagedVec()
+	result = v1_4_managedVerifyEd25519Batch(context.Pointer, emptyHandle, emptyHandle, emptyHandle, resultHandle)
+	require.Equal(t, int32(0), result)
+	context.RequireBufferHex(t, "", resultHandle)
+
+	result = v1_4_managedVerifyEd25519Batch(context.Pointer, keysHandle, emptyHandle, sigsHandle, resultHandle)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrSignatureBatchLengthMismatch, context.Runtime.Err)
+
+	result = v1_4_managedVerifyEd25519Batch(context.Pointer, int32(1000), messagesHandle, sigsHandle, resultHandle)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrNoManagedVecUnderThisHandle, context.Runtime.Err)
+}
+
+func TestManagedVerifySecp256k1Batch(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	key, _ := hex.DecodeString("044338845e8308b819bf33a43dc7f47713f92d8d377dfde399831e9d8da23446be32cef60a7c923332ab06c768242d11017a6bcf419c17b8b184fc19ea603b07d6")
+	sig, _ := hex.DecodeString("3046022100da0db89620513df9a90cf8c97edf227e07182d1c91b3cab55a472122d639daee022100d5b9cf4a02274cf5b606df7b4fa73bff1190f54e0c6ef8cd362e63dc1dbecce1")
+
+	keysHandle := context.ManagedTypes.NewManagedVec()
+	messagesHandle := context.ManagedTypes.NewManagedVec()
+	sigsHandle := context.ManagedTypes.NewManagedVec()
+	entries := []struct {
+		key     []byte
+		message []byte
+	}{
+		{key, []byte("aaa")},
+		{key, []byte("bbb")},
+		{key[1:], []byte("aaa")},
+	}
+	for _, entry := range entries {
+		require.Nil(t, context.ManagedTypes.PushManagedVecItem(keysHandle, entry.key))
+		require.Nil(t, context.ManagedTypes.PushManagedVecItem(messagesHandle, entry.message))
+		require.Nil(t, context.ManagedTypes.PushManagedVecItem(sigsHandle, sig))
+	}
+
+	resultHandle := context.ManagedTypes.NewManagedBuffer()
+	result := v1_4_managedVerifyCustomSecp256k1Batch(context.Pointer, keysHandle, messagesHandle, sigsHandle, int32(secp256k1.ECDSASha256), resultHandle)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+	context.RequireBufferHex(t, "01", resultHandle)
+
+	result = v1_4_managedVerifySecp256k1Batch(context.Pointer, keysHandle, messagesHandle, sigsHandle, resultHandle)
+	require.Equal(t, int32(-1), result)
+	context.RequireBufferHex(t, "00", resultHandle)
+}
+
+func TestManagedVerifyMerkleProof(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	leafHash := sha3.NewLegacyKeccak256()
+	leafHash.Write([]byte("leaf"))
+	leaf := leafHash.Sum(nil)
+	sibling := make([]byte, 32)
+
+	// with sorted pairs, the zero sibling is always hashed first
+	rootHash := sha3.NewLegacyKeccak256()
+	rootHash.Write(sibling)
+	rootHash.Write(leaf)
+
+	leafHandle := context.ManagedTypes.NewManagedBufferFromBytes(leaf)
+	rootHandle := context.ManagedTypes.NewManagedBufferFromBytes(rootHash.Sum(nil))
+	proofHandle := context.ManagedTypes.NewManagedVec()
+	require.Nil(t, context.ManagedTypes.PushManagedVecItem(proofHandle, sibling))
+
+	flags := int32(merkleProofKeccak256 | merkleProofSortPairs)
+	result := v1_4_managedVerifyMerkleProof(context.Pointer, leafHandle, proofHandle, 0, rootHandle, flags)
+	require.Equal(t, int32(0), result)
+
+	result = v1_4_managedVerifyMerkleProof(context.Pointer, leafHandle, proofHandle, 0, rootHandle, merkleProofKeccak256)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+
+	// the siblings must be digests
+	longProofHandle := context.ManagedTypes.NewManagedVec()
+	require.Nil(t, context.ManagedTypes.PushManagedVecItem(longProofHandle, make([]byte, 1024)))
+	result = v1_4_managedVerifyMerkleProof(context.Pointer, leafHandle, longProofHandle, 0, rootHandle, flags)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+
+	result = v1_4_managedVerifyMerkleProof(context.Pointer, leafHandle, int32(1000), 0, rootHandle, flags)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrNoManagedVecUnderThisHandle, context.Runtime.Err)
+}
+
+func TestManagedVerifyPatriciaMerkleProof(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+
+	// a trie holding a single leaf, for the key 0xab, whose path is made of
+	// the reversed nibbles of the key, followed by the terminator
+	leaf := []byte{0x0a, 0x03, 0x0b, 0x0a, 0x10, 0x12, 0x05}
+	leaf = append(leaf, []byte("value")...)
+	leaf = append(leaf, 0x01)
+	rootHash := blake2b.Sum256(leaf)
+
+	rootHashHandle := context.ManagedTypes.NewManagedBufferFromBytes(rootHash[:])
+	keyHandle := context.ManagedTypes.NewManagedBufferFromBytes([]byte{0xab})
+	proofHandle := context.ManagedTypes.NewManagedVec()
+	require.Nil(t, context.ManagedTypes.PushManagedVecItem(proofHandle, leaf))
+	valueHandle := context.ManagedTypes.NewManagedBuffer()
+
+	result := v1_4_managedVerifyPatriciaMerkleProof(context.Pointer, rootHashHandle, keyHandle, proofHandle, valueHandle)
+	require.Equal(t, int32(0), result)
+	context.RequireBufferHex(t, hex.EncodeToString([]byte("value")), valueHandle)
+
+	keyHandle = context.ManagedTypes.NewManagedBufferFromBytes([]byte{0xba})
+	result = v1_4_managedVerifyPatriciaMerkleProof(context.Pointer, rootHashHandle, keyHandle, proofHandle, valueHandle)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+
+	result = v1_4_managedVerifyPatriciaMerkleProof(context.Pointer, rootHashHandle, keyHandle, int32(1000), valueHandle)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrNoManagedVecUnderThisHandle, context.Runtime.Err)
+}
+
+func TestManagedEncodeSecp256k1DerSignature(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	rHandle := context.ManagedTypes.NewManagedBufferFromBytes([]byte{0x01})
+	sHandle := context.ManagedTypes.NewManagedBufferFromBytes([]byte{0x02})
+	sigHandle := context.ManagedTypes.NewManagedBuffer()
+
+	result := v1_4_managedEncodeSecp256k1DerSignature(context.Pointer, rHandle, sHandle, sigHandle)
+	require.Equal(t, int32(0), result)
+	context.RequireBufferHex(t, "3006020101020102", sigHandle)
+}
+
+func TestManagedEllipticCurveOperations(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+
+	ecHandle := v1_4_managedCreateEC(context.Pointer, context.ManagedTypes.NewManagedBufferFromBytes([]byte("p256")))
+	require.True(t, ecHandle >= 0)
+	require.Equal(t, int32(-1), v1_4_managedCreateEC(context.Pointer, context.ManagedTypes.NewManagedBufferFromBytes([]byte("p999"))))
+
+	x := context.ManagedTypes.NewBigIntFromInt64(0)
+	y := context.ManagedTypes.NewBigIntFromInt64(0)
+	scalarHandle := context.ManagedTypes.NewManagedBufferFromBytes([]byte{0x02})
+	result := v1_4_managedScalarBaseMultEC(context.Pointer, x, y, ecHandle, scalarHandle)
+	require.Equal(t, int32(0), result)
+
+	expectedX, expectedY := elliptic.P256().ScalarBaseMult([]byte{0x02})
+	require.Equal(t, expectedX, context.MustGetBigInt(t, x))
+	require.Equal(t, expectedY, context.MustGetBigInt(t, y))
+
+	marshaledHandle := context.ManagedTypes.NewManagedBuffer()
+	length := v1_4_managedMarshalCompressedEC(context.Pointer, x, y, ecHandle, marshaledHandle)
+	require.Equal(t, int32(33), length)
+
+	unmarshaledX := context.ManagedTypes.NewBigIntFromInt64(0)
+	unmarshaledY := context.ManagedTypes.NewBigIntFromInt64(0)
+	result = v1_4_managedUnmarshalCompressedEC(context.Pointer, unmarshaledX, unmarshaledY, ecHandle, marshaledHandle)
+	require.Equal(t, int32(0), result)
+	require.Equal(t, expectedX, context.MustGetBigInt(t, unmarshaledX))
+	require.Equal(t, expectedY, context.MustGetBigInt(t, unmarshaledY))
+
+	length = v1_4_managedMarshalEC(context.Pointer, x, y, ecHandle, marshaledHandle)
+	require.Equal(t, int32(65), length)
+	result = v1_4_managedUnmarshalEC(context.Pointer, unmarshaledX, unmarshaledY, ecHandle, marshaledHandle)
+	require.Equal(t, int32(0), result)
+	require.Equal(t, expectedY, context.MustGetBigInt(t, unmarshaledY))
+
+	doubledX := context.ManagedTypes.NewBigIntFromInt64(0)
+	doubledY := context.ManagedTypes.NewBigIntFromInt64(0)
+	result = v1_4_managedScalarMultEC(context.Pointer, doubledX, doubledY, ecHandle, x, y, scalarHandle)
+	require.Equal(t, int32(0), result)
+	expectedX, expectedY = elliptic.P256().ScalarBaseMult([]byte{0x04})
+	require.Equal(t, expectedX, context.MustGetBigInt(t, doubledX))
+	require.Equal(t, expectedY, context.MustGetBigInt(t, doubledY))
+
+	result = v1_4_managedUnmarshalEC(context.Pointer, unmarshaledX, unmarshaledY, ecHandle, scalarHandle)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrLengthOfBufferNotCorrect, context.Runtime.Err)
+}
+func TestManagedVerifySecp256r1(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	key, message, sig := signSecp256r1(t, []byte("webauthn assertion"))
+
+	keyHandle := context.ManagedTypes.NewManagedBufferFromBytes(key)
+	messageHandle := context.ManagedTypes.NewManagedBufferFromBytes(message)
+	sigHandle := context.ManagedTypes.NewManagedBufferFromBytes(sig)
+	otherMessageHandle := context.ManagedTypes.NewManagedBufferFromBytes([]byte("another assertion"))
+
+	result := v1_4_managedVerifySecp256r1(context.Pointer, keyHandle, messageHandle, sigHandle)
+	require.Equal(t, int32(0), result)
+
+	result = v1_4_managedVerifySecp256r1(context.Pointer, keyHandle, otherMessageHandle, sigHandle)
+	require.Equal(t, int32(-1), result)
+	require.Nil(t, context.Runtime.Err)
+
+	result = v1_4_managedVerifySecp256r1(context.Pointer, messageHandle, messageHandle, sigHandle)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrInvalidPublicKeySize, context.Runtime.Err)
+
+	context.Runtime.Err = nil
+	result = v1_4_managedVerifySecp256r1(context.Pointer, keyHandle, int32(1000), sigHandle)
+	require.Equal(t, int32(1), result)
+	require.Equal(t, arwen.ErrNoManagedBufferUnderThisHandle, context.Runtime.Err)
+}
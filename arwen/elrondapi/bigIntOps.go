@@ -31,6 +31,12 @@ package elrondapi
 // extern int32_t		v1_4_bigIntLog2(void* context, int32_t op);
 // extern void			v1_4_bigIntSqrt(void* context, int32_t destination, int32_t op);
 //
+// extern void			v1_4_bigIntModPow(void* context, int32_t destination, int32_t base, int32_t exponent, int32_t modulus);
+// extern void			v1_4_bigIntExpMod(void* context, int32_t destination, int32_t base, int32_t exponent, int32_t modulus);
+// extern void			v1_4_bigIntModInverse(void* context, int32_t destination, int32_t op, int32_t modulus);
+// extern void			v1_4_bigIntMulMod(void* context, int32_t destination, int32_t op1, int32_t op2, int32_t modulus);
+// extern void			v1_4_bigIntGCD(void* context, int32_t destination, int32_t op1, int32_t op2);
+//
 // extern void			v1_4_bigIntAbs(void* context, int32_t destination, int32_t op);
 // extern void			v1_4_bigIntNeg(void* context, int32_t destination, int32_t op);
 // extern int32_t		v1_4_bigIntSign(void* context, int32_t op);
@@ -57,6 +63,7 @@ package elrondapi
 import "C"
 
 import (
+	basicMath "math"
 	"math/big"
 	"unsafe"
 
@@ -87,6 +94,11 @@ const (
 	bigIntPowName                     = "bigIntPow"
 	bigIntLog2Name                    = "bigIntLog2"
 	bigIntSqrtName                    = "bigIntSqrt"
+	bigIntModPowName                  = "bigIntModPow"
+	bigIntExpModName                  = "bigIntExpMod"
+	bigIntModInverseName              = "bigIntModInverse"
+	bigIntMulModName                  = "bigIntMulMod"
+	bigIntGCDName                     = "bigIntGCD"
 	bigIntAbsName                     = "bigIntAbs"
 	bigIntNegName                     = "bigIntNeg"
 	bigIntSignName                    = "bigIntSign"
@@ -214,6 +226,31 @@ func BigIntImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("bigIntModPow", v1_4_bigIntModPow, C.v1_4_bigIntModPow)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntExpMod", v1_4_bigIntExpMod, C.v1_4_bigIntExpMod)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntModInverse", v1_4_bigIntModInverse, C.v1_4_bigIntModInverse)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntMulMod", v1_4_bigIntMulMod, C.v1_4_bigIntMulMod)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntGCD", v1_4_bigIntGCD, C.v1_4_bigIntGCD)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("bigIntAbs", v1_4_bigIntAbs, C.v1_4_bigIntAbs)
 	if err != nil {
 		return nil, err
@@ -906,6 +943,194 @@ func v1_4_bigIntLog2(context unsafe.Pointer, op1Handle int32) int32 {
 	return int32(a.BitLen() - 1)
 }
 
+//export v1_4_bigIntModPow
+func v1_4_bigIntModPow(context unsafe.Pointer, destinationHandle, baseHandle, exponentHandle, modulusHandle int32) {
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(bigIntModPowName)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntModPow
+	metering.UseAndTraceGas(gasToUse)
+
+	bigIntModularExponentiation(context, destinationHandle, baseHandle, exponentHandle, modulusHandle, false)
+}
+
+//export v1_4_bigIntExpMod
+func v1_4_bigIntExpMod(context unsafe.Pointer, destinationHandle, baseHandle, exponentHandle, modulusHandle int32) {
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(bigIntExpModName)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntExpMod
+	metering.UseAndTraceGas(gasToUse)
+
+	bigIntModularExponentiation(context, destinationHandle, baseHandle, exponentHandle, modulusHandle, true)
+}
+
+// bigIntModularExponentiation implements both bigIntModPow and bigIntExpMod;
+// the latter also accepts negative exponents, raising the modular inverse of
+// the base
+func bigIntModularExponentiation(
+	context unsafe.Pointer,
+	destinationHandle, baseHandle, exponentHandle, modulusHandle int32,
+	allowNegativeExponent bool,
+) {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+
+	dest := managedType.GetBigIntOrCreate(destinationHandle)
+	base, exponent, modulus, err := getModularOperands(managedType, baseHandle, exponentHandle, modulusHandle)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+	consumeGasForModPow(managedType, arwen.GetMeteringContext(context), base, exponent, modulus)
+
+	if exponent.Sign() >= 0 {
+		dest.Exp(base, exponent, modulus)
+		return
+	}
+	if !allowNegativeExponent {
+		_ = arwen.WithFault(arwen.ErrBadLowerBounds, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+
+	inverse := big.NewInt(0).ModInverse(big.NewInt(0).Mod(base, modulus), modulus)
+	if inverse == nil {
+		_ = arwen.WithFault(arwen.ErrNoModularInverse, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+	dest.Exp(inverse, big.NewInt(0).Neg(exponent), modulus)
+}
+
+//export v1_4_bigIntModInverse
+func v1_4_bigIntModInverse(context unsafe.Pointer, destinationHandle, opHandle, modulusHandle int32) {
+	managedType := arwen.GetManagedTypesContext(context)
+	metering := arwen.GetMeteringContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering.StartGasTracing(bigIntModInverseName)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntModInverse
+	metering.UseAndTraceGas(gasToUse)
+
+	dest := managedType.GetBigIntOrCreate(destinationHandle)
+	a, modulus, err := managedType.GetTwoBigInt(opHandle, modulusHandle)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+	managedType.ConsumeGasForBigIntCopy(dest, a, modulus)
+	if modulus.Sign() <= 0 {
+		_ = arwen.WithFault(arwen.ErrBadLowerBounds, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+
+	inverse := big.NewInt(0).ModInverse(big.NewInt(0).Mod(a, modulus), modulus)
+	if inverse == nil {
+		_ = arwen.WithFault(arwen.ErrNoModularInverse, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+	dest.Set(inverse)
+}
+
+//export v1_4_bigIntMulMod
+func v1_4_bigIntMulMod(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle, modulusHandle int32) {
+	managedType := arwen.GetManagedTypesContext(context)
+	metering := arwen.GetMeteringContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering.StartGasTracing(bigIntMulModName)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntMulMod
+	metering.UseAndTraceGas(gasToUse)
+
+	dest := managedType.GetBigIntOrCreate(destinationHandle)
+	a, b, modulus, err := getModularOperands(managedType, op1Handle, op2Handle, modulusHandle)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+	managedType.ConsumeGasForBigIntCopy(dest, a, b, modulus)
+	// the intermediate product is as long as both operands together
+	managedType.ConsumeGasForThisIntNumberOfBytes((a.BitLen() + b.BitLen()) / 8)
+
+	product := big.NewInt(0).Mul(a, b)
+	dest.Mod(product, modulus)
+}
+
+//export v1_4_bigIntGCD
+func v1_4_bigIntGCD(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	managedType := arwen.GetManagedTypesContext(context)
+	metering := arwen.GetMeteringContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering.StartGasTracing(bigIntGCDName)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntGCD
+	metering.UseAndTraceGas(gasToUse)
+
+	dest := managedType.GetBigIntOrCreate(destinationHandle)
+	a, b, err := managedType.GetTwoBigInt(op1Handle, op2Handle)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+	managedType.ConsumeGasForBigIntCopy(dest, a, b)
+
+	// the GCD is computed on the absolute values, so that GCD(0, 0) is 0 and
+	// the result is never negative
+	absA := big.NewInt(0).Abs(a)
+	absB := big.NewInt(0).Abs(b)
+	if absA.Sign() == 0 || absB.Sign() == 0 {
+		dest.Add(absA, absB)
+		return
+	}
+	dest.GCD(nil, nil, absA, absB)
+}
+
+// getModularOperands returns the two operands and the modulus of a modular
+// operation; the modulus must be strictly positive
+func getModularOperands(managedType arwen.ManagedTypesContext, op1Handle, op2Handle, modulusHandle int32) (*big.Int, *big.Int, *big.Int, error) {
+	a, b, err := managedType.GetTwoBigInt(op1Handle, op2Handle)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	modulus, err := managedType.GetBigInt(modulusHandle)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if modulus.Sign() <= 0 {
+		return nil, nil, nil, arwen.ErrBadLowerBounds
+	}
+	return a, b, modulus, nil
+}
+
+// modPowGasDivisor divides the work of a modular exponentiation, like the
+// ModExp precompile of EIP-2565
+const modPowGasDivisor = 3
+
+// consumeGasForModPow uses gas like the ModExp precompile of EIP-2565: a
+// multiplication costs the squared number of 64-bit words of the longer of the
+// base and the modulus, and there is about one multiplication for each bit of
+// the exponent; the operands are also charged for copying
+func consumeGasForModPow(managedType arwen.ManagedTypesContext, metering arwen.MeteringContext, base, exponent, modulus *big.Int) {
+	byteLen := (base.BitLen() + 7) / 8
+	modulusByteLen := (modulus.BitLen() + 7) / 8
+	if modulusByteLen > byteLen {
+		byteLen = modulusByteLen
+	}
+	words := big.NewInt(int64((byteLen + 7) / 8))
+	iterations := big.NewInt(1)
+	if exponent.BitLen() > 1 {
+		iterations.SetInt64(int64(exponent.BitLen()))
+	}
+
+	gasToUseBigInt := big.NewInt(0).Mul(words, words)
+	gasToUseBigInt.Mul(gasToUseBigInt, iterations)
+	gasToUseBigInt.Mul(gasToUseBigInt, big.NewInt(0).SetUint64(metering.GasSchedule().BigIntAPICost.BigIntModPowPerWord))
+	gasToUseBigInt.Div(gasToUseBigInt, big.NewInt(modPowGasDivisor))
+
+	gasToUse := uint64(basicMath.MaxUint64)
+	if gasToUseBigInt.IsUint64() {
+		gasToUse = gasToUseBigInt.Uint64()
+	}
+	metering.UseAndTraceGas(gasToUse)
+
+	managedType.ConsumeGasForBigIntCopy(base, exponent, modulus)
+}
+
 //export v1_4_bigIntAbs
 func v1_4_bigIntAbs(context unsafe.Pointer, destinationHandle, opHandle int32) {
 	managedType := arwen.GetManagedTypesContext(context)
@@ -0,0 +1,149 @@
+package elrondapi
+
+import (
+	basicMath "math"
+	"math/big"
+	"testing"
+	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context/eicontext"
+	"github.com/stretchr/testify/require"
+)
+
+// gasRecordingMetering sums the gas used through UseAndTraceGas
+type gasRecordingMetering struct {
+	*contextmock.MeteringContextMock
+	gasUsed uint64
+}
+
+func (metering *gasRecordingMetering) UseAndTraceGas(gas uint64) {
+	metering.gasUsed += gas
+}
+
+func TestBigIntModPow(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	dest := context.NewBigInt(0)
+
+	v1_4_bigIntModPow(context.Pointer, dest, context.NewBigInt(4), context.NewBigInt(13), context.NewBigInt(497))
+	require.Nil(t, context.Runtime.Err)
+	context.RequireBigInt(t, "445", dest)
+
+	v1_4_bigIntModPow(context.Pointer, dest, context.NewBigInt(-2), context.NewBigInt(3), context.NewBigInt(5))
+	require.Nil(t, context.Runtime.Err)
+	context.RequireBigInt(t, "2", dest)
+
+	v1_4_bigIntModPow(context.Pointer, dest, context.NewBigInt(4), context.NewBigInt(-1), context.NewBigInt(7))
+	require.ErrorIs(t, context.Runtime.Err, arwen.ErrBadLowerBounds)
+
+	context.Runtime.Err = nil
+	v1_4_bigIntModPow(context.Pointer, dest, context.NewBigInt(4), context.NewBigInt(2), context.NewBigInt(0))
+	require.ErrorIs(t, context.Runtime.Err, arwen.ErrBadLowerBounds)
+
+	context.Runtime.Err = nil
+	v1_4_bigIntModPow(context.Pointer, dest, context.NewBigInt(4), context.NewBigInt(2), int32(1000))
+	require.Equal(t, arwen.ErrNoBigIntUnderThisHandle, context.Runtime.Err)
+}
+
+func TestBigIntExpMod(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	dest := context.NewBigInt(0)
+
+	v1_4_bigIntExpMod(context.Pointer, dest, context.NewBigInt(4), context.NewBigInt(13), context.NewBigInt(497))
+	require.Nil(t, context.Runtime.Err)
+	context.RequireBigInt(t, "445", dest)
+
+	// 3^-2 mod 7 = 5^2 mod 7, since 3 * 5 = 1 mod 7
+	v1_4_bigIntExpMod(context.Pointer, dest, context.NewBigInt(3), context.NewBigInt(-2), context.NewBigInt(7))
+	require.Nil(t, context.Runtime.Err)
+	context.RequireBigInt(t, "4", dest)
+
+	v1_4_bigIntExpMod(context.Pointer, dest, context.NewBigInt(2), context.NewBigInt(-1), context.NewBigInt(8))
+	require.Equal(t, arwen.ErrNoModularInverse, context.Runtime.Err)
+}
+
+func TestBigIntModPow_GasIsQuadraticInModulusWords(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	metering := &gasRecordingMetering{MeteringContextMock: context.Host.MeteringContext.(*contextmock.MeteringContextMock)}
+	context.Host.MeteringContext = metering
+
+	gasSchedule := metering.GasSchedule().BigIntAPICost
+	modPowGas := func(modPow func(unsafe.Pointer, int32, int32, int32, int32), exponent *big.Int, modulusByteLen uint) uint64 {
+		modulus := big.NewInt(0).Lsh(big.NewInt(1), modulusByteLen*8-1)
+		metering.gasUsed = 0
+		modPow(context.Pointer, context.NewBigInt(0), context.NewBigInt(3), context.ManagedTypes.NewBigInt(exponent), context.ManagedTypes.NewBigInt(modulus))
+		require.Nil(t, context.Runtime.Err)
+		return metering.gasUsed
+	}
+	expectedGas := func(apiCost uint64, words uint64, exponentBits uint64) uint64 {
+		return apiCost + words*words*exponentBits*gasSchedule.BigIntModPowPerWord/modPowGasDivisor
+	}
+
+	// the operands are short enough not to be charged for copying
+	exponent := big.NewInt(255)
+	require.Equal(t, expectedGas(gasSchedule.BigIntModPow, 2, 8), modPowGas(v1_4_bigIntModPow, exponent, 16))
+	require.Equal(t, expectedGas(gasSchedule.BigIntModPow, 4, 8), modPowGas(v1_4_bigIntModPow, exponent, 32))
+	require.Equal(t, expectedGas(gasSchedule.BigIntExpMod, 4, 8), modPowGas(v1_4_bigIntExpMod, exponent, 32))
+
+	longExponent := big.NewInt(0).SetUint64(basicMath.MaxUint64)
+	require.Equal(t, expectedGas(gasSchedule.BigIntModPow, 4, 64), modPowGas(v1_4_bigIntModPow, longExponent, 32))
+}
+
+func TestBigIntModInverse(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	dest := context.NewBigInt(0)
+
+	v1_4_bigIntModInverse(context.Pointer, dest, context.NewBigInt(3), context.NewBigInt(11))
+	require.Nil(t, context.Runtime.Err)
+	context.RequireBigInt(t, "4", dest)
+
+	v1_4_bigIntModInverse(context.Pointer, dest, context.NewBigInt(-3), context.NewBigInt(11))
+	require.Nil(t, context.Runtime.Err)
+	context.RequireBigInt(t, "7", dest)
+
+	v1_4_bigIntModInverse(context.Pointer, dest, context.NewBigInt(6), context.NewBigInt(9))
+	require.Equal(t, arwen.ErrNoModularInverse, context.Runtime.Err)
+
+	context.Runtime.Err = nil
+	v1_4_bigIntModInverse(context.Pointer, dest, context.NewBigInt(6), context.NewBigInt(-9))
+	require.ErrorIs(t, context.Runtime.Err, arwen.ErrBadLowerBounds)
+}
+
+func TestBigIntMulMod(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	dest := context.NewBigInt(0)
+
+	a := context.NewBigIntFromString(t, "123456789012345678901234567890")
+	b := context.NewBigIntFromString(t, "987654321098765432109876543210")
+	modulus := context.NewBigIntFromString(t, "1000000007")
+	v1_4_bigIntMulMod(context.Pointer, dest, a, b, modulus)
+	require.Nil(t, context.Runtime.Err)
+
+	expected := big.NewInt(0).Mul(context.MustGetBigInt(t, a), context.MustGetBigInt(t, b))
+	expected.Mod(expected, context.MustGetBigInt(t, modulus))
+	context.RequireBigInt(t, expected.String(), dest)
+
+	v1_4_bigIntMulMod(context.Pointer, dest, context.NewBigInt(-3), context.NewBigInt(4), context.NewBigInt(5))
+	require.Nil(t, context.Runtime.Err)
+	context.RequireBigInt(t, "3", dest)
+
+	v1_4_bigIntMulMod(context.Pointer, dest, context.NewBigInt(3), context.NewBigInt(4), context.NewBigInt(0))
+	require.ErrorIs(t, context.Runtime.Err, arwen.ErrBadLowerBounds)
+}
+
+func TestBigIntGCD(t *testing.T) {
+	context := eicontext.NewEIContext(t)
+	dest := context.NewBigInt(0)
+
+	v1_4_bigIntGCD(context.Pointer, dest, context.NewBigInt(84), context.NewBigInt(-36))
+	require.Nil(t, context.Runtime.Err)
+	context.RequireBigInt(t, "12", dest)
+
+	v1_4_bigIntGCD(context.Pointer, dest, context.NewBigInt(0), context.NewBigInt(-5))
+	context.RequireBigInt(t, "5", dest)
+
+	v1_4_bigIntGCD(context.Pointer, dest, context.NewBigInt(0), context.NewBigInt(0))
+	context.RequireBigInt(t, "0", dest)
+	require.Nil(t, context.Runtime.Err)
+}
@@ -10,6 +10,8 @@ package elrondapi
 // extern void		v1_4_getOwnerAddress(void *context, int32_t resultOffset);
 // extern int32_t	v1_4_getShardOfAddress(void *context, int32_t addressOffset);
 // extern int32_t	v1_4_isSmartContract(void *context, int32_t addressOffset);
+// extern int32_t	v1_4_getCodeMetadata(void *context, int32_t addressOffset, int32_t resultOffset);
+// extern int32_t	v1_4_isPayable(void *context, int32_t addressOffset);
 // extern void		v1_4_getExternalBalance(void *context, int32_t addressOffset, int32_t resultOffset);
 // extern int32_t	v1_4_blockHash(void *context, long long nonce, int32_t resultOffset);
 // extern int32_t	v1_4_transferValue(void *context, int32_t dstOffset, int32_t valueOffset, int32_t dataOffset, int32_t length);
@@ -49,6 +51,10 @@ package elrondapi
 // extern int32_t	v1_4_getESDTNFTNameLength(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen, long long nonce);
 // extern int32_t	v1_4_getESDTNFTAttributeLength(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen, long long nonce);
 // extern int32_t	v1_4_getESDTNFTURILength(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen, long long nonce);
+// extern long long	v1_4_getESDTLocalRoles(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen);
+// extern int32_t	v1_4_isESDTFrozen(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen);
+// extern int32_t	v1_4_isESDTPaused(void *context, int32_t tokenIDOffset, int32_t tokenIDLen);
+// extern int32_t	v1_4_isESDTLimitedTransfer(void *context, int32_t tokenIDOffset, int32_t tokenIDLen);
 // extern int32_t	v1_4_getESDTTokenData(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen, long long nonce, int32_t valueOffset, int32_t propertiesOffset, int32_t hashOffset, int32_t nameOffset, int32_t attributesOffset, int32_t creatorOffset, int32_t royaltiesOffset, int32_t urisOffset);
 //
 // extern int32_t	v1_4_executeOnDestContext(void *context, long long gas, int32_t addressOffset, int32_t valueOffset, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
@@ -104,11 +110,38 @@ import (
 	"github.com/ElrondNetwork/elrond-vm-common/parsers"
 )
 
+// The bits of the local ESDT roles of an address, as returned by getESDTLocalRoles
+const (
+	esdtRoleLocalMint = 1 << iota
+	esdtRoleLocalBurn
+	esdtRoleNFTCreate
+	esdtRoleNFTAddQuantity
+	esdtRoleNFTBurn
+	esdtRoleNFTAddURI
+	esdtRoleNFTUpdateAttributes
+	esdtRoleTransfer
+	esdtRoleNFTCreateMultiShard
+)
+
+var esdtRoleFlags = map[string]int64{
+	core.ESDTRoleLocalMint:           esdtRoleLocalMint,
+	core.ESDTRoleLocalBurn:           esdtRoleLocalBurn,
+	core.ESDTRoleNFTCreate:           esdtRoleNFTCreate,
+	core.ESDTRoleNFTAddQuantity:      esdtRoleNFTAddQuantity,
+	core.ESDTRoleNFTBurn:             esdtRoleNFTBurn,
+	core.ESDTRoleNFTAddURI:           esdtRoleNFTAddURI,
+	core.ESDTRoleNFTUpdateAttributes: esdtRoleNFTUpdateAttributes,
+	core.ESDTRoleTransfer:            esdtRoleTransfer,
+	core.ESDTRoleNFTCreateMultiShard: esdtRoleNFTCreateMultiShard,
+}
+
 const (
 	getSCAddressName                 = "getSCAddress"
 	getOwnerAddressName              = "getOwnerAddress"
 	getShardOfAddressName            = "getShardOfAddress"
 	isSmartContractName              = "isSmartContract"
+	getCodeMetadataName              = "getCodeMetadata"
+	isPayableName                    = "isPayable"
 	getExternalBalanceName           = "getExternalBalance"
 	blockHashName                    = "blockHash"
 	transferValueName                = "transferValue"
@@ -149,6 +182,10 @@ const (
 	getESDTNFTAttributeLengthName    = "getESDTNFTAttributeLength"
 	getESDTNFTURILengthName          = "getESDTNFTURILength"
 	getESDTTokenDataName             = "getESDTTokenData"
+	getESDTLocalRolesName            = "getESDTLocalRoles"
+	isESDTFrozenName                 = "isESDTFrozen"
+	isESDTPausedName                 = "isESDTPaused"
+	isESDTLimitedTransferName        = "isESDTLimitedTransfer"
 	executeOnDestContextName         = "executeOnDestContext"
 	executeOnDestContextByCallerName = "executeOnDestContextByCaller"
 	executeOnSameContextName         = "executeOnSameContext"
@@ -222,6 +259,16 @@ func ElrondEIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("getCodeMetadata", v1_4_getCodeMetadata, C.v1_4_getCodeMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("isPayable", v1_4_isPayable, C.v1_4_isPayable)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getExternalBalance", v1_4_getExternalBalance, C.v1_4_getExternalBalance)
 	if err != nil {
 		return nil, err
@@ -547,6 +594,26 @@ func ElrondEIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("getESDTLocalRoles", v1_4_getESDTLocalRoles, C.v1_4_getESDTLocalRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("isESDTFrozen", v1_4_isESDTFrozen, C.v1_4_isESDTFrozen)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("isESDTPaused", v1_4_isESDTPaused, C.v1_4_isESDTPaused)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("isESDTLimitedTransfer", v1_4_isESDTLimitedTransfer, C.v1_4_isESDTLimitedTransfer)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getESDTNFTNameLength", v1_4_getESDTNFTNameLength, C.v1_4_getESDTNFTNameLength)
 	if err != nil {
 		return nil, err
@@ -646,6 +713,55 @@ func v1_4_isSmartContract(context unsafe.Pointer, addressOffset int32) int32 {
 	return int32(arwen.BooleanToInt(isSmartContract))
 }
 
+//export v1_4_getCodeMetadata
+func v1_4_getCodeMetadata(context unsafe.Pointer, addressOffset int32, resultOffset int32) int32 {
+	blockchain := arwen.GetBlockchainContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetCodeMetadata
+	metering.UseGasAndAddTracedGas(getCodeMetadataName, gasToUse)
+
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	codeMetadata, err := blockchain.GetCodeMetadata(address)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	err = runtime.MemStore(resultOffset, codeMetadata)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_4_isPayable
+func v1_4_isPayable(context unsafe.Pointer, addressOffset int32) int32 {
+	blockchain := arwen.GetBlockchainContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.IsPayable
+	metering.UseGasAndAddTracedGas(isPayableName, gasToUse)
+
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	payable, err := blockchain.IsPayable(address)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(arwen.BooleanToInt(payable))
+}
+
 //export v1_4_signalError
 func v1_4_signalError(context unsafe.Pointer, messageOffset int32, messageLength int32) {
 	runtime := arwen.GetRuntimeContext(context)
@@ -773,6 +889,137 @@ func v1_4_getESDTBalance(
 	return int32(len(esdtData.Value.Bytes()))
 }
 
+//export v1_4_getESDTLocalRoles
+func v1_4_getESDTLocalRoles(
+	context unsafe.Pointer,
+	addressOffset int32,
+	tokenIDOffset int32,
+	tokenIDLen int32,
+) int64 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	metering.StartGasTracing(getESDTLocalRolesName)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetESDTLocalRoles
+	metering.UseAndTraceGas(gasToUse)
+
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	tokenID, err := runtime.MemLoad(tokenIDOffset, tokenIDLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	roles, err := blockchain.GetESDTLocalRoles(address, tokenID)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return esdtRolesToFlags(roles)
+}
+
+//export v1_4_isESDTFrozen
+func v1_4_isESDTFrozen(
+	context unsafe.Pointer,
+	addressOffset int32,
+	tokenIDOffset int32,
+	tokenIDLen int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	metering.StartGasTracing(isESDTFrozenName)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.IsESDTFrozen
+	metering.UseAndTraceGas(gasToUse)
+
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	tokenID, err := runtime.MemLoad(tokenIDOffset, tokenIDLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	frozen, err := blockchain.IsESDTFrozen(address, tokenID)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(arwen.BooleanToInt(frozen))
+}
+
+//export v1_4_isESDTPaused
+func v1_4_isESDTPaused(
+	context unsafe.Pointer,
+	tokenIDOffset int32,
+	tokenIDLen int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	metering.StartGasTracing(isESDTPausedName)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.IsESDTPaused
+	metering.UseAndTraceGas(gasToUse)
+
+	tokenID, err := runtime.MemLoad(tokenIDOffset, tokenIDLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	paused, err := blockchain.IsESDTPaused(tokenID)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(arwen.BooleanToInt(paused))
+}
+
+//export v1_4_isESDTLimitedTransfer
+func v1_4_isESDTLimitedTransfer(
+	context unsafe.Pointer,
+	tokenIDOffset int32,
+	tokenIDLen int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	metering.StartGasTracing(isESDTLimitedTransferName)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.IsESDTLimitedTransfer
+	metering.UseAndTraceGas(gasToUse)
+
+	tokenID, err := runtime.MemLoad(tokenIDOffset, tokenIDLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	limitedTransfer, err := blockchain.IsESDTLimitedTransfer(tokenID)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(arwen.BooleanToInt(limitedTransfer))
+}
+
+// esdtRolesToFlags encodes the local ESDT roles of an address as a bitmask,
+// ignoring the roles unknown to the VM
+func esdtRolesToFlags(roles [][]byte) int64 {
+	flags := int64(0)
+	for _, role := range roles {
+		flags |= esdtRoleFlags[string(role)]
+	}
+
+	return flags
+}
+
 //export v1_4_getESDTNFTNameLength
 func v1_4_getESDTNFTNameLength(
 	context unsafe.Pointer,
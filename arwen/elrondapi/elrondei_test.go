@@ -0,0 +1,21 @@
+package elrondapi
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestESDTRolesToFlags(t *testing.T) {
+	require.Equal(t, int64(0), esdtRolesToFlags(nil))
+
+	roles := [][]byte{
+		[]byte(core.ESDTRoleLocalBurn),
+		[]byte(core.ESDTRoleNFTCreate),
+		[]byte("unknownRole"),
+		[]byte(core.ESDTRoleTransfer),
+	}
+	require.Equal(t, int64(esdtRoleLocalBurn|esdtRoleNFTCreate|esdtRoleTransfer), esdtRolesToFlags(roles))
+	require.Equal(t, int64(0x86), esdtRolesToFlags(roles))
+}
@@ -0,0 +1,375 @@
+package elrondapi
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef unsigned char uint8_t;
+// typedef int int32_t;
+//
+// extern int32_t	v1_4_mVecNew(void* context);
+// extern int32_t	v1_4_mVecGetLength(void* context, int32_t mVecHandle);
+// extern int32_t	v1_4_mVecPush(void* context, int32_t mVecHandle, int32_t mBufferHandle);
+// extern int32_t	v1_4_mVecGet(void* context, int32_t mVecHandle, int32_t index, int32_t destinationHandle);
+// extern int32_t	v1_4_mVecSet(void* context, int32_t mVecHandle, int32_t index, int32_t mBufferHandle);
+// extern int32_t	v1_4_mVecRemove(void* context, int32_t mVecHandle, int32_t index);
+//
+// extern int32_t	v1_4_mMapNew(void* context);
+// extern int32_t	v1_4_mMapGetLength(void* context, int32_t mMapHandle);
+// extern int32_t	v1_4_mMapPut(void* context, int32_t mMapHandle, int32_t keyHandle, int32_t valueHandle);
+// extern int32_t	v1_4_mMapGet(void* context, int32_t mMapHandle, int32_t keyHandle, int32_t destinationHandle);
+// extern int32_t	v1_4_mMapRemove(void* context, int32_t mMapHandle, int32_t keyHandle, int32_t destinationHandle);
+// extern int32_t	v1_4_mMapContains(void* context, int32_t mMapHandle, int32_t keyHandle);
+import "C"
+import (
+	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/math"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer"
+)
+
+const (
+	mVecNewName       = "mVecNew"
+	mVecGetLengthName = "mVecGetLength"
+	mVecPushName      = "mVecPush"
+	mVecGetName       = "mVecGet"
+	mVecSetName       = "mVecSet"
+	mVecRemoveName    = "mVecRemove"
+	mMapNewName       = "mMapNew"
+	mMapGetLengthName = "mMapGetLength"
+	mMapPutName       = "mMapPut"
+	mMapGetName       = "mMapGet"
+	mMapRemoveName    = "mMapRemove"
+	mMapContainsName  = "mMapContains"
+)
+
+// ManagedCollectionImports populates the wasmer.Imports with the managed vector and managed map API methods
+func ManagedCollectionImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
+	imports = imports.Namespace("env")
+
+	imports, err := imports.Append("mVecNew", v1_4_mVecNew, C.v1_4_mVecNew)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mVecGetLength", v1_4_mVecGetLength, C.v1_4_mVecGetLength)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mVecPush", v1_4_mVecPush, C.v1_4_mVecPush)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mVecGet", v1_4_mVecGet, C.v1_4_mVecGet)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mVecSet", v1_4_mVecSet, C.v1_4_mVecSet)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mVecRemove", v1_4_mVecRemove, C.v1_4_mVecRemove)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mMapNew", v1_4_mMapNew, C.v1_4_mMapNew)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mMapGetLength", v1_4_mMapGetLength, C.v1_4_mMapGetLength)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mMapPut", v1_4_mMapPut, C.v1_4_mMapPut)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mMapGet", v1_4_mMapGet, C.v1_4_mMapGet)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mMapRemove", v1_4_mMapRemove, C.v1_4_mMapRemove)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mMapContains", v1_4_mMapContains, C.v1_4_mMapContains)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+//export v1_4_mVecNew
+func v1_4_mVecNew(context unsafe.Pointer) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MVecNew
+	metering.UseGasAndAddTracedGas(mVecNewName, gasToUse)
+
+	return managedType.NewManagedVec()
+}
+
+//export v1_4_mVecGetLength
+func v1_4_mVecGetLength(context unsafe.Pointer, mVecHandle int32) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MVecGetLength
+	metering.UseGasAndAddTracedGas(mVecGetLengthName, gasToUse)
+
+	length := managedType.GetManagedVecLength(mVecHandle)
+	if length == -1 {
+		_ = arwen.WithFault(arwen.ErrNoManagedVecUnderThisHandle, context, runtime.ManagedBufferAPIErrorShouldFailExecution())
+		return -1
+	}
+
+	return length
+}
+
+//export v1_4_mVecPush
+func v1_4_mVecPush(context unsafe.Pointer, mVecHandle int32, mBufferHandle int32) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(mVecPushName)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MVecPush
+	metering.UseAndTraceGas(gasToUse)
+
+	item, err := managedType.GetBytes(mBufferHandle)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	err = managedType.PushManagedVecItem(mVecHandle, item)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(item)))
+	metering.UseAndTraceGas(gasToUse)
+
+	return 0
+}
+
+//export v1_4_mVecGet
+func v1_4_mVecGet(context unsafe.Pointer, mVecHandle int32, index int32, destinationHandle int32) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(mVecGetName)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MVecGet
+	metering.UseAndTraceGas(gasToUse)
+
+	item, err := managedType.GetManagedVecItem(mVecHandle, index)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	managedType.SetBytes(destinationHandle, item)
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(item)))
+	metering.UseAndTraceGas(gasToUse)
+
+	return 0
+}
+
+//export v1_4_mVecSet
+func v1_4_mVecSet(context unsafe.Pointer, mVecHandle int32, index int32, mBufferHandle int32) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(mVecSetName)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MVecSet
+	metering.UseAndTraceGas(gasToUse)
+
+	item, err := managedType.GetBytes(mBufferHandle)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	err = managedType.SetManagedVecItem(mVecHandle, index, item)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(item)))
+	metering.UseAndTraceGas(gasToUse)
+
+	return 0
+}
+
+//export v1_4_mVecRemove
+func v1_4_mVecRemove(context unsafe.Pointer, mVecHandle int32, index int32) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MVecRemove
+	metering.UseGasAndAddTracedGas(mVecRemoveName, gasToUse)
+
+	err := managedType.RemoveManagedVecItem(mVecHandle, index)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
+//export v1_4_mMapNew
+func v1_4_mMapNew(context unsafe.Pointer) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MMapNew
+	metering.UseGasAndAddTracedGas(mMapNewName, gasToUse)
+
+	return managedType.NewManagedMap()
+}
+
+//export v1_4_mMapGetLength
+func v1_4_mMapGetLength(context unsafe.Pointer, mMapHandle int32) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MMapGetLength
+	metering.UseGasAndAddTracedGas(mMapGetLengthName, gasToUse)
+
+	length := managedType.GetManagedMapLength(mMapHandle)
+	if length == -1 {
+		_ = arwen.WithFault(arwen.ErrNoManagedMapUnderThisHandle, context, runtime.ManagedBufferAPIErrorShouldFailExecution())
+		return -1
+	}
+
+	return length
+}
+
+//export v1_4_mMapPut
+func v1_4_mMapPut(context unsafe.Pointer, mMapHandle int32, keyHandle int32, valueHandle int32) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(mMapPutName)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MMapPut
+	metering.UseAndTraceGas(gasToUse)
+
+	key, err := managedType.GetBytes(keyHandle)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	value, err := managedType.GetBytes(valueHandle)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	err = managedType.PutManagedMapValue(mMapHandle, key, value)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(key)+len(value)))
+	metering.UseAndTraceGas(gasToUse)
+
+	return 0
+}
+
+//export v1_4_mMapGet
+func v1_4_mMapGet(context unsafe.Pointer, mMapHandle int32, keyHandle int32, destinationHandle int32) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(mMapGetName)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MMapGet
+	metering.UseAndTraceGas(gasToUse)
+
+	key, err := managedType.GetBytes(keyHandle)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	// a missing key reads as an empty value, like a missing storage key
+	value, _, err := managedType.GetManagedMapValue(mMapHandle, key)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	managedType.SetBytes(destinationHandle, value)
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(value)))
+	metering.UseAndTraceGas(gasToUse)
+
+	return 0
+}
+
+//export v1_4_mMapRemove
+func v1_4_mMapRemove(context unsafe.Pointer, mMapHandle int32, keyHandle int32, destinationHandle int32) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	metering.StartGasTracing(mMapRemoveName)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MMapRemove
+	metering.UseAndTraceGas(gasToUse)
+
+	key, err := managedType.GetBytes(keyHandle)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	value, _, err := managedType.RemoveManagedMapValue(mMapHandle, key)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	managedType.SetBytes(destinationHandle, value)
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(value)))
+	metering.UseAndTraceGas(gasToUse)
+
+	return 0
+}
+
+//export v1_4_mMapContains
+func v1_4_mMapContains(context unsafe.Pointer, mMapHandle int32, keyHandle int32) int32 {
+	managedType := arwen.GetManagedTypesContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MMapContains
+	metering.UseGasAndAddTracedGas(mMapContainsName, gasToUse)
+
+	key, err := managedType.GetBytes(keyHandle)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	_, exists, err := managedType.GetManagedMapValue(mMapHandle, key)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	if exists {
+		return 1
+	}
+
+	return 0
+}
@@ -25,7 +25,14 @@ package elrondapi
 // extern void		v1_4_managedAsyncCall(void *context, int32_t dstHandle, int32_t valueHandle, int32_t functionHandle, int32_t argumentsHandle);
 //
 // extern void		v1_4_managedGetMultiESDTCallValue(void *context, int32_t multiCallValueHandle);
+// extern void		v1_4_managedGetBackTransfers(void *context, int32_t esdtTransfersValueHandle, int32_t callValueHandle);
 // extern void		v1_4_managedGetESDTBalance(void *context, int32_t addressHandle, int32_t tokenIDHandle, long long nonce, int32_t valueHandle);
+// extern long long	v1_4_managedGetESDTLocalRoles(void *context, int32_t addressHandle, int32_t tokenIDHandle);
+// extern int32_t	v1_4_managedIsESDTFrozen(void *context, int32_t addressHandle, int32_t tokenIDHandle);
+// extern int32_t	v1_4_managedIsESDTPaused(void *context, int32_t tokenIDHandle);
+// extern int32_t	v1_4_managedIsESDTLimitedTransfer(void *context, int32_t tokenIDHandle);
+// extern void		v1_4_managedGetCodeMetadata(void *context, int32_t addressHandle, int32_t resultHandle);
+// extern int32_t	v1_4_managedIsPayable(void *context, int32_t addressHandle);
 // extern void		v1_4_managedGetESDTTokenData(void *context, int32_t addressHandle, int32_t tokenIDHandle, long long nonce, int32_t valueHandle, int32_t propertiesHandle, int32_t hashHandle, int32_t nameHandle, int32_t attributesHandle, int32_t creatorHandle, int32_t royaltiesHandle, int32_t urisHandle);
 //
 // extern void		v1_4_managedGetReturnData(void *context, int32_t resultID, int32_t resultHandle);
@@ -63,8 +70,15 @@ const (
 	managedUpgradeFromSourceContractName    = "managedUpgradeFromSourceContract"
 	managedAsyncCallName                    = "managedAsyncCall"
 	managedGetMultiESDTCallValueName        = "managedGetMultiESDTCallValue"
+	managedGetBackTransfersName             = "managedGetBackTransfers"
 	managedGetESDTBalanceName               = "managedGetESDTBalance"
 	managedGetESDTTokenDataName             = "managedGetESDTTokenData"
+	managedGetESDTLocalRolesName            = "managedGetESDTLocalRoles"
+	managedIsESDTFrozenName                 = "managedIsESDTFrozen"
+	managedIsESDTPausedName                 = "managedIsESDTPaused"
+	managedIsESDTLimitedTransferName        = "managedIsESDTLimitedTransfer"
+	managedGetCodeMetadataName              = "managedGetCodeMetadata"
+	managedIsPayableName                    = "managedIsPayable"
 	managedGetReturnDataName                = "managedGetReturnData"
 	managedGetPrevBlockRandomSeedName       = "managedGetPrevBlockRandomSeed"
 	managedGetBlockRandomSeedName           = "managedGetBlockRandomSeed"
@@ -161,6 +175,11 @@ func ManagedEIImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("managedGetBackTransfers", v1_4_managedGetBackTransfers, C.v1_4_managedGetBackTransfers)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("managedGetESDTBalance", v1_4_managedGetESDTBalance, C.v1_4_managedGetESDTBalance)
 	if err != nil {
 		return nil, err
@@ -171,6 +190,36 @@ func ManagedEIImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("managedGetESDTLocalRoles", v1_4_managedGetESDTLocalRoles, C.v1_4_managedGetESDTLocalRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedIsESDTFrozen", v1_4_managedIsESDTFrozen, C.v1_4_managedIsESDTFrozen)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedIsESDTPaused", v1_4_managedIsESDTPaused, C.v1_4_managedIsESDTPaused)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedIsESDTLimitedTransfer", v1_4_managedIsESDTLimitedTransfer, C.v1_4_managedIsESDTLimitedTransfer)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedGetCodeMetadata", v1_4_managedGetCodeMetadata, C.v1_4_managedGetCodeMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedIsPayable", v1_4_managedIsPayable, C.v1_4_managedIsPayable)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("managedGetReturnData", v1_4_managedGetReturnData, C.v1_4_managedGetReturnData)
 	if err != nil {
 		return nil, err
@@ -352,6 +401,158 @@ func v1_4_managedGetPrevBlockRandomSeed(context unsafe.Pointer, resultHandle int
 	managedType.SetBytes(resultHandle, blockchain.LastRandomSeed())
 }
 
+//export v1_4_managedGetESDTLocalRoles
+func v1_4_managedGetESDTLocalRoles(context unsafe.Pointer, addressHandle int32, tokenIDHandle int32) int64 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(managedGetESDTLocalRolesName)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetESDTLocalRoles
+	metering.UseAndTraceGas(gasToUse)
+
+	address, err := managedType.GetBytes(addressHandle)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+	tokenID, err := managedType.GetBytes(tokenIDHandle)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	roles, err := blockchain.GetESDTLocalRoles(address, tokenID)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return esdtRolesToFlags(roles)
+}
+
+//export v1_4_managedIsESDTFrozen
+func v1_4_managedIsESDTFrozen(context unsafe.Pointer, addressHandle int32, tokenIDHandle int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(managedIsESDTFrozenName)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.IsESDTFrozen
+	metering.UseAndTraceGas(gasToUse)
+
+	address, err := managedType.GetBytes(addressHandle)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+	tokenID, err := managedType.GetBytes(tokenIDHandle)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	frozen, err := blockchain.IsESDTFrozen(address, tokenID)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(arwen.BooleanToInt(frozen))
+}
+
+//export v1_4_managedIsESDTPaused
+func v1_4_managedIsESDTPaused(context unsafe.Pointer, tokenIDHandle int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(managedIsESDTPausedName)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.IsESDTPaused
+	metering.UseAndTraceGas(gasToUse)
+
+	tokenID, err := managedType.GetBytes(tokenIDHandle)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	paused, err := blockchain.IsESDTPaused(tokenID)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(arwen.BooleanToInt(paused))
+}
+
+//export v1_4_managedIsESDTLimitedTransfer
+func v1_4_managedIsESDTLimitedTransfer(context unsafe.Pointer, tokenIDHandle int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(managedIsESDTLimitedTransferName)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.IsESDTLimitedTransfer
+	metering.UseAndTraceGas(gasToUse)
+
+	tokenID, err := managedType.GetBytes(tokenIDHandle)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	limitedTransfer, err := blockchain.IsESDTLimitedTransfer(tokenID)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(arwen.BooleanToInt(limitedTransfer))
+}
+
+//export v1_4_managedGetCodeMetadata
+func v1_4_managedGetCodeMetadata(context unsafe.Pointer, addressHandle int32, resultHandle int32) {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(managedGetCodeMetadataName)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetCodeMetadata
+	metering.UseAndTraceGas(gasToUse)
+
+	address, err := managedType.GetBytes(addressHandle)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	codeMetadata, err := blockchain.GetCodeMetadata(address)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	managedType.SetBytes(resultHandle, codeMetadata)
+}
+
+//export v1_4_managedIsPayable
+func v1_4_managedIsPayable(context unsafe.Pointer, addressHandle int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+	metering.StartGasTracing(managedIsPayableName)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.IsPayable
+	metering.UseAndTraceGas(gasToUse)
+
+	address, err := managedType.GetBytes(addressHandle)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	payable, err := blockchain.IsPayable(address)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(arwen.BooleanToInt(payable))
+}
+
 //export v1_4_managedGetReturnData
 func v1_4_managedGetReturnData(context unsafe.Pointer, resultID int32, resultHandle int32) {
 	runtime := arwen.GetRuntimeContext(context)
@@ -387,6 +588,25 @@ func v1_4_managedGetMultiESDTCallValue(context unsafe.Pointer, multiCallValueHan
 	managedType.SetBytes(multiCallValueHandle, multiCallBytes)
 }
 
+//export v1_4_managedGetBackTransfers
+func v1_4_managedGetBackTransfers(context unsafe.Pointer, esdtTransfersValueHandle int32, callValueHandle int32) {
+	metering := arwen.GetMeteringContext(context)
+	output := arwen.GetOutputContext(context)
+	managedType := arwen.GetManagedTypesContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetCallValue
+	metering.UseGasAndAddTracedGas(managedGetBackTransfersName, gasToUse)
+
+	esdtTransfers, transferValue := output.GetBackTransfers()
+	esdtTransfersBytes := writeESDTTransfersToBytes(managedType, esdtTransfers)
+	managedType.ConsumeGasForBytes(esdtTransfersBytes)
+
+	managedType.SetBytes(esdtTransfersValueHandle, esdtTransfersBytes)
+	managedType.GetBigIntOrCreate(callValueHandle).Set(transferValue)
+
+	output.ClearBackTransfers()
+}
+
 //export v1_4_managedGetESDTBalance
 func v1_4_managedGetESDTBalance(context unsafe.Pointer, addressHandle int32, tokenIDHandle int32, nonce int64, valueHandle int32) {
 	runtime := arwen.GetRuntimeContext(context)
@@ -107,6 +107,9 @@ var ErrStorageValueOutOfRange = errors.New("storage value out of range")
 // ErrDivZero signals that an attempt to divide by 0 has been made
 var ErrDivZero = errors.New("division by 0")
 
+// ErrNoModularInverse signals that a number has no inverse modulo the given modulus
+var ErrNoModularInverse = errors.New("no modular inverse")
+
 // ErrBitwiseNegative signals that an attempt to apply a bitwise operation on negative numbers has been made
 var ErrBitwiseNegative = errors.New("bitwise operations only allowed on positive integers")
 
@@ -165,6 +168,12 @@ var ErrPointNotOnCurve = errors.New("point is not on curve")
 // ErrNoManagedBufferUnderThisHandle signals that there is no buffer for the given handle
 var ErrNoManagedBufferUnderThisHandle = errors.New("no managed buffer under the given handle")
 
+// ErrNoManagedVecUnderThisHandle signals that there is no managed vector for the given handle
+var ErrNoManagedVecUnderThisHandle = errors.New("no managed vector under the given handle")
+
+// ErrNoManagedMapUnderThisHandle signals that there is no managed map for the given handle
+var ErrNoManagedMapUnderThisHandle = errors.New("no managed map under the given handle")
+
 // ErrNilHostParameters signals that nil host parameters was provided
 var ErrNilHostParameters = errors.New("nil host parameters")
 
@@ -182,3 +191,48 @@ var ErrTooManyESDTTransfers = errors.New("too many ESDT transfers")
 
 // ErrNilEpochNotifier signals that epoch notifier is nil
 var ErrNilEpochNotifier = errors.New("nil epoch notifier")
+
+// ErrNilVMCrypto signals that a nil pointer was given as the crypto implementation
+var ErrNilVMCrypto = errors.New("nil VM crypto")
+
+// ErrGasEstimationFailed signals that the execution fails even with the maximum gas limit
+var ErrGasEstimationFailed = errors.New("gas estimation failed")
+
+// ErrInvalidWASMModule signals that the WASM module of the contract is malformed
+var ErrInvalidWASMModule = fmt.Errorf("%w (malformed WASM module)", ErrContractInvalid)
+
+// ErrTooManyFunctions signals that the contract declares more functions than allowed
+var ErrTooManyFunctions = fmt.Errorf("%w (too many functions)", ErrContractInvalid)
+
+// ErrTooManyGlobals signals that the contract declares more globals than allowed
+var ErrTooManyGlobals = fmt.Errorf("%w (too many globals)", ErrContractInvalid)
+
+// ErrTableTooLarge signals that the contract declares a table larger than allowed
+var ErrTableTooLarge = fmt.Errorf("%w (table too large)", ErrContractInvalid)
+
+// ErrDataSegmentsTooLarge signals that the data segments of the contract are larger than allowed
+var ErrDataSegmentsTooLarge = fmt.Errorf("%w (data segments too large)", ErrContractInvalid)
+
+// ErrInitialMemoryTooLarge signals that the contract requests more initial memory pages than allowed
+var ErrInitialMemoryTooLarge = fmt.Errorf("%w (initial memory too large)", ErrContractInvalid)
+
+// ErrMaxMemoryTooLarge signals that the contract declares a maximum memory larger than allowed
+var ErrMaxMemoryTooLarge = fmt.Errorf("%w (maximum memory too large)", ErrContractInvalid)
+
+// ErrTooManyImports signals that the contract imports more functions and globals than allowed
+var ErrTooManyImports = fmt.Errorf("%w (too many imports)", ErrContractInvalid)
+
+// ErrFloatingPointInstruction signals that the contract uses a forbidden floating point instruction
+var ErrFloatingPointInstruction = fmt.Errorf("%w (forbidden floating point instruction)", ErrContractInvalid)
+
+// ErrCustomSectionNotAllowed signals that the contract contains a custom section which is not whitelisted
+var ErrCustomSectionNotAllowed = fmt.Errorf("%w (custom section not allowed)", ErrContractInvalid)
+
+// ErrUnknownWASMBackend signals that the requested WASM backend does not exist
+var ErrUnknownWASMBackend = errors.New("unknown WASM backend")
+
+// ErrSignatureBatchLengthMismatch signals that a batch of signatures does not hold the same number of keys, messages and signatures
+var ErrSignatureBatchLengthMismatch = errors.New("mismatched number of keys, messages and signatures in batch")
+
+// ErrInvalidMerkleProofFlags signals that the flags of a Merkle proof verification hold unknown bits
+var ErrInvalidMerkleProofFlags = errors.New("invalid merkle proof flags")
@@ -0,0 +1,106 @@
+package arwen
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+// CallEnterEvent is emitted when the VM starts executing a nested contract call
+type CallEnterEvent struct {
+	CallerAddr    []byte
+	RecipientAddr []byte
+	Function      string
+	Arguments     [][]byte
+	CallValue     *big.Int
+	ESDTTransfers []*vmcommon.ESDTTransfer
+	CallType      vm.CallType
+	GasProvided   uint64
+	SameContext   bool
+}
+
+// CallExitEvent is emitted when the VM finishes executing a nested contract call
+type CallExitEvent struct {
+	CallerAddr    []byte
+	RecipientAddr []byte
+	Function      string
+	SameContext   bool
+	ReturnCode    vmcommon.ReturnCode
+	ReturnMessage string
+	ReturnData    [][]byte
+	GasRemaining  uint64
+	Err           error
+}
+
+// StorageAccessEvent is emitted whenever a contract reads or writes its storage
+type StorageAccessEvent struct {
+	Address []byte
+	Key     []byte
+	Value   []byte
+	Write   bool
+	Status  StorageStatus
+	Err     error
+}
+
+// TransferEvent is emitted whenever a contract transfers EGLD or ESDT tokens
+type TransferEvent struct {
+	Sender        []byte
+	Destination   []byte
+	Value         *big.Int
+	ESDTTransfers []*vmcommon.ESDTTransfer
+	Data          []byte
+	GasLimit      uint64
+	GasLocked     uint64
+	CallType      vm.CallType
+}
+
+// LogEvent is emitted whenever a contract writes a log entry
+type LogEvent struct {
+	Address    []byte
+	Identifier []byte
+	Topics     [][]byte
+	Data       []byte
+}
+
+// AsyncCallEvent is emitted whenever a contract creates an async call
+type AsyncCallEvent struct {
+	CallerAddr        []byte
+	Destination       []byte
+	Data              []byte
+	ValueBytes        []byte
+	GasLimit          uint64
+	GasLocked         uint64
+	ContextIdentifier []byte
+	SuccessCallback   string
+	ErrorCallback     string
+}
+
+// CallbackEvent is emitted after the VM has executed the callback of an async call
+type CallbackEvent struct {
+	CallerAddr    []byte
+	RecipientAddr []byte
+	Function      string
+	ReturnCode    vmcommon.ReturnCode
+	ReturnMessage string
+	Err           error
+}
+
+// GasUsedEvent is emitted whenever gas is used by a traced step of the execution,
+// such as an EI function call; NewCall is set on the first event of each call
+type GasUsedEvent struct {
+	Address  []byte
+	Function string
+	GasUsed  uint64
+	NewCall  bool
+}
+
+// FunctionCallEvent is emitted whenever the execution enters or leaves a
+// function of a contract, including its imported EI functions; it is only
+// emitted by the WASM backends which support function call observation
+type FunctionCallEvent struct {
+	Address    []byte
+	Function   string
+	Imported   bool
+	PointsUsed uint64
+}
@@ -0,0 +1,179 @@
+package gasprofiler
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// field numbers of the messages defined by the pprof profile.proto
+const (
+	profileSampleType  = 1
+	profileSample      = 2
+	profileLocation    = 4
+	profileFunction    = 5
+	profileStringTable = 6
+	profilePeriodType  = 11
+	profilePeriod      = 12
+
+	valueTypeType = 1
+	valueTypeUnit = 2
+
+	sampleLocationID = 1
+	sampleValue      = 2
+
+	locationID   = 1
+	locationLine = 4
+
+	lineFunctionID = 1
+
+	functionID         = 1
+	functionName       = 2
+	functionSystemName = 3
+)
+
+const (
+	wireVarint          = 0
+	wireLengthDelimited = 2
+)
+
+// protoBuffer encodes protocol buffer messages
+type protoBuffer struct {
+	data []byte
+}
+
+func (buffer *protoBuffer) varint(value uint64) {
+	for value >= 0x80 {
+		buffer.data = append(buffer.data, byte(value)|0x80)
+		value >>= 7
+	}
+	buffer.data = append(buffer.data, byte(value))
+}
+
+func (buffer *protoBuffer) tag(field int, wireType int) {
+	buffer.varint(uint64(field)<<3 | uint64(wireType))
+}
+
+func (buffer *protoBuffer) uint64Field(field int, value uint64) {
+	if value == 0 {
+		return
+	}
+	buffer.tag(field, wireVarint)
+	buffer.varint(value)
+}
+
+func (buffer *protoBuffer) bytesField(field int, value []byte) {
+	buffer.tag(field, wireLengthDelimited)
+	buffer.varint(uint64(len(value)))
+	buffer.data = append(buffer.data, value...)
+}
+
+func (buffer *protoBuffer) messageField(field int, message *protoBuffer) {
+	buffer.bytesField(field, message.data)
+}
+
+func (buffer *protoBuffer) packedField(field int, values []uint64) {
+	packed := &protoBuffer{}
+	for _, value := range values {
+		packed.varint(value)
+	}
+	buffer.bytesField(field, packed.data)
+}
+
+// pprofBuilder collects the string table and the functions of a pprof profile;
+// each function has a single location, sharing its id
+type pprofBuilder struct {
+	strings       []string
+	stringIndexes map[string]uint64
+	functionIDs   map[string]uint64
+	functions     []string
+}
+
+func newPprofBuilder() *pprofBuilder {
+	builder := &pprofBuilder{
+		stringIndexes: make(map[string]uint64),
+		functionIDs:   make(map[string]uint64),
+	}
+	builder.stringIndex("")
+	return builder
+}
+
+func (builder *pprofBuilder) stringIndex(value string) uint64 {
+	index, exists := builder.stringIndexes[value]
+	if !exists {
+		index = uint64(len(builder.strings))
+		builder.stringIndexes[value] = index
+		builder.strings = append(builder.strings, value)
+	}
+	return index
+}
+
+func (builder *pprofBuilder) functionID(name string) uint64 {
+	id, exists := builder.functionIDs[name]
+	if !exists {
+		builder.functions = append(builder.functions, name)
+		id = uint64(len(builder.functions))
+		builder.functionIDs[name] = id
+	}
+	return id
+}
+
+func (builder *pprofBuilder) valueType(typeName string, unit string) *protoBuffer {
+	message := &protoBuffer{}
+	message.uint64Field(valueTypeType, builder.stringIndex(typeName))
+	message.uint64Field(valueTypeUnit, builder.stringIndex(unit))
+	return message
+}
+
+// WritePprof writes the profile in the gzipped protocol buffer format read by
+// "go tool pprof", with the consumed gas as the only sample value
+func (profile *Profile) WritePprof(writer io.Writer) error {
+	builder := newPprofBuilder()
+	encoded := &protoBuffer{}
+
+	encoded.messageField(profileSampleType, builder.valueType("gas", "count"))
+
+	for _, sample := range profile.Samples {
+		// pprof expects the innermost frame first
+		locationIDs := make([]uint64, len(sample.Stack))
+		for i, name := range sample.Stack {
+			locationIDs[len(sample.Stack)-1-i] = builder.functionID(name)
+		}
+
+		message := &protoBuffer{}
+		message.packedField(sampleLocationID, locationIDs)
+		message.packedField(sampleValue, []uint64{sample.Gas})
+		encoded.messageField(profileSample, message)
+	}
+
+	for i, name := range builder.functions {
+		id := uint64(i + 1)
+
+		line := &protoBuffer{}
+		line.uint64Field(lineFunctionID, id)
+		location := &protoBuffer{}
+		location.uint64Field(locationID, id)
+		location.messageField(locationLine, line)
+		encoded.messageField(profileLocation, location)
+
+		function := &protoBuffer{}
+		function.uint64Field(functionID, id)
+		function.uint64Field(functionName, builder.stringIndex(name))
+		function.uint64Field(functionSystemName, builder.stringIndex(name))
+		encoded.messageField(profileFunction, function)
+	}
+
+	periodType := builder.valueType("gas", "count")
+	for _, value := range builder.strings {
+		encoded.bytesField(profileStringTable, []byte(value))
+	}
+	encoded.messageField(profilePeriodType, periodType)
+	encoded.uint64Field(profilePeriod, 1)
+
+	gzipWriter := gzip.NewWriter(writer)
+	_, err := gzipWriter.Write(encoded.data)
+	if err != nil {
+		return err
+	}
+
+	return gzipWriter.Close()
+}
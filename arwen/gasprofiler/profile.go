@@ -0,0 +1,93 @@
+package gasprofiler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/math"
+)
+
+// stackSeparator separates the frames of a stack in the folded format
+const stackSeparator = ";"
+
+// Sample holds the gas consumed by the innermost frame of a call stack
+type Sample struct {
+	Stack []string
+	Gas   uint64
+}
+
+// Profile holds the gas consumed during a transaction, by call stack
+type Profile struct {
+	Name    string
+	Samples []*Sample
+
+	sampleIndexes map[string]int
+}
+
+func newProfile(name string) *Profile {
+	return &Profile{
+		Name:          name,
+		Samples:       make([]*Sample, 0),
+		sampleIndexes: make(map[string]int),
+	}
+}
+
+func (profile *Profile) add(stack []string, gas uint64) {
+	key := strings.Join(stack, "\x00")
+	index, exists := profile.sampleIndexes[key]
+	if exists {
+		sample := profile.Samples[index]
+		sample.Gas = math.AddUint64(sample.Gas, gas)
+		return
+	}
+
+	profile.sampleIndexes[key] = len(profile.Samples)
+	profile.Samples = append(profile.Samples, &Sample{
+		Stack: stack,
+		Gas:   gas,
+	})
+}
+
+// TotalGas returns the gas consumed by all the call stacks
+func (profile *Profile) TotalGas() uint64 {
+	total := uint64(0)
+	for _, sample := range profile.Samples {
+		total = math.AddUint64(total, sample.Gas)
+	}
+	return total
+}
+
+// GasByFunction returns the gas consumed by each frame itself, excluding the
+// gas consumed by the frames it called
+func (profile *Profile) GasByFunction() map[string]uint64 {
+	gasByFunction := make(map[string]uint64)
+	for _, sample := range profile.Samples {
+		leaf := sample.Stack[len(sample.Stack)-1]
+		gasByFunction[leaf] = math.AddUint64(gasByFunction[leaf], sample.Gas)
+	}
+	return gasByFunction
+}
+
+// WriteFolded writes the profile in the folded stacks format, one sorted
+// "frame;frame;frame gas" line per call stack, which is the input expected by
+// flame graph generators
+func (profile *Profile) WriteFolded(writer io.Writer) error {
+	lines := make([]string, 0, len(profile.Samples))
+	for _, sample := range profile.Samples {
+		lines = append(lines, fmt.Sprintf("%s %d", strings.Join(sample.Stack, stackSeparator), sample.Gas))
+	}
+	sort.Strings(lines)
+
+	bufferedWriter := bufio.NewWriter(writer)
+	for _, line := range lines {
+		_, err := bufferedWriter.WriteString(line + "\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	return bufferedWriter.Flush()
+}
@@ -0,0 +1,259 @@
+package gasprofiler
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/math"
+)
+
+var _ arwen.ExecutionObserver = (*GasProfiler)(nil)
+
+type frameKind int
+
+const (
+	transactionFrame frameKind = iota
+	contractFrame
+	functionFrame
+	importedFunctionFrame
+)
+
+// frame is an entry of the call stack being profiled; startGas is the gas
+// provided to a contract call, or the points used when a function was entered
+type frame struct {
+	name     string
+	kind     frameKind
+	startGas uint64
+	childGas uint64
+}
+
+// GasProfiler is an ExecutionObserver which attributes the gas consumed during
+// each profiled transaction to the call stacks of the executed contracts. The
+// contract calls are always profiled, while the WASM functions and the EI
+// functions are only visible with a WASM backend supporting function call
+// observation; otherwise, the traced gas of the EI functions is attributed to
+// leaf frames named after them.
+type GasProfiler struct {
+	mutex    sync.Mutex
+	stack    []*frame
+	current  *Profile
+	profiles []*Profile
+}
+
+// NewGasProfiler creates a new GasProfiler, to be registered as the
+// ExecutionObserver of the VM host
+func NewGasProfiler() *GasProfiler {
+	return &GasProfiler{
+		stack:    make([]*frame, 0),
+		profiles: make([]*Profile, 0),
+	}
+}
+
+// Profiles returns the profiles of the transactions completed so far
+func (gp *GasProfiler) Profiles() []*Profile {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+
+	profiles := make([]*Profile, len(gp.profiles))
+	copy(profiles, gp.profiles)
+	return profiles
+}
+
+// LastProfile returns the profile of the most recently completed transaction
+func (gp *GasProfiler) LastProfile() *Profile {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+
+	if len(gp.profiles) == 0 {
+		return nil
+	}
+	return gp.profiles[len(gp.profiles)-1]
+}
+
+// Reset discards the completed profiles
+func (gp *GasProfiler) Reset() {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+
+	gp.profiles = make([]*Profile, 0)
+}
+
+// BeginTransaction starts the profile of a transaction, whose root frame has
+// the given name; the events received outside a transaction are ignored
+func (gp *GasProfiler) BeginTransaction(name string) {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+
+	gp.current = newProfile(name)
+	gp.stack = append(gp.stack[:0], &frame{
+		name: name,
+		kind: transactionFrame,
+	})
+}
+
+// EndTransaction completes the profile of the current transaction, given the
+// total gas it consumed, and returns it
+func (gp *GasProfiler) EndTransaction(gasUsed uint64) *Profile {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+
+	if len(gp.stack) == 0 {
+		return nil
+	}
+
+	for len(gp.stack) > 1 {
+		gp.closeFrame(gp.stack[len(gp.stack)-1].childGas)
+	}
+	gp.closeFrame(gasUsed)
+
+	profile := gp.current
+	gp.profiles = append(gp.profiles, profile)
+	gp.current = nil
+	return profile
+}
+
+// OnCallEnter opens a contract frame
+func (gp *GasProfiler) OnCallEnter(event *arwen.CallEnterEvent) {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+
+	if len(gp.stack) == 0 {
+		return
+	}
+
+	gp.stack = append(gp.stack, &frame{
+		name:     contractFrameName(event.RecipientAddr, event.Function),
+		kind:     contractFrame,
+		startGas: event.GasProvided,
+	})
+}
+
+// OnCallExit closes the innermost contract frame, together with any function
+// frame left open by a failed execution
+func (gp *GasProfiler) OnCallExit(event *arwen.CallExitEvent) {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+
+	for len(gp.stack) > 1 {
+		top := gp.stack[len(gp.stack)-1]
+		if top.kind == contractFrame {
+			gp.closeFrame(math.SubUint64(top.startGas, event.GasRemaining))
+			return
+		}
+		gp.closeFrame(top.childGas)
+	}
+}
+
+// OnFunctionEnter opens a function frame
+func (gp *GasProfiler) OnFunctionEnter(event *arwen.FunctionCallEvent) {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+
+	if len(gp.stack) == 0 {
+		return
+	}
+
+	kind := functionFrame
+	if event.Imported {
+		kind = importedFunctionFrame
+	}
+
+	gp.stack = append(gp.stack, &frame{
+		name:     event.Function,
+		kind:     kind,
+		startGas: event.PointsUsed,
+	})
+}
+
+// OnFunctionExit closes the innermost function frame
+func (gp *GasProfiler) OnFunctionExit(event *arwen.FunctionCallEvent) {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+
+	if len(gp.stack) == 0 {
+		return
+	}
+
+	top := gp.stack[len(gp.stack)-1]
+	if top.kind == transactionFrame || top.kind == contractFrame {
+		return
+	}
+
+	gp.closeFrame(math.SubUint64(event.PointsUsed, top.startGas))
+}
+
+// OnGasUsed attributes the gas of an EI function to a leaf frame named after
+// it, unless the EI function call is already profiled as a function frame
+func (gp *GasProfiler) OnGasUsed(event *arwen.GasUsedEvent) {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+
+	if len(gp.stack) == 0 || event.GasUsed == 0 {
+		return
+	}
+
+	top := gp.stack[len(gp.stack)-1]
+	if top.kind == importedFunctionFrame {
+		return
+	}
+
+	top.childGas = math.AddUint64(top.childGas, event.GasUsed)
+	gp.current.add(gp.stackNames(event.Function), event.GasUsed)
+}
+
+// OnStorageAccess does nothing
+func (gp *GasProfiler) OnStorageAccess(_ *arwen.StorageAccessEvent) {
+}
+
+// OnTransfer does nothing
+func (gp *GasProfiler) OnTransfer(_ *arwen.TransferEvent) {
+}
+
+// OnLog does nothing
+func (gp *GasProfiler) OnLog(_ *arwen.LogEvent) {
+}
+
+// OnAsyncCall does nothing
+func (gp *GasProfiler) OnAsyncCall(_ *arwen.AsyncCallEvent) {
+}
+
+// OnCallback does nothing
+func (gp *GasProfiler) OnCallback(_ *arwen.CallbackEvent) {
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (gp *GasProfiler) IsInterfaceNil() bool {
+	return gp == nil
+}
+
+// closeFrame pops the innermost frame, records the gas it consumed itself and
+// adds its inclusive gas to its parent
+func (gp *GasProfiler) closeFrame(inclusiveGas uint64) {
+	last := len(gp.stack) - 1
+	closed := gp.stack[last]
+
+	selfGas := math.SubUint64(inclusiveGas, closed.childGas)
+	if selfGas > 0 {
+		gp.current.add(gp.stackNames(), selfGas)
+	}
+
+	gp.stack = gp.stack[:last]
+	if last > 0 {
+		parent := gp.stack[last-1]
+		parent.childGas = math.AddUint64(parent.childGas, inclusiveGas)
+	}
+}
+
+func (gp *GasProfiler) stackNames(leaves ...string) []string {
+	names := make([]string, 0, len(gp.stack)+len(leaves))
+	for _, f := range gp.stack {
+		names = append(names, f.name)
+	}
+	return append(names, leaves...)
+}
+
+func contractFrameName(address []byte, function string) string {
+	return fmt.Sprintf("%s::%s", hex.EncodeToString(address), function)
+}
@@ -0,0 +1,462 @@
+package gasprofiler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	arwenHost "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/host"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/mock"
+	gasSchedules "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwenmandos/gasSchedules"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/testcommon"
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/builtInFunctions"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var testOwner = testcommon.UserAddress
+var testContract = testcommon.MakeTestSCAddress("counter")
+
+const gasProvidedToParent = 100000
+const gasUsedByParent = 100
+const gasProvidedToChild = 10000
+const gasUsedByChild = 300
+
+func TestGasProfiler_EventsOutsideTransactionAreIgnored(t *testing.T) {
+	profiler := NewGasProfiler()
+
+	profiler.OnGasUsed(&arwen.GasUsedEvent{Function: "getArgument", GasUsed: 10})
+	profiler.OnCallEnter(&arwen.CallEnterEvent{RecipientAddr: []byte("a"), Function: "f", GasProvided: 100})
+	profiler.OnCallExit(&arwen.CallExitEvent{GasRemaining: 50})
+
+	require.Nil(t, profiler.EndTransaction(100))
+	require.Nil(t, profiler.LastProfile())
+	require.Empty(t, profiler.Profiles())
+}
+
+func TestGasProfiler_ContractCallsAndTracedGas(t *testing.T) {
+	profiler := NewGasProfiler()
+
+	profiler.BeginTransaction("root")
+	profiler.OnGasUsed(&arwen.GasUsedEvent{Function: "getArgument", GasUsed: 10})
+	profiler.OnCallEnter(&arwen.CallEnterEvent{RecipientAddr: []byte{0xaa}, Function: "child", GasProvided: 500})
+	profiler.OnGasUsed(&arwen.GasUsedEvent{Function: "storageStore", GasUsed: 100})
+	profiler.OnGasUsed(&arwen.GasUsedEvent{Function: "storageStore", GasUsed: 50})
+	profiler.OnCallExit(&arwen.CallExitEvent{GasRemaining: 200})
+	profiler.OnGasUsed(&arwen.GasUsedEvent{Function: "finish", GasUsed: 5})
+	profile := profiler.EndTransaction(1000)
+
+	require.NotNil(t, profile)
+	require.Equal(t, "root", profile.Name)
+	require.Equal(t, uint64(1000), profile.TotalGas())
+	require.Equal(t, profile, profiler.LastProfile())
+
+	folded := &bytes.Buffer{}
+	require.Nil(t, profile.WriteFolded(folded))
+	expected := strings.Join([]string{
+		"root 685",
+		"root;aa::child 150",
+		"root;aa::child;storageStore 150",
+		"root;finish 5",
+		"root;getArgument 10",
+	}, "\n") + "\n"
+	require.Equal(t, expected, folded.String())
+
+	gasByFunction := profile.GasByFunction()
+	require.Equal(t, uint64(150), gasByFunction["storageStore"])
+	require.Equal(t, uint64(150), gasByFunction["aa::child"])
+	require.Equal(t, uint64(685), gasByFunction["root"])
+}
+
+func TestGasProfiler_FunctionCalls(t *testing.T) {
+	profiler := NewGasProfiler()
+
+	profiler.BeginTransaction("root")
+	profiler.OnFunctionEnter(&arwen.FunctionCallEvent{Function: "main", PointsUsed: 0})
+	profiler.OnFunctionEnter(&arwen.FunctionCallEvent{Function: "helper", PointsUsed: 20})
+	profiler.OnFunctionEnter(&arwen.FunctionCallEvent{Function: "storageLoad", Imported: true, PointsUsed: 30})
+	profiler.OnGasUsed(&arwen.GasUsedEvent{Function: "storageLoad", GasUsed: 100})
+	profiler.OnFunctionExit(&arwen.FunctionCallEvent{Function: "storageLoad", Imported: true, PointsUsed: 130})
+	profiler.OnFunctionExit(&arwen.FunctionCallEvent{Function: "helper", PointsUsed: 140})
+	profiler.OnFunctionExit(&arwen.FunctionCallEvent{Function: "main", PointsUsed: 150})
+	profile := profiler.EndTransaction(160)
+
+	require.Equal(t, uint64(160), profile.TotalGas())
+
+	gasByFunction := profile.GasByFunction()
+	require.Equal(t, uint64(100), gasByFunction["storageLoad"])
+	require.Equal(t, uint64(20), gasByFunction["helper"])
+	require.Equal(t, uint64(30), gasByFunction["main"])
+	require.Equal(t, uint64(10), gasByFunction["root"])
+	require.Equal(t, []string{"root", "main", "helper", "storageLoad"}, findSample(profile, "storageLoad").Stack)
+}
+
+func TestGasProfiler_OpenFramesAreClosed(t *testing.T) {
+	profiler := NewGasProfiler()
+
+	profiler.BeginTransaction("root")
+	profiler.OnCallEnter(&arwen.CallEnterEvent{RecipientAddr: []byte{0xbb}, Function: "child", GasProvided: 300})
+	profiler.OnFunctionEnter(&arwen.FunctionCallEvent{Function: "child", PointsUsed: 0})
+	profiler.OnGasUsed(&arwen.GasUsedEvent{Function: "signalError", GasUsed: 40})
+	profiler.OnCallExit(&arwen.CallExitEvent{GasRemaining: 0})
+	profiler.OnGasUsed(&arwen.GasUsedEvent{Function: "finish", GasUsed: 7})
+	profile := profiler.EndTransaction(400)
+
+	require.Equal(t, uint64(400), profile.TotalGas())
+	require.Equal(t, []string{"root", "bb::child", "child", "signalError"}, findSample(profile, "signalError").Stack)
+	require.Equal(t, uint64(260), findSample(profile, "bb::child").Gas)
+	require.Equal(t, []string{"root", "finish"}, findSample(profile, "finish").Stack)
+
+	profiler.Reset()
+	require.Empty(t, profiler.Profiles())
+}
+
+func TestProfile_WritePprof(t *testing.T) {
+	profile := newProfile("root")
+	profile.add([]string{"root", "f", "g"}, 200)
+	profile.add([]string{"root", "f"}, 300)
+	profile.add([]string{"root"}, 700)
+
+	encoded := &bytes.Buffer{}
+	require.Nil(t, profile.WritePprof(encoded))
+
+	decoded := decodePprof(t, encoded)
+	require.Equal(t, []string{"gas/count"}, decoded.sampleTypes)
+	require.Equal(t, "gas/count", decoded.periodType)
+	require.Equal(t, uint64(1), decoded.period)
+	require.Equal(t, map[string][]uint64{
+		"root;f;g": {200},
+		"root;f":   {300},
+		"root":     {700},
+	}, decoded.samples)
+}
+
+func TestGasProfiler_ProfileContractCall(t *testing.T) {
+	profiler := NewGasProfiler()
+	host, world := newTestHost(t, profiler)
+
+	deployInput := &vmcommon.ContractCreateInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  testOwner,
+			CallValue:   big.NewInt(0),
+			CallType:    vm.DirectCall,
+			GasProvided: 100000000,
+		},
+		ContractCode: testcommon.GetTestSCCode("counter", "../../"),
+	}
+	vmOutput, profile, err := profiler.ProfileContractCreate(host, deployInput)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, "deploy::init", profile.Name)
+	require.Equal(t, deployInput.GasProvided-vmOutput.GasRemaining, profile.TotalGas())
+	_ = world.UpdateAccounts(vmOutput.OutputAccounts, nil)
+
+	callInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  testOwner,
+			CallValue:   big.NewInt(0),
+			CallType:    vm.DirectCall,
+			GasProvided: 100000000,
+		},
+		RecipientAddr: testContract,
+		Function:      "increment",
+	}
+	vmOutput, profile, err = profiler.ProfileContractCall(host, callInput)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	require.Equal(t, callInput.GasProvided-vmOutput.GasRemaining, profile.TotalGas())
+
+	rootName := contractFrameName(testContract, "increment")
+	require.Equal(t, rootName, profile.Name)
+
+	sample := findSample(profile, "int64storageStore")
+	require.NotNil(t, sample)
+	require.Equal(t, []string{rootName, "increment", "int64storageStore"}, sample.Stack)
+	require.Len(t, profiler.Profiles(), 2)
+}
+
+// sameContextParentMock calls the child on the same context, once successfully
+// and once failing
+func sameContextParentMock(instanceMock *contextmock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("callChild", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		host.Metering().UseGasAndAddTracedGas("getArgument", gasUsedByParent)
+
+		for _, function := range []string{"useGas", "useGasAndFail"} {
+			input := testcommon.DefaultTestContractCallInput()
+			input.CallerAddr = instance.Address
+			input.RecipientAddr = testcommon.ChildAddress
+			input.GasProvided = gasProvidedToChild
+			input.Function = function
+			// the host is called directly, so that the failed call does not fail the parent
+			_, _ = host.ExecuteOnSameContext(input)
+		}
+
+		return instance
+	})
+}
+
+// sameContextChildMock uses some gas, then optionally signals an error
+func sameContextChildMock(instanceMock *contextmock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("useGas", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		host.Metering().UseGasAndAddTracedGas("storageStore", gasUsedByChild)
+		return contextmock.GetMockInstance(host)
+	})
+	instanceMock.AddMockMethod("useGasAndFail", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		host.Metering().UseGasAndAddTracedGas("storageStore", gasUsedByChild)
+		host.Runtime().SignalUserError("child failed")
+		return contextmock.GetMockInstance(host)
+	})
+}
+
+func TestGasProfiler_ProfileSameContextCall(t *testing.T) {
+	profiler := NewGasProfiler()
+	var profile *Profile
+	var gasUsed uint64
+
+	testcommon.BuildMockInstanceCallTest(t).
+		WithContracts(
+			testcommon.CreateMockContract(testcommon.ParentAddress).
+				WithBalance(1000).
+				WithMethods(sameContextParentMock),
+			testcommon.CreateMockContract(testcommon.ChildAddress).
+				WithBalance(1000).
+				WithMethods(sameContextChildMock)).
+		WithInput(testcommon.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(testcommon.ParentAddress).
+			WithGasProvided(gasProvidedToParent).
+			WithFunction("callChild").
+			Build()).
+		WithExecutionObserver(profiler).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			profiler.BeginTransaction("root")
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *testcommon.VMOutputVerifier) {
+			verify.Ok()
+			gasUsed = gasProvidedToParent - verify.VmOutput.GasRemaining
+			profile = profiler.EndTransaction(gasUsed)
+		})
+
+	require.Equal(t, gasUsed, profile.TotalGas())
+	require.Equal(t, uint64(gasUsedByParent), findSample(profile, "getArgument").Gas)
+
+	succeeded := contractFrameName(testcommon.ChildAddress, "useGas")
+	require.Equal(t, uint64(gasUsedByChild), frameGas(profile, succeeded, "storageStore"))
+	require.Less(t, frameGas(profile, succeeded), uint64(gasProvidedToChild))
+
+	// the gas provided to a failed call is consumed entirely
+	failed := contractFrameName(testcommon.ChildAddress, "useGasAndFail")
+	require.Equal(t, uint64(gasUsedByChild), frameGas(profile, failed, "storageStore"))
+	require.Equal(t, uint64(gasProvidedToChild), frameGas(profile, failed))
+}
+
+func newTestHost(t *testing.T, profiler *GasProfiler) (arwen.VMHost, *worldmock.MockWorld) {
+	world := worldmock.NewMockWorld()
+	ownerAccount := &worldmock.Account{
+		Address: testOwner,
+		Nonce:   1,
+		Balance: big.NewInt(0),
+	}
+	world.AcctMap.PutAccount(ownerAccount)
+	world.NewAddressMocks = append(world.NewAddressMocks, &worldmock.NewAddressMock{
+		CreatorAddress: testOwner,
+		CreatorNonce:   ownerAccount.Nonce,
+		NewAddress:     testContract,
+	})
+	ownerAccount.Nonce++ // nonce increases before deploy
+
+	gasMap, err := gasSchedules.LoadGasScheduleConfig(gasSchedules.GetV3())
+	require.Nil(t, err)
+
+	esdtTransferParser, _ := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	host, err := arwenHost.NewArwenVM(world, &arwen.VMHostParameters{
+		VMType:                   testcommon.DefaultVMType,
+		BlockGasLimit:            uint64(1000),
+		GasSchedule:              gasMap,
+		BuiltInFuncContainer:     builtInFunctions.NewBuiltInFunctionContainer(),
+		ElrondProtectedKeyPrefix: []byte("ELROND"),
+		ESDTTransferParser:       esdtTransferParser,
+		EpochNotifier:            &mock.EpochNotifierStub{},
+		ExecutionObserver:        profiler,
+		WASMBackend:              arwen.WASMBackendGo,
+	})
+	require.Nil(t, err)
+
+	return host, world
+}
+
+func findSample(profile *Profile, leaf string) *Sample {
+	for _, sample := range profile.Samples {
+		if sample.Stack[len(sample.Stack)-1] == leaf {
+			return sample
+		}
+	}
+	return nil
+}
+
+// frameGas returns the gas consumed by the frames with the given stack, and
+// by all the frames they called
+func frameGas(profile *Profile, stack ...string) uint64 {
+	gas := uint64(0)
+	for _, sample := range profile.Samples {
+		if hasStackPrefix(sample.Stack, append([]string{profile.Name}, stack...)) {
+			gas += sample.Gas
+		}
+	}
+	return gas
+}
+
+func hasStackPrefix(stack []string, prefix []string) bool {
+	if len(stack) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if stack[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// decodedPprof holds the sample types and the samples of a pprof profile; the
+// samples are keyed by their stack of function names, outermost first
+type decodedPprof struct {
+	sampleTypes []string
+	periodType  string
+	period      uint64
+	samples     map[string][]uint64
+}
+
+// decodePprof decodes a gzipped pprof profile with a generic protocol buffer
+// reader, independent of the encoder of the profiler
+func decodePprof(t *testing.T, encoded io.Reader) *decodedPprof {
+	reader, err := gzip.NewReader(encoded)
+	require.Nil(t, err)
+	data, err := ioutil.ReadAll(reader)
+	require.Nil(t, err)
+
+	var stringTable []string
+	var sampleTypes, samples [][]byte
+	var periodType []byte
+	functionNames := make(map[uint64]uint64)
+	locationFunctions := make(map[uint64]uint64)
+
+	decoded := &decodedPprof{samples: make(map[string][]uint64)}
+	forEachProtoField(t, data, func(field protowire.Number, value []byte, number uint64) {
+		switch field {
+		case profileSampleType:
+			sampleTypes = append(sampleTypes, value)
+		case profileSample:
+			samples = append(samples, value)
+		case profileLocation:
+			var id, functionID uint64
+			forEachProtoField(t, value, func(field protowire.Number, value []byte, number uint64) {
+				switch field {
+				case locationID:
+					id = number
+				case locationLine:
+					functionID = decodeProtoUint(t, value, lineFunctionID)
+				}
+			})
+			locationFunctions[id] = functionID
+		case profileFunction:
+			functionNames[decodeProtoUint(t, value, functionID)] = decodeProtoUint(t, value, functionName)
+		case profileStringTable:
+			stringTable = append(stringTable, string(value))
+		case profilePeriodType:
+			periodType = value
+		case profilePeriod:
+			decoded.period = number
+		}
+	})
+
+	valueType := func(message []byte) string {
+		typeName := stringTable[decodeProtoUint(t, message, valueTypeType)]
+		unit := stringTable[decodeProtoUint(t, message, valueTypeUnit)]
+		return typeName + "/" + unit
+	}
+	for _, sampleType := range sampleTypes {
+		decoded.sampleTypes = append(decoded.sampleTypes, valueType(sampleType))
+	}
+	decoded.periodType = valueType(periodType)
+
+	for _, sample := range samples {
+		var locationIDs, values []uint64
+		forEachProtoField(t, sample, func(field protowire.Number, value []byte, number uint64) {
+			switch field {
+			case sampleLocationID:
+				locationIDs = append(locationIDs, decodePackedProtoUints(t, value)...)
+			case sampleValue:
+				values = append(values, decodePackedProtoUints(t, value)...)
+			}
+		})
+
+		// pprof stores the innermost frame first
+		stack := make([]string, len(locationIDs))
+		for i, id := range locationIDs {
+			stack[len(locationIDs)-1-i] = stringTable[functionNames[locationFunctions[id]]]
+		}
+		decoded.samples[strings.Join(stack, ";")] = values
+	}
+
+	return decoded
+}
+
+// forEachProtoField calls the handler with the field number and either the
+// bytes of a length-delimited field or the value of a varint field
+func forEachProtoField(t *testing.T, data []byte, handler func(field protowire.Number, value []byte, number uint64)) {
+	for len(data) > 0 {
+		field, wireType, length := protowire.ConsumeTag(data)
+		require.True(t, length > 0, protowire.ParseError(length))
+		data = data[length:]
+
+		switch wireType {
+		case protowire.VarintType:
+			number, length := protowire.ConsumeVarint(data)
+			require.True(t, length > 0, protowire.ParseError(length))
+			data = data[length:]
+			handler(field, nil, number)
+		case protowire.BytesType:
+			value, length := protowire.ConsumeBytes(data)
+			require.True(t, length > 0, protowire.ParseError(length))
+			data = data[length:]
+			handler(field, value, 0)
+		default:
+			require.Fail(t, "unexpected wire type", "field %d has wire type %d", field, wireType)
+		}
+	}
+}
+
+func decodeProtoUint(t *testing.T, message []byte, field protowire.Number) uint64 {
+	result := uint64(0)
+	forEachProtoField(t, message, func(decodedField protowire.Number, _ []byte, number uint64) {
+		if decodedField == field {
+			result = number
+		}
+	})
+	return result
+}
+
+func decodePackedProtoUints(t *testing.T, packed []byte) []uint64 {
+	var values []uint64
+	for len(packed) > 0 {
+		value, length := protowire.ConsumeVarint(packed)
+		require.True(t, length > 0, protowire.ParseError(length))
+		packed = packed[length:]
+		values = append(values, value)
+	}
+	return values
+}
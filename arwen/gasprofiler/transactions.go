@@ -0,0 +1,43 @@
+package gasprofiler
+
+import (
+	"fmt"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/math"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+// ProfileContractCall runs a contract call on a VM which has the profiler as
+// its ExecutionObserver, and returns the profile of the call
+func (gp *GasProfiler) ProfileContractCall(
+	vm vmcommon.VMExecutionHandler,
+	input *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, *Profile, error) {
+	gp.BeginTransaction(contractFrameName(input.RecipientAddr, input.Function))
+	vmOutput, err := vm.RunSmartContractCall(input)
+	profile := gp.EndTransaction(computeGasUsed(input.GasProvided, vmOutput))
+
+	return vmOutput, profile, err
+}
+
+// ProfileContractCreate runs a contract deployment on a VM which has the
+// profiler as its ExecutionObserver, and returns the profile of the deployment
+func (gp *GasProfiler) ProfileContractCreate(
+	vm vmcommon.VMExecutionHandler,
+	input *vmcommon.ContractCreateInput,
+) (*vmcommon.VMOutput, *Profile, error) {
+	gp.BeginTransaction(fmt.Sprintf("deploy::%s", arwen.InitFunctionName))
+	vmOutput, err := vm.RunSmartContractCreate(input)
+	profile := gp.EndTransaction(computeGasUsed(input.GasProvided, vmOutput))
+
+	return vmOutput, profile, err
+}
+
+func computeGasUsed(gasProvided uint64, vmOutput *vmcommon.VMOutput) uint64 {
+	if vmOutput == nil {
+		return gasProvided
+	}
+
+	return math.SubUint64(gasProvided, vmOutput.GasRemaining)
+}
@@ -0,0 +1,17 @@
+package hookrecorder
+
+import "errors"
+
+// ErrNilRecording signals that a nil recording was provided
+var ErrNilRecording = errors.New("nil recording")
+
+// ErrCallNotRecorded signals that the replayed execution made a BlockchainHook
+// call which was not present in the recording
+var ErrCallNotRecorded = errors.New("blockchain hook call not recorded")
+
+// ErrReadOnlyAccount signals an attempt to modify an account while replaying
+var ErrReadOnlyAccount = errors.New("replayed accounts are read-only")
+
+// ErrNoRecordedInput signals that a recording without the input of the
+// recorded execution was provided for replay
+var ErrNoRecordedInput = errors.New("no recorded execution input")
@@ -0,0 +1,313 @@
+package hookrecorder
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	arwenHost "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/host"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/config"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/testcommon"
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/builtInFunctions"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+	"github.com/stretchr/testify/require"
+)
+
+var contractAddress = []byte("contract_______________________0")
+var userAddress = []byte("user___________________________0")
+
+func createRecordedWorld() *worldmock.MockWorld {
+	world := worldmock.NewMockWorld()
+	world.CurrentBlockInfo.BlockNonce = 42
+	world.CurrentBlockInfo.BlockEpoch = 3
+
+	contract := world.AcctMap.CreateSmartContractAccount(userAddress, contractAddress, []byte("contract code"), world)
+	contract.Balance = big.NewInt(1000)
+	contract.Storage["key"] = []byte("value")
+	contract.Storage[string([]byte{0xff, 0x00, 0xfe})] = []byte("binary key")
+
+	return world
+}
+
+func TestNewRecordingBlockchainHook_NilHook(t *testing.T) {
+	t.Parallel()
+
+	hook, err := NewRecordingBlockchainHook(nil)
+	require.Nil(t, hook)
+	require.NotNil(t, err)
+}
+
+func TestNewReplayBlockchainHook_NilRecording(t *testing.T) {
+	t.Parallel()
+
+	hook, err := NewReplayBlockchainHook(nil)
+	require.Nil(t, hook)
+	require.Equal(t, ErrNilRecording, err)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	world := createRecordedWorld()
+	recorder, err := NewRecordingBlockchainHook(world)
+	require.Nil(t, err)
+
+	account, err := recorder.GetUserAccount(contractAddress)
+	require.Nil(t, err)
+	code := recorder.GetCode(account)
+	value, err := recorder.GetStorageData(contractAddress, []byte("key"))
+	require.Nil(t, err)
+	state, err := recorder.GetAllState(contractAddress)
+	require.Nil(t, err)
+	_, missingAccountErr := recorder.GetUserAccount(userAddress)
+	require.NotNil(t, missingAccountErr)
+	nonce := recorder.CurrentNonce()
+	epoch := recorder.CurrentEpoch()
+
+	filePath := filepath.Join(t.TempDir(), "recording.json")
+	err = recorder.Recording().Save(filePath)
+	require.Nil(t, err)
+
+	recording, err := LoadRecording(filePath)
+	require.Nil(t, err)
+	require.Len(t, recording.Calls, 7)
+
+	replay, err := NewReplayBlockchainHook(recording)
+	require.Nil(t, err)
+
+	replayedAccount, err := replay.GetUserAccount(contractAddress)
+	require.Nil(t, err)
+	require.Equal(t, account.AddressBytes(), replayedAccount.AddressBytes())
+	require.Equal(t, account.GetBalance(), replayedAccount.GetBalance())
+	require.Equal(t, account.GetCodeHash(), replayedAccount.GetCodeHash())
+	require.Equal(t, code, replay.GetCode(replayedAccount))
+
+	replayedValue, err := replay.GetStorageData(contractAddress, []byte("key"))
+	require.Nil(t, err)
+	require.Equal(t, value, replayedValue)
+
+	replayedState, err := replay.GetAllState(contractAddress)
+	require.Nil(t, err)
+	require.Equal(t, state, replayedState)
+
+	replayedAccount, err = replay.GetUserAccount(userAddress)
+	require.Nil(t, replayedAccount)
+	require.Equal(t, missingAccountErr.Error(), err.Error())
+
+	require.Equal(t, nonce, replay.CurrentNonce())
+	require.Equal(t, epoch, replay.CurrentEpoch())
+}
+
+func TestReplay_RepeatedCallsFollowRecordedOrder(t *testing.T) {
+	t.Parallel()
+
+	world := createRecordedWorld()
+	recorder, _ := NewRecordingBlockchainHook(world)
+
+	first, _ := recorder.GetStorageData(contractAddress, []byte("key"))
+	world.AcctMap.GetAccount(contractAddress).Storage["key"] = []byte("changed")
+	second, _ := recorder.GetStorageData(contractAddress, []byte("key"))
+
+	replay, _ := NewReplayBlockchainHook(recorder.Recording())
+
+	value, _ := replay.GetStorageData(contractAddress, []byte("key"))
+	require.Equal(t, first, value)
+	value, _ = replay.GetStorageData(contractAddress, []byte("key"))
+	require.Equal(t, second, value)
+	value, _ = replay.GetStorageData(contractAddress, []byte("key"))
+	require.Equal(t, second, value)
+}
+
+func TestReplay_CallNotRecorded(t *testing.T) {
+	t.Parallel()
+
+	replay, _ := NewReplayBlockchainHook(&Recording{})
+
+	value, err := replay.GetStorageData(contractAddress, []byte("key"))
+	require.Nil(t, value)
+	require.True(t, errors.Is(err, ErrCallNotRecorded))
+
+	isPayable, err := replay.IsPayable(contractAddress)
+	require.False(t, isPayable)
+	require.True(t, errors.Is(err, ErrCallNotRecorded))
+}
+
+func TestReplay_BuiltInFunctionCallsDifferingByValueAndGas(t *testing.T) {
+	t.Parallel()
+
+	hook := &contextmock.BlockchainHookStub{
+		ProcessBuiltInFunctionCalled: func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return &vmcommon.VMOutput{
+				ReturnData:   [][]byte{input.CallValue.Bytes()},
+				GasRemaining: input.GasProvided / 2,
+			}, nil
+		},
+	}
+	recorder, _ := NewRecordingBlockchainHook(hook)
+
+	inputs := []*vmcommon.ContractCallInput{
+		createBuiltInFunctionInput(10, 1000),
+		createBuiltInFunctionInput(20, 1000),
+		createBuiltInFunctionInput(10, 2000),
+	}
+	recordedOutputs := make([]*vmcommon.VMOutput, len(inputs))
+	for i, input := range inputs {
+		recordedOutputs[i], _ = recorder.ProcessBuiltInFunction(input)
+	}
+
+	replay, _ := NewReplayBlockchainHook(recorder.Recording())
+
+	// Replayed in reverse order, each call must still find its own result
+	for i := len(inputs) - 1; i >= 0; i-- {
+		vmOutput, err := replay.ProcessBuiltInFunction(inputs[i])
+		require.Nil(t, err)
+		require.Equal(t, recordedOutputs[i].ReturnData, vmOutput.ReturnData)
+		require.Equal(t, recordedOutputs[i].GasRemaining, vmOutput.GasRemaining)
+	}
+}
+
+func TestReplay_AccountIsReadOnly(t *testing.T) {
+	t.Parallel()
+
+	world := createRecordedWorld()
+	recorder, _ := NewRecordingBlockchainHook(world)
+	_, _ = recorder.GetUserAccount(contractAddress)
+
+	replay, _ := NewReplayBlockchainHook(recorder.Recording())
+	account, err := replay.GetUserAccount(contractAddress)
+	require.Nil(t, err)
+
+	require.Equal(t, ErrReadOnlyAccount, account.AddToBalance(big.NewInt(1)))
+	require.Equal(t, ErrReadOnlyAccount, account.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value")))
+	require.Equal(t, big.NewInt(1000), account.GetBalance())
+}
+
+func TestRecordAndReplay_ContractExecution(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	testcommon.AddTestSmartContractToWorld(world, "counter", testcommon.GetTestSCCode("counter", "../../"))
+	world.AcctMap.CreateAccount(testcommon.UserAddress, world)
+
+	recorder, err := NewRecordingBlockchainHook(world)
+	require.Nil(t, err)
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  testcommon.UserAddress,
+			CallValue:   big.NewInt(0),
+			CallType:    vm.DirectCall,
+			GasProvided: 1000000,
+		},
+		RecipientAddr: testcommon.MakeTestSCAddress("counter"),
+		Function:      "increment",
+	}
+
+	recorder.RecordContractCallInput(input)
+	recordedOutput, err := newTestHost(t, recorder).RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, recordedOutput.ReturnCode, recordedOutput.ReturnMessage)
+
+	filePath := filepath.Join(t.TempDir(), "recording.json")
+	require.Nil(t, recorder.Recording().Save(filePath))
+	recording, err := LoadRecording(filePath)
+	require.Nil(t, err)
+	require.NotEmpty(t, recording.Calls)
+	require.Equal(t, input, recording.CallInput)
+	require.Nil(t, recording.CreateInput)
+
+	// The replay does not have access to the world anymore
+	replayedOutput, err := Replay(recording, newTestHostParameters())
+	require.Nil(t, err)
+	require.Equal(t, recordedOutput, replayedOutput)
+}
+
+func TestRecordAndReplay_ContractDeployment(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	world.AcctMap.CreateAccount(testcommon.UserAddress, world)
+	world.NewAddressMocks = append(world.NewAddressMocks, &worldmock.NewAddressMock{
+		CreatorAddress: testcommon.UserAddress,
+		CreatorNonce:   0,
+		NewAddress:     testcommon.MakeTestSCAddress("counter"),
+	})
+
+	recorder, err := NewRecordingBlockchainHook(world)
+	require.Nil(t, err)
+
+	input := &vmcommon.ContractCreateInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  testcommon.UserAddress,
+			CallValue:   big.NewInt(0),
+			CallType:    vm.DirectCall,
+			GasProvided: 1000000,
+		},
+		ContractCode: testcommon.GetTestSCCode("counter", "../../"),
+	}
+
+	recorder.RecordContractCreateInput(input)
+	recordedOutput, err := newTestHost(t, recorder).RunSmartContractCreate(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, recordedOutput.ReturnCode, recordedOutput.ReturnMessage)
+
+	recording := recorder.Recording()
+	require.Nil(t, recording.CallInput)
+	require.Equal(t, input, recording.CreateInput)
+
+	replayedOutput, err := Replay(recording, newTestHostParameters())
+	require.Nil(t, err)
+	require.Equal(t, recordedOutput, replayedOutput)
+
+	recorder.Reset()
+	require.Nil(t, recorder.Recording().CreateInput)
+}
+
+func TestReplay_NoRecordedInput(t *testing.T) {
+	t.Parallel()
+
+	vmOutput, err := Replay(nil, newTestHostParameters())
+	require.Nil(t, vmOutput)
+	require.Equal(t, ErrNilRecording, err)
+
+	vmOutput, err = Replay(&Recording{}, newTestHostParameters())
+	require.Nil(t, vmOutput)
+	require.Equal(t, ErrNoRecordedInput, err)
+}
+
+func createBuiltInFunctionInput(callValue int64, gasProvided uint64) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  userAddress,
+			CallValue:   big.NewInt(callValue),
+			GasProvided: gasProvided,
+		},
+		RecipientAddr: contractAddress,
+		Function:      "builtIn",
+	}
+}
+
+// newTestHost creates a host running on the pure-Go WASM backend, which does
+// not depend on the native Wasmer library
+func newTestHost(t *testing.T, hook vmcommon.BlockchainHook) arwen.VMHost {
+	host, err := arwenHost.NewArwenVM(hook, newTestHostParameters())
+	require.Nil(t, err)
+
+	return host
+}
+
+func newTestHostParameters() *arwen.VMHostParameters {
+	esdtTransferParser, _ := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	return &arwen.VMHostParameters{
+		VMType:                   testcommon.DefaultVMType,
+		BlockGasLimit:            uint64(1000),
+		GasSchedule:              config.MakeGasMapForTests(),
+		BuiltInFuncContainer:     builtInFunctions.NewBuiltInFunctionContainer(),
+		ElrondProtectedKeyPrefix: []byte("ELROND"),
+		ESDTTransferParser:       esdtTransferParser,
+		EpochNotifier:            &worldmock.EpochNotifierStub{},
+		WASMBackend:              arwen.WASMBackendGo,
+	}
+}
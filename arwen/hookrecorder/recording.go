@@ -0,0 +1,288 @@
+package hookrecorder
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"sort"
+
+	"github.com/ElrondNetwork/elrond-go-core/data/esdt"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+// Recording holds all the calls made by the VM to the BlockchainHook during
+// one or more executions, in the order in which they were made, together with
+// the input of the recorded execution, if it was provided to the recorder
+type Recording struct {
+	CallInput   *vmcommon.ContractCallInput   `json:"callInput,omitempty"`
+	CreateInput *vmcommon.ContractCreateInput `json:"createInput,omitempty"`
+	Calls       []*HookCall                   `json:"calls"`
+}
+
+// HookCall is a single call made to the BlockchainHook, together with its result
+type HookCall struct {
+	Method string          `json:"method"`
+	Args   []string        `json:"args,omitempty"`
+	Result *HookCallResult `json:"result"`
+}
+
+// HookCallResult holds the values returned by a BlockchainHook method; only the
+// fields relevant to the method are populated
+type HookCallResult struct {
+	Bytes     []byte               `json:"bytes,omitempty"`
+	Number    uint64               `json:"number,omitempty"`
+	Flag      bool                 `json:"flag,omitempty"`
+	Error     string               `json:"error,omitempty"`
+	Account   *RecordedAccount     `json:"account,omitempty"`
+	State     []*KeyValue          `json:"state,omitempty"`
+	VMOutput  *RecordedVMOutput    `json:"vmOutput,omitempty"`
+	ESDTToken *esdt.ESDigitalToken `json:"esdtToken,omitempty"`
+	Names     []string             `json:"names,omitempty"`
+}
+
+// RecordedAccount is the snapshot of a user account, as returned by GetUserAccount
+type RecordedAccount struct {
+	Address         []byte   `json:"address"`
+	Nonce           uint64   `json:"nonce"`
+	Balance         *big.Int `json:"balance,omitempty"`
+	DeveloperReward *big.Int `json:"developerReward,omitempty"`
+	CodeHash        []byte   `json:"codeHash,omitempty"`
+	CodeMetadata    []byte   `json:"codeMetadata,omitempty"`
+	RootHash        []byte   `json:"rootHash,omitempty"`
+	OwnerAddress    []byte   `json:"ownerAddress,omitempty"`
+	UserName        []byte   `json:"userName,omitempty"`
+}
+
+// KeyValue is a serializable entry of a map with binary keys
+type KeyValue struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// RecordedVMOutput is the serializable form of a vmcommon.VMOutput; the maps of
+// the original structure are stored as lists, because their keys are binary
+type RecordedVMOutput struct {
+	ReturnData      [][]byte                 `json:"returnData,omitempty"`
+	ReturnCode      vmcommon.ReturnCode      `json:"returnCode"`
+	ReturnMessage   string                   `json:"returnMessage,omitempty"`
+	GasRemaining    uint64                   `json:"gasRemaining"`
+	GasRefund       *big.Int                 `json:"gasRefund,omitempty"`
+	OutputAccounts  []*RecordedOutputAccount `json:"outputAccounts,omitempty"`
+	DeletedAccounts [][]byte                 `json:"deletedAccounts,omitempty"`
+	TouchedAccounts [][]byte                 `json:"touchedAccounts,omitempty"`
+	Logs            []*vmcommon.LogEntry     `json:"logs,omitempty"`
+}
+
+// RecordedOutputAccount is the serializable form of a vmcommon.OutputAccount
+type RecordedOutputAccount struct {
+	Address             []byte                    `json:"address"`
+	Nonce               uint64                    `json:"nonce"`
+	Balance             *big.Int                  `json:"balance,omitempty"`
+	StorageUpdates      []*vmcommon.StorageUpdate `json:"storageUpdates,omitempty"`
+	Code                []byte                    `json:"code,omitempty"`
+	CodeMetadata        []byte                    `json:"codeMetadata,omitempty"`
+	CodeDeployerAddress []byte                    `json:"codeDeployerAddress,omitempty"`
+	BalanceDelta        *big.Int                  `json:"balanceDelta,omitempty"`
+	OutputTransfers     []vmcommon.OutputTransfer `json:"outputTransfers,omitempty"`
+	GasUsed             uint64                    `json:"gasUsed"`
+}
+
+// LoadRecording reads a Recording previously saved with Save
+func LoadRecording(filePath string) (*Recording, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	recording := &Recording{}
+	err = json.Unmarshal(data, recording)
+	if err != nil {
+		return nil, err
+	}
+
+	return recording, nil
+}
+
+// Save writes the Recording to the given file, as JSON
+func (recording *Recording) Save(filePath string) error {
+	data, err := json.MarshalIndent(recording, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filePath, data, 0644)
+}
+
+func errorToString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+func recordAccount(account vmcommon.UserAccountHandler) *RecordedAccount {
+	if account == nil || account.IsInterfaceNil() {
+		return nil
+	}
+
+	return &RecordedAccount{
+		Address:         account.AddressBytes(),
+		Nonce:           account.GetNonce(),
+		Balance:         account.GetBalance(),
+		DeveloperReward: account.GetDeveloperReward(),
+		CodeHash:        account.GetCodeHash(),
+		CodeMetadata:    account.GetCodeMetadata(),
+		RootHash:        account.GetRootHash(),
+		OwnerAddress:    account.GetOwnerAddress(),
+		UserName:        account.GetUserName(),
+	}
+}
+
+func recordState(state map[string][]byte) []*KeyValue {
+	if state == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(state))
+	for key := range state {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	recordedState := make([]*KeyValue, 0, len(state))
+	for _, key := range keys {
+		recordedState = append(recordedState, &KeyValue{Key: []byte(key), Value: state[key]})
+	}
+
+	return recordedState
+}
+
+func restoreState(recordedState []*KeyValue) map[string][]byte {
+	if recordedState == nil {
+		return nil
+	}
+
+	state := make(map[string][]byte, len(recordedState))
+	for _, keyValue := range recordedState {
+		state[string(keyValue.Key)] = keyValue.Value
+	}
+
+	return state
+}
+
+func recordFunctionNames(names vmcommon.FunctionNames) []string {
+	recordedNames := make([]string, 0, len(names))
+	for name := range names {
+		recordedNames = append(recordedNames, name)
+	}
+	sort.Strings(recordedNames)
+
+	return recordedNames
+}
+
+func restoreFunctionNames(recordedNames []string) vmcommon.FunctionNames {
+	names := make(vmcommon.FunctionNames, len(recordedNames))
+	for _, name := range recordedNames {
+		names[name] = struct{}{}
+	}
+
+	return names
+}
+
+func recordVMOutput(vmOutput *vmcommon.VMOutput) *RecordedVMOutput {
+	if vmOutput == nil {
+		return nil
+	}
+
+	recordedOutput := &RecordedVMOutput{
+		ReturnData:      vmOutput.ReturnData,
+		ReturnCode:      vmOutput.ReturnCode,
+		ReturnMessage:   vmOutput.ReturnMessage,
+		GasRemaining:    vmOutput.GasRemaining,
+		GasRefund:       vmOutput.GasRefund,
+		OutputAccounts:  make([]*RecordedOutputAccount, 0, len(vmOutput.OutputAccounts)),
+		DeletedAccounts: vmOutput.DeletedAccounts,
+		TouchedAccounts: vmOutput.TouchedAccounts,
+		Logs:            vmOutput.Logs,
+	}
+
+	addresses := make([]string, 0, len(vmOutput.OutputAccounts))
+	for address := range vmOutput.OutputAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		outputAccount := vmOutput.OutputAccounts[address]
+		recordedOutput.OutputAccounts = append(recordedOutput.OutputAccounts, &RecordedOutputAccount{
+			Address:             outputAccount.Address,
+			Nonce:               outputAccount.Nonce,
+			Balance:             outputAccount.Balance,
+			StorageUpdates:      recordStorageUpdates(outputAccount.StorageUpdates),
+			Code:                outputAccount.Code,
+			CodeMetadata:        outputAccount.CodeMetadata,
+			CodeDeployerAddress: outputAccount.CodeDeployerAddress,
+			BalanceDelta:        outputAccount.BalanceDelta,
+			OutputTransfers:     outputAccount.OutputTransfers,
+			GasUsed:             outputAccount.GasUsed,
+		})
+	}
+
+	return recordedOutput
+}
+
+func recordStorageUpdates(storageUpdates map[string]*vmcommon.StorageUpdate) []*vmcommon.StorageUpdate {
+	keys := make([]string, 0, len(storageUpdates))
+	for key := range storageUpdates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	recordedUpdates := make([]*vmcommon.StorageUpdate, 0, len(storageUpdates))
+	for _, key := range keys {
+		recordedUpdates = append(recordedUpdates, storageUpdates[key])
+	}
+
+	return recordedUpdates
+}
+
+func restoreVMOutput(recordedOutput *RecordedVMOutput) *vmcommon.VMOutput {
+	if recordedOutput == nil {
+		return nil
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnData:      recordedOutput.ReturnData,
+		ReturnCode:      recordedOutput.ReturnCode,
+		ReturnMessage:   recordedOutput.ReturnMessage,
+		GasRemaining:    recordedOutput.GasRemaining,
+		GasRefund:       recordedOutput.GasRefund,
+		OutputAccounts:  make(map[string]*vmcommon.OutputAccount, len(recordedOutput.OutputAccounts)),
+		DeletedAccounts: recordedOutput.DeletedAccounts,
+		TouchedAccounts: recordedOutput.TouchedAccounts,
+		Logs:            recordedOutput.Logs,
+	}
+
+	for _, recordedAccount := range recordedOutput.OutputAccounts {
+		storageUpdates := make(map[string]*vmcommon.StorageUpdate, len(recordedAccount.StorageUpdates))
+		for _, storageUpdate := range recordedAccount.StorageUpdates {
+			storageUpdates[string(storageUpdate.Offset)] = storageUpdate
+		}
+
+		vmOutput.OutputAccounts[string(recordedAccount.Address)] = &vmcommon.OutputAccount{
+			Address:             recordedAccount.Address,
+			Nonce:               recordedAccount.Nonce,
+			Balance:             recordedAccount.Balance,
+			StorageUpdates:      storageUpdates,
+			Code:                recordedAccount.Code,
+			CodeMetadata:        recordedAccount.CodeMetadata,
+			CodeDeployerAddress: recordedAccount.CodeDeployerAddress,
+			BalanceDelta:        recordedAccount.BalanceDelta,
+			OutputTransfers:     recordedAccount.OutputTransfers,
+			GasUsed:             recordedAccount.GasUsed,
+		}
+	}
+
+	return vmOutput
+}
@@ -0,0 +1,340 @@
+package hookrecorder
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/ElrondNetwork/elrond-go-core/data/esdt"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+var _ vmcommon.BlockchainHook = (*RecordingBlockchainHook)(nil)
+
+// RecordingBlockchainHook wraps a BlockchainHook and records every call the VM
+// makes through it, so that the execution can later be replayed offline with
+// a ReplayBlockchainHook. The compiled code cache is not recorded.
+type RecordingBlockchainHook struct {
+	hook      vmcommon.BlockchainHook
+	mutCalls  sync.Mutex
+	recording *Recording
+}
+
+// NewRecordingBlockchainHook creates a new RecordingBlockchainHook around the given hook
+func NewRecordingBlockchainHook(hook vmcommon.BlockchainHook) (*RecordingBlockchainHook, error) {
+	if check.IfNil(hook) {
+		return nil, arwen.ErrNilBlockChainHook
+	}
+
+	return &RecordingBlockchainHook{
+		hook:      hook,
+		recording: &Recording{Calls: make([]*HookCall, 0)},
+	}, nil
+}
+
+// Recording returns the calls recorded so far
+func (r *RecordingBlockchainHook) Recording() *Recording {
+	r.mutCalls.Lock()
+	defer r.mutCalls.Unlock()
+
+	calls := make([]*HookCall, len(r.recording.Calls))
+	copy(calls, r.recording.Calls)
+
+	return &Recording{
+		CallInput:   r.recording.CallInput,
+		CreateInput: r.recording.CreateInput,
+		Calls:       calls,
+	}
+}
+
+// RecordContractCallInput stores the input of the recorded execution, so that
+// the execution can be replayed with Replay
+func (r *RecordingBlockchainHook) RecordContractCallInput(input *vmcommon.ContractCallInput) {
+	r.mutCalls.Lock()
+	r.recording.CallInput = input
+	r.recording.CreateInput = nil
+	r.mutCalls.Unlock()
+}
+
+// RecordContractCreateInput stores the input of the recorded deployment, so
+// that the deployment can be replayed with Replay
+func (r *RecordingBlockchainHook) RecordContractCreateInput(input *vmcommon.ContractCreateInput) {
+	r.mutCalls.Lock()
+	r.recording.CallInput = nil
+	r.recording.CreateInput = input
+	r.mutCalls.Unlock()
+}
+
+// Reset discards the calls and the input recorded so far
+func (r *RecordingBlockchainHook) Reset() {
+	r.mutCalls.Lock()
+	r.recording = &Recording{Calls: make([]*HookCall, 0)}
+	r.mutCalls.Unlock()
+}
+
+func (r *RecordingBlockchainHook) record(method string, args []string, result *HookCallResult) {
+	r.mutCalls.Lock()
+	r.recording.Calls = append(r.recording.Calls, &HookCall{
+		Method: method,
+		Args:   args,
+		Result: result,
+	})
+	r.mutCalls.Unlock()
+}
+
+// NewAddress records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	address, err := r.hook.NewAddress(creatorAddress, creatorNonce, vmType)
+	r.record("NewAddress", []string{bytesArg(creatorAddress), uint64Arg(creatorNonce), bytesArg(vmType)},
+		&HookCallResult{Bytes: address, Error: errorToString(err)})
+	return address, err
+}
+
+// GetStorageData records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, error) {
+	data, err := r.hook.GetStorageData(accountAddress, index)
+	r.record("GetStorageData", []string{bytesArg(accountAddress), bytesArg(index)},
+		&HookCallResult{Bytes: data, Error: errorToString(err)})
+	return data, err
+}
+
+// GetBlockhash records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) GetBlockhash(nonce uint64) ([]byte, error) {
+	blockHash, err := r.hook.GetBlockhash(nonce)
+	r.record("GetBlockhash", []string{uint64Arg(nonce)},
+		&HookCallResult{Bytes: blockHash, Error: errorToString(err)})
+	return blockHash, err
+}
+
+// LastNonce records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) LastNonce() uint64 {
+	nonce := r.hook.LastNonce()
+	r.record("LastNonce", nil, &HookCallResult{Number: nonce})
+	return nonce
+}
+
+// LastRound records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) LastRound() uint64 {
+	round := r.hook.LastRound()
+	r.record("LastRound", nil, &HookCallResult{Number: round})
+	return round
+}
+
+// LastTimeStamp records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) LastTimeStamp() uint64 {
+	timestamp := r.hook.LastTimeStamp()
+	r.record("LastTimeStamp", nil, &HookCallResult{Number: timestamp})
+	return timestamp
+}
+
+// LastRandomSeed records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) LastRandomSeed() []byte {
+	seed := r.hook.LastRandomSeed()
+	r.record("LastRandomSeed", nil, &HookCallResult{Bytes: seed})
+	return seed
+}
+
+// LastEpoch records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) LastEpoch() uint32 {
+	epoch := r.hook.LastEpoch()
+	r.record("LastEpoch", nil, &HookCallResult{Number: uint64(epoch)})
+	return epoch
+}
+
+// GetStateRootHash records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) GetStateRootHash() []byte {
+	rootHash := r.hook.GetStateRootHash()
+	r.record("GetStateRootHash", nil, &HookCallResult{Bytes: rootHash})
+	return rootHash
+}
+
+// CurrentNonce records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) CurrentNonce() uint64 {
+	nonce := r.hook.CurrentNonce()
+	r.record("CurrentNonce", nil, &HookCallResult{Number: nonce})
+	return nonce
+}
+
+// CurrentRound records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) CurrentRound() uint64 {
+	round := r.hook.CurrentRound()
+	r.record("CurrentRound", nil, &HookCallResult{Number: round})
+	return round
+}
+
+// CurrentTimeStamp records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) CurrentTimeStamp() uint64 {
+	timestamp := r.hook.CurrentTimeStamp()
+	r.record("CurrentTimeStamp", nil, &HookCallResult{Number: timestamp})
+	return timestamp
+}
+
+// CurrentRandomSeed records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) CurrentRandomSeed() []byte {
+	seed := r.hook.CurrentRandomSeed()
+	r.record("CurrentRandomSeed", nil, &HookCallResult{Bytes: seed})
+	return seed
+}
+
+// CurrentEpoch records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) CurrentEpoch() uint32 {
+	epoch := r.hook.CurrentEpoch()
+	r.record("CurrentEpoch", nil, &HookCallResult{Number: uint64(epoch)})
+	return epoch
+}
+
+// ProcessBuiltInFunction records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	vmOutput, err := r.hook.ProcessBuiltInFunction(input)
+	r.record("ProcessBuiltInFunction", builtInFunctionArgs(input),
+		&HookCallResult{VMOutput: recordVMOutput(vmOutput), Error: errorToString(err)})
+	return vmOutput, err
+}
+
+// GetBuiltinFunctionNames records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) GetBuiltinFunctionNames() vmcommon.FunctionNames {
+	names := r.hook.GetBuiltinFunctionNames()
+	r.record("GetBuiltinFunctionNames", nil, &HookCallResult{Names: recordFunctionNames(names)})
+	return names
+}
+
+// GetAllState records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) GetAllState(address []byte) (map[string][]byte, error) {
+	state, err := r.hook.GetAllState(address)
+	r.record("GetAllState", []string{bytesArg(address)},
+		&HookCallResult{State: recordState(state), Error: errorToString(err)})
+	return state, err
+}
+
+// GetUserAccount records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := r.hook.GetUserAccount(address)
+	r.record("GetUserAccount", []string{bytesArg(address)},
+		&HookCallResult{Account: recordAccount(account), Error: errorToString(err)})
+	return account, err
+}
+
+// GetCode records and forwards the call to the wrapped hook; the code is
+// recorded under the address of the account
+func (r *RecordingBlockchainHook) GetCode(account vmcommon.UserAccountHandler) []byte {
+	code := r.hook.GetCode(account)
+	r.record("GetCode", []string{accountArg(account)}, &HookCallResult{Bytes: code})
+	return code
+}
+
+// GetShardOfAddress records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) GetShardOfAddress(address []byte) uint32 {
+	shard := r.hook.GetShardOfAddress(address)
+	r.record("GetShardOfAddress", []string{bytesArg(address)}, &HookCallResult{Number: uint64(shard)})
+	return shard
+}
+
+// IsSmartContract records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) IsSmartContract(address []byte) bool {
+	isSC := r.hook.IsSmartContract(address)
+	r.record("IsSmartContract", []string{bytesArg(address)}, &HookCallResult{Flag: isSC})
+	return isSC
+}
+
+// IsPayable records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) IsPayable(address []byte) (bool, error) {
+	isPayable, err := r.hook.IsPayable(address)
+	r.record("IsPayable", []string{bytesArg(address)},
+		&HookCallResult{Flag: isPayable, Error: errorToString(err)})
+	return isPayable, err
+}
+
+// SaveCompiledCode forwards the call to the wrapped hook, without recording it
+func (r *RecordingBlockchainHook) SaveCompiledCode(codeHash []byte, code []byte) {
+	r.hook.SaveCompiledCode(codeHash, code)
+}
+
+// GetCompiledCode forwards the call to the wrapped hook, without recording it
+func (r *RecordingBlockchainHook) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	return r.hook.GetCompiledCode(codeHash)
+}
+
+// ClearCompiledCodes forwards the call to the wrapped hook, without recording it
+func (r *RecordingBlockchainHook) ClearCompiledCodes() {
+	r.hook.ClearCompiledCodes()
+}
+
+// GetESDTToken records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) GetESDTToken(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error) {
+	token, err := r.hook.GetESDTToken(address, tokenID, nonce)
+	r.record("GetESDTToken", []string{bytesArg(address), bytesArg(tokenID), uint64Arg(nonce)},
+		&HookCallResult{ESDTToken: token, Error: errorToString(err)})
+	return token, err
+}
+
+// GetSnapshot records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) GetSnapshot() int {
+	snapshot := r.hook.GetSnapshot()
+	r.record("GetSnapshot", nil, &HookCallResult{Number: uint64(snapshot)})
+	return snapshot
+}
+
+// RevertToSnapshot records and forwards the call to the wrapped hook
+func (r *RecordingBlockchainHook) RevertToSnapshot(snapshot int) error {
+	err := r.hook.RevertToSnapshot(snapshot)
+	r.record("RevertToSnapshot", []string{uint64Arg(uint64(snapshot))}, &HookCallResult{Error: errorToString(err)})
+	return err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (r *RecordingBlockchainHook) IsInterfaceNil() bool {
+	return r == nil
+}
+
+func bytesArg(value []byte) string {
+	return hex.EncodeToString(value)
+}
+
+func uint64Arg(value uint64) string {
+	return strconv.FormatUint(value, 10)
+}
+
+func bigIntArg(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+
+	return value.String()
+}
+
+func accountArg(account vmcommon.UserAccountHandler) string {
+	if check.IfNil(account) {
+		return ""
+	}
+
+	return bytesArg(account.AddressBytes())
+}
+
+// builtInFunctionArgs identifies a call to a built-in function by everything
+// which may change its result, so that distinct calls are replayed distinctly
+func builtInFunctionArgs(input *vmcommon.ContractCallInput) []string {
+	if input == nil {
+		return nil
+	}
+
+	args := []string{
+		input.Function,
+		bytesArg(input.CallerAddr),
+		bytesArg(input.RecipientAddr),
+		bigIntArg(input.CallValue),
+		uint64Arg(input.GasProvided),
+	}
+	for _, argument := range input.Arguments {
+		args = append(args, bytesArg(argument))
+	}
+
+	return args
+}
+
+func callKey(method string, args []string) string {
+	return method + "(" + strings.Join(args, ",") + ")"
+}
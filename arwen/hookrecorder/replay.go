@@ -0,0 +1,35 @@
+package hookrecorder
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	arwenHost "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/host"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+// Replay executes the input stored in the given Recording again, on a new VM
+// host created with the given parameters, whose BlockchainHook answers with
+// the recorded results
+func Replay(recording *Recording, hostParameters *arwen.VMHostParameters) (*vmcommon.VMOutput, error) {
+	if recording == nil {
+		return nil, ErrNilRecording
+	}
+	if recording.CallInput == nil && recording.CreateInput == nil {
+		return nil, ErrNoRecordedInput
+	}
+
+	replayHook, err := NewReplayBlockchainHook(recording)
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := arwenHost.NewArwenVM(replayHook, hostParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	if recording.CreateInput != nil {
+		return host.RunSmartContractCreate(recording.CreateInput)
+	}
+
+	return host.RunSmartContractCall(recording.CallInput)
+}
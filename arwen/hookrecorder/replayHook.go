@@ -0,0 +1,239 @@
+package hookrecorder
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go-core/data/esdt"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+var log = logger.GetOrCreate("arwen/hookrecorder")
+
+var _ vmcommon.BlockchainHook = (*ReplayBlockchainHook)(nil)
+
+// ReplayBlockchainHook is a BlockchainHook which answers the calls of the VM
+// using the results stored in a Recording, without accessing any real state.
+// Repeated calls with the same arguments receive the recorded results in
+// order; once these are exhausted, the last one is returned again.
+type ReplayBlockchainHook struct {
+	mutResults sync.Mutex
+	results    map[string][]*HookCallResult
+	positions  map[string]int
+}
+
+// NewReplayBlockchainHook creates a new ReplayBlockchainHook from the given Recording
+func NewReplayBlockchainHook(recording *Recording) (*ReplayBlockchainHook, error) {
+	if recording == nil {
+		return nil, ErrNilRecording
+	}
+
+	results := make(map[string][]*HookCallResult)
+	for _, call := range recording.Calls {
+		key := callKey(call.Method, call.Args)
+		results[key] = append(results[key], call.Result)
+	}
+
+	return &ReplayBlockchainHook{
+		results:   results,
+		positions: make(map[string]int),
+	}, nil
+}
+
+func (r *ReplayBlockchainHook) replay(method string, args []string) (*HookCallResult, error) {
+	r.mutResults.Lock()
+	defer r.mutResults.Unlock()
+
+	key := callKey(method, args)
+	results, ok := r.results[key]
+	if !ok || len(results) == 0 {
+		log.Warn("call not recorded", "call", key)
+		return &HookCallResult{}, fmt.Errorf("%w: %s", ErrCallNotRecorded, key)
+	}
+
+	position := r.positions[key]
+	if position < len(results)-1 {
+		r.positions[key] = position + 1
+	}
+
+	result := results[position]
+	if len(result.Error) > 0 {
+		return result, errors.New(result.Error)
+	}
+
+	return result, nil
+}
+
+// NewAddress returns the recorded result
+func (r *ReplayBlockchainHook) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	result, err := r.replay("NewAddress", []string{bytesArg(creatorAddress), uint64Arg(creatorNonce), bytesArg(vmType)})
+	return result.Bytes, err
+}
+
+// GetStorageData returns the recorded result
+func (r *ReplayBlockchainHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, error) {
+	result, err := r.replay("GetStorageData", []string{bytesArg(accountAddress), bytesArg(index)})
+	return result.Bytes, err
+}
+
+// GetBlockhash returns the recorded result
+func (r *ReplayBlockchainHook) GetBlockhash(nonce uint64) ([]byte, error) {
+	result, err := r.replay("GetBlockhash", []string{uint64Arg(nonce)})
+	return result.Bytes, err
+}
+
+// LastNonce returns the recorded result
+func (r *ReplayBlockchainHook) LastNonce() uint64 {
+	result, _ := r.replay("LastNonce", nil)
+	return result.Number
+}
+
+// LastRound returns the recorded result
+func (r *ReplayBlockchainHook) LastRound() uint64 {
+	result, _ := r.replay("LastRound", nil)
+	return result.Number
+}
+
+// LastTimeStamp returns the recorded result
+func (r *ReplayBlockchainHook) LastTimeStamp() uint64 {
+	result, _ := r.replay("LastTimeStamp", nil)
+	return result.Number
+}
+
+// LastRandomSeed returns the recorded result
+func (r *ReplayBlockchainHook) LastRandomSeed() []byte {
+	result, _ := r.replay("LastRandomSeed", nil)
+	return result.Bytes
+}
+
+// LastEpoch returns the recorded result
+func (r *ReplayBlockchainHook) LastEpoch() uint32 {
+	result, _ := r.replay("LastEpoch", nil)
+	return uint32(result.Number)
+}
+
+// GetStateRootHash returns the recorded result
+func (r *ReplayBlockchainHook) GetStateRootHash() []byte {
+	result, _ := r.replay("GetStateRootHash", nil)
+	return result.Bytes
+}
+
+// CurrentNonce returns the recorded result
+func (r *ReplayBlockchainHook) CurrentNonce() uint64 {
+	result, _ := r.replay("CurrentNonce", nil)
+	return result.Number
+}
+
+// CurrentRound returns the recorded result
+func (r *ReplayBlockchainHook) CurrentRound() uint64 {
+	result, _ := r.replay("CurrentRound", nil)
+	return result.Number
+}
+
+// CurrentTimeStamp returns the recorded result
+func (r *ReplayBlockchainHook) CurrentTimeStamp() uint64 {
+	result, _ := r.replay("CurrentTimeStamp", nil)
+	return result.Number
+}
+
+// CurrentRandomSeed returns the recorded result
+func (r *ReplayBlockchainHook) CurrentRandomSeed() []byte {
+	result, _ := r.replay("CurrentRandomSeed", nil)
+	return result.Bytes
+}
+
+// CurrentEpoch returns the recorded result
+func (r *ReplayBlockchainHook) CurrentEpoch() uint32 {
+	result, _ := r.replay("CurrentEpoch", nil)
+	return uint32(result.Number)
+}
+
+// ProcessBuiltInFunction returns the recorded result
+func (r *ReplayBlockchainHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	result, err := r.replay("ProcessBuiltInFunction", builtInFunctionArgs(input))
+	return restoreVMOutput(result.VMOutput), err
+}
+
+// GetBuiltinFunctionNames returns the recorded result
+func (r *ReplayBlockchainHook) GetBuiltinFunctionNames() vmcommon.FunctionNames {
+	result, _ := r.replay("GetBuiltinFunctionNames", nil)
+	return restoreFunctionNames(result.Names)
+}
+
+// GetAllState returns the recorded result
+func (r *ReplayBlockchainHook) GetAllState(address []byte) (map[string][]byte, error) {
+	result, err := r.replay("GetAllState", []string{bytesArg(address)})
+	return restoreState(result.State), err
+}
+
+// GetUserAccount returns an account built from the recorded snapshot
+func (r *ReplayBlockchainHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	result, err := r.replay("GetUserAccount", []string{bytesArg(address)})
+	if result.Account == nil {
+		return nil, err
+	}
+
+	return newReplayedAccount(result.Account), err
+}
+
+// GetCode returns the code recorded for the address of the given account
+func (r *ReplayBlockchainHook) GetCode(account vmcommon.UserAccountHandler) []byte {
+	result, _ := r.replay("GetCode", []string{accountArg(account)})
+	return result.Bytes
+}
+
+// GetShardOfAddress returns the recorded result
+func (r *ReplayBlockchainHook) GetShardOfAddress(address []byte) uint32 {
+	result, _ := r.replay("GetShardOfAddress", []string{bytesArg(address)})
+	return uint32(result.Number)
+}
+
+// IsSmartContract returns the recorded result
+func (r *ReplayBlockchainHook) IsSmartContract(address []byte) bool {
+	result, _ := r.replay("IsSmartContract", []string{bytesArg(address)})
+	return result.Flag
+}
+
+// IsPayable returns the recorded result
+func (r *ReplayBlockchainHook) IsPayable(address []byte) (bool, error) {
+	result, err := r.replay("IsPayable", []string{bytesArg(address)})
+	return result.Flag, err
+}
+
+// SaveCompiledCode does nothing, the compiled code is never cached while replaying
+func (r *ReplayBlockchainHook) SaveCompiledCode(_ []byte, _ []byte) {
+}
+
+// GetCompiledCode always reports a miss, forcing the VM to compile the recorded code
+func (r *ReplayBlockchainHook) GetCompiledCode(_ []byte) (bool, []byte) {
+	return false, nil
+}
+
+// ClearCompiledCodes does nothing
+func (r *ReplayBlockchainHook) ClearCompiledCodes() {
+}
+
+// GetESDTToken returns the recorded result
+func (r *ReplayBlockchainHook) GetESDTToken(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error) {
+	result, err := r.replay("GetESDTToken", []string{bytesArg(address), bytesArg(tokenID), uint64Arg(nonce)})
+	return result.ESDTToken, err
+}
+
+// GetSnapshot returns the recorded result
+func (r *ReplayBlockchainHook) GetSnapshot() int {
+	result, _ := r.replay("GetSnapshot", nil)
+	return int(result.Number)
+}
+
+// RevertToSnapshot returns the recorded result
+func (r *ReplayBlockchainHook) RevertToSnapshot(snapshot int) error {
+	_, err := r.replay("RevertToSnapshot", []string{uint64Arg(uint64(snapshot))})
+	return err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (r *ReplayBlockchainHook) IsInterfaceNil() bool {
+	return r == nil
+}
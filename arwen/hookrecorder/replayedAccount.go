@@ -0,0 +1,121 @@
+package hookrecorder
+
+import (
+	"math/big"
+
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+var _ vmcommon.UserAccountHandler = (*replayedAccount)(nil)
+var _ vmcommon.AccountDataHandler = (*replayedAccount)(nil)
+
+// replayedAccount is the read-only account handed to the VM while replaying,
+// built from the snapshot recorded by GetUserAccount. The VM reads the storage
+// through GetStorageData, thus the account does not hold any storage itself.
+type replayedAccount struct {
+	snapshot *RecordedAccount
+}
+
+func newReplayedAccount(snapshot *RecordedAccount) *replayedAccount {
+	return &replayedAccount{snapshot: snapshot}
+}
+
+// AddressBytes returns the recorded address
+func (a *replayedAccount) AddressBytes() []byte {
+	return a.snapshot.Address
+}
+
+// GetNonce returns the recorded nonce
+func (a *replayedAccount) GetNonce() uint64 {
+	return a.snapshot.Nonce
+}
+
+// IncreaseNonce does nothing, the replayed account is read-only
+func (a *replayedAccount) IncreaseNonce(_ uint64) {
+}
+
+// GetBalance returns the recorded balance
+func (a *replayedAccount) GetBalance() *big.Int {
+	if a.snapshot.Balance == nil {
+		return big.NewInt(0)
+	}
+
+	return big.NewInt(0).Set(a.snapshot.Balance)
+}
+
+// AddToBalance returns ErrReadOnlyAccount
+func (a *replayedAccount) AddToBalance(_ *big.Int) error {
+	return ErrReadOnlyAccount
+}
+
+// GetDeveloperReward returns the recorded developer reward
+func (a *replayedAccount) GetDeveloperReward() *big.Int {
+	if a.snapshot.DeveloperReward == nil {
+		return big.NewInt(0)
+	}
+
+	return big.NewInt(0).Set(a.snapshot.DeveloperReward)
+}
+
+// ClaimDeveloperRewards returns ErrReadOnlyAccount
+func (a *replayedAccount) ClaimDeveloperRewards(_ []byte) (*big.Int, error) {
+	return nil, ErrReadOnlyAccount
+}
+
+// GetCodeMetadata returns the recorded code metadata
+func (a *replayedAccount) GetCodeMetadata() []byte {
+	return a.snapshot.CodeMetadata
+}
+
+// GetCodeHash returns the recorded code hash
+func (a *replayedAccount) GetCodeHash() []byte {
+	return a.snapshot.CodeHash
+}
+
+// GetRootHash returns the recorded root hash
+func (a *replayedAccount) GetRootHash() []byte {
+	return a.snapshot.RootHash
+}
+
+// GetOwnerAddress returns the recorded owner address
+func (a *replayedAccount) GetOwnerAddress() []byte {
+	return a.snapshot.OwnerAddress
+}
+
+// ChangeOwnerAddress returns ErrReadOnlyAccount
+func (a *replayedAccount) ChangeOwnerAddress(_ []byte, _ []byte) error {
+	return ErrReadOnlyAccount
+}
+
+// SetOwnerAddress does nothing, the replayed account is read-only
+func (a *replayedAccount) SetOwnerAddress(_ []byte) {
+}
+
+// GetUserName returns the recorded user name
+func (a *replayedAccount) GetUserName() []byte {
+	return a.snapshot.UserName
+}
+
+// SetUserName does nothing, the replayed account is read-only
+func (a *replayedAccount) SetUserName(_ []byte) {
+}
+
+// AccountDataHandler returns the account itself, which refuses any storage access
+func (a *replayedAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return a
+}
+
+// RetrieveValue returns ErrCallNotRecorded, the storage is replayed through GetStorageData
+func (a *replayedAccount) RetrieveValue(_ []byte) ([]byte, error) {
+	return nil, ErrCallNotRecorded
+}
+
+// SaveKeyValue returns ErrReadOnlyAccount
+func (a *replayedAccount) SaveKeyValue(_ []byte, _ []byte) error {
+	return ErrReadOnlyAccount
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (a *replayedAccount) IsInterfaceNil() bool {
+	return a == nil
+}
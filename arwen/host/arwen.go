@@ -6,8 +6,6 @@ import (
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/contexts"
-	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/cryptoapi"
-	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/elrondapi"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/config"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/factory"
@@ -44,6 +42,7 @@ type vmHost struct {
 	meteringContext     arwen.MeteringContext
 	storageContext      arwen.StorageContext
 	managedTypesContext arwen.ManagedTypesContext
+	executionObserver   arwen.ExecutionObserver
 
 	gasSchedule          config.GasScheduleMap
 	scAPIMethods         *wasmer.Imports
@@ -85,7 +84,23 @@ func NewArwenVM(
 		return nil, arwen.ErrNilEpochNotifier
 	}
 
-	cryptoHook := factory.NewVMCrypto()
+	var executionObserver arwen.ExecutionObserver = contexts.NewDisabledExecutionObserver()
+	if !check.IfNil(hostParameters.ExecutionObserver) {
+		executionObserver = hostParameters.ExecutionObserver
+	}
+
+	// an unset crypto selects the default implementation
+	cryptoHook := hostParameters.Crypto
+	if cryptoHook == nil {
+		var err error
+		cryptoHook, err = factory.NewVMCrypto()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if check.IfNil(cryptoHook) {
+		return nil, arwen.ErrNilVMCrypto
+	}
 	host := &vmHost{
 		cryptoHook:           cryptoHook,
 		meteringContext:      nil,
@@ -93,6 +108,7 @@ func NewArwenVM(
 		blockchainContext:    nil,
 		storageContext:       nil,
 		managedTypesContext:  nil,
+		executionObserver:    executionObserver,
 		gasSchedule:          hostParameters.GasSchedule,
 		scAPIMethods:         nil,
 		builtInFuncContainer: hostParameters.BuiltInFuncContainer,
@@ -105,32 +121,7 @@ func NewArwenVM(
 
 	var err error
 
-	imports, err := elrondapi.ElrondEIImports()
-	if err != nil {
-		return nil, err
-	}
-
-	imports, err = elrondapi.BigIntImports(imports)
-	if err != nil {
-		return nil, err
-	}
-
-	imports, err = elrondapi.SmallIntImports(imports)
-	if err != nil {
-		return nil, err
-	}
-
-	imports, err = elrondapi.ManagedEIImports(imports)
-	if err != nil {
-		return nil, err
-	}
-
-	imports, err = elrondapi.ManagedBufferImports(imports)
-	if err != nil {
-		return nil, err
-	}
-
-	imports, err = cryptoapi.CryptoImports(imports)
+	imports, err := NewEIImports()
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +142,8 @@ func NewArwenVM(
 		host,
 		hostParameters.VMType,
 		host.builtInFuncContainer,
+		hostParameters.WASMModulePolicy,
+		hostParameters.WASMBackend,
 	)
 	if err != nil {
 		return nil, err
@@ -227,6 +220,11 @@ func (host *vmHost) Storage() arwen.StorageContext {
 	return host.storageContext
 }
 
+// Observer returns the ExecutionObserver registered on the host
+func (host *vmHost) Observer() arwen.ExecutionObserver {
+	return host.executionObserver
+}
+
 // BigInt returns the BigIntContext instance of the host
 func (host *vmHost) ManagedTypes() arwen.ManagedTypesContext {
 	return host.managedTypesContext
@@ -222,6 +222,7 @@ func (host *vmHost) executeSyncCallbackCall(
 	host.Metering().RestoreGas(asyncCallInfo.GetGasLocked())
 
 	callbackVMOutput, _, callBackErr := host.ExecuteOnDestContext(callbackCallInput)
+	host.notifyCallback(callbackCallInput, callbackVMOutput, callBackErr)
 	if callbackVMOutput != nil {
 		log.Trace("async call: sync callback call",
 			"retCode", callbackVMOutput.ReturnCode,
@@ -463,6 +464,29 @@ func (host *vmHost) isSameShardNFTTransfer(contractCallInput *vmcommon.ContractC
 		contractCallInput.Function == core.BuiltInFunctionESDTNFTTransfer
 }
 
+func (host *vmHost) notifyCallback(
+	callbackCallInput *vmcommon.ContractCallInput,
+	callbackVMOutput *vmcommon.VMOutput,
+	callBackErr error,
+) {
+	event := &arwen.CallbackEvent{
+		CallerAddr:    callbackCallInput.CallerAddr,
+		RecipientAddr: callbackCallInput.RecipientAddr,
+		Function:      callbackCallInput.Function,
+		ReturnCode:    vmcommon.Ok,
+		Err:           callBackErr,
+	}
+	if callBackErr != nil {
+		event.ReturnCode = vmcommon.ExecutionFailed
+	}
+	if callbackVMOutput != nil {
+		event.ReturnCode = callbackVMOutput.ReturnCode
+		event.ReturnMessage = callbackVMOutput.ReturnMessage
+	}
+
+	host.Observer().OnCallback(event)
+}
+
 func (host *vmHost) processCallbackVMOutput(callbackVMOutput *vmcommon.VMOutput, callBackErr error) error {
 	if callBackErr == nil {
 		return nil
@@ -599,6 +623,7 @@ func (host *vmHost) callbackAsync(asyncCall *arwen.AsyncGeneratedCall, vmOutput
 
 	// Callback omits for now any async call - TODO: take into consideration async calls generated from callbacks
 	callbackVMOutput, _, callBackErr := host.ExecuteOnDestContext(callbackCallInput)
+	host.notifyCallback(callbackCallInput, callbackVMOutput, callBackErr)
 	err = host.processCallbackVMOutput(callbackVMOutput, callBackErr)
 	if err != nil {
 		return err
@@ -787,6 +812,7 @@ func (host *vmHost) processCallbackStack() error {
 	}
 
 	callbackVMOutput, _, callBackErr := host.ExecuteOnDestContext(callbackCallInput)
+	host.notifyCallback(callbackCallInput, callbackVMOutput, callBackErr)
 	err = host.processCallbackVMOutput(callbackVMOutput, callBackErr)
 	if err != nil {
 		return err
@@ -77,7 +77,7 @@ func (host *vmHost) performCodeDeployment(input arwen.CodeDeployInput) (*vmcommo
 	err = runtime.StartWasmerInstance(input.ContractCode, metering.GetGasForExecution(), true)
 	if err != nil {
 		log.Trace("performCodeDeployment/StartWasmerInstance", "err", err)
-		return nil, arwen.ErrContractInvalid
+		return nil, contractInvalidError(err)
 	}
 
 	err = host.callInitFunction()
@@ -95,6 +95,16 @@ func (host *vmHost) performCodeDeployment(input arwen.CodeDeployInput) (*vmcommo
 	return vmOutput, nil
 }
 
+// contractInvalidError keeps the errors which already signal an invalid
+// contract, such as the violations of the WASM module policy, and replaces any
+// other error of the instance creation with ErrContractInvalid
+func contractInvalidError(err error) error {
+	if errors.Is(err, arwen.ErrContractInvalid) {
+		return err
+	}
+	return arwen.ErrContractInvalid
+}
+
 // doRunSmartContractUpgrade upgrades a contract directly
 func (host *vmHost) doRunSmartContractUpgrade(input *vmcommon.ContractCallInput) *vmcommon.VMOutput {
 	host.InitState()
@@ -222,6 +232,11 @@ func copyTxHashesFromContext(runtime arwen.RuntimeContext, input *vmcommon.Contr
 func (host *vmHost) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, asyncInfo *arwen.AsyncContextInfo, err error) {
 	log.Trace("ExecuteOnDestContext", "caller", input.CallerAddr, "dest", input.RecipientAddr, "function", input.Function)
 
+	host.notifyCallEnter(input, false)
+	defer func() {
+		host.notifyCallExit(input, false, vmOutput, err)
+	}()
+
 	scExecutionInput := input
 
 	blockchain := host.Blockchain()
@@ -325,6 +340,8 @@ func (host *vmHost) finishExecuteOnDestContext(executeErr error) *vmcommon.VMOut
 	}
 
 	gasSpentByChildContract := metering.GasSpentByContract()
+	gasProvidedToChildContract := metering.GetGasProvided()
+	gasRequiredByChildContract := metering.GasRequired()
 
 	// Restore the previous context states
 	managedTypes.PopSetActiveState()
@@ -341,6 +358,10 @@ func (host *vmHost) finishExecuteOnDestContext(executeErr error) *vmcommon.VMOut
 	// Return to the caller context completely
 	runtime.PopSetActiveState()
 
+	if vmOutput.ReturnCode == vmcommon.Ok {
+		metering.TrackGasRequiredByNestedCall(gasProvidedToChildContract, gasRequiredByChildContract)
+	}
+
 	// Restore remaining gas to the caller Wasmer instance
 	metering.RestoreGas(vmOutput.GasRemaining)
 
@@ -358,6 +379,8 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 		return nil, arwen.ErrBuiltinCallOnSameContextDisallowed
 	}
 
+	host.notifyCallEnter(input, true)
+
 	managedTypes, blockchain, metering, output, runtime, _ := host.GetContexts()
 
 	// Back up the states of the contexts (except Storage, which isn't affected
@@ -377,7 +400,8 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 
 	defer func() {
 		runtime.AddError(err, input.Function)
-		host.finishExecuteOnSameContext(err)
+		exitOutput := host.finishExecuteOnSameContext(err)
+		host.notifyCallExit(input, true, exitOutput, err)
 	}()
 
 	// Perform a value transfer to the called SC. If the execution fails, this
@@ -396,31 +420,89 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 	return
 }
 
-func (host *vmHost) finishExecuteOnSameContext(executeErr error) {
+// finishExecuteOnSameContext restores the contexts of the caller and returns
+// the outcome of the call, as reported to the ExecutionObserver
+func (host *vmHost) finishExecuteOnSameContext(executeErr error) *vmcommon.VMOutput {
 	managedTypes, blockchain, metering, output, runtime, _ := host.GetContexts()
 
 	if output.ReturnCode() != vmcommon.Ok || executeErr != nil {
+		// The gas provided to a failed call is not given back to the caller.
+		exitOutput := &vmcommon.VMOutput{
+			ReturnCode:    output.ReturnCode(),
+			ReturnMessage: output.ReturnMessage(),
+			GasRemaining:  0,
+		}
+		if exitOutput.ReturnCode == vmcommon.Ok {
+			exitOutput.ReturnCode = vmcommon.ExecutionFailed
+		}
+
 		// Execution failed: restore contexts as if the execution didn't happen.
 		managedTypes.PopSetActiveState()
 		metering.PopSetActiveState()
 		output.PopSetActiveState()
 		blockchain.PopSetActiveState()
 		runtime.PopSetActiveState()
-		return
+		return exitOutput
 	}
 
 	// Execution successful; retrieve the VMOutput before popping the Runtime
 	// state and the previous instance, to ensure accurate GasRemaining and
 	// GasUsed for all accounts.
 	vmOutput := output.GetVMOutput()
+	gasProvidedToChildContract := metering.GetGasProvided()
+	gasRequiredByChildContract := metering.GasRequired()
 
 	metering.PopMergeActiveState()
 	output.PopDiscard()
 	blockchain.PopDiscard()
 	managedTypes.PopSetActiveState()
 	runtime.PopSetActiveState()
+	metering.TrackGasRequiredByNestedCall(gasProvidedToChildContract, gasRequiredByChildContract)
 	// Restore remaining gas to the caller (parent) Wasmer instance
 	metering.RestoreGas(vmOutput.GasRemaining)
+
+	return vmOutput
+}
+
+func (host *vmHost) notifyCallEnter(input *vmcommon.ContractCallInput, sameContext bool) {
+	host.Observer().OnCallEnter(&arwen.CallEnterEvent{
+		CallerAddr:    input.CallerAddr,
+		RecipientAddr: input.RecipientAddr,
+		Function:      input.Function,
+		Arguments:     input.Arguments,
+		CallValue:     input.CallValue,
+		ESDTTransfers: input.ESDTTransfers,
+		CallType:      input.CallType,
+		GasProvided:   input.GasProvided,
+		SameContext:   sameContext,
+	})
+}
+
+func (host *vmHost) notifyCallExit(
+	input *vmcommon.ContractCallInput,
+	sameContext bool,
+	vmOutput *vmcommon.VMOutput,
+	executeErr error,
+) {
+	event := &arwen.CallExitEvent{
+		CallerAddr:    input.CallerAddr,
+		RecipientAddr: input.RecipientAddr,
+		Function:      input.Function,
+		SameContext:   sameContext,
+		ReturnCode:    vmcommon.Ok,
+		Err:           executeErr,
+	}
+	if executeErr != nil {
+		event.ReturnCode = vmcommon.ExecutionFailed
+	}
+	if vmOutput != nil {
+		event.ReturnCode = vmOutput.ReturnCode
+		event.ReturnMessage = vmOutput.ReturnMessage
+		event.ReturnData = vmOutput.ReturnData
+		event.GasRemaining = vmOutput.GasRemaining
+	}
+
+	host.Observer().OnCallExit(event)
 }
 
 func (host *vmHost) isInitFunctionBeingCalled() bool {
@@ -564,7 +646,7 @@ func (host *vmHost) executeUpgrade(input *vmcommon.ContractCallInput) error {
 	err = runtime.StartWasmerInstance(codeDeployInput.ContractCode, metering.GetGasForExecution(), true)
 	if err != nil {
 		log.Trace("performCodeDeployment/StartWasmerInstance", "err", err)
-		return arwen.ErrContractInvalid
+		return contractInvalidError(err)
 	}
 
 	err = host.callInitFunction()
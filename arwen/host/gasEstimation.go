@@ -0,0 +1,89 @@
+package host
+
+import (
+	"fmt"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/math"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+type gasEstimationRun func(gasLimit uint64) (*vmcommon.VMOutput, error)
+
+// EstimateGas returns the gas limit with which the given contract call
+// executes successfully. The call is executed with input.GasProvided, or with
+// the block gas limit if the input provides no gas, and the estimate is taken
+// from the metering context: the gas used, the gas locked for async callbacks
+// and the gas required by the nested calls executed in this shard, which may
+// exceed the gas they actually used. The gas needed by calls forwarded to
+// other shards is not included. The estimate is confirmed by executing the
+// call once more with it.
+func (host *vmHost) EstimateGas(input *vmcommon.ContractCallInput) (uint64, error) {
+	run := func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		trialInput := *input
+		trialInput.GasProvided = gasLimit
+		return host.RunSmartContractCall(&trialInput)
+	}
+
+	return host.estimateGas(input.GasProvided, run)
+}
+
+// EstimateGasForCreate returns the minimal gas limit with which the given
+// contract deployment executes successfully, following the same rules as
+// EstimateGas.
+func (host *vmHost) EstimateGasForCreate(input *vmcommon.ContractCreateInput) (uint64, error) {
+	run := func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		trialInput := *input
+		trialInput.GasProvided = gasLimit
+		return host.RunSmartContractCreate(&trialInput)
+	}
+
+	return host.estimateGas(input.GasProvided, run)
+}
+
+func (host *vmHost) estimateGas(gasLimit uint64, run gasEstimationRun) (uint64, error) {
+	maxGas := gasLimit
+	if maxGas == 0 {
+		maxGas = host.Metering().BlockGasLimit()
+	}
+
+	vmOutput, err := run(maxGas)
+	if err != nil {
+		return 0, err
+	}
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		return 0, fmt.Errorf("%w: %s (%s)", arwen.ErrGasEstimationFailed, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	}
+
+	// The metering context still holds the state of the completed execution.
+	metering := host.Metering()
+	estimatedGas := computeGasUsedByAccounts(vmOutput)
+	if metering.GasRequired() > estimatedGas {
+		estimatedGas = metering.GasRequired()
+	}
+	estimatedGas = math.AddUint64(estimatedGas, metering.GetGasLocked())
+	if estimatedGas >= maxGas {
+		return maxGas, nil
+	}
+
+	vmOutput, err = run(estimatedGas)
+	if err != nil {
+		return 0, err
+	}
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		return 0, fmt.Errorf("%w: execution with the estimated gas %d failed: %s (%s)",
+			arwen.ErrGasEstimationFailed, estimatedGas, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	}
+
+	log.Trace("gas estimated", "gas", estimatedGas)
+	return estimatedGas, nil
+}
+
+func computeGasUsedByAccounts(vmOutput *vmcommon.VMOutput) uint64 {
+	gasUsed := uint64(0)
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		gasUsed = math.AddUint64(gasUsed, outputAccount.GasUsed)
+	}
+
+	return gasUsed
+}
@@ -0,0 +1,42 @@
+package host
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/cryptoapi"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/elrondapi"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer"
+)
+
+// EIImportGroup is a named group of EI functions, which appends its functions
+// to the given imports
+type EIImportGroup struct {
+	Name    string
+	Imports func(imports *wasmer.Imports) (*wasmer.Imports, error)
+}
+
+// EIImportGroups lists the groups of EI functions provided to the contracts,
+// in the order in which the VM host registers them
+var EIImportGroups = []EIImportGroup{
+	{Name: "ElrondEI", Imports: func(_ *wasmer.Imports) (*wasmer.Imports, error) { return elrondapi.ElrondEIImports() }},
+	{Name: "BigInt", Imports: elrondapi.BigIntImports},
+	{Name: "SmallInt", Imports: elrondapi.SmallIntImports},
+	{Name: "ManagedEI", Imports: elrondapi.ManagedEIImports},
+	{Name: "ManagedBuffer", Imports: elrondapi.ManagedBufferImports},
+	{Name: "ManagedCollection", Imports: elrondapi.ManagedCollectionImports},
+	{Name: "Crypto", Imports: cryptoapi.CryptoImports},
+	{Name: "ManagedCrypto", Imports: cryptoapi.ManagedCryptoImports},
+}
+
+// NewEIImports creates the imports of all the EI functions provided to the contracts
+func NewEIImports() (*wasmer.Imports, error) {
+	imports := wasmer.NewImports()
+
+	var err error
+	for _, group := range EIImportGroups {
+		imports, err = group.Imports(imports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return imports, nil
+}
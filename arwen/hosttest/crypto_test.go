@@ -0,0 +1,89 @@
+package hosttest
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	arwenHost "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/host"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/config"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/testcommon"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/builtInFunctions"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+	"github.com/stretchr/testify/require"
+)
+
+func newHostParametersForCryptoTests() *arwen.VMHostParameters {
+	esdtTransferParser, _ := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	return &arwen.VMHostParameters{
+		VMType:                   []byte{5, 0},
+		BlockGasLimit:            uint64(1000),
+		GasSchedule:              config.MakeGasMapForTests(),
+		BuiltInFuncContainer:     builtInFunctions.NewBuiltInFunctionContainer(),
+		ElrondProtectedKeyPrefix: []byte("ELROND"),
+		ESDTTransferParser:       esdtTransferParser,
+		EpochNotifier:            &worldmock.EpochNotifierStub{},
+	}
+}
+
+func TestNewArwenVM_DefaultCrypto(t *testing.T) {
+	host, err := arwenHost.NewArwenVM(worldmock.NewMockWorld(), newHostParametersForCryptoTests())
+	require.Nil(t, err)
+	require.NotNil(t, host.Crypto())
+
+	hash, err := host.Crypto().Sha256([]byte("abc"))
+	require.Nil(t, err)
+	require.Len(t, hash, 32)
+}
+
+func TestNewArwenVM_TypedNilCrypto(t *testing.T) {
+	hostParameters := newHostParametersForCryptoTests()
+	hostParameters.Crypto = (*mock.VMCryptoStub)(nil)
+	host, err := arwenHost.NewArwenVM(worldmock.NewMockWorld(), hostParameters)
+	require.Nil(t, host)
+	require.Equal(t, arwen.ErrNilVMCrypto, err)
+}
+
+func TestNewArwenVM_CustomCrypto(t *testing.T) {
+	customHash := bytes.Repeat([]byte{0xAB}, 32)
+	var hashedData [][]byte
+	cryptoStub := &mock.VMCryptoStub{
+		Sha256Called: func(data []byte) ([]byte, error) {
+			hashedData = append(hashedData, data)
+			return customHash, nil
+		},
+	}
+
+	world := worldmock.NewMockWorld()
+	features := testcommon.AddTestSmartContractToWorld(world, "features", testcommon.GetSCCode("../../test/features/basic-features/output/basic-features.wasm"))
+	world.AcctMap.CreateAccount(testcommon.UserAddress, world)
+
+	// the contract is executed by the pure-Go backend, which does not depend
+	// on the native Wasmer library
+	hostParameters := newHostParametersForCryptoTests()
+	hostParameters.Crypto = cryptoStub
+	hostParameters.WASMBackend = arwen.WASMBackendGo
+	host, err := arwenHost.NewArwenVM(world, hostParameters)
+	require.Nil(t, err)
+	require.Equal(t, cryptoStub, host.Crypto())
+
+	vmOutput, err := host.RunSmartContractCall(&vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  testcommon.UserAddress,
+			Arguments:   [][]byte{{1, 2, 3}},
+			CallValue:   big.NewInt(0),
+			GasProvided: 50000000,
+		},
+		RecipientAddr: features.Address,
+		Function:      "computeSha256",
+	})
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	// the host also hashes the code of the contract with the injected provider
+	require.Contains(t, hashedData, []byte{1, 2, 3})
+	require.Equal(t, [][]byte{customHash}, vmOutput.ReturnData)
+}
@@ -0,0 +1,121 @@
+package hosttest
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/testcommon"
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+const backTransferValue = 7
+const gasProvidedToBackTransferChild = 10000
+
+// backTransfersParentMock calls the child on the destination context and on
+// the same context, and finishes the value of its back transfers after each call
+func backTransfersParentMock(instanceMock *mock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("callChild", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		// the host is called directly, so that the failed calls do not fail the parent
+		executeOnDestContext := func(input *vmcommon.ContractCallInput) {
+			_, _, _ = host.ExecuteOnDestContext(input)
+		}
+		executeOnSameContext := func(input *vmcommon.ContractCallInput) {
+			_, _ = host.ExecuteOnSameContext(input)
+		}
+
+		callChild := func(function string, execute func(*vmcommon.ContractCallInput)) {
+			input := test.DefaultTestContractCallInput()
+			input.CallerAddr = instance.Address
+			input.RecipientAddr = test.ChildAddress
+			input.GasProvided = gasProvidedToBackTransferChild
+			input.Function = function
+			execute(input)
+			finishBackTransfersValue(host)
+		}
+
+		callChild("sendBack", executeOnDestContext)
+		callChild("finishBackTransfers", executeOnDestContext)
+		callChild("sendBackAndFail", executeOnDestContext)
+		callChild("sendBack", executeOnSameContext)
+		callChild("sendBackAndFail", executeOnSameContext)
+
+		return instance
+	})
+}
+
+// backTransfersChildMock sends value back to its caller, optionally failing
+// afterwards, or finishes the value of its own back transfers
+func backTransfersChildMock(instanceMock *mock.InstanceMock, _ interface{}) {
+	sendBack := func() {
+		host := instanceMock.Host
+		runtime := host.Runtime()
+		err := host.Output().Transfer(runtime.GetVMInput().CallerAddr, runtime.GetSCAddress(), 0, 0, big.NewInt(backTransferValue), nil, vm.DirectCall)
+		if err != nil {
+			runtime.FailExecution(err)
+		}
+	}
+
+	instanceMock.AddMockMethod("sendBack", func() *mock.InstanceMock {
+		sendBack()
+		return mock.GetMockInstance(instanceMock.Host)
+	})
+	instanceMock.AddMockMethod("sendBackAndFail", func() *mock.InstanceMock {
+		sendBack()
+		instanceMock.Host.Runtime().FailExecution(errors.New("forced fail"))
+		return mock.GetMockInstance(instanceMock.Host)
+	})
+	instanceMock.AddMockMethod("finishBackTransfers", func() *mock.InstanceMock {
+		finishBackTransfersValue(instanceMock.Host)
+		return mock.GetMockInstance(instanceMock.Host)
+	})
+}
+
+func finishBackTransfersValue(host arwen.VMHost) {
+	_, value := host.Output().GetBackTransfers()
+	host.Output().Finish(value.Bytes())
+}
+
+func TestExecution_BackTransfers_NestedCalls(t *testing.T) {
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(1000).
+				WithMethods(backTransfersParentMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(1000).
+				WithMethods(backTransfersChildMock)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(100000).
+			WithFunction("callChild").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				BalanceDelta(test.ParentAddress, 2*backTransferValue).
+				BalanceDelta(test.ChildAddress, -2*backTransferValue).
+				ReturnData(
+					// the value sent back on the destination context is received by the parent
+					big.NewInt(backTransferValue).Bytes(),
+					// the child does not see the back transfers of the parent
+					[]byte{},
+					big.NewInt(backTransferValue).Bytes(),
+					// the value sent back by the failed call is not received
+					big.NewInt(backTransferValue).Bytes(),
+					// the value sent back on the same context is received as well
+					big.NewInt(2*backTransferValue).Bytes(),
+					big.NewInt(2*backTransferValue).Bytes(),
+				)
+		})
+}
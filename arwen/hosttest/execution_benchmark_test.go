@@ -101,6 +101,7 @@ func deploy(tb testing.TB, totalTokenSupply *big.Int) (arwen.VMHost, *worldmock.
 		ElrondProtectedKeyPrefix: []byte("ELROND"),
 		ESDTTransferParser:       esdtTransferParser,
 		EpochNotifier:            &mock.EpochNotifierStub{},
+		WASMBackend:              testcommon.TestWASMBackend(),
 	})
 	require.Nil(tb, err)
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/contracts"
 	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
 	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/testcommon"
@@ -1355,6 +1356,120 @@ func createMockBuiltinFunctions(tb testing.TB, host arwen.VMHost, world *worldmo
 	host.SetBuiltInFunctionsContainer(world.BuiltinFuncs.Container)
 }
 
+func TestGasUsed_EstimateGas_SingleContract(t *testing.T) {
+	var testHost arwen.VMHost
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(simpleGasTestConfig.GasProvided).
+		WithFunction("wasteGas").
+		Build()
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(simpleGasTestConfig.ParentBalance).
+				WithConfig(simpleGasTestConfig).
+				WithMethods(contracts.WasteGasParentMock)).
+		WithInput(input).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			testHost = host
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+
+			estimatedGas, err := testHost.EstimateGas(input)
+			require.Nil(t, err)
+			require.Equal(t, simpleGasTestConfig.GasUsedByParent, estimatedGas)
+			require.Equal(t, simpleGasTestConfig.GasProvided, input.GasProvided)
+		})
+}
+
+func TestGasUsed_EstimateGas_TwoContracts_ExecuteOnDestCtx(t *testing.T) {
+	var testHost arwen.VMHost
+	numNestedCalls := 0
+	observer := &contextmock.ExecutionObserverStub{
+		OnCallEnterCalled: func(event *arwen.CallEnterEvent) {
+			numNestedCalls++
+		},
+	}
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(simpleGasTestConfig.GasProvided).
+		WithFunction("execOnDestCtx").
+		WithArguments(test.ChildAddress, []byte("wasteGas"), big.NewInt(2).Bytes()).
+		Build()
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(simpleGasTestConfig.ParentBalance).
+				WithConfig(simpleGasTestConfig).
+				WithMethods(contracts.ExecOnDestCtxParentMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(simpleGasTestConfig.ChildBalance).
+				WithConfig(simpleGasTestConfig).
+				WithMethods(contracts.WasteGasChildMock),
+		).
+		WithInput(input).
+		WithExecutionObserver(observer).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			testHost = host
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+
+			numNestedCalls = 0
+			estimatedGas, err := testHost.EstimateGas(input)
+			require.Nil(t, err)
+
+			gasUsed := simpleGasTestConfig.GasUsedByParent + 2*simpleGasTestConfig.GasUsedByChild
+			require.Equal(t, gasUsed, estimatedGas)
+			// a single execution, followed by a single confirmation run
+			require.Equal(t, 2*2, numNestedCalls)
+
+			estimatedInput := *input
+			estimatedInput.GasProvided = estimatedGas
+			vmOutput, err := testHost.RunSmartContractCall(&estimatedInput)
+			require.Nil(t, err)
+			require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+			estimatedInput.GasProvided = estimatedGas - 1
+			vmOutput, err = testHost.RunSmartContractCall(&estimatedInput)
+			require.Nil(t, err)
+			require.NotEqual(t, vmcommon.Ok, vmOutput.ReturnCode)
+		})
+}
+
+func TestGasUsed_EstimateGas_ExecutionFails(t *testing.T) {
+	var testHost arwen.VMHost
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(simpleGasTestConfig.GasUsedByParent - 1).
+		WithFunction("wasteGas").
+		Build()
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(simpleGasTestConfig.ParentBalance).
+				WithConfig(simpleGasTestConfig).
+				WithMethods(contracts.WasteGasParentMock)).
+		WithInput(input).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			testHost = host
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.OutOfGas()
+
+			estimatedGas, err := testHost.EstimateGas(input)
+			require.True(t, errors.Is(err, arwen.ErrGasEstimationFailed))
+			require.Zero(t, estimatedGas)
+		})
+}
+
 func setZeroCodeCosts(host arwen.VMHost) {
 	host.Metering().GasSchedule().BaseOperationCost.CompilePerByte = 0
 	host.Metering().GasSchedule().BaseOperationCost.AoTPreparePerByte = 0
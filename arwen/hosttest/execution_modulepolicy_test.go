@@ -0,0 +1,135 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	arwenHost "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/host"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/config"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/testcommon"
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/builtInFunctions"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+	"github.com/stretchr/testify/require"
+)
+
+// floatingPointModule is a WASM module with a single function, which pushes
+// an f32 constant and drops it
+var floatingPointModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, // magic and version
+	0x01, 0x04, 0x01, 0x60, 0x00, 0x00, // type section: func () -> ()
+	0x03, 0x02, 0x01, 0x00, // function section
+	0x05, 0x03, 0x01, 0x00, 0x01, // memory section: one page
+	0x0a, 0x0a, 0x01, 0x08, 0x00, 0x43, 0x00, 0x00, 0x00, 0x00, 0x1a, 0x0b, // code section
+}
+
+func TestExecution_DeployCode_ModulePolicyViolations(t *testing.T) {
+	counterCode := test.GetTestSCCode("counter", "../../")
+	testCases := []struct {
+		name            string
+		code            []byte
+		policy          *arwen.WASMModulePolicy
+		expectedMessage string
+	}{
+		{
+			name:            "too many functions",
+			code:            counterCode,
+			policy:          &arwen.WASMModulePolicy{MaxFunctions: 1},
+			expectedMessage: arwen.ErrTooManyFunctions.Error(),
+		},
+		{
+			name:            "too many imports",
+			code:            counterCode,
+			policy:          &arwen.WASMModulePolicy{MaxImports: 1},
+			expectedMessage: arwen.ErrTooManyImports.Error(),
+		},
+		{
+			name:            "floating point instruction",
+			code:            floatingPointModule,
+			policy:          &arwen.WASMModulePolicy{ForbidFloatingPoint: true},
+			expectedMessage: arwen.ErrFloatingPointInstruction.Error(),
+		},
+		{
+			name:            "custom section not whitelisted",
+			code:            appendCustomSection(counterCode, "debug"),
+			policy:          &arwen.WASMModulePolicy{CustomSectionsWhitelist: []string{"name"}},
+			expectedMessage: arwen.ErrCustomSectionNotAllowed.Error() + ": debug",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			host, world := createHostWithModulePolicy(t, testCase.policy)
+
+			vmOutput, err := host.RunSmartContractCreate(createDeployInput(testCase.code))
+			require.Nil(t, err)
+			require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
+			require.Equal(t, testCase.expectedMessage, vmOutput.ReturnMessage)
+			require.Nil(t, world.AcctMap.GetAccount(test.ParentAddress))
+		})
+	}
+}
+
+func TestExecution_DeployCode_ModulePolicySatisfied(t *testing.T) {
+	host, _ := createHostWithModulePolicy(t, &arwen.WASMModulePolicy{
+		MaxFunctions:        1000,
+		MaxImports:          1000,
+		ForbidFloatingPoint: true,
+	})
+
+	vmOutput, err := host.RunSmartContractCreate(createDeployInput(test.GetTestSCCode("counter", "../../")))
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+}
+
+func createHostWithModulePolicy(t *testing.T, policy *arwen.WASMModulePolicy) (arwen.VMHost, *worldmock.MockWorld) {
+	world := worldmock.NewMockWorld()
+	world.AcctMap.CreateAccount(test.UserAddress, world)
+	world.NewAddressMocks = append(world.NewAddressMocks, &worldmock.NewAddressMock{
+		CreatorAddress: test.UserAddress,
+		CreatorNonce:   0,
+		NewAddress:     test.ParentAddress,
+	})
+
+	esdtTransferParser, _ := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	host, err := arwenHost.NewArwenVM(world, &arwen.VMHostParameters{
+		VMType:                   test.DefaultVMType,
+		BlockGasLimit:            uint64(1000),
+		GasSchedule:              config.MakeGasMapForTests(),
+		BuiltInFuncContainer:     builtInFunctions.NewBuiltInFunctionContainer(),
+		ElrondProtectedKeyPrefix: []byte("ELROND"),
+		ESDTTransferParser:       esdtTransferParser,
+		EpochNotifier:            &worldmock.EpochNotifierStub{},
+		WASMModulePolicy:         policy,
+		WASMBackend:              arwen.WASMBackendGo,
+	})
+	require.Nil(t, err)
+
+	return host, world
+}
+
+func createDeployInput(code []byte) *vmcommon.ContractCreateInput {
+	return &vmcommon.ContractCreateInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  test.UserAddress,
+			CallValue:   big.NewInt(0),
+			CallType:    vm.DirectCall,
+			GasProvided: 1000000,
+		},
+		ContractCode: code,
+	}
+}
+
+// appendCustomSection appends a custom section with the given name and a
+// short payload to a WASM module; the names and sizes must fit in one byte
+func appendCustomSection(code []byte, name string) []byte {
+	payload := []byte{0x01, 0x02, 0x03}
+	content := append([]byte{byte(len(name))}, []byte(name)...)
+	content = append(content, payload...)
+
+	section := append([]byte{0x00, byte(len(content))}, content...)
+	return append(append([]byte{}, code...), section...)
+}
@@ -0,0 +1,223 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/contracts"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/testcommon"
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiShard_AsyncCall_CrossShard(t *testing.T) {
+	testConfig := asyncTestConfig
+	testConfig.GasProvided = 1000
+
+	test.BuildMockInstanceMultiShardTest(t, 2).
+		WithContracts(
+			test.CreateMockContractOnShard(test.ParentAddress, 0).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.PerformAsyncCallParentMock, contracts.CallBackParentMock),
+			test.CreateMockContractOnShard(test.ChildAddress, 1).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.TransferToThirdPartyAsyncChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("performAsyncCall").
+			WithArguments([]byte{0}).
+			Build()).
+		WithSetup(func(hosts []arwen.VMHost, world *worldmock.MultiShardMockWorld) {
+			for _, host := range hosts {
+				setZeroCodeCosts(host)
+				setAsyncCosts(host, testConfig.GasLockCost)
+			}
+		}).
+		AndAssertResults(func(world *worldmock.MultiShardMockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				ReturnData(test.ParentFinishA, test.ParentFinishB)
+
+			require.Equal(t, uint64(2), world.Round)
+
+			asyncCall := findDeliveredCall(t, world, vm.AsynchronousCall)
+			require.Nil(t, asyncCall.Err)
+			require.Equal(t, vmcommon.Ok, asyncCall.VMOutput.ReturnCode)
+			require.Equal(t, test.ChildAddress, asyncCall.Call.Destination)
+
+			callback := findDeliveredCall(t, world, vm.AsynchronousCallBack)
+			require.Nil(t, callback.Err)
+			require.Equal(t, vmcommon.Ok, callback.VMOutput.ReturnCode)
+			require.Equal(t, [][]byte{{0}, []byte("succ")}, callback.VMOutput.ReturnData)
+
+			// the child sends the value of the async call back with the callback
+			parent := world.Shard(0).AcctMap.GetAccount(test.ParentAddress)
+			require.Equal(t, test.ParentDataA, parent.Storage[string(test.ParentKeyA)])
+			require.Equal(t, test.ParentDataB, parent.Storage[string(test.ParentKeyB)])
+			require.Equal(t, big.NewInt(testConfig.ParentBalance-testConfig.TransferToThirdParty), parent.Balance)
+
+			child := world.Shard(1).AcctMap.GetAccount(test.ChildAddress)
+			require.Equal(t, test.ChildData, child.Storage[string(test.ChildKey)])
+			require.Equal(t, big.NewInt(testConfig.ChildBalance-testConfig.TransferToThirdParty-testConfig.TransferToVault), child.Balance)
+
+			require.Nil(t, world.Shard(1).AcctMap.GetAccount(test.ThirdPartyAddress))
+			require.Equal(t, big.NewInt(2*testConfig.TransferToThirdParty), world.GetAccount(test.ThirdPartyAddress).Balance)
+			require.Equal(t, big.NewInt(testConfig.TransferToVault), world.GetAccount(test.VaultAddress).Balance)
+		})
+}
+
+func TestMultiShard_AsyncCall_CrossShard_ChildFails(t *testing.T) {
+	testConfig := asyncTestConfig
+	testConfig.GasProvided = 1000
+
+	test.BuildMockInstanceMultiShardTest(t, 2).
+		WithContracts(
+			test.CreateMockContractOnShard(test.ParentAddress, 0).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.PerformAsyncCallParentMock, contracts.CallBackParentMock),
+			test.CreateMockContractOnShard(test.ChildAddress, 1).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.TransferToThirdPartyAsyncChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("performAsyncCall").
+			WithArguments([]byte{1}).
+			Build()).
+		WithSetup(func(hosts []arwen.VMHost, world *worldmock.MultiShardMockWorld) {
+			for _, host := range hosts {
+				setZeroCodeCosts(host)
+				setAsyncCosts(host, testConfig.GasLockCost)
+			}
+		}).
+		AndAssertResults(func(world *worldmock.MultiShardMockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+
+			asyncCall := findDeliveredCall(t, world, vm.AsynchronousCall)
+			require.Equal(t, vmcommon.UserError, asyncCall.VMOutput.ReturnCode)
+
+			callback := findDeliveredCall(t, world, vm.AsynchronousCallBack)
+			require.Nil(t, callback.Err)
+			require.Equal(t, vmcommon.Ok, callback.VMOutput.ReturnCode)
+			require.Equal(t, [][]byte{[]byte("succ")}, callback.VMOutput.ReturnData)
+
+			// the value sent to the child is returned with the callback, then the
+			// callback sends its own value to the vault
+			parent := world.Shard(0).AcctMap.GetAccount(test.ParentAddress)
+			require.Equal(t, big.NewInt(testConfig.ParentBalance-testConfig.TransferToThirdParty-testConfig.TransferToVault), parent.Balance)
+
+			child := world.Shard(1).AcctMap.GetAccount(test.ChildAddress)
+			require.Equal(t, big.NewInt(testConfig.ChildBalance), child.Balance)
+			require.Nil(t, child.Storage[string(test.ChildKey)])
+
+			require.Equal(t, big.NewInt(testConfig.TransferToThirdParty), world.GetAccount(test.ThirdPartyAddress).Balance)
+			require.Equal(t, big.NewInt(testConfig.TransferToVault), world.GetAccount(test.VaultAddress).Balance)
+		})
+}
+
+func TestMultiShard_FailedCallWithValue_IntraShard(t *testing.T) {
+	userBalance := int64(100)
+	callValue := int64(10)
+
+	test.BuildMockInstanceMultiShardTest(t, 2).
+		WithContracts(
+			test.CreateMockContractOnShard(test.ParentAddress, 0).
+				WithBalance(simpleGasTestConfig.ParentBalance).
+				WithConfig(simpleGasTestConfig).
+				WithMethods(contracts.FailChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithCallValue(callValue).
+			WithGasProvided(simpleGasTestConfig.GasProvided).
+			WithFunction("fail").
+			Build()).
+		WithSetup(func(hosts []arwen.VMHost, world *worldmock.MultiShardMockWorld) {
+			for _, host := range hosts {
+				setZeroCodeCosts(host)
+			}
+			createUserAccountOnShard(world, 0, userBalance)
+		}).
+		AndAssertResults(func(world *worldmock.MultiShardMockWorld, verify *test.VMOutputVerifier) {
+			verify.ExecutionFailed()
+
+			// the value taken from the user is given back
+			require.Equal(t, big.NewInt(userBalance), world.GetAccount(test.UserAddress).Balance)
+			require.Equal(t, big.NewInt(simpleGasTestConfig.ParentBalance), world.GetAccount(test.ParentAddress).Balance)
+		})
+}
+
+func TestMultiShard_FailedCallWithValue_CrossShard(t *testing.T) {
+	testConfig := transferAndExecuteTestConfig
+
+	test.BuildMockInstanceMultiShardTest(t, 2).
+		WithContracts(
+			test.CreateMockContractOnShard(test.ParentAddress, 0).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.TransferAndExecuteOnChild),
+			test.CreateMockContractOnShard(test.ChildAddress, 1).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.FailChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction(contracts.TransferAndExecuteOnChildFuncName).
+			WithArguments([]byte("fail")).
+			Build()).
+		WithSetup(func(hosts []arwen.VMHost, world *worldmock.MultiShardMockWorld) {
+			for _, host := range hosts {
+				setZeroCodeCosts(host)
+			}
+		}).
+		AndAssertResults(func(world *worldmock.MultiShardMockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+
+			call := findDeliveredCall(t, world, vm.DirectCall)
+			require.Equal(t, test.ChildAddress, call.Call.Destination)
+			require.Equal(t, vmcommon.ExecutionFailed, call.VMOutput.ReturnCode)
+
+			refund := world.DeliveredCalls[len(world.DeliveredCalls)-1]
+			require.Equal(t, test.ParentAddress, refund.Call.Destination)
+			require.Equal(t, big.NewInt(testConfig.TransferFromParentToChild), refund.Call.Value)
+
+			// the value of the failed call returns to the parent, in the next round
+			require.Equal(t, uint64(2), world.Round)
+			require.Equal(t, big.NewInt(testConfig.ParentBalance), world.Shard(0).AcctMap.GetAccount(test.ParentAddress).Balance)
+			require.Equal(t, big.NewInt(testConfig.ChildBalance), world.Shard(1).AcctMap.GetAccount(test.ChildAddress).Balance)
+		})
+}
+
+func createUserAccountOnShard(world *worldmock.MultiShardMockWorld, shardID uint32, balance int64) {
+	shard := world.Shard(shardID)
+	account := shard.AcctMap.CreateAccount(test.UserAddress, shard)
+	account.ShardID = shardID
+	account.Balance = big.NewInt(balance)
+}
+
+func findDeliveredCall(t *testing.T, world *worldmock.MultiShardMockWorld, callType vm.CallType) *worldmock.CrossShardCallResult {
+	for _, result := range world.DeliveredCalls {
+		if result.Call.CallType == callType {
+			return result
+		}
+	}
+
+	require.Fail(t, "cross-shard call not delivered", "call type %d", callType)
+	return nil
+}
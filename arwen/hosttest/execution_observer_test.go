@@ -0,0 +1,102 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/testcommon"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/stretchr/testify/require"
+)
+
+const gasProvidedToObservedChild = 10000
+const gasUsedByObservedChild = 300
+const observedChildErrorMessage = "child failed"
+
+// observedParentMock calls the child on the same context, once successfully
+// and once failing
+func observedParentMock(instanceMock *mock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("callChild", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		for _, function := range []string{"useGas", "useGasAndFail"} {
+			input := test.DefaultTestContractCallInput()
+			input.CallerAddr = instance.Address
+			input.RecipientAddr = test.ChildAddress
+			input.GasProvided = gasProvidedToObservedChild
+			input.Function = function
+			// the host is called directly, so that the failed call does not fail the parent
+			_, _ = host.ExecuteOnSameContext(input)
+		}
+
+		return instance
+	})
+}
+
+// observedChildMock uses some gas, then optionally signals an error
+func observedChildMock(instanceMock *mock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("useGas", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		host.Metering().UseGas(gasUsedByObservedChild)
+		host.Output().Finish([]byte("child"))
+		return mock.GetMockInstance(host)
+	})
+	instanceMock.AddMockMethod("useGasAndFail", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		host.Metering().UseGas(gasUsedByObservedChild)
+		host.Runtime().SignalUserError(observedChildErrorMessage)
+		return mock.GetMockInstance(host)
+	})
+}
+
+func TestExecution_Observer_SameContextCallExit(t *testing.T) {
+	exitEvents := make([]*arwen.CallExitEvent, 0)
+	observer := &mock.ExecutionObserverStub{
+		OnCallExitCalled: func(event *arwen.CallExitEvent) {
+			if event.SameContext {
+				exitEvents = append(exitEvents, event)
+			}
+		},
+	}
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(1000).
+				WithMethods(observedParentMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(1000).
+				WithMethods(observedChildMock)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(100000).
+			WithFunction("callChild").
+			Build()).
+		WithExecutionObserver(observer).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+		})
+
+	require.Len(t, exitEvents, 2)
+
+	succeeded := exitEvents[0]
+	require.Equal(t, "useGas", succeeded.Function)
+	require.Equal(t, vmcommon.Ok, succeeded.ReturnCode)
+	require.Nil(t, succeeded.Err)
+	require.Equal(t, uint64(gasProvidedToObservedChild-gasUsedByObservedChild), succeeded.GasRemaining)
+	require.Equal(t, [][]byte{[]byte("child")}, succeeded.ReturnData)
+
+	// the gas provided to a failed call is consumed entirely
+	failed := exitEvents[1]
+	require.Equal(t, "useGasAndFail", failed.Function)
+	require.Equal(t, vmcommon.UserError, failed.ReturnCode)
+	require.Equal(t, observedChildErrorMessage, failed.ReturnMessage)
+	require.NotNil(t, failed.Err)
+	require.Equal(t, uint64(0), failed.GasRemaining)
+}
@@ -38,11 +38,14 @@ type VMHost interface {
 	Output() OutputContext
 	Metering() MeteringContext
 	Storage() StorageContext
+	Observer() ExecutionObserver
 
 	ExecuteESDTTransfer(destination []byte, sender []byte, esdtTransfers []*vmcommon.ESDTTransfer, callType vm.CallType) (*vmcommon.VMOutput, uint64, error)
 	CreateNewContract(input *vmcommon.ContractCreateInput) ([]byte, error)
 	ExecuteOnSameContext(input *vmcommon.ContractCallInput) (*AsyncContextInfo, error)
 	ExecuteOnDestContext(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *AsyncContextInfo, error)
+	EstimateGas(input *vmcommon.ContractCallInput) (uint64, error)
+	EstimateGasForCreate(input *vmcommon.ContractCreateInput) (uint64, error)
 	GetAPIMethods() *wasmer.Imports
 	IsBuiltinFunctionName(functionName string) bool
 	AreInSameShard(leftAddress []byte, rightAddress []byte) bool
@@ -87,9 +90,14 @@ type BlockchainContext interface {
 	GetShardOfAddress(addr []byte) uint32
 	IsSmartContract(addr []byte) bool
 	IsPayable(address []byte) (bool, error)
+	GetCodeMetadata(address []byte) ([]byte, error)
 	SaveCompiledCode(codeHash []byte, code []byte)
 	GetCompiledCode(codeHash []byte) (bool, []byte)
 	GetESDTToken(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error)
+	GetESDTLocalRoles(address []byte, tokenID []byte) ([][]byte, error)
+	IsESDTFrozen(address []byte, tokenID []byte) (bool, error)
+	IsESDTPaused(tokenID []byte) (bool, error)
+	IsESDTLimitedTransfer(tokenID []byte) (bool, error)
 	GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error)
 	ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error)
 	GetSnapshot() int
@@ -187,6 +195,17 @@ type ManagedTypesContext interface {
 	GetSlice(mBufferHandle int32, startPosition int32, lengthOfSlice int32) ([]byte, error)
 	DeleteSlice(mBufferHandle int32, startPosition int32, lengthOfSlice int32) ([]byte, error)
 	InsertSlice(mBufferHandle int32, startPosition int32, slice []byte) ([]byte, error)
+	NewManagedVec() int32
+	GetManagedVecLength(mVecHandle int32) int32
+	PushManagedVecItem(mVecHandle int32, item []byte) error
+	GetManagedVecItem(mVecHandle int32, index int32) ([]byte, error)
+	SetManagedVecItem(mVecHandle int32, index int32, item []byte) error
+	RemoveManagedVecItem(mVecHandle int32, index int32) error
+	NewManagedMap() int32
+	GetManagedMapLength(mMapHandle int32) int32
+	PutManagedMapValue(mMapHandle int32, key []byte, value []byte) error
+	GetManagedMapValue(mMapHandle int32, key []byte) ([]byte, bool, error)
+	RemoveManagedMapValue(mMapHandle int32, key []byte) ([]byte, bool, error)
 }
 
 // OutputContext defines the functionality needed for interacting with the output context
@@ -203,6 +222,8 @@ type OutputContext interface {
 	TransferValueOnly(destination []byte, sender []byte, value *big.Int, checkPayable bool) error
 	Transfer(destination []byte, sender []byte, gasLimit uint64, gasLocked uint64, value *big.Int, input []byte, callType vm.CallType) error
 	TransferESDT(destination []byte, sender []byte, transfers []*vmcommon.ESDTTransfer, callInput *vmcommon.ContractCallInput) (uint64, error)
+	GetBackTransfers() ([]*vmcommon.ESDTTransfer, *big.Int)
+	ClearBackTransfers()
 	SelfDestruct(address []byte, beneficiary []byte)
 	GetRefund() uint64
 	SetRefund(refund uint64)
@@ -238,6 +259,8 @@ type MeteringContext interface {
 	GasLeft() uint64
 	GasUsedForExecution() uint64
 	GasSpentByContract() uint64
+	GasRequired() uint64
+	TrackGasRequiredByNestedCall(gasProvidedToCall uint64, gasRequiredByCall uint64)
 	GetGasForExecution() uint64
 	GetGasProvided() uint64
 	GetSCPrepareInitialCost() uint64
@@ -311,3 +334,19 @@ type GasTracing interface {
 	GetGasTrace() map[string]map[string][]uint64
 	IsInterfaceNil() bool
 }
+
+// ExecutionObserver defines the functionality needed to receive structured events
+// about the execution of smart contracts
+type ExecutionObserver interface {
+	OnCallEnter(event *CallEnterEvent)
+	OnCallExit(event *CallExitEvent)
+	OnStorageAccess(event *StorageAccessEvent)
+	OnTransfer(event *TransferEvent)
+	OnLog(event *LogEvent)
+	OnAsyncCall(event *AsyncCallEvent)
+	OnCallback(event *CallbackEvent)
+	OnGasUsed(event *GasUsedEvent)
+	OnFunctionEnter(event *FunctionCallEvent)
+	OnFunctionExit(event *FunctionCallEvent)
+	IsInterfaceNil() bool
+}
@@ -156,6 +156,11 @@ func (b *BlockchainContextMock) IsPayable(_ []byte) (bool, error) {
 	return true, nil
 }
 
+// GetCodeMetadata -
+func (b *BlockchainContextMock) GetCodeMetadata(_ []byte) ([]byte, error) {
+	return make([]byte, 2), nil
+}
+
 // SaveCompiledCode -
 func (b *BlockchainContextMock) SaveCompiledCode(_ []byte, _ []byte) {
 }
@@ -170,6 +175,26 @@ func (b *BlockchainContextMock) GetESDTToken(_ []byte, _ []byte, _ uint64) (*esd
 	return &esdt.ESDigitalToken{Value: big.NewInt(0)}, nil
 }
 
+// GetESDTLocalRoles -
+func (b *BlockchainContextMock) GetESDTLocalRoles(_ []byte, _ []byte) ([][]byte, error) {
+	return nil, nil
+}
+
+// IsESDTFrozen -
+func (b *BlockchainContextMock) IsESDTFrozen(_ []byte, _ []byte) (bool, error) {
+	return false, nil
+}
+
+// IsESDTPaused -
+func (b *BlockchainContextMock) IsESDTPaused(_ []byte) (bool, error) {
+	return false, nil
+}
+
+// IsESDTLimitedTransfer -
+func (b *BlockchainContextMock) IsESDTLimitedTransfer(_ []byte) (bool, error) {
+	return false, nil
+}
+
 // GetUserAccount -
 func (b *BlockchainContextMock) GetUserAccount(_ []byte) (vmcommon.UserAccountHandler, error) {
 	return nil, nil
@@ -1,4 +1,13 @@
 package arwendebug
 
+import "time"
+
 // DefaultGasPrice is the default gas price for debugging
 const DefaultGasPrice = 200000000000
+
+// DefaultPausedSessionTimeout is the time after which a paused debug session,
+// left without commands, is aborted
+const DefaultPausedSessionTimeout = 10 * time.Minute
+
+// DefaultChainID is the default chain ID of the simulated chain of the proxy mode
+const DefaultChainID = "local-testnet"
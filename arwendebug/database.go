@@ -6,15 +6,24 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"sort"
+	"strings"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
 )
 
 type database struct {
-	rootPath string
+	rootPath    string
+	wasmBackend arwen.WASMBackend
 }
 
-// newDatabase creates a new debugging database (basically, a folder with JSON files)
-func newDatabase(rootPath string) *database {
-	db := &database{rootPath: rootPath}
+// newDatabase creates a new debugging database (basically, a folder with JSON
+// files), whose worlds execute contracts with the given WASM backend
+func newDatabase(rootPath string, wasmBackend arwen.WASMBackend) *database {
+	db := &database{
+		rootPath:    rootPath,
+		wasmBackend: wasmBackend,
+	}
 	db.initFolders()
 	return db
 }
@@ -29,9 +38,18 @@ func (db *database) initFolders() {
 	if err != nil {
 		log.Error("database.initFolders", "err", err)
 	}
+
+	err = os.MkdirAll(path.Join(db.rootPath, "history"), os.ModePerm)
+	if err != nil {
+		log.Error("database.initFolders", "err", err)
+	}
 }
 
 func (db *database) loadWorld(worldID string) (*world, error) {
+	return db.loadWorldWithObserver(worldID, nil)
+}
+
+func (db *database) loadWorldWithObserver(worldID string, observer arwen.ExecutionObserver) (*world, error) {
 	var err error
 	dataModel := newWorldDataModel(worldID)
 	filePath := db.getWorldFile(worldID)
@@ -43,7 +61,7 @@ func (db *database) loadWorld(worldID string) (*world, error) {
 		}
 	}
 
-	world, err := newWorld(dataModel)
+	world, err := newWorld(dataModel, observer, db.wasmBackend)
 	if err != nil {
 		return nil, err
 	}
@@ -71,13 +89,55 @@ func (db *database) readWorldDataModel(filePath string) (*worldDataModel, error)
 }
 
 func (db *database) storeWorld(world *world) error {
-	filePath := db.getWorldFile(world.id)
+	return db.storeWorldDataModel(world.toDataModel())
+}
+
+func (db *database) storeWorldDataModel(dataModel *worldDataModel) error {
+	err := db.snapshotInitialWorld(dataModel.ID)
+	if err != nil {
+		return err
+	}
+
+	filePath := db.getWorldFile(dataModel.ID)
 	log.Trace("Database.storeWorld()", "file", filePath)
 
-	dataModel := world.toDataModel()
 	return db.marshalDataModel(filePath, dataModel)
 }
 
+// snapshotInitialWorld records a stored world whose history is still empty as
+// step 0 of its history, before it gets overwritten; worlds without a stored
+// state are empty at step 0
+func (db *database) snapshotInitialWorld(worldID string) error {
+	worldFile := db.getWorldFile(worldID)
+	initialFile := db.getHistoryFile(worldID, 0)
+	if !fileExists(worldFile) || fileExists(initialFile) || db.getHistoryLength(worldID) > 0 {
+		return nil
+	}
+
+	dataModel, err := db.readWorldDataModel(worldFile)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(db.getHistoryFolder(worldID), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	entry := &historyEntry{
+		Step:   0,
+		Action: HistoryActionInitial,
+		World:  dataModel,
+	}
+
+	log.Trace("Database.snapshotInitialWorld()", "file", initialFile)
+	return db.marshalDataModel(initialFile, entry)
+}
+
+func (db *database) worldExists(worldID string) bool {
+	return fileExists(db.getWorldFile(worldID)) || db.getHistoryLength(worldID) > 0
+}
+
 func (db *database) storeOutcome(key string, outcome interface{}) error {
 	if len(key) == 0 {
 		log.Trace("Database.storeOutcome(), won't store (empty key)")
@@ -89,6 +149,158 @@ func (db *database) storeOutcome(key string, outcome interface{}) error {
 	return db.marshalDataModel(filePath, outcome)
 }
 
+func (db *database) listWorlds() ([]string, error) {
+	return db.listDataModels(path.Join(db.rootPath, "worlds"))
+}
+
+func (db *database) listOutcomes() ([]string, error) {
+	return db.listDataModels(path.Join(db.rootPath, "out"))
+}
+
+// listDataModels returns the sorted names of the JSON files in the given folder, without extension
+func (db *database) listDataModels(folder string) ([]string, error) {
+	files, err := ioutil.ReadDir(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || path.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		names = append(names, strings.TrimSuffix(file.Name(), ".json"))
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// appendHistory records the action which has just been performed on the world, along
+// with a snapshot of the world
+func (db *database) appendHistory(world *world, entry *historyEntry) error {
+	return db.appendHistoryWithDataModel(world.toDataModel(), entry)
+}
+
+// appendHistoryWithDataModel records an action along with the given snapshot of the world
+func (db *database) appendHistoryWithDataModel(dataModel *worldDataModel, entry *historyEntry) error {
+	err := os.MkdirAll(db.getHistoryFolder(dataModel.ID), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	entry.Step = db.getHistoryLength(dataModel.ID) + 1
+	entry.World = dataModel
+
+	filePath := db.getHistoryFile(dataModel.ID, entry.Step)
+	log.Trace("Database.appendHistory()", "file", filePath)
+	return db.marshalDataModel(filePath, entry)
+}
+
+// loadHistory loads the history of a world, ordered by step; it starts with
+// the initial snapshot of the world, if any
+func (db *database) loadHistory(worldID string) ([]*historyEntry, error) {
+	length := db.getHistoryLength(worldID)
+	history := make([]*historyEntry, 0, length+1)
+
+	for step := db.getFirstHistoryStep(worldID); step <= length; step++ {
+		entry, err := db.loadHistoryEntry(worldID, step)
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, entry)
+	}
+
+	return history, nil
+}
+
+func (db *database) loadHistoryEntry(worldID string, step int) (*historyEntry, error) {
+	entry := &historyEntry{}
+	err := db.unmarshalDataModel(db.getHistoryFile(worldID, step), entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// loadWorldDataModelAtStep returns the snapshot of the world right after the
+// given step; step 0 stands for the world before its history started
+func (db *database) loadWorldDataModelAtStep(worldID string, step int) (*worldDataModel, error) {
+	if step > db.getHistoryLength(worldID) {
+		return nil, ErrInvalidHistoryStep
+	}
+
+	err := db.snapshotInitialWorld(worldID)
+	if err != nil {
+		return nil, err
+	}
+
+	if step < db.getFirstHistoryStep(worldID) {
+		return newWorldDataModel(worldID), nil
+	}
+
+	entry, err := db.loadHistoryEntry(worldID, step)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.World, nil
+}
+
+// forkHistory copies the history of a world, up to the given step, as the
+// history of another world
+func (db *database) forkHistory(worldID string, step int, newWorldID string) error {
+	err := os.MkdirAll(db.getHistoryFolder(newWorldID), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	for i := db.getFirstHistoryStep(worldID); i <= step; i++ {
+		entry, err := db.loadHistoryEntry(worldID, i)
+		if err != nil {
+			return err
+		}
+
+		entry.World.ID = newWorldID
+		err = db.marshalDataModel(db.getHistoryFile(newWorldID, i), entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getHistoryLength returns the number of contiguous steps recorded for a world
+func (db *database) getHistoryLength(worldID string) int {
+	length := 0
+	for fileExists(db.getHistoryFile(worldID, length+1)) {
+		length++
+	}
+
+	return length
+}
+
+// getFirstHistoryStep returns 0 if the initial snapshot of a world was recorded, 1 otherwise
+func (db *database) getFirstHistoryStep(worldID string) int {
+	if fileExists(db.getHistoryFile(worldID, 0)) {
+		return 0
+	}
+
+	return 1
+}
+
+func (db *database) getHistoryFolder(worldID string) string {
+	return path.Join(db.rootPath, "history", worldID)
+}
+
+func (db *database) getHistoryFile(worldID string, step int) string {
+	return path.Join(db.getHistoryFolder(worldID), fmt.Sprintf("%d.json", step))
+}
+
 func (db *database) getOutcomeFile(uniqueID string) string {
 	return path.Join(db.rootPath, "out", fmt.Sprintf("%s.json", uniqueID))
 }
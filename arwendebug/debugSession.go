@@ -0,0 +1,91 @@
+package arwendebug
+
+import "time"
+
+// debugSession holds a paused execution, together with the world it runs on
+type debugSession struct {
+	database *database
+	world    *world
+	request  DebugRunRequest
+	debugger *debugger
+	state    *DebugState
+
+	timeout   *time.Timer
+	timeoutID int
+}
+
+func newDebugSession(database *database, request DebugRunRequest) (*debugSession, error) {
+	debugger := newDebugger(&request.Breakpoints)
+	world, err := database.loadWorldWithObserver(request.World, debugger)
+	if err != nil {
+		return nil, err
+	}
+
+	debugger.host = world.vm
+
+	return &debugSession{
+		database: database,
+		world:    world,
+		request:  request,
+		debugger: debugger,
+	}, nil
+}
+
+func (session *debugSession) start() {
+	rootFrame := &CallFrame{
+		CallerAddressHex:   session.request.ImpersonatedHex,
+		ContractAddressHex: session.request.ContractAddressHex,
+		Function:           session.request.Function,
+		GasProvided:        session.request.GasLimit,
+	}
+
+	session.state = session.debugger.start(rootFrame, func() *RunResponse {
+		return session.world.runSmartContract(session.request.RunRequest)
+	})
+}
+
+func (session *debugSession) resume(action debugAction) error {
+	if !session.isPaused() {
+		return ErrDebugSessionNotPaused
+	}
+
+	session.state = session.debugger.resume(action)
+	return nil
+}
+
+func (session *debugSession) inspect(inspectFunc func() (interface{}, error)) (interface{}, error) {
+	if !session.isPaused() {
+		return nil, ErrDebugSessionNotPaused
+	}
+
+	return session.debugger.inspect(inspectFunc)
+}
+
+func (session *debugSession) stopTimeout() {
+	if session.timeout != nil {
+		session.timeout.Stop()
+	}
+}
+
+func (session *debugSession) isPaused() bool {
+	return session.state != nil && session.state.Paused
+}
+
+func (session *debugSession) isFinished() bool {
+	return session.state != nil && session.state.Finished
+}
+
+// finish stores the world and the outcome of a finished execution
+func (session *debugSession) finish() error {
+	err := session.database.storeWorld(session.world)
+	if err != nil {
+		return err
+	}
+
+	err = session.database.appendHistory(session.world, newRunHistoryEntry(session.request.RunRequest, session.state.Result))
+	if err != nil {
+		return err
+	}
+
+	return session.database.storeOutcome(session.request.Outcome, session.state.Result)
+}
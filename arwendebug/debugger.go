@@ -0,0 +1,255 @@
+package arwendebug
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+)
+
+var _ arwen.ExecutionObserver = (*debugger)(nil)
+
+// eiFunctionPrefix is the prefix of the exported Go functions implementing the EI
+const eiFunctionPrefix = "v1_4_"
+
+const (
+	// PauseReasonFunction signals a pause on a breakpoint set on an EI function
+	PauseReasonFunction = "function"
+
+	// PauseReasonCallEnter signals a pause on a breakpoint set on a contract address
+	PauseReasonCallEnter = "callEnter"
+
+	// PauseReasonGas signals a pause on the gas threshold breakpoint
+	PauseReasonGas = "gas"
+
+	// PauseReasonStep signals a pause after stepping to the next EI call
+	PauseReasonStep = "step"
+)
+
+type debugAction int
+
+const (
+	actionContinue debugAction = iota
+	actionStep
+	actionInspect
+	actionAbort
+)
+
+type debugCommand struct {
+	action  debugAction
+	inspect func() (interface{}, error)
+	reply   chan *inspection
+}
+
+type inspection struct {
+	value interface{}
+	err   error
+}
+
+// debugger is an ExecutionObserver which pauses the execution of a
+// transaction when a breakpoint is hit. While paused, the VM goroutine is
+// blocked in the observer callback, waiting for commands; the inspections
+// are executed on the VM goroutine as well, so they never race the VM. The EI
+// calls failing for lack of gas are notified with no gas used, so the function
+// breakpoints fire on them too.
+//
+// Pausing does not go through the runtime breakpoint value: once set, it
+// stops the WASM instance for good, so the execution could not be resumed.
+// Only aborting does, by failing the execution. Since a blocked VM goroutine
+// holds the debug session, the facade aborts the sessions left paused for too
+// long.
+type debugger struct {
+	host        arwen.VMHost
+	breakpoints *Breakpoints
+
+	stepping         bool
+	aborted          bool
+	gasBreakpointHit bool
+	callStack        []*CallFrame
+
+	commands chan *debugCommand
+	states   chan *DebugState
+}
+
+func newDebugger(breakpoints *Breakpoints) *debugger {
+	return &debugger{
+		breakpoints: breakpoints,
+		callStack:   make([]*CallFrame, 0),
+		commands:    make(chan *debugCommand),
+		states:      make(chan *DebugState),
+	}
+}
+
+// start runs the execution on a separate goroutine, then waits until it
+// either pauses or finishes
+func (d *debugger) start(rootFrame *CallFrame, execute func() *RunResponse) *DebugState {
+	d.callStack = append(d.callStack, rootFrame)
+
+	go func() {
+		result := execute()
+		d.states <- &DebugState{
+			Finished: true,
+			Result:   result,
+		}
+	}()
+
+	return <-d.states
+}
+
+// resume lets the paused execution continue, then waits until it either
+// pauses again or finishes
+func (d *debugger) resume(action debugAction) *DebugState {
+	d.commands <- &debugCommand{action: action}
+	return <-d.states
+}
+
+// inspect evaluates the given function on the paused VM goroutine
+func (d *debugger) inspect(inspectFunc func() (interface{}, error)) (interface{}, error) {
+	command := &debugCommand{
+		action:  actionInspect,
+		inspect: inspectFunc,
+		reply:   make(chan *inspection),
+	}
+	d.commands <- command
+
+	result := <-command.reply
+	return result.value, result.err
+}
+
+func (d *debugger) pause(reason string, address []byte, function string) {
+	if d.aborted {
+		return
+	}
+
+	d.states <- &DebugState{
+		Paused:             true,
+		Reason:             reason,
+		ContractAddressHex: toHex(address),
+		Function:           function,
+		GasLeft:            d.host.Metering().GasLeft(),
+		CallStack:          d.copyCallStack(),
+	}
+
+	for command := range d.commands {
+		switch command.action {
+		case actionInspect:
+			value, err := command.inspect()
+			command.reply <- &inspection{value: value, err: err}
+		case actionContinue:
+			d.stepping = false
+			return
+		case actionStep:
+			d.stepping = true
+			return
+		case actionAbort:
+			d.stepping = false
+			d.aborted = true
+			d.host.Runtime().FailExecution(ErrDebugSessionAborted)
+			return
+		}
+	}
+}
+
+func (d *debugger) copyCallStack() []*CallFrame {
+	callStack := make([]*CallFrame, len(d.callStack))
+	copy(callStack, d.callStack)
+	return callStack
+}
+
+func (d *debugger) hasFunctionBreakpoint(function string) bool {
+	for _, breakpoint := range d.breakpoints.Functions {
+		if strings.TrimPrefix(breakpoint, eiFunctionPrefix) == function {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *debugger) hasAddressBreakpoint(address []byte) bool {
+	for _, breakpoint := range d.breakpoints.ContractAddresses {
+		if bytes.Equal(breakpoint, address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OnCallEnter pushes a frame on the call stack, pausing if there is a
+// breakpoint on the called contract
+func (d *debugger) OnCallEnter(event *arwen.CallEnterEvent) {
+	d.callStack = append(d.callStack, &CallFrame{
+		CallerAddressHex:   toHex(event.CallerAddr),
+		ContractAddressHex: toHex(event.RecipientAddr),
+		Function:           event.Function,
+		GasProvided:        event.GasProvided,
+		SameContext:        event.SameContext,
+	})
+
+	if d.hasAddressBreakpoint(event.RecipientAddr) {
+		d.pause(PauseReasonCallEnter, event.RecipientAddr, event.Function)
+	}
+}
+
+// OnCallExit pops a frame from the call stack
+func (d *debugger) OnCallExit(_ *arwen.CallExitEvent) {
+	if len(d.callStack) > 1 {
+		d.callStack = d.callStack[:len(d.callStack)-1]
+	}
+}
+
+// OnStorageAccess does nothing
+func (d *debugger) OnStorageAccess(_ *arwen.StorageAccessEvent) {
+}
+
+// OnTransfer does nothing
+func (d *debugger) OnTransfer(_ *arwen.TransferEvent) {
+}
+
+// OnLog does nothing
+func (d *debugger) OnLog(_ *arwen.LogEvent) {
+}
+
+// OnAsyncCall does nothing
+func (d *debugger) OnAsyncCall(_ *arwen.AsyncCallEvent) {
+}
+
+// OnCallback does nothing
+func (d *debugger) OnCallback(_ *arwen.CallbackEvent) {
+}
+
+// OnGasUsed pauses when stepping, on the breakpoints set on EI functions and
+// once the gas left drops below the gas threshold
+func (d *debugger) OnGasUsed(event *arwen.GasUsedEvent) {
+	if event.NewCall {
+		if d.stepping {
+			d.pause(PauseReasonStep, event.Address, event.Function)
+			return
+		}
+
+		if d.hasFunctionBreakpoint(event.Function) {
+			d.pause(PauseReasonFunction, event.Address, event.Function)
+			return
+		}
+	}
+
+	gasLeftBelow := d.breakpoints.GasLeftBelow
+	if gasLeftBelow > 0 && !d.gasBreakpointHit && d.host.Metering().GasLeft() < gasLeftBelow {
+		d.gasBreakpointHit = true
+		d.pause(PauseReasonGas, event.Address, event.Function)
+	}
+}
+
+// OnFunctionEnter does nothing
+func (d *debugger) OnFunctionEnter(_ *arwen.FunctionCallEvent) {
+}
+
+// OnFunctionExit does nothing
+func (d *debugger) OnFunctionExit(_ *arwen.FunctionCallEvent) {
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (d *debugger) IsInterfaceNil() bool {
+	return d == nil
+}
@@ -0,0 +1,204 @@
+package arwendebug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugger_BreakpointsAndStepping(t *testing.T) {
+	caller := []byte("caller")
+	callee := []byte("callee")
+
+	runtime := &contextmock.RuntimeContextMock{SCAddress: caller, MemLoadResult: []byte{0xab, 0xcd}}
+	metering := &contextmock.MeteringContextMock{GasLeftMock: 1000}
+	host := &contextmock.VMHostMock{RuntimeContext: runtime, MeteringContext: metering}
+
+	breakpoints := &Breakpoints{
+		Functions:         []string{"v1_4_storageStore"},
+		ContractAddresses: [][]byte{callee},
+		GasLeftBelow:      100,
+	}
+	d := newDebugger(breakpoints)
+	d.host = host
+
+	rootFrame := &CallFrame{ContractAddressHex: toHex(caller), Function: "increment"}
+	state := d.start(rootFrame, func() *RunResponse {
+		d.OnGasUsed(&arwen.GasUsedEvent{Address: caller, Function: "getArgument", NewCall: true})
+		d.OnGasUsed(&arwen.GasUsedEvent{Address: caller, Function: "storageStore", NewCall: true})
+		d.OnGasUsed(&arwen.GasUsedEvent{Address: caller, Function: "storageStore"})
+		d.OnGasUsed(&arwen.GasUsedEvent{Address: caller, Function: "finish", NewCall: true})
+		d.OnCallEnter(&arwen.CallEnterEvent{CallerAddr: caller, RecipientAddr: callee, Function: "swap"})
+		metering.GasLeftMock = 50
+		d.OnGasUsed(&arwen.GasUsedEvent{Address: callee, Function: "getArgument", NewCall: true})
+		d.OnGasUsed(&arwen.GasUsedEvent{Address: callee, Function: "getArgument", NewCall: true})
+		d.OnCallExit(&arwen.CallExitEvent{CallerAddr: caller, RecipientAddr: callee})
+		return &RunResponse{}
+	})
+
+	require.True(t, state.Paused)
+	require.Equal(t, PauseReasonFunction, state.Reason)
+	require.Equal(t, "storageStore", state.Function)
+	require.Equal(t, uint64(1000), state.GasLeft)
+	require.Len(t, state.CallStack, 1)
+
+	data, err := d.inspect(func() (interface{}, error) {
+		return host.Runtime().MemLoad(0, 2)
+	})
+	require.Nil(t, err)
+	require.Equal(t, []byte{0xab, 0xcd}, data)
+
+	state = d.resume(actionStep)
+	require.True(t, state.Paused)
+	require.Equal(t, PauseReasonStep, state.Reason)
+	require.Equal(t, "finish", state.Function)
+
+	state = d.resume(actionContinue)
+	require.True(t, state.Paused)
+	require.Equal(t, PauseReasonCallEnter, state.Reason)
+	require.Equal(t, toHex(callee), state.ContractAddressHex)
+	require.Equal(t, "swap", state.Function)
+	require.Len(t, state.CallStack, 2)
+	require.Equal(t, toHex(callee), state.CallStack[1].ContractAddressHex)
+
+	state = d.resume(actionContinue)
+	require.True(t, state.Paused)
+	require.Equal(t, PauseReasonGas, state.Reason)
+	require.Equal(t, uint64(50), state.GasLeft)
+
+	state = d.resume(actionContinue)
+	require.False(t, state.Paused)
+	require.True(t, state.Finished)
+	require.NotNil(t, state.Result)
+}
+
+func TestDebugger_Abort(t *testing.T) {
+	host := &contextmock.VMHostMock{
+		RuntimeContext:  &contextmock.RuntimeContextMock{},
+		MeteringContext: &contextmock.MeteringContextMock{},
+	}
+
+	d := newDebugger(&Breakpoints{Functions: []string{"finish"}})
+	d.host = host
+
+	state := d.start(&CallFrame{}, func() *RunResponse {
+		d.OnGasUsed(&arwen.GasUsedEvent{Function: "finish", NewCall: true})
+		d.OnGasUsed(&arwen.GasUsedEvent{Function: "finish", NewCall: true})
+		return &RunResponse{}
+	})
+	require.True(t, state.Paused)
+
+	state = d.resume(actionAbort)
+	require.True(t, state.Finished)
+	require.True(t, d.aborted)
+}
+
+func TestFacade_DebugSessionErrors(t *testing.T) {
+	facade := NewDebugFacade()
+
+	_, err := facade.ContinueDebugSession()
+	require.Equal(t, ErrNoDebugSession, err)
+
+	_, err = facade.GetDebugCallStack()
+	require.Equal(t, ErrNoDebugSession, err)
+
+	_, err = facade.InspectMemory(DebugMemoryRequest{Offset: 0, Length: 4})
+	require.Equal(t, ErrNoDebugSession, err)
+
+	_, err = facade.InspectHandle(DebugHandleRequest{Handle: 1, Type: "vector"})
+	require.Equal(t, ErrInvalidHandleType, err)
+}
+
+func (context *testContext) startDebugSession(contract string, impersonated string, function string, breakpoints Breakpoints) *DebugState {
+	request := DebugRunRequest{
+		RunRequest: RunRequest{
+			ContractRequestBase: ContractRequestBase{
+				RequestBase:     context.createRequestBase(),
+				ImpersonatedHex: impersonated,
+				GasLimit:        gasLimit,
+			},
+			ContractAddressHex: contract,
+			Function:           function,
+		},
+		Breakpoints: breakpoints,
+	}
+
+	state, err := context.facade.StartDebugSession(request)
+	require.Nil(context.t, err)
+	return state
+}
+
+func TestFacade_DebugSession_Counter(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+	contractAddressHex := context.deployContract(wasmCounterPath, alice.hex).ContractAddressHex
+
+	// int64storageLoad is implemented by smallIntStorageLoadUnsigned
+	state := context.startDebugSession(contractAddressHex, alice.hex, "increment", Breakpoints{
+		Functions: []string{"v1_4_smallIntStorageLoadUnsigned"},
+	})
+	require.True(t, state.Paused)
+	require.Equal(t, PauseReasonFunction, state.Reason)
+	require.Equal(t, "smallIntStorageLoadUnsigned", state.Function)
+	require.Equal(t, contractAddressHex, state.ContractAddressHex)
+	require.Len(t, state.CallStack, 1)
+
+	// the storage key of the counter is in the memory of the paused instance
+	memory, err := context.facade.InspectMemory(DebugMemoryRequest{Offset: 0, Length: 4096})
+	require.Nil(t, err)
+	require.Contains(t, memory.DataHex, toHex([]byte("COUNTER")))
+
+	_, err = context.facade.StartDebugSession(DebugRunRequest{})
+	require.Equal(t, ErrDebugSessionInProgress, err)
+
+	gasLeft := state.GasLeft
+	state, err = context.facade.StepDebugSession()
+	require.Nil(t, err)
+	require.True(t, state.Paused)
+	require.Equal(t, PauseReasonStep, state.Reason)
+	require.Less(t, state.GasLeft, gasLeft)
+
+	state, err = context.facade.ContinueDebugSession()
+	require.Nil(t, err)
+	require.True(t, state.Finished)
+	require.Equal(t, int64(2), state.Result.getFirstResultAsInt64())
+
+	counterValue := context.queryContract(contractAddressHex, alice.hex, "get").getFirstResultAsInt64()
+	require.Equal(t, int64(2), counterValue)
+}
+
+func TestFacade_DebugSession_AbortedWhenIdle(t *testing.T) {
+	context := newTestContext(t)
+	context.facade.pausedSessionTimeout = 50 * time.Millisecond
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+	contractAddressHex := context.deployContract(wasmCounterPath, alice.hex).ContractAddressHex
+
+	state := context.startDebugSession(contractAddressHex, alice.hex, "increment", Breakpoints{
+		Functions: []string{"smallIntStorageLoadUnsigned"},
+	})
+	require.True(t, state.Paused)
+
+	require.Eventually(t, func() bool {
+		context.facade.mutSession.Lock()
+		defer context.facade.mutSession.Unlock()
+		return context.facade.session == nil
+	}, time.Second, 10*time.Millisecond)
+
+	history, err := context.facade.GetHistory(HistoryRequest{RequestBase: context.createRequestBase()})
+	require.Nil(t, err)
+	lastStep := history.Steps[len(history.Steps)-1]
+	require.Equal(t, HistoryActionRun, lastStep.Action)
+	require.Equal(t, vmcommon.ExecutionFailed.String(), lastStep.ReturnCode)
+
+	// the aborted execution left the world unchanged
+	counterValue := context.queryContract(contractAddressHex, alice.hex, "get").getFirstResultAsInt64()
+	require.Equal(t, int64(1), counterValue)
+}
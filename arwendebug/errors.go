@@ -44,3 +44,45 @@ var ErrInvalidArgumentEncoding = errors.New("invalid contract argument encoding"
 
 // ErrAccountDoesntExist signals an error
 var ErrAccountDoesntExist = errors.New("account does not exist")
+
+// ErrNoDebugSession signals that there is no debug session in progress
+var ErrNoDebugSession = errors.New("no debug session in progress")
+
+// ErrDebugSessionInProgress signals that another debug session is in progress
+var ErrDebugSessionInProgress = errors.New("a debug session is already in progress")
+
+// ErrDebugSessionNotPaused signals that the execution of the debug session is not paused
+var ErrDebugSessionNotPaused = errors.New("debug session is not paused")
+
+// ErrDebugSessionAborted signals that the debugged execution has been aborted
+var ErrDebugSessionAborted = errors.New("debug session aborted")
+
+// ErrInvalidHandleType signals an unknown type of managed handle
+var ErrInvalidHandleType = errors.New("invalid handle type")
+
+// ErrInvalidHistoryStep signals a step which is not in the history of the world
+var ErrInvalidHistoryStep = errors.New("invalid history step")
+
+// ErrWorldAlreadyExists signals that the world to be created already exists
+var ErrWorldAlreadyExists = errors.New("world already exists")
+
+// ErrEmptyHistory signals that no action has been recorded for the world
+var ErrEmptyHistory = errors.New("the world has no history")
+
+// ErrInvalidChainID signals a transaction meant for another chain
+var ErrInvalidChainID = errors.New("invalid chain ID")
+
+// ErrInvalidTransactionNonce signals a transaction nonce which differs from the nonce of its sender
+var ErrInvalidTransactionNonce = errors.New("invalid transaction nonce")
+
+// ErrInsufficientGasPrice signals a gas price lower than the minimum gas price of the chain
+var ErrInsufficientGasPrice = errors.New("insufficient gas price")
+
+// ErrInvalidGasLimit signals a gas limit which does not cover the data of the transaction or exceeds the maximum
+var ErrInvalidGasLimit = errors.New("invalid gas limit")
+
+// ErrInsufficientFunds signals a sender which cannot pay for the value and the gas of the transaction
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrTransactionNotFound signals an unknown transaction hash
+var ErrTransactionNotFound = errors.New("transaction not found")
@@ -3,7 +3,10 @@ package arwendebug
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
 	logger "github.com/ElrondNetwork/elrond-go-logger"
 )
 
@@ -11,11 +14,25 @@ var log = logger.GetOrCreate("arwendebug")
 
 // DebugFacade is the debug facade
 type DebugFacade struct {
+	wasmBackend          arwen.WASMBackend
+	pausedSessionTimeout time.Duration
+
+	mutSession sync.Mutex
+	session    *debugSession
 }
 
-// NewDebugFacade creates a new debug facade
+// NewDebugFacade creates a new debug facade, whose worlds execute contracts with Wasmer
 func NewDebugFacade() *DebugFacade {
-	return &DebugFacade{}
+	return NewDebugFacadeWithWASMBackend(arwen.WASMBackendWasmer)
+}
+
+// NewDebugFacadeWithWASMBackend creates a new debug facade, whose worlds
+// execute contracts with the given WASM backend
+func NewDebugFacadeWithWASMBackend(wasmBackend arwen.WASMBackend) *DebugFacade {
+	return &DebugFacade{
+		wasmBackend:          wasmBackend,
+		pausedSessionTimeout: DefaultPausedSessionTimeout,
+	}
 }
 
 // DeploySmartContract deploys a smart contract
@@ -40,6 +57,11 @@ func (f *DebugFacade) DeploySmartContract(request DeployRequest) (*DeployRespons
 		return nil, err
 	}
 
+	err = database.appendHistory(world, newDeployHistoryEntry(request, response))
+	if err != nil {
+		return nil, err
+	}
+
 	err = database.storeOutcome(request.Outcome, response)
 	if err != nil {
 		return nil, err
@@ -50,7 +72,7 @@ func (f *DebugFacade) DeploySmartContract(request DeployRequest) (*DeployRespons
 }
 
 func (f *DebugFacade) loadDatabase(rootPath string) *database {
-	database := newDatabase(rootPath)
+	database := newDatabase(rootPath, f.wasmBackend)
 	return database
 }
 
@@ -76,6 +98,11 @@ func (f *DebugFacade) UpgradeSmartContract(request UpgradeRequest) (*UpgradeResp
 		return nil, err
 	}
 
+	err = database.appendHistory(world, newUpgradeHistoryEntry(request, response))
+	if err != nil {
+		return nil, err
+	}
+
 	err = database.storeOutcome(request.Outcome, response)
 	if err != nil {
 		return nil, err
@@ -107,6 +134,11 @@ func (f *DebugFacade) RunSmartContract(request RunRequest) (*RunResponse, error)
 		return nil, err
 	}
 
+	err = database.appendHistory(world, newRunHistoryEntry(request, response))
+	if err != nil {
+		return nil, err
+	}
+
 	err = database.storeOutcome(request.Outcome, response)
 	if err != nil {
 		return nil, err
@@ -133,6 +165,11 @@ func (f *DebugFacade) QuerySmartContract(request QueryRequest) (*QueryResponse,
 
 	response := world.querySmartContract(request)
 
+	err = database.appendHistory(world, newQueryHistoryEntry(request, response))
+	if err != nil {
+		return nil, err
+	}
+
 	err = database.storeOutcome(request.Outcome, response)
 	if err != nil {
 		return nil, err
@@ -164,6 +201,11 @@ func (f *DebugFacade) CreateAccount(request CreateAccountRequest) (*CreateAccoun
 		return nil, err
 	}
 
+	err = database.appendHistory(world, newCreateAccountHistoryEntry(request))
+	if err != nil {
+		return nil, err
+	}
+
 	err = database.storeOutcome(request.Outcome, response)
 	if err != nil {
 		return nil, err
@@ -173,6 +215,530 @@ func (f *DebugFacade) CreateAccount(request CreateAccountRequest) (*CreateAccoun
 	return response, err
 }
 
+// ListWorlds lists the worlds stored in the database
+func (f *DebugFacade) ListWorlds(request ListWorldsRequest) (*ListWorldsResponse, error) {
+	log.Debug("Debugf.ListWorlds()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	names, err := database.listWorlds()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListWorldsResponse{Worlds: names}
+	dumpOutcome(&response)
+	return response, nil
+}
+
+// ListOutcomes lists the outcomes stored in the database
+func (f *DebugFacade) ListOutcomes(request ListOutcomesRequest) (*ListOutcomesResponse, error) {
+	log.Debug("Debugf.ListOutcomes()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	names, err := database.listOutcomes()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListOutcomesResponse{Outcomes: names}
+	dumpOutcome(&response)
+	return response, nil
+}
+
+// GetAccount returns the state of an account
+func (f *DebugFacade) GetAccount(request AccountRequest) (*GetAccountResponse, error) {
+	log.Debug("Debugf.GetAccount()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := world.getAccount(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, nil
+}
+
+// ListStorageKeys lists the storage keys of an account
+func (f *DebugFacade) ListStorageKeys(request AccountRequest) (*ListStorageKeysResponse, error) {
+	log.Debug("Debugf.ListStorageKeys()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := world.listStorageKeys(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, nil
+}
+
+// GetStorage returns a storage value of an account
+func (f *DebugFacade) GetStorage(request GetStorageRequest) (*GetStorageResponse, error) {
+	log.Debug("Debugf.GetStorage()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := world.getStorage(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, nil
+}
+
+// ListESDT lists the ESDT tokens held by an account, together with its ESDT roles
+func (f *DebugFacade) ListESDT(request AccountRequest) (*ListESDTResponse, error) {
+	log.Debug("Debugf.ListESDT()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := world.listESDT(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, nil
+}
+
+// GetHistory lists the recorded actions performed on a world
+func (f *DebugFacade) GetHistory(request HistoryRequest) (*HistoryResponse, error) {
+	log.Debug("Debugf.GetHistory()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	history, err := database.loadHistory(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HistoryResponse{
+		World: request.World,
+		Steps: make([]*HistoryStep, len(history)),
+	}
+	for i, entry := range history {
+		response.Steps[i] = entry.toHistoryStep()
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, nil
+}
+
+// RevertWorld restores a world to its state right after the given step; the
+// revert is appended to the history, thus the later steps are kept and the
+// revert can itself be reverted; step 0 stands for the world before its
+// history started
+func (f *DebugFacade) RevertWorld(request RevertWorldRequest) (*RevertWorldResponse, error) {
+	log.Debug("Debugf.RevertWorld()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	dataModel, err := database.loadWorldDataModelAtStep(request.World, request.Step)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeWorldDataModel(dataModel)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.appendHistoryWithDataModel(dataModel, newRevertHistoryEntry(request))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevertWorldResponse{
+		World: request.World,
+		Step:  request.Step,
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, nil
+}
+
+// ForkWorld creates a new world from the state of a world right after the
+// given step, inheriting its history up to that step
+func (f *DebugFacade) ForkWorld(request ForkWorldRequest) (*ForkWorldResponse, error) {
+	log.Debug("Debugf.ForkWorld()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	if database.worldExists(request.NewWorld) {
+		return nil, ErrWorldAlreadyExists
+	}
+
+	dataModel, err := database.loadWorldDataModelAtStep(request.World, request.Step)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.forkHistory(request.World, request.Step, request.NewWorld)
+	if err != nil {
+		return nil, err
+	}
+
+	dataModel.ID = request.NewWorld
+	err = database.storeWorldDataModel(dataModel)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ForkWorldResponse{
+		World: request.NewWorld,
+		Step:  request.Step,
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, nil
+}
+
+// ExportScenario converts the history of a world into a mandos scenario,
+// which replays the recorded actions and checks their results
+func (f *DebugFacade) ExportScenario(request ExportScenarioRequest) (*ExportScenarioResponse, error) {
+	log.Debug("Debugf.ExportScenario()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	history, err := database.loadHistory(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(history) == 0 {
+		return nil, ErrEmptyHistory
+	}
+
+	scenario := newScenarioExporter(request.ScenarioPath).export(request.World, history)
+	err = writeScenario(scenario, request.ScenarioPath)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportScenarioResponse{
+		ScenarioPath: request.ScenarioPath,
+		NumSteps:     len(scenario.Steps),
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, nil
+}
+
+// StartDebugSession executes a smart contract function until it hits a breakpoint or finishes
+func (f *DebugFacade) StartDebugSession(request DebugRunRequest) (*DebugState, error) {
+	log.Debug("Debugf.StartDebugSession()")
+
+	f.mutSession.Lock()
+	defer f.mutSession.Unlock()
+
+	if f.session != nil {
+		return nil, ErrDebugSessionInProgress
+	}
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	session, err := newDebugSession(database, request)
+	if err != nil {
+		return nil, err
+	}
+
+	session.start()
+	return f.handleDebugState(session)
+}
+
+// ContinueDebugSession resumes the paused execution until the next breakpoint
+func (f *DebugFacade) ContinueDebugSession() (*DebugState, error) {
+	log.Debug("Debugf.ContinueDebugSession()")
+	return f.resumeDebugSession(actionContinue)
+}
+
+// StepDebugSession resumes the paused execution until the next EI function call
+func (f *DebugFacade) StepDebugSession() (*DebugState, error) {
+	log.Debug("Debugf.StepDebugSession()")
+	return f.resumeDebugSession(actionStep)
+}
+
+// AbortDebugSession makes the paused execution fail; a paused execution left
+// without commands for longer than the paused session timeout is aborted as well
+func (f *DebugFacade) AbortDebugSession() (*DebugState, error) {
+	log.Debug("Debugf.AbortDebugSession()")
+	return f.resumeDebugSession(actionAbort)
+}
+
+func (f *DebugFacade) resumeDebugSession(action debugAction) (*DebugState, error) {
+	f.mutSession.Lock()
+	defer f.mutSession.Unlock()
+
+	if f.session == nil {
+		return nil, ErrNoDebugSession
+	}
+
+	err := f.session.resume(action)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.handleDebugState(f.session)
+}
+
+func (f *DebugFacade) handleDebugState(session *debugSession) (*DebugState, error) {
+	if !session.isFinished() {
+		f.session = session
+		f.restartPausedSessionTimeout(session)
+		return session.state, nil
+	}
+
+	session.stopTimeout()
+	f.session = nil
+	err := session.finish()
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(session.state.Result)
+	return session.state, nil
+}
+
+// restartPausedSessionTimeout schedules the abortion of the paused session,
+// replacing the previously scheduled one
+func (f *DebugFacade) restartPausedSessionTimeout(session *debugSession) {
+	session.stopTimeout()
+	session.timeoutID++
+
+	timeoutID := session.timeoutID
+	session.timeout = time.AfterFunc(f.pausedSessionTimeout, func() {
+		f.abortIdleDebugSession(session, timeoutID)
+	})
+}
+
+// abortIdleDebugSession aborts the session, unless it received a command
+// since the timeout was scheduled
+func (f *DebugFacade) abortIdleDebugSession(session *debugSession, timeoutID int) {
+	f.mutSession.Lock()
+	defer f.mutSession.Unlock()
+
+	if f.session != session || session.timeoutID != timeoutID {
+		return
+	}
+
+	log.Debug("Debugf.abortIdleDebugSession()", "timeout", f.pausedSessionTimeout)
+	err := session.resume(actionAbort)
+	if err != nil {
+		log.Error("abortIdleDebugSession", "err", err)
+		return
+	}
+
+	_, err = f.handleDebugState(session)
+	if err != nil {
+		log.Error("abortIdleDebugSession", "err", err)
+	}
+}
+
+// GetDebugCallStack returns the call stack of the paused execution
+func (f *DebugFacade) GetDebugCallStack() ([]*CallFrame, error) {
+	log.Debug("Debugf.GetDebugCallStack()")
+
+	f.mutSession.Lock()
+	defer f.mutSession.Unlock()
+
+	if f.session == nil {
+		return nil, ErrNoDebugSession
+	}
+
+	f.restartPausedSessionTimeout(f.session)
+	return f.session.state.CallStack, nil
+}
+
+// InspectMemory reads the linear memory of the contract instance of the paused execution
+func (f *DebugFacade) InspectMemory(request DebugMemoryRequest) (*DebugMemoryResponse, error) {
+	log.Debug("Debugf.InspectMemory()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := f.inspectDebugSession(func(host arwen.VMHost) (interface{}, error) {
+		return host.Runtime().MemLoad(request.Offset, request.Length)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DebugMemoryResponse{
+		Offset:  request.Offset,
+		DataHex: toHex(data.([]byte)),
+	}, nil
+}
+
+// InspectHandle reads a managed big int or buffer of the paused execution
+func (f *DebugFacade) InspectHandle(request DebugHandleRequest) (*DebugHandleResponse, error) {
+	log.Debug("Debugf.InspectHandle()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DebugHandleResponse{
+		Handle: request.Handle,
+		Type:   request.Type,
+	}
+
+	_, err = f.inspectDebugSession(func(host arwen.VMHost) (interface{}, error) {
+		managedTypes := host.ManagedTypes()
+		if request.Type == HandleTypeBigInt {
+			value, err := managedTypes.GetBigInt(request.Handle)
+			if err != nil {
+				return nil, err
+			}
+
+			response.DataHex = toHex(value.Bytes())
+			response.Value = value.String()
+			return nil, nil
+		}
+
+		data, err := managedTypes.GetBytes(request.Handle)
+		if err != nil {
+			return nil, err
+		}
+
+		response.DataHex = toHex(data)
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *DebugFacade) inspectDebugSession(inspectFunc func(host arwen.VMHost) (interface{}, error)) (interface{}, error) {
+	f.mutSession.Lock()
+	defer f.mutSession.Unlock()
+
+	if f.session == nil {
+		return nil, ErrNoDebugSession
+	}
+
+	f.restartPausedSessionTimeout(f.session)
+
+	host := f.session.world.vm
+	return f.session.inspect(func() (interface{}, error) {
+		return inspectFunc(host)
+	})
+}
+
 func dumpOutcome(outcome interface{}) {
 	data, err := json.MarshalIndent(outcome, "", "\t")
 	if err != nil {
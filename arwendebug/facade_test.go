@@ -104,3 +104,147 @@ func TestFacade_UpgradeContract_CounterToERC20(t *testing.T) {
 	require.Equal(t, int64(90), balanceOfAlice)
 	require.Equal(t, int64(10), balanceOfBob)
 }
+
+func TestFacade_InspectWorld(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+
+	world := context.loadWorld()
+	account := world.blockchainHook.AcctMap.GetAccount(alice.raw)
+	account.Storage["counter"] = []byte{7}
+	require.Nil(t, account.SetTokenBalanceUint64([]byte("TOKEN-abcdef"), 0, 100))
+	require.Nil(t, newDatabase(databasePath, testWASMBackend).storeWorld(world))
+
+	worlds, err := context.facade.ListWorlds(ListWorldsRequest{RequestBase: context.createRequestBase()})
+	require.Nil(t, err)
+	require.Contains(t, worlds.Worlds, context.worldID)
+
+	accountRequest := AccountRequest{RequestBase: context.createRequestBase(), AddressHex: alice.hex}
+	accountResponse, err := context.facade.GetAccount(accountRequest)
+	require.Nil(t, err)
+	require.Equal(t, "42", accountResponse.Balance)
+	require.False(t, accountResponse.IsSmartContract)
+
+	keys, err := context.facade.ListStorageKeys(accountRequest)
+	require.Nil(t, err)
+	require.Contains(t, keys.KeysHex, toHex([]byte("counter")))
+
+	storage, err := context.facade.GetStorage(GetStorageRequest{AccountRequest: accountRequest, KeyHex: toHex([]byte("counter"))})
+	require.Nil(t, err)
+	require.Equal(t, "07", storage.ValueHex)
+
+	esdt, err := context.facade.ListESDT(accountRequest)
+	require.Nil(t, err)
+	require.Len(t, esdt.Holdings, 1)
+	require.Equal(t, "TOKEN-abcdef", esdt.Holdings[0].TokenIdentifier)
+	require.Equal(t, "100", esdt.Holdings[0].Balance)
+
+	outcomes, err := context.facade.ListOutcomes(ListOutcomesRequest{RequestBase: context.createRequestBase()})
+	require.Nil(t, err)
+	require.NotEmpty(t, outcomes.Outcomes)
+
+	accountRequest.AddressHex = newDummyAddress("bob").hex
+	_, err = context.facade.GetAccount(accountRequest)
+	require.Equal(t, ErrAccountDoesntExist, err)
+}
+
+func TestFacade_HistoryRevertAndFork(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	bob := newDummyAddress("bob")
+	carol := newDummyAddress("carol")
+	context.createAccount(alice.hex, "42")
+	context.createAccount(bob.hex, "43")
+	context.createAccount(carol.hex, "44")
+
+	history, err := context.facade.GetHistory(HistoryRequest{RequestBase: context.createRequestBase()})
+	require.Nil(t, err)
+	require.Len(t, history.Steps, 3)
+	require.Equal(t, 2, history.Steps[1].Step)
+	require.Equal(t, HistoryActionCreateAccount, history.Steps[1].Action)
+	require.Equal(t, bob.hex, history.Steps[1].AddressHex)
+
+	forkedWorld := context.worldID + "_fork"
+	forkResponse, err := context.facade.ForkWorld(ForkWorldRequest{RequestBase: context.createRequestBase(), Step: 1, NewWorld: forkedWorld})
+	require.Nil(t, err)
+	require.Equal(t, forkedWorld, forkResponse.World)
+
+	_, err = context.facade.ForkWorld(ForkWorldRequest{RequestBase: context.createRequestBase(), Step: 1, NewWorld: forkedWorld})
+	require.Equal(t, ErrWorldAlreadyExists, err)
+
+	_, err = context.facade.RevertWorld(RevertWorldRequest{RequestBase: context.createRequestBase(), Step: 4})
+	require.Equal(t, ErrInvalidHistoryStep, err)
+
+	_, err = context.facade.RevertWorld(RevertWorldRequest{RequestBase: context.createRequestBase(), Step: 2})
+	require.Nil(t, err)
+	require.True(t, context.accountExists(bob.raw))
+	require.False(t, context.accountExists(carol.raw))
+
+	history, err = context.facade.GetHistory(HistoryRequest{RequestBase: context.createRequestBase()})
+	require.Nil(t, err)
+	require.Len(t, history.Steps, 4)
+	require.Equal(t, HistoryActionRevert, history.Steps[3].Action)
+	require.Equal(t, 2, history.Steps[3].RevertedToStep)
+
+	// the revert is undone by reverting to the step right before it
+	_, err = context.facade.RevertWorld(RevertWorldRequest{RequestBase: context.createRequestBase(), Step: 3})
+	require.Nil(t, err)
+	require.True(t, context.accountExists(carol.raw))
+
+	// the reverted steps can still be forked from
+	forkedAfterRevert := context.worldID + "_forkAfterRevert"
+	_, err = context.facade.ForkWorld(ForkWorldRequest{RequestBase: context.createRequestBase(), Step: 4, NewWorld: forkedAfterRevert})
+	require.Nil(t, err)
+	context.worldID = forkedAfterRevert
+	require.True(t, context.accountExists(bob.raw))
+	require.False(t, context.accountExists(carol.raw))
+
+	context.worldID = forkedWorld
+	require.True(t, context.accountExists(alice.raw))
+	require.False(t, context.accountExists(bob.raw))
+
+	context.createAccount(carol.hex, "44")
+	history, err = context.facade.GetHistory(HistoryRequest{RequestBase: context.createRequestBase()})
+	require.Nil(t, err)
+	require.Len(t, history.Steps, 2)
+	require.Equal(t, carol.hex, history.Steps[1].AddressHex)
+}
+
+func TestFacade_HistoryRevertToInitialWorld(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	bob := newDummyAddress("bob")
+
+	// the world is stored before its history starts
+	world := context.loadWorld()
+	world.blockchainHook.AcctMap.CreateAccount(alice.raw, world.blockchainHook)
+	require.Nil(t, newDatabase(databasePath, testWASMBackend).storeWorld(world))
+
+	context.createAccount(bob.hex, "42")
+
+	history, err := context.facade.GetHistory(HistoryRequest{RequestBase: context.createRequestBase()})
+	require.Nil(t, err)
+	require.Len(t, history.Steps, 2)
+	require.Equal(t, 0, history.Steps[0].Step)
+	require.Equal(t, HistoryActionInitial, history.Steps[0].Action)
+
+	_, err = context.facade.RevertWorld(RevertWorldRequest{RequestBase: context.createRequestBase(), Step: 0})
+	require.Nil(t, err)
+	require.True(t, context.accountExists(alice.raw))
+	require.False(t, context.accountExists(bob.raw))
+
+	forkedWorld := context.worldID + "_fork"
+	_, err = context.facade.ForkWorld(ForkWorldRequest{RequestBase: context.createRequestBase(), Step: 0, NewWorld: forkedWorld})
+	require.Nil(t, err)
+	context.worldID = forkedWorld
+	require.True(t, context.accountExists(alice.raw))
+
+	history, err = context.facade.GetHistory(HistoryRequest{RequestBase: context.createRequestBase()})
+	require.Nil(t, err)
+	require.Len(t, history.Steps, 1)
+	require.Equal(t, HistoryActionInitial, history.Steps[0].Action)
+}
@@ -0,0 +1,137 @@
+package arwendebug
+
+import (
+	"github.com/ElrondNetwork/elrond-vm-common"
+)
+
+const (
+	// HistoryActionInitial marks the snapshot of a world which already existed
+	// when its history started to be recorded; it is always step 0
+	HistoryActionInitial = "initial"
+
+	// HistoryActionCreateAccount marks the creation of an account
+	HistoryActionCreateAccount = "createAccount"
+
+	// HistoryActionDeploy marks the deployment of a contract
+	HistoryActionDeploy = "deploy"
+
+	// HistoryActionUpgrade marks the upgrade of a contract
+	HistoryActionUpgrade = "upgrade"
+
+	// HistoryActionRun marks the execution of a contract function
+	HistoryActionRun = "run"
+
+	// HistoryActionQuery marks the query of a contract function, which leaves the world unchanged
+	HistoryActionQuery = "query"
+
+	// HistoryActionRevert marks the restoration of a world to its state right after an earlier step
+	HistoryActionRevert = "revert"
+)
+
+// historyEntry is the append-only record of an action performed on a world,
+// holding the request, its result and the snapshot of the world right after
+type historyEntry struct {
+	Step               int
+	Action             string
+	Outcome            string
+	CreateAccount      *CreateAccountRequest `json:",omitempty"`
+	Deploy             *DeployRequest        `json:",omitempty"`
+	Upgrade            *UpgradeRequest       `json:",omitempty"`
+	Run                *RunRequest           `json:",omitempty"`
+	Query              *QueryRequest         `json:",omitempty"`
+	ContractAddressHex string
+	RevertedToStep     int `json:",omitempty"`
+	Output             *vmcommon.VMOutput
+	Error              string
+	World              *worldDataModel
+}
+
+func newCreateAccountHistoryEntry(request CreateAccountRequest) *historyEntry {
+	return &historyEntry{
+		Action:        HistoryActionCreateAccount,
+		Outcome:       request.Outcome,
+		CreateAccount: &request,
+	}
+}
+
+func newRevertHistoryEntry(request RevertWorldRequest) *historyEntry {
+	return &historyEntry{
+		Action:         HistoryActionRevert,
+		Outcome:        request.Outcome,
+		RevertedToStep: request.Step,
+	}
+}
+
+func newDeployHistoryEntry(request DeployRequest, response *DeployResponse) *historyEntry {
+	entry := newContractHistoryEntry(HistoryActionDeploy, request.Outcome, response.ContractResponseBase)
+	entry.Deploy = &request
+	entry.ContractAddressHex = response.ContractAddressHex
+	return entry
+}
+
+func newUpgradeHistoryEntry(request UpgradeRequest, response *UpgradeResponse) *historyEntry {
+	entry := newContractHistoryEntry(HistoryActionUpgrade, request.Outcome, response.ContractResponseBase)
+	entry.Upgrade = &request
+	entry.ContractAddressHex = request.ContractAddressHex
+	return entry
+}
+
+func newRunHistoryEntry(request RunRequest, response *RunResponse) *historyEntry {
+	entry := newContractHistoryEntry(HistoryActionRun, request.Outcome, response.ContractResponseBase)
+	entry.Run = &request
+	entry.ContractAddressHex = request.ContractAddressHex
+	return entry
+}
+
+func newQueryHistoryEntry(request QueryRequest, response *QueryResponse) *historyEntry {
+	entry := newContractHistoryEntry(HistoryActionQuery, request.Outcome, response.ContractResponseBase)
+	entry.Query = &request
+	entry.ContractAddressHex = request.ContractAddressHex
+	return entry
+}
+
+func newContractHistoryEntry(action string, outcome string, response ContractResponseBase) *historyEntry {
+	entry := &historyEntry{
+		Action:  action,
+		Outcome: outcome,
+		Output:  response.Output,
+	}
+
+	if response.Error != nil {
+		entry.Error = response.Error.Error()
+	}
+
+	return entry
+}
+
+func (entry *historyEntry) toHistoryStep() *HistoryStep {
+	step := &HistoryStep{
+		Step:               entry.Step,
+		Action:             entry.Action,
+		Outcome:            entry.Outcome,
+		ContractAddressHex: entry.ContractAddressHex,
+		RevertedToStep:     entry.RevertedToStep,
+		Error:              entry.Error,
+	}
+
+	switch entry.Action {
+	case HistoryActionCreateAccount:
+		step.AddressHex = entry.CreateAccount.AddressHex
+	case HistoryActionDeploy:
+		step.AddressHex = entry.Deploy.ImpersonatedHex
+	case HistoryActionUpgrade:
+		step.AddressHex = entry.Upgrade.ImpersonatedHex
+	case HistoryActionRun:
+		step.AddressHex = entry.Run.ImpersonatedHex
+		step.Function = entry.Run.Function
+	case HistoryActionQuery:
+		step.AddressHex = entry.Query.ImpersonatedHex
+		step.Function = entry.Query.Function
+	}
+
+	if entry.Output != nil {
+		step.ReturnCode = entry.Output.ReturnCode.String()
+	}
+
+	return step
+}
@@ -0,0 +1,110 @@
+package arwendebug
+
+// Breakpoints holds the conditions on which a debugged execution pauses
+type Breakpoints struct {
+	Functions            []string
+	ContractAddressesHex []string
+	ContractAddresses    [][]byte
+	GasLeftBelow         uint64
+}
+
+func (breakpoints *Breakpoints) digest() error {
+	var err error
+
+	breakpoints.ContractAddresses = make([][]byte, len(breakpoints.ContractAddressesHex))
+	for i, addressHex := range breakpoints.ContractAddressesHex {
+		breakpoints.ContractAddresses[i], err = fromHex(addressHex)
+		if err != nil {
+			return NewRequestErrorMessageInner("invalid breakpoint address", err)
+		}
+	}
+
+	return nil
+}
+
+// DebugRunRequest is a REST request message
+type DebugRunRequest struct {
+	RunRequest
+	Breakpoints Breakpoints
+}
+
+func (request *DebugRunRequest) digest() error {
+	err := request.RunRequest.digest()
+	if err != nil {
+		return err
+	}
+
+	return request.Breakpoints.digest()
+}
+
+// DebugMemoryRequest is a REST request message
+type DebugMemoryRequest struct {
+	Offset int32
+	Length int32
+}
+
+func (request *DebugMemoryRequest) digest() error {
+	if request.Offset < 0 || request.Length < 0 {
+		return NewRequestError("invalid memory range")
+	}
+
+	return nil
+}
+
+// DebugHandleRequest is a REST request message
+type DebugHandleRequest struct {
+	Handle int32
+	Type   string
+}
+
+func (request *DebugHandleRequest) digest() error {
+	if request.Type != HandleTypeBigInt && request.Type != HandleTypeBuffer {
+		return ErrInvalidHandleType
+	}
+
+	return nil
+}
+
+const (
+	// HandleTypeBigInt selects the managed big ints
+	HandleTypeBigInt = "bigInt"
+
+	// HandleTypeBuffer selects the managed buffers
+	HandleTypeBuffer = "buffer"
+)
+
+// CallFrame describes a contract call on the call stack of a debugged execution
+type CallFrame struct {
+	CallerAddressHex   string
+	ContractAddressHex string
+	Function           string
+	GasProvided        uint64
+	SameContext        bool
+}
+
+// DebugState is a REST response message, describing where a debugged
+// execution paused or, once finished, its outcome
+type DebugState struct {
+	Paused             bool
+	Finished           bool
+	Reason             string
+	ContractAddressHex string
+	Function           string
+	GasLeft            uint64
+	CallStack          []*CallFrame
+	Result             *RunResponse
+}
+
+// DebugMemoryResponse is a REST response message
+type DebugMemoryResponse struct {
+	Offset  int32
+	DataHex string
+}
+
+// DebugHandleResponse is a REST response message
+type DebugHandleResponse struct {
+	Handle  int32
+	Type    string
+	DataHex string
+	Value   string
+}
@@ -0,0 +1,109 @@
+package arwendebug
+
+// HistoryRequest is a CLI / REST request message
+type HistoryRequest struct {
+	RequestBase
+}
+
+// HistoryResponse is a CLI / REST response message
+type HistoryResponse struct {
+	World string
+	Steps []*HistoryStep
+}
+
+// HistoryStep describes a recorded action performed on a world
+type HistoryStep struct {
+	Step               int
+	Action             string
+	Outcome            string
+	AddressHex         string
+	ContractAddressHex string
+	RevertedToStep     int
+	Function           string
+	ReturnCode         string
+	Error              string
+}
+
+// RevertWorldRequest is a CLI / REST request message
+type RevertWorldRequest struct {
+	RequestBase
+	Step int
+}
+
+func (request *RevertWorldRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	if request.Step < 0 {
+		return ErrInvalidHistoryStep
+	}
+
+	return nil
+}
+
+// RevertWorldResponse is a CLI / REST response message
+type RevertWorldResponse struct {
+	World string
+	Step  int
+}
+
+// ForkWorldRequest is a CLI / REST request message
+type ForkWorldRequest struct {
+	RequestBase
+	Step     int
+	NewWorld string
+}
+
+func (request *ForkWorldRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	if request.Step < 0 {
+		return ErrInvalidHistoryStep
+	}
+
+	if len(request.NewWorld) == 0 {
+		return NewRequestError("empty new world")
+	}
+
+	if request.NewWorld == request.World {
+		return ErrWorldAlreadyExists
+	}
+
+	return nil
+}
+
+// ForkWorldResponse is a CLI / REST response message
+type ForkWorldResponse struct {
+	World string
+	Step  int
+}
+
+// ExportScenarioRequest is a CLI / REST request message
+type ExportScenarioRequest struct {
+	RequestBase
+	ScenarioPath string
+}
+
+func (request *ExportScenarioRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	if len(request.ScenarioPath) == 0 {
+		return NewRequestError("empty scenario path")
+	}
+
+	return nil
+}
+
+// ExportScenarioResponse is a CLI / REST response message
+type ExportScenarioResponse struct {
+	ScenarioPath string
+	NumSteps     int
+}
@@ -0,0 +1,79 @@
+package arwendebug
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+)
+
+// The messages of the proxy mode mirror the JSON format of the Elrond proxy,
+// so that the usual clients can talk to the simulated chain.
+
+// ProxyResponse is the envelope of all the proxy responses
+type ProxyResponse struct {
+	Data  interface{} `json:"data"`
+	Error string      `json:"error"`
+	Code  string      `json:"code"`
+}
+
+// ProxyAccount is a proxy response message
+type ProxyAccount struct {
+	Address         string `json:"address"`
+	Nonce           uint64 `json:"nonce"`
+	Balance         string `json:"balance"`
+	Username        string `json:"username"`
+	Code            string `json:"code"`
+	CodeHash        []byte `json:"codeHash"`
+	RootHash        []byte `json:"rootHash"`
+	CodeMetadata    []byte `json:"codeMetadata"`
+	DeveloperReward string `json:"developerReward"`
+	OwnerAddress    string `json:"ownerAddress"`
+}
+
+// ProxyQueryRequest is a proxy request message
+type ProxyQueryRequest struct {
+	ScAddress string   `json:"scAddress"`
+	FuncName  string   `json:"funcName"`
+	Caller    string   `json:"caller"`
+	Value     string   `json:"value"`
+	Args      []string `json:"args"`
+}
+
+// ProxyVMOutput is a proxy response message
+type ProxyVMOutput struct {
+	ReturnData    [][]byte              `json:"returnData"`
+	ReturnCode    string                `json:"returnCode"`
+	ReturnMessage string                `json:"returnMessage"`
+	GasRemaining  uint64                `json:"gasRemaining"`
+	GasRefund     *big.Int              `json:"gasRefund"`
+	Logs          []*transaction.Events `json:"logs"`
+}
+
+// ProxySimulationResult is a proxy response message
+type ProxySimulationResult struct {
+	Status     transaction.TxStatus                           `json:"status"`
+	FailReason string                                         `json:"failReason,omitempty"`
+	ScResults  map[string]*transaction.ApiSmartContractResult `json:"scResults,omitempty"`
+	Logs       *transaction.ApiLogs                           `json:"logs,omitempty"`
+	Hash       string                                         `json:"hash"`
+}
+
+// ProxyNetworkConfig is a proxy response message
+type ProxyNetworkConfig struct {
+	ChainID                  string `json:"erd_chain_id"`
+	Denomination             int    `json:"erd_denomination"`
+	GasPerDataByte           uint64 `json:"erd_gas_per_data_byte"`
+	GasPriceModifier         string `json:"erd_gas_price_modifier"`
+	LatestTagSoftwareVersion string `json:"erd_latest_tag_software_version"`
+	MetaConsensusGroupSize   int    `json:"erd_meta_consensus_group_size"`
+	MinGasLimit              uint64 `json:"erd_min_gas_limit"`
+	MinGasPrice              uint64 `json:"erd_min_gas_price"`
+	MinTransactionVersion    uint32 `json:"erd_min_transaction_version"`
+	MaxGasPerTransaction     uint64 `json:"erd_max_gas_per_transaction"`
+	NumMetachainNodes        int    `json:"erd_num_metachain_nodes"`
+	NumNodesInShard          int    `json:"erd_num_nodes_in_shard"`
+	NumShardsWithoutMeta     int    `json:"erd_num_shards_without_meta"`
+	RoundDuration            int64  `json:"erd_round_duration"`
+	ShardConsensusGroupSize  int    `json:"erd_shard_consensus_group_size"`
+	StartTime                int64  `json:"erd_start_time"`
+}
@@ -0,0 +1,102 @@
+package arwendebug
+
+// ListWorldsRequest is a CLI / REST request message
+type ListWorldsRequest struct {
+	RequestBase
+}
+
+// ListWorldsResponse is a CLI / REST response message
+type ListWorldsResponse struct {
+	Worlds []string
+}
+
+// ListOutcomesRequest is a CLI / REST request message
+type ListOutcomesRequest struct {
+	RequestBase
+}
+
+// ListOutcomesResponse is a CLI / REST response message
+type ListOutcomesResponse struct {
+	Outcomes []string
+}
+
+// AccountRequest is a CLI / REST request message
+type AccountRequest struct {
+	RequestBase
+	AddressHex string
+	Address    []byte
+}
+
+func (request *AccountRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	if len(request.AddressHex) == 0 {
+		return NewRequestError("empty account address")
+	}
+
+	request.Address, err = fromHex(request.AddressHex)
+	if err != nil {
+		return NewRequestErrorMessageInner("invalid account address", err)
+	}
+
+	return nil
+}
+
+// GetAccountResponse is a CLI / REST response message
+type GetAccountResponse struct {
+	AddressHex      string
+	Balance         string
+	Nonce           uint64
+	CodeHashHex     string
+	CodeMetadataHex string
+	OwnerAddressHex string
+	IsSmartContract bool
+}
+
+// ListStorageKeysResponse is a CLI / REST response message
+type ListStorageKeysResponse struct {
+	KeysHex []string
+}
+
+// GetStorageRequest is a CLI / REST request message
+type GetStorageRequest struct {
+	AccountRequest
+	KeyHex string
+	Key    []byte
+}
+
+func (request *GetStorageRequest) digest() error {
+	err := request.AccountRequest.digest()
+	if err != nil {
+		return err
+	}
+
+	request.Key, err = fromHex(request.KeyHex)
+	if err != nil {
+		return NewRequestErrorMessageInner("invalid storage key", err)
+	}
+
+	return nil
+}
+
+// GetStorageResponse is a CLI / REST response message
+type GetStorageResponse struct {
+	KeyHex   string
+	ValueHex string
+}
+
+// ESDTHolding describes the balance of an ESDT token instance held by an account
+type ESDTHolding struct {
+	TokenIdentifier string
+	Nonce           uint64
+	Balance         string
+}
+
+// ListESDTResponse is a CLI / REST response message
+type ListESDTResponse struct {
+	Holdings []*ESDTHolding
+	Roles    map[string][]string
+}
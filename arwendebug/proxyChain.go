@@ -0,0 +1,656 @@
+package arwendebug
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/hashing"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/core/pubkeyConverter"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+)
+
+const proxyAddressLength = 32
+
+// numInitCharactersForScAddress is the number of leading bytes of a contract
+// address, which hold zeros followed by the VM type
+const numInitCharactersForScAddress = 10
+
+const proxyTransactionType = "normal"
+
+// proxyDeployReceiver is the receiver of the transactions deploying contracts
+var proxyDeployReceiver = make([]byte, proxyAddressLength)
+
+func newProxyNetworkConfig(chainID string) *ProxyNetworkConfig {
+	return &ProxyNetworkConfig{
+		ChainID:                  chainID,
+		Denomination:             18,
+		GasPerDataByte:           1500,
+		GasPriceModifier:         "1",
+		LatestTagSoftwareVersion: "arwendebug",
+		MetaConsensusGroupSize:   1,
+		MinGasLimit:              50000,
+		MinGasPrice:              1000000000,
+		MinTransactionVersion:    1,
+		MaxGasPerTransaction:     600000000,
+		NumMetachainNodes:        1,
+		NumNodesInShard:          1,
+		NumShardsWithoutMeta:     1,
+		RoundDuration:            6000,
+		ShardConsensusGroupSize:  1,
+		StartTime:                time.Now().Unix(),
+	}
+}
+
+// simulatedChain is a single shard chain, simulated on top of a debugging
+// world. Each transaction is executed as soon as it is sent, in a block of its
+// own, and the world is stored after each block. The signatures are not
+// verified and the built-in functions (e.g. ESDT transfers) are not available.
+type simulatedChain struct {
+	mutex           sync.Mutex
+	database        *database
+	world           *world
+	config          *ProxyNetworkConfig
+	pubkeyConverter core.PubkeyConverter
+	hasher          crypto.Hasher
+	marshalizer     marshal.Marshalizer
+	blockNonce      uint64
+	transactions    map[string]*transaction.ApiTransactionResult
+}
+
+// simulatedTransaction is a transaction which passed the validation, along
+// with its decoded fields
+type simulatedTransaction struct {
+	source     *transaction.FrontendTransaction
+	hash       string
+	sender     []byte
+	receiver   []byte
+	value      *big.Int
+	deployArgs *parsers.DeployArgs
+	function   string
+	arguments  [][]byte
+}
+
+// newSimulatedChain creates a chain on top of a world of the database, whose
+// contracts are executed with the given WASM backend
+func newSimulatedChain(databasePath string, worldID string, chainID string, wasmBackend arwen.WASMBackend) (*simulatedChain, error) {
+	base := RequestBase{DatabasePath: databasePath, World: worldID}
+	err := base.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chainID) == 0 {
+		chainID = DefaultChainID
+	}
+
+	database := newDatabase(base.DatabasePath, wasmBackend)
+	world, err := database.loadWorld(base.World)
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := pubkeyConverter.NewBech32PubkeyConverter(proxyAddressLength, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &simulatedChain{
+		database:        database,
+		world:           world,
+		config:          newProxyNetworkConfig(chainID),
+		pubkeyConverter: converter,
+		hasher:          hashing.NewHasher(),
+		marshalizer:     &marshal.GogoProtoMarshalizer{},
+		transactions:    make(map[string]*transaction.ApiTransactionResult),
+	}, nil
+}
+
+func (chain *simulatedChain) getNetworkConfig() *ProxyNetworkConfig {
+	return chain.config
+}
+
+func (chain *simulatedChain) getAccount(bech32Address string) (*ProxyAccount, error) {
+	address, err := chain.decodeAddress(bech32Address)
+	if err != nil {
+		return nil, err
+	}
+
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	response := &ProxyAccount{
+		Address:         bech32Address,
+		Balance:         "0",
+		DeveloperReward: "0",
+	}
+
+	account := chain.world.blockchainHook.AcctMap.GetAccount(address)
+	if account == nil {
+		return response, nil
+	}
+
+	response.Nonce = account.Nonce
+	response.Balance = account.Balance.String()
+	response.Username = string(account.Username)
+	response.Code = toHex(account.Code)
+	response.CodeHash = account.CodeHash
+	response.RootHash = account.RootHash
+	response.CodeMetadata = account.CodeMetadata
+	if account.DeveloperReward != nil {
+		response.DeveloperReward = account.DeveloperReward.String()
+	}
+	if len(account.OwnerAddress) > 0 {
+		response.OwnerAddress = chain.pubkeyConverter.Encode(account.OwnerAddress)
+	}
+
+	return response, nil
+}
+
+func (chain *simulatedChain) getStorage(bech32Address string, keyHex string) (string, error) {
+	address, err := chain.decodeAddress(bech32Address)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := fromHex(keyHex)
+	if err != nil {
+		return "", NewRequestErrorMessageInner("invalid storage key", err)
+	}
+
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	account := chain.world.blockchainHook.AcctMap.GetAccount(address)
+	if account == nil {
+		return "", nil
+	}
+
+	return toHex(account.StorageValue(string(key))), nil
+}
+
+func (chain *simulatedChain) query(request *ProxyQueryRequest) (*ProxyVMOutput, error) {
+	contractAddress, err := chain.decodeAddress(request.ScAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	caller := contractAddress
+	if len(request.Caller) > 0 {
+		caller, err = chain.decodeAddress(request.Caller)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	value, err := parseValue(request.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	arguments, err := decodeArguments(request.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	queryRequest := QueryRequest{}
+	queryRequest.Impersonated = caller
+	queryRequest.ValueAsBigInt = value
+	queryRequest.GasLimit = chain.config.MaxGasPerTransaction
+	queryRequest.GasPrice = chain.config.MinGasPrice
+	queryRequest.ContractAddress = contractAddress
+	queryRequest.Function = request.FuncName
+	queryRequest.Arguments = arguments
+
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	response := chain.world.querySmartContract(queryRequest)
+	if response.Error != nil {
+		return nil, response.Error
+	}
+
+	output := response.Output
+	return &ProxyVMOutput{
+		ReturnData:    output.ReturnData,
+		ReturnCode:    output.ReturnCode.String(),
+		ReturnMessage: output.ReturnMessage,
+		GasRemaining:  output.GasRemaining,
+		GasRefund:     output.GasRefund,
+		Logs:          chain.convertEvents(output.Logs),
+	}, nil
+}
+
+// sendTransaction executes the transaction in a new block
+func (chain *simulatedChain) sendTransaction(tx *transaction.FrontendTransaction) (string, error) {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	simulated, err := chain.prepareTransaction(chain.world, tx)
+	if err != nil {
+		return "", err
+	}
+
+	chain.blockNonce++
+	result := chain.executeTransaction(chain.world, simulated, chain.blockNonce)
+	chain.transactions[result.Hash] = result
+
+	err = chain.database.storeWorld(chain.world)
+	if err != nil {
+		return "", err
+	}
+
+	return result.Hash, nil
+}
+
+// simulateTransaction executes the transaction on a copy of the world
+func (chain *simulatedChain) simulateTransaction(tx *transaction.FrontendTransaction) (*ProxySimulationResult, error) {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	world, err := newWorld(chain.world.toDataModel(), nil, chain.database.wasmBackend)
+	if err != nil {
+		return nil, err
+	}
+
+	simulated, err := chain.prepareTransaction(world, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := chain.executeTransaction(world, simulated, chain.blockNonce+1)
+
+	scResults := make(map[string]*transaction.ApiSmartContractResult)
+	for _, scResult := range result.SmartContractResults {
+		scResults[scResult.Hash] = scResult
+	}
+
+	return &ProxySimulationResult{
+		Status:     result.Status,
+		FailReason: result.ReturnMessage,
+		ScResults:  scResults,
+		Logs:       result.Logs,
+		Hash:       result.Hash,
+	}, nil
+}
+
+func (chain *simulatedChain) getTransaction(hash string) (*transaction.ApiTransactionResult, error) {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	result, ok := chain.transactions[hash]
+	if !ok {
+		return nil, ErrTransactionNotFound
+	}
+
+	return result, nil
+}
+
+// prepareTransaction decodes and validates the transaction, without changing the world
+func (chain *simulatedChain) prepareTransaction(w *world, tx *transaction.FrontendTransaction) (*simulatedTransaction, error) {
+	sender, err := chain.decodeAddress(tx.Sender)
+	if err != nil {
+		return nil, err
+	}
+
+	receiver, err := chain.decodeAddress(tx.Receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := parseValue(tx.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.ChainID != chain.config.ChainID {
+		return nil, ErrInvalidChainID
+	}
+	if tx.GasPrice < chain.config.MinGasPrice {
+		return nil, ErrInsufficientGasPrice
+	}
+	if tx.GasLimit < chain.computeMinGasLimit(tx) || tx.GasLimit > chain.config.MaxGasPerTransaction {
+		return nil, ErrInvalidGasLimit
+	}
+
+	senderAccount := w.blockchainHook.AcctMap.GetAccount(sender)
+	if senderAccount == nil {
+		return nil, ErrAccountDoesntExist
+	}
+	if tx.Nonce != senderAccount.Nonce {
+		return nil, ErrInvalidTransactionNonce
+	}
+
+	maxCost := big.NewInt(0).Mul(big.NewInt(0).SetUint64(tx.GasLimit), big.NewInt(0).SetUint64(tx.GasPrice))
+	maxCost.Add(maxCost, value)
+	if senderAccount.Balance.Cmp(maxCost) < 0 {
+		return nil, ErrInsufficientFunds
+	}
+
+	hash, err := chain.computeTransactionHash(tx, sender, receiver, value)
+	if err != nil {
+		return nil, err
+	}
+
+	simulated := &simulatedTransaction{
+		source:   tx,
+		hash:     hash,
+		sender:   sender,
+		receiver: receiver,
+		value:    value,
+	}
+
+	if bytes.Equal(receiver, proxyDeployReceiver) {
+		simulated.deployArgs, err = parsers.NewDeployArgsParser().ParseData(string(tx.Data))
+		if err != nil {
+			return nil, NewRequestErrorMessageInner("invalid deploy data", err)
+		}
+	} else if len(tx.Data) > 0 && chain.isSmartContract(w, receiver) {
+		simulated.function, simulated.arguments, err = parsers.NewCallArgsParser().ParseData(string(tx.Data))
+		if err != nil {
+			return nil, NewRequestErrorMessageInner("invalid call data", err)
+		}
+	}
+
+	return simulated, nil
+}
+
+// executeTransaction charges the sender for the gas upfront, executes the
+// transaction, then refunds the gas left
+func (chain *simulatedChain) executeTransaction(w *world, tx *simulatedTransaction, blockNonce uint64) *transaction.ApiTransactionResult {
+	timestamp := time.Now().Unix()
+	w.blockchainHook.CurrentBlockInfo = &worldmock.BlockInfo{
+		BlockTimestamp: uint64(timestamp),
+		BlockNonce:     blockNonce,
+		BlockRound:     blockNonce,
+	}
+
+	gasPrice := big.NewInt(0).SetUint64(tx.source.GasPrice)
+	gasForExecution := tx.source.GasLimit - chain.computeMinGasLimit(tx.source)
+
+	senderAccount := w.blockchainHook.AcctMap.GetAccount(tx.sender)
+	senderAccount.Nonce++
+	senderAccount.Balance.Sub(senderAccount.Balance, big.NewInt(0).Mul(big.NewInt(0).SetUint64(tx.source.GasLimit), gasPrice))
+
+	result := chain.newTransactionResult(tx, blockNonce, timestamp)
+
+	var output *vmcommon.VMOutput
+	var contractAddress []byte
+	switch {
+	case tx.deployArgs != nil:
+		contractAddress, output = chain.deploy(w, tx, gasForExecution)
+	case len(tx.function) > 0:
+		output = chain.call(w, tx, gasForExecution)
+	default:
+		output = chain.transfer(w, tx, gasForExecution)
+	}
+
+	if output.ReturnCode != vmcommon.Ok {
+		result.Status = transaction.TxStatusFail
+		result.ReturnMessage = output.ReturnMessage
+		result.SmartContractResults = chain.createSmartContractResults(tx, output, big.NewInt(0))
+		return result
+	}
+
+	refund := big.NewInt(0).Mul(big.NewInt(0).SetUint64(output.GasRemaining), gasPrice)
+	senderAccount.Balance.Sub(senderAccount.Balance, tx.value)
+	senderAccount.Balance.Add(senderAccount.Balance, refund)
+
+	result.Status = transaction.TxStatusSuccess
+	if tx.deployArgs != nil || len(tx.function) > 0 {
+		result.SmartContractResults = chain.createSmartContractResults(tx, output, refund)
+	}
+
+	events := chain.convertEvents(output.Logs)
+	if len(contractAddress) > 0 {
+		events = append(events, &transaction.Events{
+			Address:    chain.pubkeyConverter.Encode(contractAddress),
+			Identifier: "SCDeploy",
+			Topics:     [][]byte{contractAddress, tx.sender},
+		})
+	}
+	if len(events) > 0 {
+		result.Logs = &transaction.ApiLogs{
+			Address: tx.source.Receiver,
+			Events:  events,
+		}
+	}
+
+	return result
+}
+
+func (chain *simulatedChain) deploy(w *world, tx *simulatedTransaction, gasForExecution uint64) ([]byte, *vmcommon.VMOutput) {
+	contractAddress, err := chain.computeContractAddress(tx.sender, tx.source.Nonce, tx.deployArgs.VMType)
+	if err != nil {
+		return nil, failedOutput(err)
+	}
+
+	w.blockchainHook.NewAddressMocks = []*worldmock.NewAddressMock{
+		{
+			CreatorAddress: tx.sender,
+			CreatorNonce:   tx.source.Nonce,
+			NewAddress:     contractAddress,
+		},
+	}
+
+	request := DeployRequest{}
+	request.Impersonated = tx.sender
+	request.ValueAsBigInt = tx.value
+	request.GasLimit = gasForExecution
+	request.GasPrice = tx.source.GasPrice
+	request.Code = tx.deployArgs.Code
+	request.CodeMetadataBytes = tx.deployArgs.CodeMetadata.ToBytes()
+	request.Arguments = tx.deployArgs.Arguments
+
+	response := w.deploySmartContract(request)
+	if response.Error != nil {
+		return nil, failedOutput(response.Error)
+	}
+
+	return contractAddress, response.Output
+}
+
+func (chain *simulatedChain) call(w *world, tx *simulatedTransaction, gasForExecution uint64) *vmcommon.VMOutput {
+	request := RunRequest{}
+	request.Impersonated = tx.sender
+	request.ValueAsBigInt = tx.value
+	request.GasLimit = gasForExecution
+	request.GasPrice = tx.source.GasPrice
+	request.ContractAddress = tx.receiver
+	request.Function = tx.function
+	request.Arguments = tx.arguments
+
+	response := w.runSmartContract(request)
+	if response.Error != nil {
+		return failedOutput(response.Error)
+	}
+
+	return response.Output
+}
+
+// transfer moves the value to the receiver; the sender is debited afterwards,
+// as for the other transactions
+func (chain *simulatedChain) transfer(w *world, tx *simulatedTransaction, gasForExecution uint64) *vmcommon.VMOutput {
+	receiverAccount := w.blockchainHook.AcctMap.GetAccount(tx.receiver)
+	if receiverAccount == nil {
+		receiverAccount = w.blockchainHook.AcctMap.CreateAccount(tx.receiver, w.blockchainHook)
+	}
+
+	receiverAccount.Balance.Add(receiverAccount.Balance, tx.value)
+
+	return &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: gasForExecution,
+	}
+}
+
+func failedOutput(err error) *vmcommon.VMOutput {
+	return &vmcommon.VMOutput{
+		ReturnCode:    vmcommon.ExecutionFailed,
+		ReturnMessage: err.Error(),
+	}
+}
+
+func (chain *simulatedChain) newTransactionResult(tx *simulatedTransaction, blockNonce uint64, timestamp int64) *transaction.ApiTransactionResult {
+	return &transaction.ApiTransactionResult{
+		Type:       proxyTransactionType,
+		Hash:       tx.hash,
+		Nonce:      tx.source.Nonce,
+		Round:      blockNonce,
+		Value:      tx.value.String(),
+		Receiver:   tx.source.Receiver,
+		Sender:     tx.source.Sender,
+		GasPrice:   tx.source.GasPrice,
+		GasLimit:   tx.source.GasLimit,
+		Data:       tx.source.Data,
+		Signature:  tx.source.Signature,
+		BlockNonce: blockNonce,
+		BlockHash:  chain.computeBlockHash(blockNonce),
+		Timestamp:  timestamp,
+	}
+}
+
+// createSmartContractResults returns the result sent back to the sender, with
+// the data formatted as on the chain: @<return code>@<return data>...
+func (chain *simulatedChain) createSmartContractResults(
+	tx *simulatedTransaction,
+	output *vmcommon.VMOutput,
+	refund *big.Int,
+) []*transaction.ApiSmartContractResult {
+	data := "@" + toHex([]byte(output.ReturnCode.String()))
+	if output.ReturnCode == vmcommon.Ok {
+		for _, returnData := range output.ReturnData {
+			data += "@" + toHex(returnData)
+		}
+	} else {
+		data += "@" + toHex([]byte(output.ReturnMessage))
+	}
+
+	return []*transaction.ApiSmartContractResult{
+		{
+			Hash:           chain.computeHash([]byte(tx.hash + data)),
+			Nonce:          tx.source.Nonce + 1,
+			Value:          refund,
+			RcvAddr:        tx.source.Sender,
+			SndAddr:        tx.source.Receiver,
+			Data:           data,
+			PrevTxHash:     tx.hash,
+			OriginalTxHash: tx.hash,
+			GasPrice:       tx.source.GasPrice,
+			ReturnMessage:  output.ReturnMessage,
+		},
+	}
+}
+
+func (chain *simulatedChain) convertEvents(logs []*vmcommon.LogEntry) []*transaction.Events {
+	events := make([]*transaction.Events, 0, len(logs))
+	for _, logEntry := range logs {
+		events = append(events, &transaction.Events{
+			Address:    chain.pubkeyConverter.Encode(logEntry.Address),
+			Identifier: string(logEntry.Identifier),
+			Topics:     logEntry.Topics,
+			Data:       logEntry.Data,
+		})
+	}
+
+	return events
+}
+
+// computeContractAddress mirrors the way the nodes derive the address of a
+// new contract, so that the clients can compute it upfront
+func (chain *simulatedChain) computeContractAddress(creator []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	nonceBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonceBytes, creatorNonce)
+
+	address, err := chain.hasher.Keccak256(append(append([]byte{}, creator...), nonceBytes...))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, numInitCharactersForScAddress-len(vmType))
+	prefix = append(prefix, vmType...)
+	copy(address, prefix)
+	copy(address[len(address)-2:], creator[len(creator)-2:])
+
+	return address, nil
+}
+
+func (chain *simulatedChain) computeMinGasLimit(tx *transaction.FrontendTransaction) uint64 {
+	return chain.config.MinGasLimit + uint64(len(tx.Data))*chain.config.GasPerDataByte
+}
+
+// computeTransactionHash mirrors the way the nodes hash the transactions: the
+// protocol transaction, including the signature, is marshalled with protobuf,
+// then hashed with blake2b
+func (chain *simulatedChain) computeTransactionHash(
+	tx *transaction.FrontendTransaction,
+	sender []byte,
+	receiver []byte,
+	value *big.Int,
+) (string, error) {
+	signature, err := fromHex(tx.Signature)
+	if err != nil {
+		return "", NewRequestErrorMessageInner("invalid signature", err)
+	}
+
+	protocolTransaction := &transaction.Transaction{
+		Nonce:       tx.Nonce,
+		Value:       value,
+		RcvAddr:     receiver,
+		RcvUserName: tx.ReceiverUsername,
+		SndAddr:     sender,
+		SndUserName: tx.SenderUsername,
+		GasPrice:    tx.GasPrice,
+		GasLimit:    tx.GasLimit,
+		Data:        tx.Data,
+		ChainID:     []byte(tx.ChainID),
+		Version:     tx.Version,
+		Signature:   signature,
+		Options:     tx.Options,
+	}
+
+	data, err := chain.marshalizer.Marshal(protocolTransaction)
+	if err != nil {
+		return "", err
+	}
+
+	return chain.computeHash(data), nil
+}
+
+func (chain *simulatedChain) computeBlockHash(blockNonce uint64) string {
+	return chain.computeHash([]byte(fmt.Sprintf("block %d", blockNonce)))
+}
+
+func (chain *simulatedChain) computeHash(data []byte) string {
+	hash, err := chain.hasher.Blake2b256(data)
+	if err != nil {
+		log.Error("simulatedChain.computeHash", "err", err)
+	}
+
+	return toHex(hash)
+}
+
+func (chain *simulatedChain) isSmartContract(w *world, address []byte) bool {
+	account := w.blockchainHook.AcctMap.GetAccount(address)
+	return account != nil && len(account.Code) > 0
+}
+
+func (chain *simulatedChain) decodeAddress(bech32Address string) ([]byte, error) {
+	address, err := chain.pubkeyConverter.Decode(strings.TrimSpace(bech32Address))
+	if err != nil {
+		return nil, NewRequestErrorMessageInner("invalid address", err)
+	}
+
+	return address, nil
+}
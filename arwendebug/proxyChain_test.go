@@ -0,0 +1,323 @@
+package arwendebug
+
+import (
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/hashing/blake2b"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	"github.com/stretchr/testify/require"
+)
+
+const oneEGLD = "1000000000000000000"
+const testGasForExecution = 1000000
+
+func newTestChain(context *testContext) *simulatedChain {
+	chain, err := newSimulatedChain(databasePath, context.worldID, "", testWASMBackend)
+	require.Nil(context.t, err)
+
+	return chain
+}
+
+func (context *testContext) newTransfer(chain *simulatedChain, sender []byte, receiver []byte, nonce uint64, value string) *transaction.FrontendTransaction {
+	return &transaction.FrontendTransaction{
+		Nonce:    nonce,
+		Value:    value,
+		Sender:   chain.pubkeyConverter.Encode(sender),
+		Receiver: chain.pubkeyConverter.Encode(receiver),
+		GasPrice: chain.config.MinGasPrice,
+		GasLimit: chain.config.MinGasLimit,
+		ChainID:  chain.config.ChainID,
+		Version:  chain.config.MinTransactionVersion,
+	}
+}
+
+func (context *testContext) newDeploy(chain *simulatedChain, sender []byte, nonce uint64, value string, codePath string) *transaction.FrontendTransaction {
+	code, err := ioutil.ReadFile(codePath)
+	require.Nil(context.t, err)
+
+	// Arwen VM type, upgradeable and payable
+	tx := context.newTransfer(chain, sender, proxyDeployReceiver, nonce, value)
+	tx.Data = []byte(toHex(code) + "@0500@0102")
+	tx.GasLimit = chain.computeMinGasLimit(tx) + testGasForExecution
+	return tx
+}
+
+func (context *testContext) newCall(chain *simulatedChain, sender []byte, contract []byte, nonce uint64, value string, function string) *transaction.FrontendTransaction {
+	tx := context.newTransfer(chain, sender, contract, nonce, value)
+	tx.Data = []byte(function)
+	tx.GasLimit = chain.computeMinGasLimit(tx) + testGasForExecution
+	return tx
+}
+
+func (context *testContext) requireBalance(chain *simulatedChain, address []byte, expected string) {
+	account, err := chain.getAccount(chain.pubkeyConverter.Encode(address))
+	require.Nil(context.t, err)
+	require.Equal(context.t, expected, account.Balance)
+}
+
+func TestProxyChain_SendTransfer(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	bob := newDummyAddress("bob")
+	context.createAccount(alice.hex, "2"+oneEGLD[1:])
+
+	chain := newTestChain(context)
+	hash, err := chain.sendTransaction(context.newTransfer(chain, alice.raw, bob.raw, 0, oneEGLD))
+	require.Nil(t, err)
+
+	result, err := chain.getTransaction(hash)
+	require.Nil(t, err)
+	require.Equal(t, transaction.TxStatusSuccess, result.Status)
+	require.Equal(t, uint64(1), result.BlockNonce)
+
+	// The sender pays the value and the gas of the transfer
+	aliceAccount, err := chain.getAccount(chain.pubkeyConverter.Encode(alice.raw))
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), aliceAccount.Nonce)
+	require.Equal(t, "999950000000000000", aliceAccount.Balance)
+
+	bobAccount, err := chain.getAccount(chain.pubkeyConverter.Encode(bob.raw))
+	require.Nil(t, err)
+	require.Equal(t, oneEGLD, bobAccount.Balance)
+
+	// The world is stored after each transaction
+	world := context.loadWorld()
+	require.Equal(t, uint64(1), world.blockchainHook.AcctMap.GetAccount(alice.raw).Nonce)
+	require.True(t, context.accountExists(bob.raw))
+}
+
+func TestProxyChain_SendTransfer_Invalid(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	bob := newDummyAddress("bob")
+	context.createAccount(alice.hex, oneEGLD)
+
+	chain := newTestChain(context)
+
+	tx := context.newTransfer(chain, alice.raw, bob.raw, 1, "1")
+	_, err := chain.sendTransaction(tx)
+	require.Equal(t, ErrInvalidTransactionNonce, err)
+
+	tx = context.newTransfer(chain, alice.raw, bob.raw, 0, oneEGLD)
+	_, err = chain.sendTransaction(tx)
+	require.Equal(t, ErrInsufficientFunds, err)
+
+	tx = context.newTransfer(chain, alice.raw, bob.raw, 0, "1")
+	tx.ChainID = "1"
+	_, err = chain.sendTransaction(tx)
+	require.Equal(t, ErrInvalidChainID, err)
+
+	tx = context.newTransfer(chain, alice.raw, bob.raw, 0, "1")
+	tx.GasPrice = 1
+	_, err = chain.sendTransaction(tx)
+	require.Equal(t, ErrInsufficientGasPrice, err)
+
+	tx = context.newTransfer(chain, alice.raw, bob.raw, 0, "1")
+	tx.Data = []byte("hello")
+	_, err = chain.sendTransaction(tx)
+	require.Equal(t, ErrInvalidGasLimit, err)
+
+	tx = context.newTransfer(chain, bob.raw, alice.raw, 0, "1")
+	_, err = chain.sendTransaction(tx)
+	require.Equal(t, ErrAccountDoesntExist, err)
+
+	_, err = chain.getTransaction("abba")
+	require.Equal(t, ErrTransactionNotFound, err)
+
+	// Rejected transactions leave the world unchanged
+	aliceAccount, err := chain.getAccount(chain.pubkeyConverter.Encode(alice.raw))
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), aliceAccount.Nonce)
+	require.Equal(t, oneEGLD, aliceAccount.Balance)
+}
+
+func TestProxyChain_SimulateTransfer(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	bob := newDummyAddress("bob")
+	context.createAccount(alice.hex, oneEGLD)
+
+	chain := newTestChain(context)
+	result, err := chain.simulateTransaction(context.newTransfer(chain, alice.raw, bob.raw, 0, "42"))
+	require.Nil(t, err)
+	require.Equal(t, transaction.TxStatusSuccess, result.Status)
+	require.NotEmpty(t, result.Hash)
+
+	// The simulation is not committed
+	_, err = chain.getTransaction(result.Hash)
+	require.Equal(t, ErrTransactionNotFound, err)
+
+	aliceAccount, err := chain.getAccount(chain.pubkeyConverter.Encode(alice.raw))
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), aliceAccount.Nonce)
+	require.Equal(t, oneEGLD, aliceAccount.Balance)
+
+	bobAccount, err := chain.getAccount(chain.pubkeyConverter.Encode(bob.raw))
+	require.Nil(t, err)
+	require.Equal(t, "0", bobAccount.Balance)
+}
+
+func TestProxyChain_GetStorage(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+
+	world := context.loadWorld()
+	world.blockchainHook.AcctMap.GetAccount(alice.raw).Storage["answer"] = []byte{42}
+	require.Nil(t, newDatabase(databasePath, testWASMBackend).storeWorld(world))
+
+	chain := newTestChain(context)
+	value, err := chain.getStorage(chain.pubkeyConverter.Encode(alice.raw), toHex([]byte("answer")))
+	require.Nil(t, err)
+	require.Equal(t, "2a", value)
+
+	value, err = chain.getStorage(chain.pubkeyConverter.Encode(alice.raw), toHex([]byte("missing")))
+	require.Nil(t, err)
+	require.Equal(t, "", value)
+
+	_, err = chain.getStorage("alice", "")
+	require.NotNil(t, err)
+}
+
+func TestProxyChain_ComputeContractAddress(t *testing.T) {
+	context := newTestContext(t)
+	chain := newTestChain(context)
+
+	alice := newDummyAddress("alice")
+	address, err := chain.computeContractAddress(alice.raw, 0, []byte{5, 0})
+	require.Nil(t, err)
+	require.Len(t, address, proxyAddressLength)
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0, 5, 0}, address[:numInitCharactersForScAddress])
+	require.Equal(t, alice.raw[30:], address[30:])
+
+	otherAddress, err := chain.computeContractAddress(alice.raw, 1, []byte{5, 0})
+	require.Nil(t, err)
+	require.NotEqual(t, address, otherAddress)
+}
+
+func TestProxyChain_NetworkConfig(t *testing.T) {
+	context := newTestContext(t)
+	chain := newTestChain(context)
+
+	config := chain.getNetworkConfig()
+	require.Equal(t, DefaultChainID, config.ChainID)
+	require.Equal(t, 1, config.NumShardsWithoutMeta)
+}
+
+func TestProxyChain_TransactionHash(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	bob := newDummyAddress("bob")
+	context.createAccount(alice.hex, oneEGLD)
+
+	chain := newTestChain(context)
+	tx := context.newTransfer(chain, alice.raw, bob.raw, 0, "42")
+	tx.Data = []byte("hello")
+	tx.GasLimit = chain.computeMinGasLimit(tx)
+	tx.Signature = "abcdef"
+
+	hash, err := chain.sendTransaction(tx)
+	require.Nil(t, err)
+
+	// The hash is the one computed by the nodes
+	expectedHash, err := core.CalculateHash(&marshal.GogoProtoMarshalizer{}, blake2b.NewBlake2b(), &transaction.Transaction{
+		Nonce:     0,
+		Value:     big.NewInt(42),
+		RcvAddr:   bob.raw,
+		SndAddr:   alice.raw,
+		GasPrice:  tx.GasPrice,
+		GasLimit:  tx.GasLimit,
+		Data:      []byte("hello"),
+		ChainID:   []byte(tx.ChainID),
+		Version:   tx.Version,
+		Signature: []byte{0xab, 0xcd, 0xef},
+	})
+	require.Nil(t, err)
+	require.Equal(t, toHex(expectedHash), hash)
+
+	tx = context.newTransfer(chain, alice.raw, bob.raw, 1, "42")
+	tx.Signature = "not hex"
+	_, err = chain.sendTransaction(tx)
+	require.NotNil(t, err)
+}
+
+func TestProxyChain_DeployAndCallWithValue(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, oneEGLD)
+
+	chain := newTestChain(context)
+	deploy := context.newDeploy(chain, alice.raw, 0, "1000", wasmCounterPath)
+	hash, err := chain.sendTransaction(deploy)
+	require.Nil(t, err)
+
+	result, err := chain.getTransaction(hash)
+	require.Nil(t, err)
+	require.Equal(t, transaction.TxStatusSuccess, result.Status)
+
+	contract, err := chain.computeContractAddress(alice.raw, 0, []byte{5, 0})
+	require.Nil(t, err)
+	require.Equal(t, contract, result.Logs.Events[0].Topics[0])
+
+	// The gas left is refunded to the sender
+	require.Equal(t, "999656000000000", result.SmartContractResults[0].Value.String())
+	context.requireBalance(chain, alice.raw, "998929655999999000")
+	context.requireBalance(chain, contract, "1000")
+
+	call := context.newCall(chain, alice.raw, contract, 1, "500", "increment")
+	hash, err = chain.sendTransaction(call)
+	require.Nil(t, err)
+
+	result, err = chain.getTransaction(hash)
+	require.Nil(t, err)
+	require.Equal(t, transaction.TxStatusSuccess, result.Status)
+	require.Equal(t, "@"+toHex([]byte("ok"))+"@02", result.SmartContractResults[0].Data)
+
+	// The sender pays the value and the gas used, the contract receives the value
+	require.Equal(t, "999647000000000", result.SmartContractResults[0].Value.String())
+	context.requireBalance(chain, alice.raw, "998865802999998500")
+	context.requireBalance(chain, contract, "1500")
+}
+
+func TestProxyChain_FailedCall(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, oneEGLD)
+
+	chain := newTestChain(context)
+	_, err := chain.sendTransaction(context.newDeploy(chain, alice.raw, 0, "1000", wasmCounterPath))
+	require.Nil(t, err)
+	contract, err := chain.computeContractAddress(alice.raw, 0, []byte{5, 0})
+	require.Nil(t, err)
+	context.requireBalance(chain, alice.raw, "998929655999999000")
+
+	call := context.newCall(chain, alice.raw, contract, 1, "500", "missingFunction")
+	hash, err := chain.sendTransaction(call)
+	require.Nil(t, err)
+
+	result, err := chain.getTransaction(hash)
+	require.Nil(t, err)
+	require.Equal(t, transaction.TxStatusFail, result.Status)
+	require.Equal(t, arwen.ErrFuncNotFound.Error(), result.ReturnMessage)
+
+	// The sender pays the whole gas limit, without refund, and keeps the value
+	require.Equal(t, "0", result.SmartContractResults[0].Value.String())
+	context.requireBalance(chain, alice.raw, "997857155999999000")
+	context.requireBalance(chain, contract, "1000")
+
+	aliceAccount, err := chain.getAccount(chain.pubkeyConverter.Encode(alice.raw))
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), aliceAccount.Nonce)
+}
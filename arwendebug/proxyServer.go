@@ -0,0 +1,166 @@
+package arwendebug
+
+import (
+	"net/http"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/gin-gonic/gin"
+)
+
+const proxyCodeSuccessful = "successful"
+const proxyCodeBadRequest = "bad_request"
+
+// ProxyServer serves a subset of the Elrond proxy API, on top of a simulated chain
+type ProxyServer struct {
+	chain   *simulatedChain
+	address string
+}
+
+// NewProxyServer creates a ProxyServer object, backed by a world of the database,
+// whose contracts are executed with the given WASM backend
+func NewProxyServer(address string, databasePath string, worldID string, chainID string, wasmBackend arwen.WASMBackend) (*ProxyServer, error) {
+	chain, err := newSimulatedChain(databasePath, worldID, chainID, wasmBackend)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProxyServer{
+		chain:   chain,
+		address: address,
+	}, nil
+}
+
+// Start starts the proxy server
+func (server *ProxyServer) Start() error {
+	log.Debug("ProxyServer.Start()")
+
+	router := gin.Default()
+
+	router.GET("/address/:address", server.handleGetAccount)
+	router.GET("/address/:address/key/:key", server.handleGetStorage)
+	router.POST("/vm-values/query", server.handleQuery)
+	router.POST("/transaction/send", server.handleSendTransaction)
+	router.POST("/transaction/simulate", server.handleSimulateTransaction)
+	router.GET("/transaction/:txhash", server.handleGetTransaction)
+	router.GET("/transaction/:txhash/status", server.handleGetTransactionStatus)
+	router.GET("/network/config", server.handleGetNetworkConfig)
+
+	return router.Run(server.address)
+}
+
+func (server *ProxyServer) handleGetAccount(ginContext *gin.Context) {
+	account, err := server.chain.getAccount(ginContext.Param("address"))
+	if err != nil {
+		returnProxyBadRequest(ginContext, "handleGetAccount.getAccount", err)
+		return
+	}
+
+	returnProxyOkResponse(ginContext, gin.H{"account": account})
+}
+
+func (server *ProxyServer) handleGetStorage(ginContext *gin.Context) {
+	value, err := server.chain.getStorage(ginContext.Param("address"), ginContext.Param("key"))
+	if err != nil {
+		returnProxyBadRequest(ginContext, "handleGetStorage.getStorage", err)
+		return
+	}
+
+	returnProxyOkResponse(ginContext, gin.H{"value": value})
+}
+
+func (server *ProxyServer) handleQuery(ginContext *gin.Context) {
+	request := ProxyQueryRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnProxyBadRequest(ginContext, "handleQuery.ShouldBindJSON", err)
+		return
+	}
+
+	output, err := server.chain.query(&request)
+	if err != nil {
+		returnProxyBadRequest(ginContext, "handleQuery.query", err)
+		return
+	}
+
+	returnProxyOkResponse(ginContext, gin.H{"data": output})
+}
+
+func (server *ProxyServer) handleSendTransaction(ginContext *gin.Context) {
+	tx := transaction.FrontendTransaction{}
+
+	err := ginContext.ShouldBindJSON(&tx)
+	if err != nil {
+		returnProxyBadRequest(ginContext, "handleSendTransaction.ShouldBindJSON", err)
+		return
+	}
+
+	hash, err := server.chain.sendTransaction(&tx)
+	if err != nil {
+		returnProxyBadRequest(ginContext, "handleSendTransaction.sendTransaction", err)
+		return
+	}
+
+	returnProxyOkResponse(ginContext, gin.H{"txHash": hash})
+}
+
+func (server *ProxyServer) handleSimulateTransaction(ginContext *gin.Context) {
+	tx := transaction.FrontendTransaction{}
+
+	err := ginContext.ShouldBindJSON(&tx)
+	if err != nil {
+		returnProxyBadRequest(ginContext, "handleSimulateTransaction.ShouldBindJSON", err)
+		return
+	}
+
+	result, err := server.chain.simulateTransaction(&tx)
+	if err != nil {
+		returnProxyBadRequest(ginContext, "handleSimulateTransaction.simulateTransaction", err)
+		return
+	}
+
+	returnProxyOkResponse(ginContext, gin.H{"result": result})
+}
+
+func (server *ProxyServer) handleGetTransaction(ginContext *gin.Context) {
+	result, err := server.chain.getTransaction(ginContext.Param("txhash"))
+	if err != nil {
+		returnProxyBadRequest(ginContext, "handleGetTransaction.getTransaction", err)
+		return
+	}
+
+	returnProxyOkResponse(ginContext, gin.H{"transaction": result})
+}
+
+func (server *ProxyServer) handleGetTransactionStatus(ginContext *gin.Context) {
+	result, err := server.chain.getTransaction(ginContext.Param("txhash"))
+	if err != nil {
+		returnProxyBadRequest(ginContext, "handleGetTransactionStatus.getTransaction", err)
+		return
+	}
+
+	returnProxyOkResponse(ginContext, gin.H{"status": result.Status})
+}
+
+func (server *ProxyServer) handleGetNetworkConfig(ginContext *gin.Context) {
+	returnProxyOkResponse(ginContext, gin.H{"config": server.chain.getNetworkConfig()})
+}
+
+func returnProxyBadRequest(context *gin.Context, errScope string, err error) {
+	log.Debug("ProxyServer", "scope", errScope, "err", err)
+
+	context.JSON(http.StatusBadRequest, ProxyResponse{
+		Data:  nil,
+		Error: err.Error(),
+		Code:  proxyCodeBadRequest,
+	})
+}
+
+func returnProxyOkResponse(context *gin.Context, data interface{}) {
+	context.JSON(http.StatusOK, ProxyResponse{
+		Data:  data,
+		Error: "",
+		Code:  proxyCodeSuccessful,
+	})
+}
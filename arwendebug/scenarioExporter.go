@@ -0,0 +1,510 @@
+package arwendebug
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mandos-go/esdtconvert"
+	mjwrite "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mandos-go/json/write"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mandos-go/model"
+	oj "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mandos-go/orderedjson"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-vm-common"
+)
+
+// scenarioExporter converts the history of a world into a mandos scenario,
+// which ends by checking the state of the world after the last step.
+// The debugging world does not charge for gas, thus the transactions are
+// exported with a zero gas price, so that the balances evolve the same way
+// when the scenario is replayed; the gas and the refunds are not checked.
+type scenarioExporter struct {
+	scenarioFolder  string
+	nonces          map[string]uint64
+	codeExpressions map[string]string
+	steps           []mj.Step
+}
+
+func newScenarioExporter(scenarioPath string) *scenarioExporter {
+	return &scenarioExporter{
+		scenarioFolder:  filepath.Dir(scenarioPath),
+		nonces:          make(map[string]uint64),
+		codeExpressions: make(map[string]string),
+		steps:           make([]mj.Step, 0),
+	}
+}
+
+func (exporter *scenarioExporter) export(name string, history []*historyEntry) *mj.Scenario {
+	for _, entry := range effectiveHistory(history) {
+		// Actions which failed before reaching the VM did not affect the world
+		if len(entry.Error) > 0 {
+			continue
+		}
+
+		switch entry.Action {
+		case HistoryActionInitial:
+			exporter.exportInitialWorld(entry.World)
+		case HistoryActionCreateAccount:
+			exporter.exportCreateAccount(entry.CreateAccount)
+		case HistoryActionDeploy:
+			exporter.exportDeploy(entry)
+		case HistoryActionUpgrade:
+			exporter.exportUpgrade(entry)
+		case HistoryActionRun:
+			exporter.exportRun(entry)
+		case HistoryActionQuery:
+			exporter.exportQuery(entry)
+		}
+	}
+
+	if len(history) > 0 {
+		exporter.exportFinalState(history[len(history)-1].World)
+	}
+
+	return &mj.Scenario{
+		Name:        name,
+		GasSchedule: mj.GasScheduleDummy,
+		Steps:       exporter.steps,
+	}
+}
+
+// effectiveHistory returns the actions which led to the current state of the
+// world, leaving out those undone by reverts
+func effectiveHistory(history []*historyEntry) []*historyEntry {
+	effectiveAtStep := make(map[int][]*historyEntry)
+	effective := make([]*historyEntry, 0, len(history))
+
+	for _, entry := range history {
+		if entry.Action == HistoryActionRevert {
+			// copied, so that the following actions do not overwrite the earlier ones
+			effective = append([]*historyEntry(nil), effectiveAtStep[entry.RevertedToStep]...)
+		} else {
+			effective = append(effective, entry)
+		}
+
+		effectiveAtStep[entry.Step] = effective
+	}
+
+	return effective
+}
+
+func (exporter *scenarioExporter) exportInitialWorld(dataModel *worldDataModel) {
+	addresses := make([]string, 0, len(dataModel.Accounts))
+	for address := range dataModel.Accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	accounts := make([]*mj.Account, 0, len(addresses))
+	for _, address := range addresses {
+		account := dataModel.Accounts[address]
+		exporter.nonces[address] = account.Nonce
+
+		accounts = append(accounts, &mj.Account{
+			Address: addressToMandos(account.Address),
+			Nonce:   uint64ToMandos(account.Nonce),
+			Balance: bigIntToMandos(account.Balance),
+			Storage: storageToMandos(account.Storage),
+			Code:    mj.NewJSONBytesFromString(account.Code, bytesToExpression(account.Code)),
+			Owner:   addressToMandos(account.OwnerAddress),
+		})
+	}
+
+	exporter.steps = append(exporter.steps, &mj.SetStateStep{
+		Comment:  "the world before its history started",
+		Accounts: accounts,
+	})
+}
+
+// exportFinalState checks the accounts of the world, along with their storage and
+// ESDT tokens; the protected storage is only checked through the ESDT tokens.
+// The debugging world does not increment the nonces, thus the senders are
+// expected to have the nonces reached by replaying their transactions.
+func (exporter *scenarioExporter) exportFinalState(dataModel *worldDataModel) {
+	addresses := make([]string, 0, len(dataModel.Accounts))
+	for address := range dataModel.Accounts {
+		if address != string(vmcommon.SystemAccountAddress) {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+
+	systemAccountStorage := make(map[string][]byte)
+	systemAccount, ok := dataModel.Accounts[string(vmcommon.SystemAccountAddress)]
+	if ok {
+		systemAccountStorage = systemAccount.Storage
+	}
+
+	accounts := make([]*mj.CheckAccount, 0, len(addresses))
+	for _, address := range addresses {
+		account := dataModel.Accounts[address]
+		nonce, ok := exporter.nonces[address]
+		if !ok {
+			nonce = account.Nonce
+		}
+
+		accounts = append(accounts, &mj.CheckAccount{
+			Address:         addressToMandos(account.Address),
+			Nonce:           mj.JSONCheckUint64{Value: nonce, Original: fmt.Sprintf("%d", nonce)},
+			Balance:         bigIntToCheckMandos(account.Balance),
+			Username:        mj.JSONCheckBytesUnspecified(),
+			ExplicitStorage: true,
+			CheckStorage:    storageToCheckMandos(account.Storage),
+			Code:            mj.JSONCheckBytesReconstructed(account.Code, exporter.codeToCheckExpression(account.Code)),
+			Owner:           mj.JSONCheckBytesReconstructed(account.OwnerAddress, bytesToExpression(account.OwnerAddress)),
+			AsyncCallData:   mj.JSONCheckBytesUnspecified(),
+			CheckESDTData:   esdtToCheckMandos(account.Storage, systemAccountStorage),
+		})
+	}
+
+	exporter.steps = append(exporter.steps, &mj.CheckStateStep{
+		Comment:       "the world after the last step",
+		CheckAccounts: &mj.CheckAccounts{Accounts: accounts},
+	})
+}
+
+// codeToCheckExpression refers the code of a contract the same way as the
+// transaction which deployed it
+func (exporter *scenarioExporter) codeToCheckExpression(code []byte) string {
+	expression, ok := exporter.codeExpressions[string(code)]
+	if ok {
+		return expression
+	}
+
+	return bytesToExpression(code)
+}
+
+func (exporter *scenarioExporter) exportCreateAccount(request *CreateAccountRequest) {
+	exporter.nonces[string(request.Address)] = request.Nonce
+
+	exporter.steps = append(exporter.steps, &mj.SetStateStep{
+		Accounts: []*mj.Account{
+			{
+				Address: addressToMandos(request.Address),
+				Nonce:   uint64ToMandos(request.Nonce),
+				Balance: bigIntToMandos(request.BalanceAsBigInt),
+			},
+		},
+	})
+}
+
+func (exporter *scenarioExporter) exportDeploy(entry *historyEntry) {
+	request := entry.Deploy
+
+	// The new address only depends on the nonce of the creator, which is
+	// incremented by each transaction when the scenario is replayed
+	contractAddress := fromHexNoError(entry.ContractAddressHex)
+	if isSuccessful(entry.Output) && len(contractAddress) > 0 {
+		exporter.steps = append(exporter.steps, &mj.SetStateStep{
+			NewAddressMocks: []*mj.NewAddressMock{
+				{
+					CreatorAddress: addressToMandos(request.Impersonated),
+					CreatorNonce:   uint64ToMandos(exporter.nonces[string(request.Impersonated)]),
+					NewAddress:     addressToMandos(contractAddress),
+				},
+			},
+		})
+	}
+
+	exporter.appendTxStep(entry, &mj.Transaction{
+		Type:      mj.ScDeploy,
+		EGLDValue: bigIntToMandos(request.ValueAsBigInt),
+		From:      addressToMandos(request.Impersonated),
+		Code:      mj.NewJSONBytesFromString(request.Code, exporter.codeToExpression(request)),
+		Arguments: argumentsToMandos(request.Arguments),
+		GasLimit:  uint64ToMandos(request.GasLimit),
+		GasPrice:  uint64ToMandos(0),
+	})
+}
+
+func (exporter *scenarioExporter) exportUpgrade(entry *historyEntry) {
+	request := entry.Upgrade
+
+	arguments := []mj.JSONBytesFromTree{
+		{
+			Value:    request.Code,
+			Original: &oj.OJsonString{Value: exporter.codeToExpression(&request.DeployRequest)},
+		},
+		bytesToMandos(request.CodeMetadataBytes),
+	}
+	arguments = append(arguments, argumentsToMandos(request.Arguments)...)
+
+	exporter.appendTxStep(entry, &mj.Transaction{
+		Type:      mj.ScCall,
+		EGLDValue: bigIntToMandos(request.ValueAsBigInt),
+		From:      addressToMandos(request.Impersonated),
+		To:        addressToMandos(request.ContractAddress),
+		Function:  arwen.UpgradeFunctionName,
+		Arguments: arguments,
+		GasLimit:  uint64ToMandos(request.GasLimit),
+		GasPrice:  uint64ToMandos(0),
+	})
+}
+
+func (exporter *scenarioExporter) exportRun(entry *historyEntry) {
+	request := entry.Run
+
+	exporter.appendTxStep(entry, &mj.Transaction{
+		Type:      mj.ScCall,
+		EGLDValue: bigIntToMandos(request.ValueAsBigInt),
+		From:      addressToMandos(request.Impersonated),
+		To:        addressToMandos(request.ContractAddress),
+		Function:  request.Function,
+		Arguments: argumentsToMandos(request.Arguments),
+		GasLimit:  uint64ToMandos(request.GasLimit),
+		GasPrice:  uint64ToMandos(0),
+	})
+}
+
+func (exporter *scenarioExporter) exportQuery(entry *historyEntry) {
+	request := entry.Query
+
+	exporter.appendTxStep(entry, &mj.Transaction{
+		Type:      mj.ScQuery,
+		To:        addressToMandos(request.ContractAddress),
+		Function:  request.Function,
+		Arguments: argumentsToMandos(request.Arguments),
+	})
+}
+
+func (exporter *scenarioExporter) appendTxStep(entry *historyEntry, tx *mj.Transaction) {
+	// Replaying a transaction increments the nonce of its sender, even if it fails
+	if tx.Type.HasSender() {
+		exporter.nonces[string(tx.From.Value)]++
+	}
+
+	exporter.steps = append(exporter.steps, &mj.TxStep{
+		TxIdent:        fmt.Sprintf("%d", entry.Step),
+		Tx:             tx,
+		ExpectedResult: resultToMandos(entry.Output),
+	})
+}
+
+// codeToExpression refers the contract code by its file, relative to the
+// scenario, if the code was loaded from a file
+func (exporter *scenarioExporter) codeToExpression(request *DeployRequest) string {
+	expression := exporter.codeFileToExpression(request)
+	if len(expression) == 0 {
+		expression = bytesToExpression(request.Code)
+	}
+
+	exporter.codeExpressions[string(request.Code)] = expression
+	return expression
+}
+
+func (exporter *scenarioExporter) codeFileToExpression(request *DeployRequest) string {
+	if len(request.CodePath) == 0 {
+		return ""
+	}
+
+	codePath, err := filepath.Abs(request.CodePath)
+	if err != nil {
+		return ""
+	}
+
+	scenarioFolder, err := filepath.Abs(exporter.scenarioFolder)
+	if err != nil {
+		return ""
+	}
+
+	relativePath, err := filepath.Rel(scenarioFolder, codePath)
+	if err != nil {
+		return ""
+	}
+
+	return "file:" + filepath.ToSlash(relativePath)
+}
+
+func writeScenario(scenario *mj.Scenario, scenarioPath string) error {
+	err := os.MkdirAll(filepath.Dir(scenarioPath), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	jsonString := mjwrite.ScenarioToJSONString(scenario)
+	return ioutil.WriteFile(scenarioPath, []byte(jsonString), 0644)
+}
+
+func resultToMandos(output *vmcommon.VMOutput) *mj.TransactionResult {
+	if output == nil {
+		return nil
+	}
+
+	out := make([]mj.JSONCheckBytes, len(output.ReturnData))
+	for i, data := range output.ReturnData {
+		out[i] = mj.JSONCheckBytesReconstructed(data, bytesToExpression(data))
+	}
+
+	message := mj.JSONCheckBytesReconstructed([]byte(output.ReturnMessage), "")
+	if len(output.ReturnMessage) > 0 {
+		message = mj.JSONCheckBytesReconstructed([]byte(output.ReturnMessage), "str:"+output.ReturnMessage)
+	}
+
+	return &mj.TransactionResult{
+		Out: out,
+		Status: mj.JSONCheckBigInt{
+			Value:    big.NewInt(int64(output.ReturnCode)),
+			Original: fmt.Sprintf("%d", output.ReturnCode),
+		},
+		Message: message,
+		Gas: mj.JSONCheckUint64{
+			IsStar:   true,
+			Original: "*",
+		},
+		Refund: mj.JSONCheckBigInt{
+			Value:    big.NewInt(0),
+			IsStar:   true,
+			Original: "*",
+		},
+		LogsStar: true,
+	}
+}
+
+func isSuccessful(output *vmcommon.VMOutput) bool {
+	return output != nil && output.ReturnCode == vmcommon.Ok
+}
+
+func bytesToExpression(value []byte) string {
+	if len(value) == 0 {
+		return ""
+	}
+
+	return "0x" + toHex(value)
+}
+
+func addressToMandos(address []byte) mj.JSONBytesFromString {
+	return mj.NewJSONBytesFromString(address, bytesToExpression(address))
+}
+
+func bytesToMandos(value []byte) mj.JSONBytesFromTree {
+	return mj.JSONBytesFromTree{
+		Value:    value,
+		Original: &oj.OJsonString{Value: bytesToExpression(value)},
+	}
+}
+
+func storageToMandos(storage map[string][]byte) []*mj.StorageKeyValuePair {
+	keys := make([]string, 0, len(storage))
+	for key := range storage {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]*mj.StorageKeyValuePair, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, &mj.StorageKeyValuePair{
+			Key:   mj.NewJSONBytesFromString([]byte(key), bytesToExpression([]byte(key))),
+			Value: bytesToMandos(storage[key]),
+		})
+	}
+
+	return result
+}
+
+func storageToCheckMandos(storage map[string][]byte) []*mj.CheckStorageKeyValuePair {
+	keys := make([]string, 0, len(storage))
+	for key, value := range storage {
+		if len(value) > 0 && !strings.HasPrefix(key, core.ElrondProtectedKeyPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	result := make([]*mj.CheckStorageKeyValuePair, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, &mj.CheckStorageKeyValuePair{
+			Key:        mj.NewJSONBytesFromString([]byte(key), bytesToExpression([]byte(key))),
+			CheckValue: mj.JSONCheckBytesReconstructed(storage[key], bytesToExpression(storage[key])),
+		})
+	}
+
+	return result
+}
+
+func esdtToCheckMandos(storage map[string][]byte, systemAccountStorage map[string][]byte) []*mj.CheckESDTData {
+	tokens, err := esdtconvert.GetFullMockESDTData(storage, systemAccountStorage)
+	if err != nil {
+		log.Warn("esdtToCheckMandos", "err", err)
+		return nil
+	}
+
+	tokenNames := make([]string, 0, len(tokens))
+	for tokenName := range tokens {
+		tokenNames = append(tokenNames, tokenName)
+	}
+	sort.Strings(tokenNames)
+
+	result := make([]*mj.CheckESDTData, 0, len(tokenNames))
+	for _, tokenName := range tokenNames {
+		token := tokens[tokenName]
+
+		instances := make([]*mj.CheckESDTInstance, 0, len(token.Instances))
+		for _, tokenInstance := range token.Instances {
+			instance := mj.NewCheckESDTInstance()
+			instance.Nonce = mj.JSONCheckUint64{
+				Value:    tokenInstance.TokenMetaData.Nonce,
+				Original: fmt.Sprintf("%d", tokenInstance.TokenMetaData.Nonce),
+			}
+			instance.Balance = bigIntToCheckMandos(tokenInstance.Value)
+			instances = append(instances, instance)
+		}
+
+		roles := make([]string, len(token.Roles))
+		for i, role := range token.Roles {
+			roles[i] = string(role)
+		}
+
+		result = append(result, &mj.CheckESDTData{
+			TokenIdentifier: mj.NewJSONBytesFromString(token.TokenIdentifier, "str:"+string(token.TokenIdentifier)),
+			Instances:       instances,
+			LastNonce:       mj.JSONCheckUint64{Value: token.LastNonce, Original: fmt.Sprintf("%d", token.LastNonce)},
+			Roles:           roles,
+			Frozen:          mj.JSONCheckUint64Unspecified(),
+		})
+	}
+
+	return result
+}
+
+func argumentsToMandos(arguments [][]byte) []mj.JSONBytesFromTree {
+	result := make([]mj.JSONBytesFromTree, len(arguments))
+	for i, argument := range arguments {
+		result[i] = bytesToMandos(argument)
+	}
+
+	return result
+}
+
+func bigIntToMandos(value *big.Int) mj.JSONBigInt {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	return mj.JSONBigInt{
+		Value:    value,
+		Original: value.String(),
+	}
+}
+
+func bigIntToCheckMandos(value *big.Int) mj.JSONCheckBigInt {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	return mj.JSONCheckBigInt{
+		Value:    value,
+		Original: value.String(),
+	}
+}
+
+func uint64ToMandos(value uint64) mj.JSONUint64 {
+	return mj.JSONUint64{
+		Value:    value,
+		Original: fmt.Sprintf("%d", value),
+	}
+}
@@ -0,0 +1,188 @@
+package arwendebug
+
+import (
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	am "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwenmandos"
+	mc "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mandos-go/controller"
+	fr "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mandos-go/fileresolver"
+	mjparse "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mandos-go/json/parse"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mandos-go/model"
+	"github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFacade_ExportScenario(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	contract := newDummyAddress("contract")
+	context.createAccount(alice.hex, "42")
+
+	deployRequest := DeployRequest{
+		ContractRequestBase: ContractRequestBase{
+			RequestBase:     context.createRequestBase(),
+			ImpersonatedHex: alice.hex,
+			GasLimit:        gasLimit,
+		},
+		CodeHex:      "0061736d01000000",
+		ArgumentsHex: []string{"64"},
+	}
+	require.Nil(t, deployRequest.digest())
+	deployResponse := &DeployResponse{ContractAddressHex: contract.hex}
+	deployResponse.Output = &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
+
+	runRequest := RunRequest{
+		ContractRequestBase: ContractRequestBase{
+			RequestBase:     context.createRequestBase(),
+			ImpersonatedHex: alice.hex,
+			GasLimit:        gasLimit,
+		},
+		ContractAddressHex: contract.hex,
+		Function:           "transferToken",
+	}
+	require.Nil(t, runRequest.digest())
+	runResponse := &RunResponse{}
+	runResponse.Output = &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, ReturnMessage: "not enough tokens"}
+
+	queryRequest := QueryRequest{RunRequest: runRequest}
+	queryRequest.Function = "totalSupply"
+	queryResponse := &QueryResponse{}
+	queryResponse.Output = &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, ReturnData: [][]byte{{100}}}
+
+	database := newDatabase(databasePath, testWASMBackend)
+	world := context.loadWorld()
+	require.Nil(t, database.appendHistory(world, newDeployHistoryEntry(deployRequest, deployResponse)))
+	require.Nil(t, database.appendHistory(world, newRunHistoryEntry(runRequest, runResponse)))
+	require.Nil(t, database.appendHistory(world, newQueryHistoryEntry(queryRequest, queryResponse)))
+
+	scenarioPath := databasePath + "/scenarios/" + context.worldID + ".scen.json"
+	response, err := context.facade.ExportScenario(ExportScenarioRequest{
+		RequestBase:  context.createRequestBase(),
+		ScenarioPath: scenarioPath,
+	})
+	require.Nil(t, err)
+	require.Equal(t, 6, response.NumSteps)
+
+	contents, err := ioutil.ReadFile(scenarioPath)
+	require.Nil(t, err)
+
+	parser := mjparse.NewParser(fr.NewDefaultFileResolver())
+	scenario, err := parser.ParseScenarioFile(contents)
+	require.Nil(t, err)
+	require.Len(t, scenario.Steps, 6)
+
+	createAccountStep := scenario.Steps[0].(*mj.SetStateStep)
+	require.Equal(t, alice.raw, createAccountStep.Accounts[0].Address.Value)
+	require.Equal(t, big.NewInt(42), createAccountStep.Accounts[0].Balance.Value)
+
+	newAddressStep := scenario.Steps[1].(*mj.SetStateStep)
+	require.Equal(t, contract.raw, newAddressStep.NewAddressMocks[0].NewAddress.Value)
+	require.Equal(t, uint64(0), newAddressStep.NewAddressMocks[0].CreatorNonce.Value)
+
+	deployStep := scenario.Steps[2].(*mj.TxStep)
+	require.Equal(t, mj.ScDeploy, deployStep.Tx.Type)
+	require.Equal(t, deployRequest.Code, deployStep.Tx.Code.Value)
+	require.Equal(t, [][]byte{{100}}, mj.JSONBytesFromTreeValues(deployStep.Tx.Arguments))
+
+	runStep := scenario.Steps[3].(*mj.TxStep)
+	require.Equal(t, mj.ScCall, runStep.Tx.Type)
+	require.Equal(t, "transferToken", runStep.Tx.Function)
+	require.Equal(t, big.NewInt(int64(vmcommon.UserError)), runStep.ExpectedResult.Status.Value)
+	require.Equal(t, []byte("not enough tokens"), runStep.ExpectedResult.Message.Value)
+
+	queryStep := scenario.Steps[4].(*mj.TxStep)
+	require.Equal(t, mj.ScQuery, queryStep.Tx.Type)
+	require.Equal(t, []byte{100}, queryStep.ExpectedResult.Out[0].Value)
+	require.True(t, queryStep.ExpectedResult.Gas.IsStar)
+
+	checkStateStep := scenario.Steps[5].(*mj.CheckStateStep)
+	require.Len(t, checkStateStep.CheckAccounts.Accounts, 1)
+	require.Equal(t, alice.raw, checkStateStep.CheckAccounts.Accounts[0].Address.Value)
+	require.Equal(t, big.NewInt(42), checkStateStep.CheckAccounts.Accounts[0].Balance.Value)
+}
+
+func TestFacade_ExportScenario_Replay(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	bob := newDummyAddress("bob")
+	carol := newDummyAddress("carol")
+	context.createAccount(alice.hex, "1000")
+	context.createAccount(bob.hex, "2000")
+
+	erc20 := context.deployContract(wasmErc20Path, alice.hex, "64").ContractAddressHex
+	counter := context.deployContract(wasmCounterPath, bob.hex).ContractAddressHex
+	context.runContract(erc20, alice.hex, "transferToken", bob.hex, "0A")
+	context.runContract(counter, alice.hex, "increment")
+	failed, err := context.facade.RunSmartContract(RunRequest{
+		ContractRequestBase: ContractRequestBase{
+			RequestBase:     context.createRequestBase(),
+			ImpersonatedHex: bob.hex,
+			GasLimit:        gasLimit,
+		},
+		ContractAddressHex: erc20,
+		Function:           "transferToken",
+		ArgumentsHex:       []string{alice.hex, "FF"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.UserError, failed.Output.ReturnCode)
+	require.Equal(t, int64(90), context.queryContract(erc20, alice.hex, "balanceOf", alice.hex).getFirstResultAsInt64())
+
+	// the reverted steps are not replayed
+	context.createAccount(carol.hex, "3000")
+	history, err := context.facade.GetHistory(HistoryRequest{RequestBase: context.createRequestBase()})
+	require.Nil(t, err)
+	_, err = context.facade.RevertWorld(RevertWorldRequest{RequestBase: context.createRequestBase(), Step: len(history.Steps) - 1})
+	require.Nil(t, err)
+	context.runContract(counter, bob.hex, "increment")
+
+	scenarioPath := databasePath + "/scenarios/" + context.worldID + ".scen.json"
+	_, err = context.facade.ExportScenario(ExportScenarioRequest{
+		RequestBase:  context.createRequestBase(),
+		ScenarioPath: scenarioPath,
+	})
+	require.Nil(t, err)
+
+	executor, err := am.NewArwenTestExecutor()
+	require.Nil(t, err)
+	executor.SetWASMBackend(testWASMBackend)
+
+	runner := mc.NewScenarioRunner(executor, mc.NewDefaultFileResolver())
+	require.Nil(t, runner.RunSingleJSONScenario(scenarioPath))
+	require.Nil(t, executor.World.AcctMap.GetAccount(carol.raw))
+}
+
+func TestFacade_ExportScenario_EmptyHistory(t *testing.T) {
+	context := newTestContext(t)
+
+	_, err := context.facade.ExportScenario(ExportScenarioRequest{
+		RequestBase:  context.createRequestBase(),
+		ScenarioPath: databasePath + "/scenarios/empty.scen.json",
+	})
+	require.Equal(t, ErrEmptyHistory, err)
+}
+
+func TestScenarioExporter_EffectiveHistory(t *testing.T) {
+	initial := &historyEntry{Step: 0, Action: HistoryActionInitial}
+	first := &historyEntry{Step: 1, Action: HistoryActionCreateAccount}
+	second := &historyEntry{Step: 2, Action: HistoryActionCreateAccount}
+	fourth := &historyEntry{Step: 4, Action: HistoryActionCreateAccount}
+	revert := func(step int, revertedToStep int) *historyEntry {
+		return &historyEntry{Step: step, Action: HistoryActionRevert, RevertedToStep: revertedToStep}
+	}
+
+	history := []*historyEntry{initial, first, second, revert(3, 1), fourth}
+	require.Equal(t, []*historyEntry{initial, first, fourth}, effectiveHistory(history))
+
+	history = append(history, revert(5, 2))
+	require.Equal(t, []*historyEntry{initial, first, second}, effectiveHistory(history))
+
+	history = append(history, revert(6, 3))
+	require.Equal(t, []*historyEntry{initial, first}, effectiveHistory(history))
+
+	history = append(history, revert(7, 0))
+	require.Equal(t, []*historyEntry{initial}, effectiveHistory(history))
+}
@@ -33,6 +33,26 @@ func (server *DebugServer) Start() error {
 	router.POST("/run", server.handleRun)
 	router.POST("/query", server.handleQuery)
 
+	router.POST("/worlds", server.handleListWorlds)
+	router.POST("/outcomes", server.handleListOutcomes)
+	router.POST("/account/get", server.handleGetAccount)
+	router.POST("/account/storage", server.handleListStorageKeys)
+	router.POST("/account/storage/get", server.handleGetStorage)
+	router.POST("/account/esdt", server.handleListESDT)
+
+	router.POST("/history", server.handleHistory)
+	router.POST("/history/revert", server.handleRevertWorld)
+	router.POST("/history/fork", server.handleForkWorld)
+	router.POST("/history/export", server.handleExportScenario)
+
+	router.POST("/debug/run", server.handleDebugRun)
+	router.POST("/debug/continue", server.handleDebugContinue)
+	router.POST("/debug/step", server.handleDebugStep)
+	router.POST("/debug/abort", server.handleDebugAbort)
+	router.GET("/debug/stack", server.handleDebugStack)
+	router.POST("/debug/memory", server.handleDebugMemory)
+	router.POST("/debug/handle", server.handleDebugHandle)
+
 	return router.Run(server.address)
 }
 
@@ -126,6 +146,280 @@ func (server *DebugServer) handleQuery(ginContext *gin.Context) {
 	returnOkResponse(ginContext, response)
 }
 
+func (server *DebugServer) handleListWorlds(ginContext *gin.Context) {
+	request := ListWorldsRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleListWorlds.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.ListWorlds(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleListWorlds.ListWorlds", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleListOutcomes(ginContext *gin.Context) {
+	request := ListOutcomesRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleListOutcomes.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.ListOutcomes(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleListOutcomes.ListOutcomes", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleGetAccount(ginContext *gin.Context) {
+	request := AccountRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleGetAccount.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.GetAccount(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleGetAccount.GetAccount", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleListStorageKeys(ginContext *gin.Context) {
+	request := AccountRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleListStorageKeys.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.ListStorageKeys(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleListStorageKeys.ListStorageKeys", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleGetStorage(ginContext *gin.Context) {
+	request := GetStorageRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleGetStorage.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.GetStorage(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleGetStorage.GetStorage", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleListESDT(ginContext *gin.Context) {
+	request := AccountRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleListESDT.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.ListESDT(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleListESDT.ListESDT", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleHistory(ginContext *gin.Context) {
+	request := HistoryRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleHistory.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.GetHistory(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleHistory.GetHistory", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleRevertWorld(ginContext *gin.Context) {
+	request := RevertWorldRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleRevertWorld.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.RevertWorld(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleRevertWorld.RevertWorld", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleForkWorld(ginContext *gin.Context) {
+	request := ForkWorldRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleForkWorld.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.ForkWorld(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleForkWorld.ForkWorld", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleExportScenario(ginContext *gin.Context) {
+	request := ExportScenarioRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleExportScenario.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.ExportScenario(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleExportScenario.ExportScenario", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleDebugRun(ginContext *gin.Context) {
+	request := DebugRunRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleDebugRun.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.StartDebugSession(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleDebugRun.StartDebugSession", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleDebugContinue(ginContext *gin.Context) {
+	response, err := server.facade.ContinueDebugSession()
+	if err != nil {
+		returnBadRequest(ginContext, "handleDebugContinue.ContinueDebugSession", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleDebugStep(ginContext *gin.Context) {
+	response, err := server.facade.StepDebugSession()
+	if err != nil {
+		returnBadRequest(ginContext, "handleDebugStep.StepDebugSession", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleDebugAbort(ginContext *gin.Context) {
+	response, err := server.facade.AbortDebugSession()
+	if err != nil {
+		returnBadRequest(ginContext, "handleDebugAbort.AbortDebugSession", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleDebugStack(ginContext *gin.Context) {
+	response, err := server.facade.GetDebugCallStack()
+	if err != nil {
+		returnBadRequest(ginContext, "handleDebugStack.GetDebugCallStack", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleDebugMemory(ginContext *gin.Context) {
+	request := DebugMemoryRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleDebugMemory.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.InspectMemory(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleDebugMemory.InspectMemory", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleDebugHandle(ginContext *gin.Context) {
+	request := DebugHandleRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleDebugHandle.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.InspectHandle(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleDebugHandle.InspectHandle", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
 func returnBadRequest(context *gin.Context, errScope string, err error) {
 	context.JSON(http.StatusBadRequest, gin.H{
 		"error":        fmt.Sprintf("%T", err),
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
 	"github.com/ElrondNetwork/elrond-go-core/data/vm"
 	"github.com/ElrondNetwork/elrond-vm-common"
 	"github.com/stretchr/testify/require"
@@ -14,6 +15,9 @@ import (
 
 const gasLimit = 50000000
 
+// testWASMBackend executes the contracts of the tests without the native Wasmer library
+const testWASMBackend = arwen.WASMBackendGo
+
 type testContext struct {
 	t       *testing.T
 	worldID string
@@ -21,12 +25,12 @@ type testContext struct {
 }
 
 func newTestContext(t *testing.T) *testContext {
-	worldID := fmt.Sprintf("%s_%d", time.Now().Format("20060102150405"), rand.Intn(100))
+	worldID := fmt.Sprintf("%s_%s_%d", t.Name(), time.Now().Format("20060102150405"), rand.Intn(100))
 
 	return &testContext{
 		t:       t,
 		worldID: worldID,
-		facade:  NewDebugFacade(),
+		facade:  NewDebugFacadeWithWASMBackend(testWASMBackend),
 	}
 }
 
@@ -171,7 +175,7 @@ func (context *testContext) createRequestBase() RequestBase {
 }
 
 func (context *testContext) loadWorld() *world {
-	database := newDatabase(databasePath)
+	database := newDatabase(databasePath, testWASMBackend)
 	world, err := database.loadWorld(context.worldID)
 	require.Nil(context.t, err)
 
@@ -2,12 +2,14 @@ package arwendebug
 
 import (
 	"math/big"
+	"sort"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/host"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/config"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mandos-go/esdtconvert"
 	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
-	"github.com/ElrondNetwork/elrond-vm-common"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
 	"github.com/ElrondNetwork/elrond-vm-common/builtInFunctions"
 	"github.com/ElrondNetwork/elrond-vm-common/parsers"
 )
@@ -20,7 +22,7 @@ type worldDataModel struct {
 type world struct {
 	id             string
 	blockchainHook *worldmock.MockWorld
-	vm             vmcommon.VMExecutionHandler
+	vm             arwen.VMHost
 }
 
 func newWorldDataModel(worldID string) *worldDataModel {
@@ -30,14 +32,15 @@ func newWorldDataModel(worldID string) *worldDataModel {
 	}
 }
 
-// newWorld creates a new debugging world
-func newWorld(dataModel *worldDataModel) (*world, error) {
+// newWorld creates a new debugging world, executing contracts with the given
+// WASM backend; the observer is optional
+func newWorld(dataModel *worldDataModel, observer arwen.ExecutionObserver, wasmBackend arwen.WASMBackend) (*world, error) {
 	blockchainHook := worldmock.NewMockWorld()
 	blockchainHook.AcctMap = dataModel.Accounts
 
 	vm, err := host.NewArwenVM(
 		blockchainHook,
-		getHostParameters(),
+		getHostParameters(observer, wasmBackend),
 	)
 	if err != nil {
 		return nil, err
@@ -50,7 +53,7 @@ func newWorld(dataModel *worldDataModel) (*world, error) {
 	}, nil
 }
 
-func getHostParameters() *arwen.VMHostParameters {
+func getHostParameters(observer arwen.ExecutionObserver, wasmBackend arwen.WASMBackend) *arwen.VMHostParameters {
 	esdtTransferParser, _ := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
 	return &arwen.VMHostParameters{
 		VMType:                   []byte{5, 0},
@@ -60,6 +63,8 @@ func getHostParameters() *arwen.VMHostParameters {
 		BuiltInFuncContainer:     builtInFunctions.NewBuiltInFunctionContainer(),
 		ESDTTransferParser:       esdtTransferParser,
 		EpochNotifier:            &worldmock.EpochNotifierStub{},
+		ExecutionObserver:        observer,
+		WASMBackend:              wasmBackend,
 	}
 }
 
@@ -139,6 +144,111 @@ func (w *world) createAccount(request CreateAccountRequest) *CreateAccountRespon
 	return &CreateAccountResponse{Account: &account}
 }
 
+func (w *world) getAccount(request AccountRequest) (*GetAccountResponse, error) {
+	account, err := w.loadAccount(request.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetAccountResponse{
+		AddressHex:      toHex(account.Address),
+		Balance:         account.Balance.String(),
+		Nonce:           account.Nonce,
+		CodeHashHex:     toHex(account.CodeHash),
+		CodeMetadataHex: toHex(account.CodeMetadata),
+		OwnerAddressHex: toHex(account.OwnerAddress),
+		IsSmartContract: account.IsSmartContract,
+	}, nil
+}
+
+func (w *world) listStorageKeys(request AccountRequest) (*ListStorageKeysResponse, error) {
+	account, err := w.loadAccount(request.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(account.Storage))
+	for key := range account.Storage {
+		keys = append(keys, toHex([]byte(key)))
+	}
+	sort.Strings(keys)
+
+	return &ListStorageKeysResponse{KeysHex: keys}, nil
+}
+
+func (w *world) getStorage(request GetStorageRequest) (*GetStorageResponse, error) {
+	account, err := w.loadAccount(request.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetStorageResponse{
+		KeyHex:   toHex(request.Key),
+		ValueHex: toHex(account.StorageValue(string(request.Key))),
+	}, nil
+}
+
+func (w *world) listESDT(request AccountRequest) (*ListESDTResponse, error) {
+	account, err := w.loadAccount(request.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	systemAccountStorage := make(map[string][]byte)
+	systemAccount := w.blockchainHook.AcctMap.GetAccount(vmcommon.SystemAccountAddress)
+	if systemAccount != nil {
+		systemAccountStorage = systemAccount.Storage
+	}
+
+	tokens, err := esdtconvert.GetFullMockESDTData(account.Storage, systemAccountStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListESDTResponse{
+		Holdings: make([]*ESDTHolding, 0),
+		Roles:    make(map[string][]string),
+	}
+	for tokenIdentifier, token := range tokens {
+		for _, instance := range token.Instances {
+			response.Holdings = append(response.Holdings, &ESDTHolding{
+				TokenIdentifier: tokenIdentifier,
+				Nonce:           instance.TokenMetaData.Nonce,
+				Balance:         instance.Value.String(),
+			})
+		}
+
+		if len(token.Roles) == 0 {
+			continue
+		}
+
+		roles := make([]string, len(token.Roles))
+		for i, role := range token.Roles {
+			roles[i] = string(role)
+		}
+		response.Roles[tokenIdentifier] = roles
+	}
+
+	sort.Slice(response.Holdings, func(i, j int) bool {
+		left, right := response.Holdings[i], response.Holdings[j]
+		if left.TokenIdentifier != right.TokenIdentifier {
+			return left.TokenIdentifier < right.TokenIdentifier
+		}
+		return left.Nonce < right.Nonce
+	})
+
+	return response, nil
+}
+
+func (w *world) loadAccount(address []byte) (*worldmock.Account, error) {
+	account := w.blockchainHook.AcctMap.GetAccount(address)
+	if account == nil {
+		return nil, ErrAccountDoesntExist
+	}
+
+	return account, nil
+}
+
 func (w *world) toDataModel() *worldDataModel {
 	accounts := w.blockchainHook.AcctMap.Clone()
 	for _, account := range accounts {
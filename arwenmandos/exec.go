@@ -28,6 +28,7 @@ type ArwenTestExecutor struct {
 	vm                vmi.VMExecutionHandler
 	vmHost            arwen.VMHost
 	checkGas          bool
+	wasmBackend       arwen.WASMBackend
 	scenarioTraceGas  []bool
 	fileResolver      fr.FileResolver
 	exprReconstructor er.ExprReconstructor
@@ -50,6 +51,12 @@ func NewArwenTestExecutor() (*ArwenTestExecutor, error) {
 	}, nil
 }
 
+// SetWASMBackend selects the engine which executes the contracts.
+// Has no effect if the VM is already initialized.
+func (ae *ArwenTestExecutor) SetWASMBackend(wasmBackend arwen.WASMBackend) {
+	ae.wasmBackend = wasmBackend
+}
+
 // InitVM will initialize the VM and the builtin function container.
 // Does nothing if the VM is already initialized.
 func (ae *ArwenTestExecutor) InitVM(mandosGasSchedule mj.GasSchedule) error {
@@ -77,6 +84,7 @@ func (ae *ArwenTestExecutor) InitVM(mandosGasSchedule mj.GasSchedule) error {
 		ElrondProtectedKeyPrefix: []byte(ElrondProtectedKeyPrefix),
 		ESDTTransferParser:       esdtTransferParser,
 		EpochNotifier:            &worldhook.EpochNotifierStub{},
+		WASMBackend:              ae.wasmBackend,
 	})
 	if err != nil {
 		return err
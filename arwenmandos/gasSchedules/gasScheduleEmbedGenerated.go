@@ -97,6 +97,12 @@ const (
     GetReturnData        = 100
     GetNumReturnData     = 100
     GetReturnDataSize    = 100
+    GetCodeMetadata      = 5000
+    IsPayable            = 5000
+    GetESDTLocalRoles    = 7000
+    IsESDTFrozen         = 7000
+    IsESDTPaused         = 5000
+    IsESDTLimitedTransfer = 5000
 
 [EthAPICost]
     UseGas              = 100
@@ -153,6 +159,12 @@ const (
     BigIntSqrt               = 6000
     BigIntPow                = 6000
     BigIntLog                = 6000
+    BigIntModPow             = 6000
+    BigIntModPowPerWord      = 30
+    BigIntExpMod             = 6000
+    BigIntModInverse         = 6000
+    BigIntMulMod             = 6000
+    BigIntGCD                = 6000
     BigIntTDiv               = 6000
     BigIntTMod               = 6000
     BigIntEDiv               = 6000
@@ -196,6 +208,27 @@ const (
     UnmarshalCompressedECC = 270000
     GenerateKeyECC         = 7000000
     EncodeDERSig           = 1000000
+    VerifyBLSMultiSig         = 2000000
+    VerifyBLSMultiSigPerKey   = 1000000
+    VerifyBLSAggregated       = 2000000
+    VerifyBLSAggregatedPerKey = 4000000
+    VerifySecp256r1           = 2000000
+    SHA512                    = 1000000
+    SHA512PerByte             = 100
+    SHA3256                   = 1000000
+    SHA3256PerByte            = 100
+    Blake2b256                = 1000000
+    Blake2b256PerByte         = 100
+    Blake2s256                = 1000000
+    Blake2s256PerByte         = 100
+    VerifyEd25519Batch          = 500000
+    VerifyEd25519BatchPerItem   = 1500000
+    VerifySecp256k1Batch        = 500000
+    VerifySecp256k1BatchPerItem = 1500000
+    MerkleProof                 = 100000
+    MerkleProofPerStep          = 250000
+    PatriciaMerkleProof         = 100000
+    PatriciaMerkleProofPerNode  = 500000
 
 [ManagedBufferAPICost]
     MBufferNew                   = 2000
@@ -216,6 +249,18 @@ const (
     MBufferGetArgument           = 1000
     MBufferFinish                = 1000
     MBufferSetRandom             = 6000
+    MVecNew                      = 2000
+    MVecGetLength                = 2000
+    MVecPush                     = 2000
+    MVecGet                      = 2000
+    MVecSet                      = 2000
+    MVecRemove                   = 2000
+    MMapNew                      = 2000
+    MMapGetLength                = 2000
+    MMapPut                      = 4000
+    MMapGet                      = 4000
+    MMapRemove                   = 4000
+    MMapContains                 = 4000
 
 [WASMOpcodeCost]
     Unreachable = 1
@@ -759,6 +804,12 @@ const (
     GetReturnData        = 100
     GetNumReturnData     = 100
     GetReturnDataSize    = 100
+    GetCodeMetadata      = 5000
+    IsPayable            = 5000
+    GetESDTLocalRoles    = 7000
+    IsESDTFrozen         = 7000
+    IsESDTPaused         = 5000
+    IsESDTLimitedTransfer = 5000
     GetOriginalTxHash    = 10000
 
 [EthAPICost]
@@ -816,6 +867,12 @@ const (
     BigIntSqrt               = 6000
     BigIntPow                = 6000
     BigIntLog                = 6000
+    BigIntModPow             = 6000
+    BigIntModPowPerWord      = 30
+    BigIntExpMod             = 6000
+    BigIntModInverse         = 6000
+    BigIntMulMod             = 6000
+    BigIntGCD                = 6000
     BigIntTDiv               = 6000
     BigIntTMod               = 6000
     BigIntEDiv               = 6000
@@ -859,6 +916,27 @@ const (
     UnmarshalCompressedECC = 270000
     GenerateKeyECC         = 7000000
     EncodeDERSig           = 10000000
+    VerifyBLSMultiSig         = 2000000
+    VerifyBLSMultiSigPerKey   = 1000000
+    VerifyBLSAggregated       = 2000000
+    VerifyBLSAggregatedPerKey = 4000000
+    VerifySecp256r1           = 2000000
+    SHA512                    = 1000000
+    SHA512PerByte             = 100
+    SHA3256                   = 1000000
+    SHA3256PerByte            = 100
+    Blake2b256                = 1000000
+    Blake2b256PerByte         = 100
+    Blake2s256                = 1000000
+    Blake2s256PerByte         = 100
+    VerifyEd25519Batch          = 500000
+    VerifyEd25519BatchPerItem   = 1500000
+    VerifySecp256k1Batch        = 500000
+    VerifySecp256k1BatchPerItem = 1500000
+    MerkleProof                 = 100000
+    MerkleProofPerStep          = 250000
+    PatriciaMerkleProof         = 100000
+    PatriciaMerkleProofPerNode  = 500000
 
 [ManagedBufferAPICost]
     MBufferNew                   = 2000
@@ -879,6 +957,18 @@ const (
     MBufferGetArgument           = 1000
     MBufferFinish                = 1000
     MBufferSetRandom             = 6000
+    MVecNew                      = 2000
+    MVecGetLength                = 2000
+    MVecPush                     = 2000
+    MVecGet                      = 2000
+    MVecSet                      = 2000
+    MVecRemove                   = 2000
+    MMapNew                      = 2000
+    MMapGetLength                = 2000
+    MMapPut                      = 4000
+    MMapGet                      = 4000
+    MMapRemove                   = 4000
+    MMapContains                 = 4000
 
 [WASMOpcodeCost]
     Unreachable = 5
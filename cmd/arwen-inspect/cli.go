@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/urfave/cli"
+)
+
+var errIncompatibleContract = errors.New("contract not compatible with this VM")
+var errNoContracts = errors.New("no contract files given")
+var errUnknownFormat = errors.New("unknown output format")
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+func initializeCLI() *cli.App {
+	app := cli.NewApp()
+	app.Name = "Arwen Inspect"
+	app.Usage = "reports the endpoints, the imported EI functions and the validity of WASM contracts"
+	app.ArgsUsage = "<contract.wasm> [<contract.wasm> ...]"
+
+	format := formatTable
+	flagFormat := cli.StringFlag{
+		Name:        "format",
+		Usage:       "output format, either \"table\" or \"json\"",
+		Value:       formatTable,
+		Destination: &format,
+	}
+
+	wasmBackend := string(arwen.WASMBackendWasmer)
+	flagWASMBackend := cli.StringFlag{
+		Name:        "wasm-backend",
+		Usage:       "the engine which instantiates the contracts, either \"wasmer\" or \"go\"",
+		Value:       string(arwen.WASMBackendWasmer),
+		Destination: &wasmBackend,
+	}
+
+	app.Flags = []cli.Flag{
+		flagFormat,
+		flagWASMBackend,
+	}
+
+	app.Authors = []cli.Author{
+		{
+			Name:  "The Elrond Team",
+			Email: "contact@elrond.com",
+		},
+	}
+
+	app.Action = func(context *cli.Context) error {
+		if context.NArg() == 0 {
+			return errNoContracts
+		}
+		if format != formatTable && format != formatJSON {
+			return errUnknownFormat
+		}
+
+		inspector, err := newContractInspector(arwen.WASMBackend(wasmBackend))
+		if err != nil {
+			return err
+		}
+
+		reports := make([]*contractReport, 0, context.NArg())
+		for _, filePath := range context.Args() {
+			report, err := inspector.inspectFile(filePath)
+			if err != nil {
+				return err
+			}
+			reports = append(reports, report)
+		}
+
+		if format == formatJSON {
+			err = writeReportsJSON(os.Stdout, reports)
+		} else {
+			err = writeReportsTable(os.Stdout, reports)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, report := range reports {
+			if !report.isCompatible() {
+				return errIncompatibleContract
+			}
+		}
+
+		return nil
+	}
+
+	return app
+}
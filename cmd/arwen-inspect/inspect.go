@@ -0,0 +1,187 @@
+package main
+
+import (
+	"io/ioutil"
+	"sort"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/contexts"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/host"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/config"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer"
+	"github.com/ElrondNetwork/elrond-vm-common/builtInFunctions"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+)
+
+const importsNamespace = "env"
+
+const inspectionGasLimit = uint64(1) << 62
+
+// contractInspector inspects contracts using a VM host, which provides both
+// the EI functions and the validation of new contracts
+type contractInspector struct {
+	vmHost       arwen.VMHost
+	importGroups map[string]string
+}
+
+func newContractInspector(wasmBackend arwen.WASMBackend) (*contractInspector, error) {
+	esdtTransferParser, err := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	if err != nil {
+		return nil, err
+	}
+
+	vmHost, err := host.NewArwenVM(worldmock.NewMockWorld(), &arwen.VMHostParameters{
+		VMType:                   []byte{5, 0},
+		BlockGasLimit:            inspectionGasLimit,
+		GasSchedule:              config.MakeGasMap(1, 1),
+		ElrondProtectedKeyPrefix: []byte("ELROND"),
+		BuiltInFuncContainer:     builtInFunctions.NewBuiltInFunctionContainer(),
+		ESDTTransferParser:       esdtTransferParser,
+		EpochNotifier:            &worldmock.EpochNotifierStub{},
+		WASMBackend:              wasmBackend,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groupsByImport := make(map[string]string)
+	for _, group := range host.EIImportGroups {
+		imports, err := group.Imports(wasmer.NewImports())
+		if err != nil {
+			return nil, err
+		}
+
+		for name := range imports.Names() {
+			groupsByImport[name] = group.Name
+		}
+	}
+
+	return &contractInspector{
+		vmHost:       vmHost,
+		importGroups: groupsByImport,
+	}, nil
+}
+
+func (inspector *contractInspector) inspectFile(filePath string) (*contractReport, error) {
+	code, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := inspector.inspect(code)
+	report.File = filePath
+	return report, nil
+}
+
+func (inspector *contractInspector) inspect(code []byte) *contractReport {
+	report := &contractReport{
+		Size:      len(code),
+		Endpoints: make([]*endpointReport, 0),
+		Imports:   make([]*importReport, 0),
+		Memories:  make([]*memoryReport, 0),
+	}
+
+	summary, err := contexts.ParseWASMModuleSummary(code)
+	if err != nil {
+		report.ValidationError = err.Error()
+		return report
+	}
+
+	inspector.reportImports(report, summary)
+	reportMemories(report, summary)
+
+	err = inspector.verifyContract(code)
+	report.Valid = err == nil
+	if err != nil {
+		report.ValidationError = err.Error()
+	}
+
+	err = inspector.reportEndpoints(report, code)
+	if err != nil && report.Valid {
+		report.Valid = false
+		report.ValidationError = err.Error()
+	}
+
+	return report
+}
+
+func (inspector *contractInspector) reportImports(report *contractReport, summary *contexts.WASMModuleSummary) {
+	knownImports := inspector.vmHost.GetAPIMethods().Names()
+
+	for _, functionImport := range summary.FunctionImports {
+		_, isKnown := knownImports[functionImport.Name]
+		isKnown = isKnown && functionImport.Module == importsNamespace
+
+		group := ""
+		if isKnown {
+			group = inspector.importGroups[functionImport.Name]
+		}
+
+		report.Imports = append(report.Imports, &importReport{
+			Module: functionImport.Module,
+			Name:   functionImport.Name,
+			Group:  group,
+			Known:  isKnown,
+		})
+	}
+}
+
+func reportMemories(report *contractReport, summary *contexts.WASMModuleSummary) {
+	for _, memory := range summary.Memories {
+		memoryReport := &memoryReport{InitialPages: memory.InitialPages}
+		if memory.HasMax {
+			maxPages := memory.MaxPages
+			memoryReport.MaxPages = &maxPages
+		}
+		report.Memories = append(report.Memories, memoryReport)
+	}
+}
+
+// verifyContract runs the verifications applied by the VM to newly deployed contracts
+func (inspector *contractInspector) verifyContract(code []byte) error {
+	runtime := inspector.vmHost.Runtime()
+	runtime.MustVerifyNextContractCode()
+
+	err := runtime.StartWasmerInstance(code, inspectionGasLimit, true)
+	if err != nil {
+		return err
+	}
+
+	runtime.CleanWasmerInstance()
+	return nil
+}
+
+// reportEndpoints instantiates the contract without the verifications applied
+// to new contracts, in order to report the endpoints of invalid contracts too
+func (inspector *contractInspector) reportEndpoints(report *contractReport, code []byte) error {
+	runtime := inspector.vmHost.Runtime()
+	runtime.InitState()
+
+	err := runtime.StartWasmerInstance(code, inspectionGasLimit, true)
+	if err != nil {
+		return err
+	}
+	defer runtime.CleanWasmerInstance()
+
+	instance := runtime.GetInstance()
+	report.HasMemory = instance.HasMemory()
+
+	names := make([]string, 0, len(instance.GetExports()))
+	for name := range instance.GetExports() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		endpoint := &endpointReport{Name: name}
+		signature, ok := instance.GetSignature(name)
+		if ok {
+			endpoint.InputArity = signature.InputArity
+			endpoint.OutputArity = signature.OutputArity
+		}
+		report.Endpoints = append(report.Endpoints, endpoint)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/host"
+	"github.com/stretchr/testify/require"
+)
+
+const counterPath = "../../test/contracts/counter/output/counter.wasm"
+
+// unknownImportModule is a WASM module which imports env.unknownFunction and
+// exports a single function, named "init"
+var unknownImportModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, // magic and version
+	0x01, 0x04, 0x01, 0x60, 0x00, 0x00, // type section: func () -> ()
+	0x02, 0x17, 0x01, // import section, with one import
+	0x03, 'e', 'n', 'v',
+	0x0f, 'u', 'n', 'k', 'n', 'o', 'w', 'n', 'F', 'u', 'n', 'c', 't', 'i', 'o', 'n',
+	0x00, 0x00, // function of type 0
+	0x03, 0x02, 0x01, 0x00, // function section
+	0x05, 0x03, 0x01, 0x00, 0x01, // memory section: one page
+	0x07, 0x08, 0x01, 0x04, 'i', 'n', 'i', 't', 0x00, 0x01, // export section
+	0x0a, 0x04, 0x01, 0x02, 0x00, 0x0b, // code section
+}
+
+func newTestInspector(t *testing.T) *contractInspector {
+	inspector, err := newContractInspector(arwen.WASMBackendGo)
+	require.Nil(t, err)
+	return inspector
+}
+
+func TestInspect_ReportJSON(t *testing.T) {
+	report, err := newTestInspector(t).inspectFile(counterPath)
+	require.Nil(t, err)
+
+	output := &bytes.Buffer{}
+	require.Nil(t, writeReportsJSON(output, []*contractReport{report}))
+
+	decoded := make([]*contractReport, 0)
+	require.Nil(t, json.Unmarshal(output.Bytes(), &decoded))
+	require.Len(t, decoded, 1)
+
+	counter := decoded[0]
+	require.Equal(t, counterPath, counter.File)
+	require.True(t, counter.Valid, counter.ValidationError)
+	require.True(t, counter.isCompatible())
+	require.True(t, counter.HasMemory)
+	require.Contains(t, endpointNames(counter), "increment")
+	require.Contains(t, endpointNames(counter), "get")
+
+	require.NotEmpty(t, counter.Imports)
+	for _, functionImport := range counter.Imports {
+		require.True(t, functionImport.Known, functionImport.Name)
+		require.NotEmpty(t, functionImport.Group, functionImport.Name)
+	}
+}
+
+func TestInspect_ReportTable(t *testing.T) {
+	report, err := newTestInspector(t).inspectFile(counterPath)
+	require.Nil(t, err)
+
+	output := &bytes.Buffer{}
+	require.Nil(t, writeReportsTable(output, []*contractReport{report}))
+
+	table := output.String()
+	require.True(t, hasLineWithFields(table, "Contract:", counterPath))
+	require.True(t, hasLineWithFields(table, "Valid:", "true"))
+	require.NotContains(t, table, "Unknown imports:")
+	require.True(t, hasLineWithFields(table, "increment", "0", "0"))
+	require.True(t, hasLineWithFields(table, "env.int64storageStore", "SmallInt", "true"))
+}
+
+func TestInspect_UnknownImport(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "unknown.wasm")
+	require.Nil(t, ioutil.WriteFile(filePath, unknownImportModule, 0644))
+
+	report, err := newTestInspector(t).inspectFile(filePath)
+	require.Nil(t, err)
+
+	require.Equal(t, []string{"env.unknownFunction"}, report.unknownImports())
+	require.False(t, report.Imports[0].Known)
+	require.Empty(t, report.Imports[0].Group)
+	require.False(t, report.Valid)
+	require.NotEmpty(t, report.ValidationError)
+	require.False(t, report.isCompatible())
+
+	output := &bytes.Buffer{}
+	require.Nil(t, writeReportsTable(output, []*contractReport{report}))
+	require.Contains(t, output.String(), "env.unknownFunction")
+}
+
+func TestInspect_ImportGroupsCoverTheHostImports(t *testing.T) {
+	inspector := newTestInspector(t)
+
+	imports, err := host.NewEIImports()
+	require.Nil(t, err)
+
+	for name := range imports.Names() {
+		require.NotEmpty(t, inspector.importGroups[name], name)
+	}
+	require.Len(t, inspector.importGroups, len(imports.Names()))
+}
+
+func endpointNames(report *contractReport) []string {
+	names := make([]string, 0, len(report.Endpoints))
+	for _, endpoint := range report.Endpoints {
+		names = append(names, endpoint.Name)
+	}
+	return names
+}
+
+func hasLineWithFields(text string, fields ...string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		if strings.Join(strings.Fields(line), " ") == strings.Join(fields, " ") {
+			return true
+		}
+	}
+	return false
+}
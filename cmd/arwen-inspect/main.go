@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+)
+
+const (
+	// ErrCodeSuccess signals that all contracts are compatible with this VM
+	ErrCodeSuccess = iota
+	// ErrCodeCriticalError signals a critical error
+	ErrCodeCriticalError
+	// ErrCodeIncompatibleContract signals that at least one contract is not
+	// compatible with this VM
+	ErrCodeIncompatibleContract
+)
+
+func main() {
+	_ = logger.SetLogLevel("*:NONE")
+
+	app := initializeCLI()
+
+	err := app.Run(os.Args)
+	if err == errIncompatibleContract {
+		os.Exit(ErrCodeIncompatibleContract)
+	}
+	if err != nil {
+		// the logger is silenced, so that it does not clutter the reports
+		fmt.Fprintln(os.Stderr, "error:", err.Error())
+		os.Exit(ErrCodeCriticalError)
+	}
+
+	os.Exit(ErrCodeSuccess)
+}
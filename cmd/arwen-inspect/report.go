@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// contractReport holds the result of inspecting a contract
+type contractReport struct {
+	File            string            `json:"file"`
+	Size            int               `json:"size"`
+	Valid           bool              `json:"valid"`
+	ValidationError string            `json:"validationError,omitempty"`
+	HasMemory       bool              `json:"hasMemory"`
+	Memories        []*memoryReport   `json:"memories"`
+	Endpoints       []*endpointReport `json:"endpoints"`
+	Imports         []*importReport   `json:"imports"`
+}
+
+// endpointReport describes a function exported by a contract
+type endpointReport struct {
+	Name        string `json:"name"`
+	InputArity  int    `json:"inputArity"`
+	OutputArity int    `json:"outputArity"`
+}
+
+// importReport describes a function imported by a contract, and whether it is
+// provided by this VM
+type importReport struct {
+	Module string `json:"module"`
+	Name   string `json:"name"`
+	Group  string `json:"group,omitempty"`
+	Known  bool   `json:"known"`
+}
+
+// memoryReport describes a memory declared or imported by a contract, in pages
+type memoryReport struct {
+	InitialPages uint32  `json:"initialPages"`
+	MaxPages     *uint32 `json:"maxPages,omitempty"`
+}
+
+func (report *contractReport) unknownImports() []string {
+	unknown := make([]string, 0)
+	for _, functionImport := range report.Imports {
+		if !functionImport.Known {
+			unknown = append(unknown, functionImport.Module+"."+functionImport.Name)
+		}
+	}
+
+	return unknown
+}
+
+func (report *contractReport) isCompatible() bool {
+	return report.Valid && len(report.unknownImports()) == 0
+}
+
+func writeReportsJSON(writer io.Writer, reports []*contractReport) error {
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(writer, string(data))
+	return err
+}
+
+func writeReportsTable(writer io.Writer, reports []*contractReport) error {
+	table := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+
+	for _, report := range reports {
+		fmt.Fprintf(table, "Contract:\t%s\n", report.File)
+		fmt.Fprintf(table, "Size:\t%d bytes\n", report.Size)
+		fmt.Fprintf(table, "Valid:\t%t\n", report.Valid)
+		if len(report.ValidationError) > 0 {
+			fmt.Fprintf(table, "Validation error:\t%s\n", report.ValidationError)
+		}
+		unknownImports := report.unknownImports()
+		if len(unknownImports) > 0 {
+			fmt.Fprintf(table, "Unknown imports:\t%s\n", strings.Join(unknownImports, ", "))
+		}
+		fmt.Fprintf(table, "Exported memory:\t%t\n", report.HasMemory)
+		for _, memory := range report.Memories {
+			maxPages := "unbounded"
+			if memory.MaxPages != nil {
+				maxPages = fmt.Sprintf("%d", *memory.MaxPages)
+			}
+			fmt.Fprintf(table, "Memory pages:\tinitial %d, max %s\n", memory.InitialPages, maxPages)
+		}
+
+		fmt.Fprintf(table, "\nENDPOINT\tINPUTS\tOUTPUTS\n")
+		for _, endpoint := range report.Endpoints {
+			fmt.Fprintf(table, "%s\t%d\t%d\n", endpoint.Name, endpoint.InputArity, endpoint.OutputArity)
+		}
+
+		fmt.Fprintf(table, "\nIMPORT\tGROUP\tKNOWN\n")
+		for _, functionImport := range report.Imports {
+			fmt.Fprintf(table, "%s.%s\t%s\t%t\n", functionImport.Module, functionImport.Name, functionImport.Group, functionImport.Known)
+		}
+		fmt.Fprintln(table)
+	}
+
+	return table.Flush()
+}
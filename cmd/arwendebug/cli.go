@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwendebug"
 	"github.com/urfave/cli"
 )
@@ -19,6 +20,20 @@ func initializeCLI(facade *arwendebug.DebugFacade) *cli.App {
 		Destination: &args.ServerAddress,
 	}
 
+	// For proxy
+	flagChainID := cli.StringFlag{
+		Name:        "chain-id",
+		Value:       arwendebug.DefaultChainID,
+		Destination: &args.ChainID,
+	}
+
+	flagWASMBackend := cli.StringFlag{
+		Name:        "wasm-backend",
+		Usage:       "the engine which executes the contracts (wasmer or go)",
+		Value:       string(arwen.WASMBackendWasmer),
+		Destination: &args.WASMBackend,
+	}
+
 	// Common for all actions
 	flagDatabase := cli.StringFlag{
 		Name:        "database",
@@ -114,6 +129,35 @@ func initializeCLI(facade *arwendebug.DebugFacade) *cli.App {
 		Destination: &args.AccountNonce,
 	}
 
+	// For state inspection
+	flagStorageKey := cli.StringFlag{
+		Required:    true,
+		Name:        "key",
+		Destination: &args.StorageKey,
+	}
+
+	// For history
+	flagStep := cli.IntFlag{
+		Required:    true,
+		Name:        "step",
+		Usage:       "the step of the history (0 for the world before its history started)",
+		Destination: &args.Step,
+	}
+
+	flagNewWorld := cli.StringFlag{
+		Required:    true,
+		Name:        "new-world",
+		Destination: &args.NewWorld,
+	}
+
+	// For export-scenario
+	flagScenarioPath := cli.StringFlag{
+		Required:    true,
+		Name:        "scenario",
+		Usage:       "the path of the .scen.json file to write",
+		Destination: &args.ScenarioPath,
+	}
+
 	app.Flags = []cli.Flag{}
 
 	app.Authors = []cli.Author{
@@ -135,6 +179,25 @@ func initializeCLI(facade *arwendebug.DebugFacade) *cli.App {
 				flagServerAddress,
 			},
 		},
+		{
+			Name:        "proxy",
+			Description: "start a local chain, served through a subset of the Elrond proxy API",
+			Action: func(context *cli.Context) error {
+				server, err := arwendebug.NewProxyServer(args.ServerAddress, args.Database, args.World, args.ChainID, arwen.WASMBackend(args.WASMBackend))
+				if err != nil {
+					return err
+				}
+
+				return server.Start()
+			},
+			Flags: []cli.Flag{
+				flagServerAddress,
+				flagDatabase,
+				flagWorld,
+				flagChainID,
+				flagWASMBackend,
+			},
+		},
 		{
 			Name:        "deploy",
 			Description: "deploy a smart contract",
@@ -232,6 +295,133 @@ func initializeCLI(facade *arwendebug.DebugFacade) *cli.App {
 				flagAccountNonce,
 			},
 		},
+		{
+			Name:        "list-worlds",
+			Description: "list the worlds in the database",
+			Action: func(context *cli.Context) error {
+				_, err := facade.ListWorlds(args.toListWorldsRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagDatabase,
+			},
+		},
+		{
+			Name:        "list-outcomes",
+			Description: "list the outcomes in the database",
+			Action: func(context *cli.Context) error {
+				_, err := facade.ListOutcomes(args.toListOutcomesRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagDatabase,
+			},
+		},
+		{
+			Name:        "get-account",
+			Description: "get account",
+			Action: func(context *cli.Context) error {
+				_, err := facade.GetAccount(args.toAccountRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagWorld,
+				flagDatabase,
+				flagAccountAddress,
+			},
+		},
+		{
+			Name:        "list-storage",
+			Description: "list the storage keys of an account",
+			Action: func(context *cli.Context) error {
+				_, err := facade.ListStorageKeys(args.toAccountRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagWorld,
+				flagDatabase,
+				flagAccountAddress,
+			},
+		},
+		{
+			Name:        "get-storage",
+			Description: "get a storage value of an account",
+			Action: func(context *cli.Context) error {
+				_, err := facade.GetStorage(args.toGetStorageRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagWorld,
+				flagDatabase,
+				flagAccountAddress,
+				flagStorageKey,
+			},
+		},
+		{
+			Name:        "list-esdt",
+			Description: "list the ESDT tokens held by an account",
+			Action: func(context *cli.Context) error {
+				_, err := facade.ListESDT(args.toAccountRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagWorld,
+				flagDatabase,
+				flagAccountAddress,
+			},
+		},
+		{
+			Name:        "history",
+			Description: "list the recorded actions performed on the world",
+			Action: func(context *cli.Context) error {
+				_, err := facade.GetHistory(args.toHistoryRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagWorld,
+				flagDatabase,
+			},
+		},
+		{
+			Name:        "revert",
+			Description: "revert the world to a step of its history",
+			Action: func(context *cli.Context) error {
+				_, err := facade.RevertWorld(args.toRevertWorldRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagWorld,
+				flagDatabase,
+				flagStep,
+			},
+		},
+		{
+			Name:        "fork",
+			Description: "fork the world at a step of its history into a new world",
+			Action: func(context *cli.Context) error {
+				_, err := facade.ForkWorld(args.toForkWorldRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagWorld,
+				flagDatabase,
+				flagStep,
+				flagNewWorld,
+			},
+		},
+		{
+			Name:        "export-scenario",
+			Description: "export the history of the world as a mandos scenario",
+			Action: func(context *cli.Context) error {
+				_, err := facade.ExportScenario(args.toExportScenarioRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagWorld,
+				flagDatabase,
+				flagScenarioPath,
+			},
+		},
 	}
 
 	return app
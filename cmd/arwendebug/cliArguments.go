@@ -27,6 +27,16 @@ type cliArguments struct {
 	AccountAddress string
 	AccountBalance string
 	AccountNonce   uint64
+	// For state inspection actions
+	StorageKey string
+	// For history actions
+	Step     int
+	NewWorld string
+	// For export-scenario
+	ScenarioPath string
+	// For proxy
+	ChainID     string
+	WASMBackend string
 }
 
 func (args *cliArguments) toDeployRequest() arwendebug.DeployRequest {
@@ -99,3 +109,70 @@ func (args *cliArguments) toCreateAccountRequest() arwendebug.CreateAccountReque
 	request.Nonce = args.AccountNonce
 	return *request
 }
+
+func (args *cliArguments) toListWorldsRequest() arwendebug.ListWorldsRequest {
+	request := &arwendebug.ListWorldsRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	return *request
+}
+
+func (args *cliArguments) toListOutcomesRequest() arwendebug.ListOutcomesRequest {
+	request := &arwendebug.ListOutcomesRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	return *request
+}
+
+func (args *cliArguments) toAccountRequest() arwendebug.AccountRequest {
+	request := &arwendebug.AccountRequest{}
+	args.populateAccountRequest(request)
+
+	return *request
+}
+
+func (args *cliArguments) populateAccountRequest(request *arwendebug.AccountRequest) {
+	args.populateRequestBase(&request.RequestBase)
+
+	request.AddressHex = args.AccountAddress
+}
+
+func (args *cliArguments) toGetStorageRequest() arwendebug.GetStorageRequest {
+	request := &arwendebug.GetStorageRequest{}
+	args.populateAccountRequest(&request.AccountRequest)
+
+	request.KeyHex = args.StorageKey
+	return *request
+}
+
+func (args *cliArguments) toHistoryRequest() arwendebug.HistoryRequest {
+	request := &arwendebug.HistoryRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	return *request
+}
+
+func (args *cliArguments) toRevertWorldRequest() arwendebug.RevertWorldRequest {
+	request := &arwendebug.RevertWorldRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	request.Step = args.Step
+	return *request
+}
+
+func (args *cliArguments) toForkWorldRequest() arwendebug.ForkWorldRequest {
+	request := &arwendebug.ForkWorldRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	request.Step = args.Step
+	request.NewWorld = args.NewWorld
+	return *request
+}
+
+func (args *cliArguments) toExportScenarioRequest() arwendebug.ExportScenarioRequest {
+	request := &arwendebug.ExportScenarioRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	request.ScenarioPath = args.ScenarioPath
+	return *request
+}
@@ -216,6 +216,12 @@ func FillGasMap_ElrondAPICosts(value, asyncCallbackGasLock uint64) map[string]ui
 	gasMap["GetReturnData"] = value
 	gasMap["GetNumReturnData"] = value
 	gasMap["GetReturnDataSize"] = value
+	gasMap["GetCodeMetadata"] = value
+	gasMap["IsPayable"] = value
+	gasMap["GetESDTLocalRoles"] = value
+	gasMap["IsESDTFrozen"] = value
+	gasMap["IsESDTPaused"] = value
+	gasMap["IsESDTLimitedTransfer"] = value
 
 	return gasMap
 }
@@ -277,6 +283,12 @@ func FillGasMap_BigIntAPICosts(value uint64) map[string]uint64 {
 	gasMap["BigIntSqrt"] = value
 	gasMap["BigIntPow"] = value
 	gasMap["BigIntLog"] = value
+	gasMap["BigIntModPow"] = value
+	gasMap["BigIntModPowPerWord"] = value
+	gasMap["BigIntExpMod"] = value
+	gasMap["BigIntModInverse"] = value
+	gasMap["BigIntMulMod"] = value
+	gasMap["BigIntGCD"] = value
 	gasMap["BigIntTDiv"] = value
 	gasMap["BigIntTMod"] = value
 	gasMap["BigIntEDiv"] = value
@@ -323,6 +335,27 @@ func FillGasMap_CryptoAPICosts(value uint64) map[string]uint64 {
 	gasMap["UnmarshalCompressedECC"] = value
 	gasMap["GenerateKeyECC"] = value
 	gasMap["EncodeDERSig"] = value
+	gasMap["VerifyBLSMultiSig"] = value
+	gasMap["VerifyBLSMultiSigPerKey"] = value
+	gasMap["VerifyBLSAggregated"] = value
+	gasMap["VerifyBLSAggregatedPerKey"] = value
+	gasMap["VerifySecp256r1"] = value
+	gasMap["SHA512"] = value
+	gasMap["SHA512PerByte"] = value
+	gasMap["SHA3256"] = value
+	gasMap["SHA3256PerByte"] = value
+	gasMap["Blake2b256"] = value
+	gasMap["Blake2b256PerByte"] = value
+	gasMap["Blake2s256"] = value
+	gasMap["Blake2s256PerByte"] = value
+	gasMap["VerifyEd25519Batch"] = value
+	gasMap["VerifyEd25519BatchPerItem"] = value
+	gasMap["VerifySecp256k1Batch"] = value
+	gasMap["VerifySecp256k1BatchPerItem"] = value
+	gasMap["MerkleProof"] = value
+	gasMap["MerkleProofPerStep"] = value
+	gasMap["PatriciaMerkleProof"] = value
+	gasMap["PatriciaMerkleProofPerNode"] = value
 
 	return gasMap
 }
@@ -347,6 +380,18 @@ func FillGasMap_ManagedBufferAPICosts(value uint64) map[string]uint64 {
 	gasMap["MBufferGetArgument"] = value
 	gasMap["MBufferFinish"] = value
 	gasMap["MBufferSetRandom"] = value
+	gasMap["MVecNew"] = value
+	gasMap["MVecGetLength"] = value
+	gasMap["MVecPush"] = value
+	gasMap["MVecGet"] = value
+	gasMap["MVecSet"] = value
+	gasMap["MVecRemove"] = value
+	gasMap["MMapNew"] = value
+	gasMap["MMapGetLength"] = value
+	gasMap["MMapPut"] = value
+	gasMap["MMapGet"] = value
+	gasMap["MMapRemove"] = value
+	gasMap["MMapContains"] = value
 
 	return gasMap
 }
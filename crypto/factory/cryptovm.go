@@ -6,19 +6,35 @@ import (
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing/bls"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing/ed25519"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing/secp256k1"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing/secp256r1"
 )
 
+// vmCrypto composes the implementations of the crypto functionality of the VM
+type vmCrypto struct {
+	crypto.Hasher
+	crypto.Ed25519
+	crypto.BLS
+	crypto.Secp256k1
+	crypto.Secp256r1
+}
+
 // NewVMCrypto returns a composite struct containing VMCrypto functionality implementations
-func NewVMCrypto() crypto.VMCrypto {
-	return struct {
-		crypto.Hasher
-		crypto.Ed25519
-		crypto.BLS
-		crypto.Secp256k1
-	}{
+func NewVMCrypto() (crypto.VMCrypto, error) {
+	blsVerifier, err := bls.NewBLS()
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmCrypto{
 		Hasher:    hashing.NewHasher(),
 		Ed25519:   ed25519.NewEd25519Signer(),
-		BLS:       bls.NewBLS(),
+		BLS:       blsVerifier,
 		Secp256k1: secp256k1.NewSecp256k1(),
-	}
+		Secp256r1: secp256r1.NewSecp256r1(),
+	}, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (vc *vmCrypto) IsInterfaceNil() bool {
+	return vc == nil
 }
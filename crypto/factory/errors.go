@@ -0,0 +1,9 @@
+package factory
+
+import "errors"
+
+// ErrNilVMCrypto signals that a nil VMCrypto implementation has been provided
+var ErrNilVMCrypto = errors.New("nil VMCrypto")
+
+// ErrInvalidCacheCapacity signals that the capacity of a cache is not a positive number
+var ErrInvalidCacheCapacity = errors.New("invalid cache capacity")
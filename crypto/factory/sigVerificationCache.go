@@ -0,0 +1,179 @@
+package factory
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+)
+
+const (
+	verifyBLSTag           = "bls"
+	verifyBLSMultiSigTag   = "blsMultiSig"
+	verifyBLSAggregatedTag = "blsAggregated"
+	verifyEd25519Tag       = "ed25519"
+	verifySecp256k1Tag     = "secp256k1"
+	verifySecp256r1Tag     = "secp256r1"
+)
+
+type cachedVerification struct {
+	key string
+	err error
+}
+
+// sigVerificationCache decorates a VMCrypto, remembering the outcome of the
+// most recent signature verifications, so that a signature which is verified
+// repeatedly (e.g. by several transactions of the same block) is checked by
+// the underlying implementation only once. The outcome of a verification
+// depends solely on its arguments, therefore it can be safely reused.
+type sigVerificationCache struct {
+	crypto.VMCrypto
+	mutCache sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+// NewSigVerificationCache wraps the provided VMCrypto with a least recently
+// used cache of signature verification outcomes, holding at most capacity
+// entries; hashing and signature encoding are forwarded unchanged
+func NewSigVerificationCache(vmCrypto crypto.VMCrypto, capacity int) (crypto.VMCrypto, error) {
+	if check.IfNil(vmCrypto) {
+		return nil, ErrNilVMCrypto
+	}
+	if capacity <= 0 {
+		return nil, ErrInvalidCacheCapacity
+	}
+
+	return &sigVerificationCache{
+		VMCrypto: vmCrypto,
+		capacity: capacity,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}, nil
+}
+
+// VerifyBLS verifies a BLS signature, consulting the cache first
+func (c *sigVerificationCache) VerifyBLS(key []byte, msg []byte, sig []byte) error {
+	cacheKey := computeCacheKey(verifyBLSTag, key, msg, sig)
+	return c.verify(cacheKey, func() error {
+		return c.VMCrypto.VerifyBLS(key, msg, sig)
+	})
+}
+
+// VerifyBLSMultiSig verifies a BLS multi-signature, consulting the cache first
+func (c *sigVerificationCache) VerifyBLSMultiSig(keys [][]byte, msg []byte, aggSig []byte) error {
+	parts := append(append([][]byte{}, keys...), msg, aggSig)
+	cacheKey := computeCacheKey(verifyBLSMultiSigTag, parts...)
+	return c.verify(cacheKey, func() error {
+		return c.VMCrypto.VerifyBLSMultiSig(keys, msg, aggSig)
+	})
+}
+
+// VerifyBLSAggregated verifies a BLS aggregate signature, consulting the cache first
+func (c *sigVerificationCache) VerifyBLSAggregated(keys [][]byte, msgs [][]byte, aggSig []byte) error {
+	// the number of keys is part of the cache key, to tell apart the keys
+	// from the messages when their counts differ
+	numKeys := make([]byte, 4)
+	binary.BigEndian.PutUint32(numKeys, uint32(len(keys)))
+
+	parts := append([][]byte{numKeys}, keys...)
+	parts = append(parts, msgs...)
+	parts = append(parts, aggSig)
+	cacheKey := computeCacheKey(verifyBLSAggregatedTag, parts...)
+	return c.verify(cacheKey, func() error {
+		return c.VMCrypto.VerifyBLSAggregated(keys, msgs, aggSig)
+	})
+}
+
+// VerifyEd25519 verifies an Ed25519 signature, consulting the cache first
+func (c *sigVerificationCache) VerifyEd25519(key []byte, msg []byte, sig []byte) error {
+	cacheKey := computeCacheKey(verifyEd25519Tag, key, msg, sig)
+	return c.verify(cacheKey, func() error {
+		return c.VMCrypto.VerifyEd25519(key, msg, sig)
+	})
+}
+
+// VerifySecp256k1 verifies a secp256k1 signature, consulting the cache first
+func (c *sigVerificationCache) VerifySecp256k1(key []byte, msg []byte, sig []byte, hashType uint8) error {
+	cacheKey := computeCacheKey(verifySecp256k1Tag, []byte{hashType}, key, msg, sig)
+	return c.verify(cacheKey, func() error {
+		return c.VMCrypto.VerifySecp256k1(key, msg, sig, hashType)
+	})
+}
+
+// VerifySecp256r1 verifies a secp256r1 signature, consulting the cache first
+func (c *sigVerificationCache) VerifySecp256r1(key []byte, msg []byte, sig []byte) error {
+	cacheKey := computeCacheKey(verifySecp256r1Tag, key, msg, sig)
+	return c.verify(cacheKey, func() error {
+		return c.VMCrypto.VerifySecp256r1(key, msg, sig)
+	})
+}
+
+func (c *sigVerificationCache) verify(cacheKey string, verifyFunc func() error) error {
+	cached, found := c.get(cacheKey)
+	if found {
+		return cached.err
+	}
+
+	err := verifyFunc()
+	c.put(cacheKey, err)
+	return err
+}
+
+func (c *sigVerificationCache) get(cacheKey string) (cachedVerification, bool) {
+	c.mutCache.Lock()
+	defer c.mutCache.Unlock()
+
+	element, found := c.entries[cacheKey]
+	if !found {
+		return cachedVerification{}, false
+	}
+
+	c.order.MoveToFront(element)
+	return *element.Value.(*cachedVerification), true
+}
+
+func (c *sigVerificationCache) put(cacheKey string, err error) {
+	c.mutCache.Lock()
+	defer c.mutCache.Unlock()
+
+	element, found := c.entries[cacheKey]
+	if found {
+		element.Value.(*cachedVerification).err = err
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[cacheKey] = c.order.PushFront(&cachedVerification{key: cacheKey, err: err})
+	if c.order.Len() <= c.capacity {
+		return
+	}
+
+	oldest := c.order.Back()
+	c.order.Remove(oldest)
+	delete(c.entries, oldest.Value.(*cachedVerification).key)
+}
+
+// computeCacheKey hashes the length-prefixed arguments of a verification,
+// together with the tag of the verification algorithm
+func computeCacheKey(tag string, parts ...[]byte) string {
+	hasher := sha256.New()
+	length := make([]byte, 4)
+
+	for _, part := range append([][]byte{[]byte(tag)}, parts...) {
+		binary.BigEndian.PutUint32(length, uint32(len(part)))
+		_, _ = hasher.Write(length)
+		_, _ = hasher.Write(part)
+	}
+
+	return string(hasher.Sum(nil))
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (c *sigVerificationCache) IsInterfaceNil() bool {
+	return c == nil
+}
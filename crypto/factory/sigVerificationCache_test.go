@@ -0,0 +1,129 @@
+package factory
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSigVerificationCache_InvalidArguments(t *testing.T) {
+	cache, err := NewSigVerificationCache(nil, 10)
+	assert.Nil(t, cache)
+	assert.Equal(t, ErrNilVMCrypto, err)
+
+	cache, err = NewSigVerificationCache((*mock.VMCryptoStub)(nil), 10)
+	assert.Nil(t, cache)
+	assert.Equal(t, ErrNilVMCrypto, err)
+
+	vmCrypto, err := NewVMCrypto()
+	require.Nil(t, err)
+
+	cache, err = NewSigVerificationCache(vmCrypto, 0)
+	assert.Nil(t, cache)
+	assert.Equal(t, ErrInvalidCacheCapacity, err)
+}
+
+func TestSigVerificationCache_ReusesOutcome(t *testing.T) {
+	numCalls := 0
+	stub := &mock.VMCryptoStub{
+		VerifyEd25519Called: func(key []byte, msg []byte, sig []byte) error {
+			numCalls++
+			if string(sig) != "valid" {
+				return signing.ErrInvalidSignature
+			}
+			return nil
+		},
+	}
+
+	cache, err := NewSigVerificationCache(stub, 10)
+	require.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, cache.VerifyEd25519([]byte("key"), []byte("msg"), []byte("valid")))
+		assert.Equal(t, signing.ErrInvalidSignature, cache.VerifyEd25519([]byte("key"), []byte("msg"), []byte("invalid")))
+	}
+	assert.Equal(t, 2, numCalls)
+
+	// the algorithm is part of the cache key
+	numBLSCalls := 0
+	stub.VerifyBLSCalled = func(key []byte, msg []byte, sig []byte) error {
+		numBLSCalls++
+		return nil
+	}
+	assert.Nil(t, cache.VerifyBLS([]byte("key"), []byte("msg"), []byte("invalid")))
+	assert.Equal(t, 1, numBLSCalls)
+}
+
+func TestSigVerificationCache_ArgumentBoundaries(t *testing.T) {
+	numCalls := 0
+	stub := &mock.VMCryptoStub{
+		VerifySecp256k1Called: func(key []byte, msg []byte, sig []byte, hashType uint8) error {
+			numCalls++
+			return nil
+		},
+		VerifyBLSAggregatedCalled: func(keys [][]byte, msgs [][]byte, aggSig []byte) error {
+			numCalls++
+			return nil
+		},
+	}
+
+	cache, err := NewSigVerificationCache(stub, 10)
+	require.Nil(t, err)
+
+	_ = cache.VerifySecp256k1([]byte("ke"), []byte("ymsg"), []byte("sig"), 0)
+	_ = cache.VerifySecp256k1([]byte("key"), []byte("msg"), []byte("sig"), 0)
+	_ = cache.VerifySecp256k1([]byte("key"), []byte("msg"), []byte("sig"), 1)
+	assert.Equal(t, 3, numCalls)
+
+	numCalls = 0
+	a, b, c := []byte("a"), []byte("b"), []byte("c")
+	_ = cache.VerifyBLSAggregated([][]byte{a, b}, [][]byte{c}, []byte("sig"))
+	_ = cache.VerifyBLSAggregated([][]byte{a}, [][]byte{b, c}, []byte("sig"))
+	assert.Equal(t, 2, numCalls)
+}
+
+func TestSigVerificationCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	verified := make(map[string]int)
+	stub := &mock.VMCryptoStub{
+		VerifySecp256r1Called: func(key []byte, msg []byte, sig []byte) error {
+			verified[string(sig)]++
+			return nil
+		},
+	}
+
+	cache, err := NewSigVerificationCache(stub, 2)
+	require.Nil(t, err)
+
+	verify := func(sig string) {
+		_ = cache.VerifySecp256r1([]byte("key"), []byte("msg"), []byte(sig))
+	}
+
+	verify("first")
+	verify("second")
+	verify("first")
+	verify("third")
+	verify("first")
+	verify("second")
+
+	assert.Equal(t, 1, verified["first"])
+	assert.Equal(t, 2, verified["second"])
+	assert.Equal(t, 1, verified["third"])
+}
+
+func TestSigVerificationCache_ForwardsHashing(t *testing.T) {
+	stub := &mock.VMCryptoStub{
+		Sha256Called: func(data []byte) ([]byte, error) {
+			return append([]byte("hash:"), data...), nil
+		},
+	}
+
+	cache, err := NewSigVerificationCache(stub, 2)
+	require.Nil(t, err)
+
+	result, err := cache.Sha256([]byte("data"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("hash:data"), result)
+}
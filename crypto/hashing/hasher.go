@@ -2,7 +2,10 @@ package hashing
 
 import (
 	"crypto/sha256"
+	"crypto/sha512"
 
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
 	"golang.org/x/crypto/ripemd160"
 	"golang.org/x/crypto/sha3"
 )
@@ -50,3 +53,27 @@ func (h *hasher) Ripemd160(data []byte) ([]byte, error) {
 	result := hash.Sum(nil)
 	return result, nil
 }
+
+// Sha512 returns a sha 512 hash of the input string
+func (h *hasher) Sha512(data []byte) ([]byte, error) {
+	hash := sha512.Sum512(data)
+	return hash[:], nil
+}
+
+// Sha3256 returns a sha3 256 hash of the input string, as standardized in FIPS 202
+func (h *hasher) Sha3256(data []byte) ([]byte, error) {
+	hash := sha3.Sum256(data)
+	return hash[:], nil
+}
+
+// Blake2b256 returns an unkeyed blake2b hash of the input string, with a 256 bits digest
+func (h *hasher) Blake2b256(data []byte) ([]byte, error) {
+	hash := blake2b.Sum256(data)
+	return hash[:], nil
+}
+
+// Blake2s256 returns an unkeyed blake2s hash of the input string, with a 256 bits digest
+func (h *hasher) Blake2s256(data []byte) ([]byte, error) {
+	hash := blake2s.Sum256(data)
+	return hash[:], nil
+}
@@ -9,10 +9,28 @@ type Hasher interface {
 
 	// Ripemd160 cryptographic function
 	Ripemd160(data []byte) ([]byte, error)
+
+	// Sha512 cryptographic function
+	Sha512(data []byte) ([]byte, error)
+
+	// Sha3256 cryptographic function, the FIPS 202 variant of sha3
+	Sha3256(data []byte) ([]byte, error)
+
+	// Blake2b256 cryptographic function, with a 256 bits digest
+	Blake2b256(data []byte) ([]byte, error)
+
+	// Blake2s256 cryptographic function, with a 256 bits digest
+	Blake2s256(data []byte) ([]byte, error)
 }
 
 type BLS interface {
 	VerifyBLS(key []byte, msg []byte, sig []byte) error
+
+	// VerifyBLSMultiSig checks a signature aggregated from the signatures of several keys over the same message
+	VerifyBLSMultiSig(keys [][]byte, msg []byte, aggSig []byte) error
+
+	// VerifyBLSAggregated checks a signature aggregated from the signatures of several keys, each over its own message
+	VerifyBLSAggregated(keys [][]byte, msgs [][]byte, aggSig []byte) error
 }
 
 type Ed25519 interface {
@@ -24,10 +42,16 @@ type Secp256k1 interface {
 	EncodeSecp256k1DERSignature(r, s []byte) []byte
 }
 
+type Secp256r1 interface {
+	VerifySecp256r1(key []byte, msg []byte, sig []byte) error
+}
+
 // VMCrypto will provide the interface to the main crypto functionalities of the vm
 type VMCrypto interface {
 	Hasher
 	Ed25519
 	BLS
 	Secp256k1
+	Secp256r1
+	IsInterfaceNil() bool
 }
@@ -0,0 +1,113 @@
+package merkle
+
+import (
+	"bytes"
+)
+
+// HashFunc computes the digest of the given data
+type HashFunc func(data []byte) ([]byte, error)
+
+// BinaryProofOptions configures how the leaves and the inner nodes of a
+// binary Merkle tree are hashed
+type BinaryProofOptions struct {
+	// HashLeaf requires the leaf to be hashed before it is combined with its
+	// sibling; otherwise the leaf is expected to be a digest already
+	HashLeaf bool
+
+	// SortPairs orders each pair of digests bytewise before they are hashed
+	// together, instead of by the position given by the leaf index
+	SortPairs bool
+
+	// LeafPrefix is prepended to the leaf when it is hashed
+	LeafPrefix []byte
+
+	// NodePrefix is prepended to each pair of digests when they are hashed
+	NodePrefix []byte
+}
+
+// VerifyBinaryProof checks that the leaf at the given index belongs to the
+// binary Merkle tree with the given root, the proof holding the siblings of
+// the nodes on the path from the leaf up to the root. Unless the pairs are
+// sorted, the bit i of the index tells whether the node at height i is the
+// right child of its parent. The siblings must be digests of the hash function.
+func VerifyBinaryProof(
+	hashFunc HashFunc,
+	leaf []byte,
+	proof [][]byte,
+	index uint64,
+	root []byte,
+	options BinaryProofOptions,
+) error {
+	if !options.SortPairs && len(proof) < 64 && index>>uint(len(proof)) != 0 {
+		return ErrInvalidProof
+	}
+	err := checkSiblingSizes(hashFunc, proof)
+	if err != nil {
+		return err
+	}
+
+	current := leaf
+	if options.HashLeaf {
+		current, err = hashFunc(concat(options.LeafPrefix, leaf))
+		if err != nil {
+			return err
+		}
+	}
+
+	for height, sibling := range proof {
+		isRightChild := height < 64 && index&(1<<uint(height)) != 0
+		if options.SortPairs {
+			isRightChild = bytes.Compare(sibling, current) < 0
+		}
+
+		left, right := current, sibling
+		if isRightChild {
+			left, right = sibling, current
+		}
+
+		current, err = hashFunc(concat(options.NodePrefix, left, right))
+		if err != nil {
+			return err
+		}
+	}
+
+	if !bytes.Equal(current, root) {
+		return ErrInvalidProof
+	}
+
+	return nil
+}
+
+// checkSiblingSizes rejects the siblings which are not as long as a digest,
+// so that the nodes hashed while verifying a proof have a bounded size
+func checkSiblingSizes(hashFunc HashFunc, proof [][]byte) error {
+	if len(proof) == 0 {
+		return nil
+	}
+
+	emptyDigest, err := hashFunc(nil)
+	if err != nil {
+		return err
+	}
+	for _, sibling := range proof {
+		if len(sibling) != len(emptyDigest) {
+			return ErrInvalidSiblingSize
+		}
+	}
+
+	return nil
+}
+
+func concat(parts ...[]byte) []byte {
+	length := 0
+	for _, part := range parts {
+		length += len(part)
+	}
+
+	result := make([]byte, 0, length)
+	for _, part := range parts {
+		result = append(result, part...)
+	}
+
+	return result
+}
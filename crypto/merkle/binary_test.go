@@ -0,0 +1,97 @@
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Hash(data []byte) ([]byte, error) {
+	hash := sha256.Sum256(data)
+	return hash[:], nil
+}
+
+func mustHash(t *testing.T, parts ...[]byte) []byte {
+	hash, err := sha256Hash(concat(parts...))
+	require.Nil(t, err)
+	return hash
+}
+
+func TestVerifyBinaryProof_Positional(t *testing.T) {
+	leaves := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}
+	hashes := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		hashes[i] = mustHash(t, leaf)
+	}
+	left := mustHash(t, hashes[0], hashes[1])
+	right := mustHash(t, hashes[2], hashes[3])
+	root := mustHash(t, left, right)
+
+	options := BinaryProofOptions{HashLeaf: true}
+	assert.Nil(t, VerifyBinaryProof(sha256Hash, leaves[2], [][]byte{hashes[3], left}, 2, root, options))
+	assert.Nil(t, VerifyBinaryProof(sha256Hash, leaves[1], [][]byte{hashes[0], right}, 1, root, options))
+	assert.Equal(t, ErrInvalidProof, VerifyBinaryProof(sha256Hash, leaves[2], [][]byte{hashes[3], left}, 3, root, options))
+	assert.Equal(t, ErrInvalidProof, VerifyBinaryProof(sha256Hash, leaves[2], [][]byte{hashes[3], left}, 6, root, options))
+
+	// without hashing, the leaf must be given as a digest
+	options.HashLeaf = false
+	assert.Nil(t, VerifyBinaryProof(sha256Hash, hashes[2], [][]byte{hashes[3], left}, 2, root, options))
+	assert.Equal(t, ErrInvalidProof, VerifyBinaryProof(sha256Hash, leaves[2], [][]byte{hashes[3], left}, 2, root, options))
+}
+
+func TestVerifyBinaryProof_SortedPairs(t *testing.T) {
+	first := mustHash(t, []byte("first"))
+	second := mustHash(t, []byte("second"))
+	sibling := mustHash(t, []byte("sibling"))
+
+	sortedHash := func(a, b []byte) []byte {
+		if bytes.Compare(a, b) > 0 {
+			a, b = b, a
+		}
+		return mustHash(t, a, b)
+	}
+	root := sortedHash(sortedHash(first, second), sibling)
+
+	options := BinaryProofOptions{SortPairs: true}
+	assert.Nil(t, VerifyBinaryProof(sha256Hash, first, [][]byte{second, sibling}, 0, root, options))
+	assert.Nil(t, VerifyBinaryProof(sha256Hash, second, [][]byte{first, sibling}, 0, root, options))
+	assert.Equal(t, ErrInvalidProof, VerifyBinaryProof(sha256Hash, sibling, [][]byte{first, second}, 0, root, options))
+}
+
+func TestVerifyBinaryProof_DomainSeparation(t *testing.T) {
+	leafHash := mustHash(t, []byte{0}, []byte("leaf"))
+	siblingHash := mustHash(t, []byte{0}, []byte("sibling"))
+	root := mustHash(t, []byte{1}, siblingHash, leafHash)
+
+	options := BinaryProofOptions{
+		HashLeaf:   true,
+		LeafPrefix: []byte{0},
+		NodePrefix: []byte{1},
+	}
+	assert.Nil(t, VerifyBinaryProof(sha256Hash, []byte("leaf"), [][]byte{siblingHash}, 1, root, options))
+
+	// an inner node cannot pass for a leaf
+	assert.Equal(t, ErrInvalidProof, VerifyBinaryProof(sha256Hash, concat(siblingHash, leafHash), nil, 0, root, options))
+}
+
+func TestVerifyBinaryProof_EmptyProof(t *testing.T) {
+	leafHash := mustHash(t, []byte("leaf"))
+
+	assert.Nil(t, VerifyBinaryProof(sha256Hash, leafHash, nil, 0, leafHash, BinaryProofOptions{}))
+	assert.Equal(t, ErrInvalidProof, VerifyBinaryProof(sha256Hash, leafHash, nil, 1, leafHash, BinaryProofOptions{}))
+}
+
+func TestVerifyBinaryProof_SiblingsMustBeDigests(t *testing.T) {
+	leafHash := mustHash(t, []byte("leaf"))
+	siblingHash := mustHash(t, []byte("sibling"))
+	root := mustHash(t, leafHash, siblingHash)
+
+	assert.Nil(t, VerifyBinaryProof(sha256Hash, leafHash, [][]byte{siblingHash}, 0, root, BinaryProofOptions{}))
+
+	longSibling := append(siblingHash, make([]byte, 1000)...)
+	assert.Equal(t, ErrInvalidSiblingSize, VerifyBinaryProof(sha256Hash, leafHash, [][]byte{longSibling}, 0, root, BinaryProofOptions{}))
+	assert.Equal(t, ErrInvalidSiblingSize, VerifyBinaryProof(sha256Hash, leafHash, [][]byte{siblingHash[:16]}, 0, root, BinaryProofOptions{}))
+}
@@ -0,0 +1,18 @@
+package merkle
+
+import "errors"
+
+// ErrInvalidProof signals that a proof does not lead to the expected root hash
+var ErrInvalidProof = errors.New("invalid merkle proof")
+
+// ErrInvalidSiblingSize signals that a sibling from a binary proof is not a digest of the hash function
+var ErrInvalidSiblingSize = errors.New("merkle proof sibling is not a digest")
+
+// ErrEmptyProof signals that a proof does not contain any node
+var ErrEmptyProof = errors.New("empty merkle proof")
+
+// ErrInvalidNodeEncoding signals that a trie node from a proof cannot be decoded
+var ErrInvalidNodeEncoding = errors.New("invalid trie node encoding")
+
+// ErrKeyNotFound signals that the proof shows that a key is missing from the trie
+var ErrKeyNotFound = errors.New("key not found in trie")
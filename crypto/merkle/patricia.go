@@ -0,0 +1,181 @@
+package merkle
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// The trie nodes of a proof are encoded the way the Elrond state trie stores
+// them: the protobuf serialization of the collapsed node, followed by a byte
+// holding the type of the node
+const (
+	extensionNode = 0
+	leafNode      = 1
+	branchNode    = 2
+)
+
+const (
+	numBranchChildren = 17
+	hexTerminator     = 16
+	nibbleMask        = 0x0f
+	nibbleSize        = 4
+)
+
+const (
+	protobufKeyField   = 1
+	protobufValueField = 2
+	wireTypeBytes      = 2
+)
+
+// VerifyPatriciaProof checks a proof obtained from a Patricia-Merkle trie of
+// the Elrond state, holding the encoded nodes on the path from the root to
+// the leaf of the key, and returns the value stored under the key. The nodes
+// are hashed with the hash function of the trie, Blake2b-256 for the state.
+func VerifyPatriciaProof(hashFunc HashFunc, rootHash []byte, key []byte, proof [][]byte) ([]byte, error) {
+	if len(proof) == 0 {
+		return nil, ErrEmptyProof
+	}
+
+	wantHash := rootHash
+	hexKey := keyBytesToHex(key)
+	for _, encodedNode := range proof {
+		hash, err := hashFunc(encodedNode)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(hash, wantHash) {
+			return nil, ErrInvalidProof
+		}
+		if len(encodedNode) == 0 {
+			return nil, ErrInvalidNodeEncoding
+		}
+
+		fields := encodedNode[:len(encodedNode)-1]
+		switch encodedNode[len(encodedNode)-1] {
+		case leafNode:
+			leafKey, value, err := decodeKeyValueNode(fields)
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(leafKey, hexKey) {
+				return nil, ErrKeyNotFound
+			}
+			return value, nil
+		case extensionNode:
+			extensionKey, child, err := decodeKeyValueNode(fields)
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.HasPrefix(hexKey, extensionKey) {
+				return nil, ErrKeyNotFound
+			}
+			wantHash = child
+			hexKey = hexKey[len(extensionKey):]
+		case branchNode:
+			children, err := decodeBranchNode(fields)
+			if err != nil {
+				return nil, err
+			}
+			if len(hexKey) == 0 || int(hexKey[0]) >= numBranchChildren {
+				return nil, ErrKeyNotFound
+			}
+			wantHash = children[hexKey[0]]
+			hexKey = hexKey[1:]
+			if len(wantHash) == 0 {
+				return nil, ErrKeyNotFound
+			}
+		default:
+			return nil, ErrInvalidNodeEncoding
+		}
+	}
+
+	// the proof ends before reaching the leaf of the key
+	return nil, ErrInvalidProof
+}
+
+// keyBytesToHex splits the key into nibbles the way the trie does, in
+// reversed order: the last nibble of the key becomes the first nibble of the
+// path. A terminator nibble marks the end of the path.
+func keyBytesToHex(key []byte) []byte {
+	hexLength := len(key)*2 + 1
+	nibbles := make([]byte, hexLength)
+	nibbles[hexLength-1] = hexTerminator
+
+	keyIndex := 0
+	for i := hexLength - 2; i > 0; i -= 2 {
+		nibbles[i] = key[keyIndex] >> nibbleSize
+		nibbles[i-1] = key[keyIndex] & nibbleMask
+		keyIndex++
+	}
+
+	return nibbles
+}
+
+// decodeKeyValueNode decodes a collapsed leaf or extension node, both having a
+// key as the first field and a value, respectively a child hash, as the second
+func decodeKeyValueNode(data []byte) ([]byte, []byte, error) {
+	var key, value []byte
+	err := decodeProtobufBytesFields(data, func(fieldNumber uint64, fieldData []byte) error {
+		switch fieldNumber {
+		case protobufKeyField:
+			key = fieldData
+		case protobufValueField:
+			value = fieldData
+		default:
+			return ErrInvalidNodeEncoding
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, value, nil
+}
+
+// decodeBranchNode decodes a collapsed branch node, holding the hashes of its
+// children as a repeated field, with empty entries for the missing children
+func decodeBranchNode(data []byte) ([][]byte, error) {
+	children := make([][]byte, 0, numBranchChildren)
+	err := decodeProtobufBytesFields(data, func(fieldNumber uint64, fieldData []byte) error {
+		if fieldNumber != protobufKeyField {
+			return ErrInvalidNodeEncoding
+		}
+		children = append(children, fieldData)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(children) != numBranchChildren {
+		return nil, ErrInvalidNodeEncoding
+	}
+
+	return children, nil
+}
+
+// decodeProtobufBytesFields walks through a protobuf message made of
+// length-delimited fields only, which is the case of the collapsed trie nodes
+func decodeProtobufBytesFields(data []byte, handleField func(fieldNumber uint64, fieldData []byte) error) error {
+	for len(data) > 0 {
+		tag, tagLength := binary.Uvarint(data)
+		if tagLength <= 0 || tag&0x07 != wireTypeBytes {
+			return ErrInvalidNodeEncoding
+		}
+		data = data[tagLength:]
+
+		length, lengthLength := binary.Uvarint(data)
+		if lengthLength <= 0 || length > uint64(len(data)-lengthLength) {
+			return ErrInvalidNodeEncoding
+		}
+		data = data[lengthLength:]
+
+		err := handleField(tag>>3, data[:length])
+		if err != nil {
+			return err
+		}
+		data = data[length:]
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package merkle
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func blake2bHash(data []byte) ([]byte, error) {
+	hash := blake2b.Sum256(data)
+	return hash[:], nil
+}
+
+func encodeProtobufField(fieldNumber uint64, data []byte) []byte {
+	buffer := make([]byte, binary.MaxVarintLen64)
+	encoded := make([]byte, 0)
+
+	length := binary.PutUvarint(buffer, fieldNumber<<3|wireTypeBytes)
+	encoded = append(encoded, buffer[:length]...)
+	length = binary.PutUvarint(buffer, uint64(len(data)))
+	encoded = append(encoded, buffer[:length]...)
+
+	return append(encoded, data...)
+}
+
+func encodeKeyValueNode(nodeType byte, key []byte, value []byte) []byte {
+	encoded := make([]byte, 0)
+	if len(key) > 0 {
+		encoded = append(encoded, encodeProtobufField(protobufKeyField, key)...)
+	}
+	if len(value) > 0 {
+		encoded = append(encoded, encodeProtobufField(protobufValueField, value)...)
+	}
+	return append(encoded, nodeType)
+}
+
+func encodeBranchNode(children map[byte][]byte) []byte {
+	encoded := make([]byte, 0)
+	for i := byte(0); i < numBranchChildren; i++ {
+		encoded = append(encoded, encodeProtobufField(protobufKeyField, children[i])...)
+	}
+	return append(encoded, branchNode)
+}
+
+func mustBlake2b(t *testing.T, data []byte) []byte {
+	hash, err := blake2bHash(data)
+	require.Nil(t, err)
+	return hash
+}
+
+type testTrie struct {
+	rootHash []byte
+	root     []byte
+	branch   []byte
+	leaves   map[string][]byte
+}
+
+// newTestTrie builds a trie with the keys 0x1234 and 0x5634, whose paths
+// share the prefix of the reversed nibbles 4, 3 and diverge afterwards
+func newTestTrie(t *testing.T) *testTrie {
+	firstPath := keyBytesToHex([]byte{0x12, 0x34})
+	secondPath := keyBytesToHex([]byte{0x56, 0x34})
+	require.Equal(t, []byte{4, 3, 2, 1, hexTerminator}, firstPath)
+
+	firstLeaf := encodeKeyValueNode(leafNode, firstPath[3:], []byte("first value"))
+	secondLeaf := encodeKeyValueNode(leafNode, secondPath[3:], []byte("second value"))
+	branch := encodeBranchNode(map[byte][]byte{
+		firstPath[2]:  mustBlake2b(t, firstLeaf),
+		secondPath[2]: mustBlake2b(t, secondLeaf),
+	})
+	extension := encodeKeyValueNode(extensionNode, firstPath[:2], mustBlake2b(t, branch))
+
+	return &testTrie{
+		rootHash: mustBlake2b(t, extension),
+		root:     extension,
+		branch:   branch,
+		leaves: map[string][]byte{
+			"first":  firstLeaf,
+			"second": secondLeaf,
+		},
+	}
+}
+
+func TestKeyBytesToHex(t *testing.T) {
+	assert.Equal(t, []byte{hexTerminator}, keyBytesToHex(nil))
+	assert.Equal(t, []byte{0xf, 0xa, 0x2, 0x1, hexTerminator}, keyBytesToHex([]byte{0x12, 0xaf}))
+}
+
+func TestVerifyPatriciaProof(t *testing.T) {
+	trie := newTestTrie(t)
+
+	value, err := VerifyPatriciaProof(blake2bHash, trie.rootHash, []byte{0x12, 0x34}, [][]byte{trie.root, trie.branch, trie.leaves["first"]})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("first value"), value)
+
+	value, err = VerifyPatriciaProof(blake2bHash, trie.rootHash, []byte{0x56, 0x34}, [][]byte{trie.root, trie.branch, trie.leaves["second"]})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("second value"), value)
+}
+
+func TestVerifyPatriciaProof_Invalid(t *testing.T) {
+	trie := newTestTrie(t)
+	firstProof := [][]byte{trie.root, trie.branch, trie.leaves["first"]}
+
+	_, err := VerifyPatriciaProof(blake2bHash, trie.rootHash, []byte{0x12, 0x34}, nil)
+	assert.Equal(t, ErrEmptyProof, err)
+
+	_, err = VerifyPatriciaProof(blake2bHash, trie.rootHash, []byte{0x12, 0x34}, firstProof[:2])
+	assert.Equal(t, ErrInvalidProof, err)
+
+	_, err = VerifyPatriciaProof(blake2bHash, trie.rootHash, []byte{0x12, 0x34}, [][]byte{trie.root, trie.branch, trie.leaves["second"]})
+	assert.Equal(t, ErrInvalidProof, err)
+
+	_, err = VerifyPatriciaProof(blake2bHash, mustBlake2b(t, []byte("other root")), []byte{0x12, 0x34}, firstProof)
+	assert.Equal(t, ErrInvalidProof, err)
+
+	// the key shares the path of the first leaf up to the leaf itself
+	_, err = VerifyPatriciaProof(blake2bHash, trie.rootHash, []byte{0x13, 0x34}, firstProof)
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	// the key leaves the path at the extension node
+	_, err = VerifyPatriciaProof(blake2bHash, trie.rootHash, []byte{0x12, 0x35}, firstProof)
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	// the key leads to an empty child of the branch node
+	_, err = VerifyPatriciaProof(blake2bHash, trie.rootHash, []byte{0x77, 0x34}, firstProof)
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
+func TestVerifyPatriciaProof_InvalidEncoding(t *testing.T) {
+	malformed := [][]byte{
+		{leafNode + 10},
+		{0x0a, 0x05, 0x01, leafNode},
+		{0x08, 0x01, leafNode},
+		append(encodeKeyValueNode(extensionNode, []byte{1}, []byte{2})[:3], branchNode),
+	}
+
+	for _, node := range malformed {
+		_, err := VerifyPatriciaProof(blake2bHash, mustBlake2b(t, node), []byte{0x12}, [][]byte{node})
+		assert.Equal(t, ErrInvalidNodeEncoding, err)
+	}
+}
@@ -1,24 +1,41 @@
 package bls
 
 import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing"
+	"github.com/ElrondNetwork/elrond-go-core/hashing/blake2b"
 	"github.com/ElrondNetwork/elrond-go-crypto"
-	"github.com/ElrondNetwork/elrond-go-crypto/signing"
+	elrondSigning "github.com/ElrondNetwork/elrond-go-crypto/signing"
 	"github.com/ElrondNetwork/elrond-go-crypto/signing/mcl"
+	"github.com/ElrondNetwork/elrond-go-crypto/signing/mcl/multisig"
 	"github.com/ElrondNetwork/elrond-go-crypto/signing/mcl/singlesig"
+	herumi "github.com/herumi/bls-go-binary/bls"
 )
 
+// multiSigHasherSize is the output size of the hasher with which the public
+// keys are weighted in the multi-signature scheme of the Elrond consensus
+const multiSigHasherSize = 16
+
 type bls struct {
+	suite        crypto.Suite
 	keyGenerator crypto.KeyGenerator
 	signer       crypto.SingleSigner
+	multiSigner  *multisig.BlsMultiSigner
 }
 
-func NewBLS() *bls {
+// NewBLS creates the verifier of BLS signatures and multi-signatures
+func NewBLS() (*bls, error) {
+	hasher, err := blake2b.NewBlake2bWithSize(multiSigHasherSize)
+	if err != nil {
+		return nil, err
+	}
+
 	b := &bls{}
-	suite := mcl.NewSuiteBLS12()
-	b.keyGenerator = signing.NewKeyGenerator(suite)
+	b.suite = mcl.NewSuiteBLS12()
+	b.keyGenerator = elrondSigning.NewKeyGenerator(b.suite)
 	b.signer = singlesig.NewBlsSigner()
+	b.multiSigner = &multisig.BlsMultiSigner{Hasher: hasher}
 
-	return b
+	return b, nil
 }
 
 func (b *bls) VerifyBLS(key []byte, msg []byte, sig []byte) error {
@@ -29,3 +46,111 @@ func (b *bls) VerifyBLS(key []byte, msg []byte, sig []byte) error {
 
 	return b.signer.Verify(publicKey, msg, sig)
 }
+
+// VerifyBLSMultiSig checks a multi-signature produced by several signers over
+// the same message, aggregated with the scheme of the Elrond consensus, where
+// each signature share is weighted by the hash of the signer's public key
+func (b *bls) VerifyBLSMultiSig(keys [][]byte, msg []byte, aggSig []byte) error {
+	if len(keys) == 0 {
+		return signing.ErrNilPublicKeys
+	}
+
+	publicKeys := make([]crypto.PublicKey, len(keys))
+	for i, key := range keys {
+		publicKey, _, err := b.publicKeyFromBytes(key)
+		if err != nil {
+			return err
+		}
+		publicKeys[i] = publicKey
+	}
+
+	_, err := b.signatureFromBytes(aggSig)
+	if err != nil {
+		return err
+	}
+
+	return b.multiSigner.VerifyAggregatedSig(b.suite, publicKeys, aggSig, msg)
+}
+
+// VerifyBLSAggregated checks an aggregate signature, obtained by adding the
+// signatures of several signers, each over its own message. The messages must
+// be distinct, which protects the scheme against rogue key attacks.
+func (b *bls) VerifyBLSAggregated(keys [][]byte, msgs [][]byte, aggSig []byte) error {
+	if len(keys) == 0 {
+		return signing.ErrNilPublicKeys
+	}
+	if len(keys) != len(msgs) {
+		return signing.ErrKeysMessagesCountMismatch
+	}
+
+	sig, err := b.signatureFromBytes(aggSig)
+	if err != nil {
+		return err
+	}
+
+	// e(aggSig, g) == e(H(m_1), pk_1) * ... * e(H(m_n), pk_n) is checked as
+	// e(-aggSig, g) * e(H(m_1), pk_1) * ... * e(H(m_n), pk_n) == 1
+	g1Points := make([]herumi.G1, len(keys)+1)
+	g2Points := make([]herumi.G2, len(keys)+1)
+
+	herumi.G1Neg(&g1Points[0], herumi.CastFromSign(sig))
+	generator := &herumi.PublicKey{}
+	herumi.BlsGetGeneratorOfPublicKey(generator)
+	g2Points[0] = *herumi.CastFromPublicKey(generator)
+
+	seenMessages := make(map[string]struct{}, len(msgs))
+	for i, msg := range msgs {
+		if len(msg) == 0 {
+			return signing.ErrNilMessage
+		}
+		if _, seen := seenMessages[string(msg)]; seen {
+			return signing.ErrDuplicateMessage
+		}
+		seenMessages[string(msg)] = struct{}{}
+
+		_, pubKeyPoint, err := b.publicKeyFromBytes(keys[i])
+		if err != nil {
+			return err
+		}
+
+		g1Points[i+1] = *herumi.CastFromSign(herumi.HashAndMapToSignature(msg))
+		g2Points[i+1] = *pubKeyPoint.G2
+	}
+
+	result := &herumi.GT{}
+	herumi.MillerLoopVec(result, g1Points, g2Points)
+	herumi.FinalExp(result, result)
+	if !result.IsOne() {
+		return signing.ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (b *bls) publicKeyFromBytes(key []byte) (crypto.PublicKey, *mcl.PointG2, error) {
+	publicKey, err := b.keyGenerator.PublicKeyFromByteArray(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pubKeyPoint, isPoint := publicKey.Point().(*mcl.PointG2)
+	if !isPoint || !singlesig.IsPubKeyPointValid(pubKeyPoint) {
+		return nil, nil, signing.ErrInvalidPublicKey
+	}
+
+	return publicKey, pubKeyPoint, nil
+}
+
+func (b *bls) signatureFromBytes(sig []byte) (*herumi.Sign, error) {
+	signature := &herumi.Sign{}
+	err := signature.Deserialize(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	if !singlesig.IsSigValidPoint(signature) {
+		return nil, signing.ErrInvalidSignature
+	}
+
+	return signature, nil
+}
@@ -2,9 +2,13 @@ package bls
 
 import (
 	"encoding/hex"
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing"
+	"github.com/ElrondNetwork/elrond-go-crypto"
+	herumi "github.com/herumi/bls-go-binary/bls"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -15,11 +19,80 @@ const checkNOK = "2c9a358953f61d34401d7ee4175eec105c476b18baacab371e2f47270035b5
 func TestBls_VerifyBLS(t *testing.T) {
 	t.Parallel()
 
-	b := NewBLS()
+	b, err := NewBLS()
+	require.Nil(t, err)
 	assert.Nil(t, b.VerifyBLS(splitString(t, checkOK)))
 	assert.NotNil(t, b.VerifyBLS(splitString(t, checkNOK)))
 }
 
+func TestBls_VerifyBLSMultiSig(t *testing.T) {
+	t.Parallel()
+
+	b, err := NewBLS()
+	require.Nil(t, err)
+	msg := []byte("message signed by all")
+	privateKeys, publicKeys, keys := generateKeys(t, b, 4)
+
+	shares := make([][]byte, len(privateKeys))
+	for i, privateKey := range privateKeys {
+		share, err := b.multiSigner.SignShare(privateKey, msg)
+		require.Nil(t, err)
+		shares[i] = share
+	}
+
+	aggSig, err := b.multiSigner.AggregateSignatures(b.suite, shares, publicKeys)
+	require.Nil(t, err)
+
+	assert.Nil(t, b.VerifyBLSMultiSig(keys, msg, aggSig))
+	assert.NotNil(t, b.VerifyBLSMultiSig(keys, []byte("another message"), aggSig))
+	assert.NotNil(t, b.VerifyBLSMultiSig(keys[1:], msg, aggSig))
+	assert.NotNil(t, b.VerifyBLSMultiSig(keys, msg, shares[0]))
+	assert.Equal(t, signing.ErrNilPublicKeys, b.VerifyBLSMultiSig(nil, msg, aggSig))
+}
+
+func TestBls_VerifyBLSAggregated(t *testing.T) {
+	t.Parallel()
+
+	b, err := NewBLS()
+	require.Nil(t, err)
+	privateKeys, _, keys := generateKeys(t, b, 3)
+
+	msgs := make([][]byte, len(privateKeys))
+	sigs := make([]herumi.Sign, len(privateKeys))
+	for i, privateKey := range privateKeys {
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		sig, err := b.signer.Sign(privateKey, msgs[i])
+		require.Nil(t, err)
+		require.Nil(t, sigs[i].Deserialize(sig))
+	}
+
+	aggSig := &herumi.Sign{}
+	aggSig.Aggregate(sigs)
+
+	assert.Nil(t, b.VerifyBLSAggregated(keys, msgs, aggSig.Serialize()))
+	assert.Nil(t, b.VerifyBLSAggregated(keys[:1], msgs[:1], sigs[0].Serialize()))
+	assert.Equal(t, signing.ErrInvalidSignature, b.VerifyBLSAggregated(keys, [][]byte{msgs[1], msgs[0], msgs[2]}, aggSig.Serialize()))
+	assert.Equal(t, signing.ErrInvalidSignature, b.VerifyBLSAggregated(keys[:2], msgs[:2], aggSig.Serialize()))
+	assert.Equal(t, signing.ErrKeysMessagesCountMismatch, b.VerifyBLSAggregated(keys, msgs[:2], aggSig.Serialize()))
+	assert.Equal(t, signing.ErrDuplicateMessage, b.VerifyBLSAggregated(keys, [][]byte{msgs[0], msgs[1], msgs[0]}, aggSig.Serialize()))
+	assert.Equal(t, signing.ErrNilPublicKeys, b.VerifyBLSAggregated(nil, nil, aggSig.Serialize()))
+}
+
+func generateKeys(t testing.TB, b *bls, numKeys int) ([]crypto.PrivateKey, []crypto.PublicKey, [][]byte) {
+	privateKeys := make([]crypto.PrivateKey, numKeys)
+	publicKeys := make([]crypto.PublicKey, numKeys)
+	keys := make([][]byte, numKeys)
+	for i := 0; i < numKeys; i++ {
+		privateKeys[i], publicKeys[i] = b.keyGenerator.GeneratePair()
+
+		key, err := publicKeys[i].ToByteArray()
+		require.Nil(t, err)
+		keys[i] = key
+	}
+
+	return privateKeys, publicKeys, keys
+}
+
 func splitString(t testing.TB, str string) ([]byte, []byte, []byte) {
 	split := strings.Split(str, "@")
 	pkBuff, err := hex.DecodeString(split[0])
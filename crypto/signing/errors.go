@@ -12,3 +12,16 @@ var ErrInvalidSignature = errors.New("invalid signature")
 
 // ErrHasherNotSupported will be returned when a provided hasher type is not supported by the signature scheme
 var ErrHasherNotSupported = errors.New("hasher not supported")
+
+// ErrNilPublicKeys will be returned when a signature is verified against an empty list of public keys
+var ErrNilPublicKeys = errors.New("nil public keys")
+
+// ErrNilMessage will be returned when a signature is verified over an empty message
+var ErrNilMessage = errors.New("nil message")
+
+// ErrKeysMessagesCountMismatch will be returned when an aggregate signature is verified with a
+// number of messages different from the number of public keys
+var ErrKeysMessagesCountMismatch = errors.New("number of public keys does not match the number of messages")
+
+// ErrDuplicateMessage will be returned when an aggregate signature is verified over messages which are not distinct
+var ErrDuplicateMessage = errors.New("duplicate message in aggregate signature")
@@ -0,0 +1,66 @@
+package secp256r1
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing"
+)
+
+// ecdsaSignature is the ASN.1 structure of a DER encoded ECDSA signature
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+type secp256r1 struct {
+	curve elliptic.Curve
+}
+
+func NewSecp256r1() *secp256r1 {
+	return &secp256r1{
+		curve: elliptic.P256(),
+	}
+}
+
+// VerifySecp256r1 checks a secp256r1 (NIST P-256) signature provided in the DER
+// encoding format, over the SHA-256 hash of the message, as in the ES256
+// algorithm used by WebAuthn. The public key can be either compressed or uncompressed.
+func (sec *secp256r1) VerifySecp256r1(key []byte, msg []byte, sig []byte) error {
+	pubKey, err := sec.parsePublicKey(key)
+	if err != nil {
+		return err
+	}
+
+	signature := &ecdsaSignature{}
+	rest, err := asn1.Unmarshal(sig, signature)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 || signature.R == nil || signature.S == nil {
+		return signing.ErrInvalidSignature
+	}
+
+	messageHash := sha256.Sum256(msg)
+	if !ecdsa.Verify(pubKey, messageHash[:], signature.R, signature.S) {
+		return signing.ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (sec *secp256r1) parsePublicKey(key []byte) (*ecdsa.PublicKey, error) {
+	var x, y *big.Int
+	if len(key) > 0 && key[0] == 4 {
+		x, y = elliptic.Unmarshal(sec.curve, key)
+	} else {
+		x, y = elliptic.UnmarshalCompressed(sec.curve, key)
+	}
+	if x == nil {
+		return nil, signing.ErrInvalidPublicKey
+	}
+
+	return &ecdsa.PublicKey{Curve: sec.curve, X: x, Y: y}, nil
+}
@@ -0,0 +1,49 @@
+package secp256r1
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecp256r1_WycheproofSig(t *testing.T) {
+	key, _ := hex.DecodeString("042927b10512bae3eddcfe467828128bad2903269919f7086069c8c4df6c732838c7787964eaac00e5921fb1498a60f4606766b3d9685001558d1a974e7341513e")
+	msg, _ := hex.DecodeString("313233343030")
+	sig, _ := hex.DecodeString("304402202ba3a8be6b94d5ec80a6d9d1190a436effe50d85a1eee859b8cc6af9bd5c2e1802204cd60b855d442f5b3c7b11eb6c4e0ae7525fe710fab9aa7c77a67f79e6fadd76")
+
+	verifier := NewSecp256r1()
+	assert.Nil(t, verifier.VerifySecp256r1(key, msg, sig))
+
+	msg[0]++
+	assert.Equal(t, signing.ErrInvalidSignature, verifier.VerifySecp256r1(key, msg, sig))
+}
+
+func TestSecp256r1_CompressedKey(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	msg := []byte("webauthn assertion")
+	hash := sha256.Sum256(msg)
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hash[:])
+	require.Nil(t, err)
+	sig, err := asn1.Marshal(ecdsaSignature{R: r, S: s})
+	require.Nil(t, err)
+
+	verifier := NewSecp256r1()
+	compressedKey := elliptic.MarshalCompressed(elliptic.P256(), privateKey.X, privateKey.Y)
+	assert.Nil(t, verifier.VerifySecp256r1(compressedKey, msg, sig))
+
+	uncompressedKey := elliptic.Marshal(elliptic.P256(), privateKey.X, privateKey.Y)
+	assert.Nil(t, verifier.VerifySecp256r1(uncompressedKey, msg, sig))
+
+	assert.Equal(t, signing.ErrInvalidPublicKey, verifier.VerifySecp256r1(compressedKey[1:], msg, sig))
+	assert.NotNil(t, verifier.VerifySecp256r1(compressedKey, msg, sig[:len(sig)-1]))
+}
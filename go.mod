@@ -10,9 +10,11 @@ require (
 	github.com/ElrondNetwork/elrond-vm-common v1.2.6
 	github.com/btcsuite/btcd v0.21.0-beta
 	github.com/gin-gonic/gin v1.7.1
+	github.com/herumi/bls-go-binary v1.0.0
 	github.com/mitchellh/mapstructure v1.4.1
 	github.com/pelletier/go-toml v1.9.3
 	github.com/stretchr/testify v1.7.0
 	github.com/urfave/cli v1.22.5
 	golang.org/x/crypto v0.0.0-20210711020723-a769d52b0f97
+	google.golang.org/protobuf v1.26.0
 )
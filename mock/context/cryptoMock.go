@@ -21,11 +21,41 @@ func (c *CryptoHookMock) Ripemd160(data []byte) ([]byte, error) {
 	return c.Result, c.Err
 }
 
+// Sha512 mocked method
+func (c *CryptoHookMock) Sha512(data []byte) ([]byte, error) {
+	return c.Result, c.Err
+}
+
+// Sha3256 mocked method
+func (c *CryptoHookMock) Sha3256(data []byte) ([]byte, error) {
+	return c.Result, c.Err
+}
+
+// Blake2b256 mocked method
+func (c *CryptoHookMock) Blake2b256(data []byte) ([]byte, error) {
+	return c.Result, c.Err
+}
+
+// Blake2s256 mocked method
+func (c *CryptoHookMock) Blake2s256(data []byte) ([]byte, error) {
+	return c.Result, c.Err
+}
+
 // VerifyBLS mocked method
 func (c *CryptoHookMock) VerifyBLS(key []byte, msg []byte, sig []byte) error {
 	return c.Err
 }
 
+// VerifyBLSMultiSig mocked method
+func (c *CryptoHookMock) VerifyBLSMultiSig(keys [][]byte, msg []byte, aggSig []byte) error {
+	return c.Err
+}
+
+// VerifyBLSAggregated mocked method
+func (c *CryptoHookMock) VerifyBLSAggregated(keys [][]byte, msgs [][]byte, aggSig []byte) error {
+	return c.Err
+}
+
 // VerifyEd25519 mocked method
 func (c *CryptoHookMock) VerifyEd25519(key []byte, msg []byte, sig []byte) error {
 	return c.Err
@@ -36,6 +66,11 @@ func (c *CryptoHookMock) VerifySecp256k1(key []byte, msg []byte, sig []byte, has
 	return c.Err
 }
 
+// VerifySecp256r1 mocked method
+func (c *CryptoHookMock) VerifySecp256r1(key []byte, msg []byte, sig []byte) error {
+	return c.Err
+}
+
 // EncodeSecp256k1DERSignature mocked method
 func (c *CryptoHookMock) EncodeSecp256k1DERSignature(r, s []byte) []byte {
 	return make([]byte, 0)
@@ -45,3 +80,8 @@ func (c *CryptoHookMock) EncodeSecp256k1DERSignature(r, s []byte) []byte {
 func (c *CryptoHookMock) Ecrecover(hash []byte, recoveryID []byte, r []byte, s []byte) ([]byte, error) {
 	return c.Result, c.Err
 }
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (c *CryptoHookMock) IsInterfaceNil() bool {
+	return c == nil
+}
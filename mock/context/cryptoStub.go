@@ -0,0 +1,140 @@
+package mock
+
+import "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto"
+
+var _ crypto.VMCrypto = (*VMCryptoStub)(nil)
+
+// VMCryptoStub is used in tests to replace the crypto functionality of the VM
+type VMCryptoStub struct {
+	Sha256Called                      func(data []byte) ([]byte, error)
+	Keccak256Called                   func(data []byte) ([]byte, error)
+	Ripemd160Called                   func(data []byte) ([]byte, error)
+	Sha512Called                      func(data []byte) ([]byte, error)
+	Sha3256Called                     func(data []byte) ([]byte, error)
+	Blake2b256Called                  func(data []byte) ([]byte, error)
+	Blake2s256Called                  func(data []byte) ([]byte, error)
+	VerifyBLSCalled                   func(key []byte, msg []byte, sig []byte) error
+	VerifyBLSMultiSigCalled           func(keys [][]byte, msg []byte, aggSig []byte) error
+	VerifyBLSAggregatedCalled         func(keys [][]byte, msgs [][]byte, aggSig []byte) error
+	VerifyEd25519Called               func(key []byte, msg []byte, sig []byte) error
+	VerifySecp256k1Called             func(key []byte, msg []byte, sig []byte, hashType uint8) error
+	EncodeSecp256k1DERSignatureCalled func(r, s []byte) []byte
+	VerifySecp256r1Called             func(key []byte, msg []byte, sig []byte) error
+}
+
+// Sha256 mocked method
+func (cs *VMCryptoStub) Sha256(data []byte) ([]byte, error) {
+	if cs.Sha256Called != nil {
+		return cs.Sha256Called(data)
+	}
+	return nil, nil
+}
+
+// Keccak256 mocked method
+func (cs *VMCryptoStub) Keccak256(data []byte) ([]byte, error) {
+	if cs.Keccak256Called != nil {
+		return cs.Keccak256Called(data)
+	}
+	return nil, nil
+}
+
+// Ripemd160 mocked method
+func (cs *VMCryptoStub) Ripemd160(data []byte) ([]byte, error) {
+	if cs.Ripemd160Called != nil {
+		return cs.Ripemd160Called(data)
+	}
+	return nil, nil
+}
+
+// Sha512 mocked method
+func (cs *VMCryptoStub) Sha512(data []byte) ([]byte, error) {
+	if cs.Sha512Called != nil {
+		return cs.Sha512Called(data)
+	}
+	return nil, nil
+}
+
+// Sha3256 mocked method
+func (cs *VMCryptoStub) Sha3256(data []byte) ([]byte, error) {
+	if cs.Sha3256Called != nil {
+		return cs.Sha3256Called(data)
+	}
+	return nil, nil
+}
+
+// Blake2b256 mocked method
+func (cs *VMCryptoStub) Blake2b256(data []byte) ([]byte, error) {
+	if cs.Blake2b256Called != nil {
+		return cs.Blake2b256Called(data)
+	}
+	return nil, nil
+}
+
+// Blake2s256 mocked method
+func (cs *VMCryptoStub) Blake2s256(data []byte) ([]byte, error) {
+	if cs.Blake2s256Called != nil {
+		return cs.Blake2s256Called(data)
+	}
+	return nil, nil
+}
+
+// VerifyBLS mocked method
+func (cs *VMCryptoStub) VerifyBLS(key []byte, msg []byte, sig []byte) error {
+	if cs.VerifyBLSCalled != nil {
+		return cs.VerifyBLSCalled(key, msg, sig)
+	}
+	return nil
+}
+
+// VerifyBLSMultiSig mocked method
+func (cs *VMCryptoStub) VerifyBLSMultiSig(keys [][]byte, msg []byte, aggSig []byte) error {
+	if cs.VerifyBLSMultiSigCalled != nil {
+		return cs.VerifyBLSMultiSigCalled(keys, msg, aggSig)
+	}
+	return nil
+}
+
+// VerifyBLSAggregated mocked method
+func (cs *VMCryptoStub) VerifyBLSAggregated(keys [][]byte, msgs [][]byte, aggSig []byte) error {
+	if cs.VerifyBLSAggregatedCalled != nil {
+		return cs.VerifyBLSAggregatedCalled(keys, msgs, aggSig)
+	}
+	return nil
+}
+
+// VerifyEd25519 mocked method
+func (cs *VMCryptoStub) VerifyEd25519(key []byte, msg []byte, sig []byte) error {
+	if cs.VerifyEd25519Called != nil {
+		return cs.VerifyEd25519Called(key, msg, sig)
+	}
+	return nil
+}
+
+// VerifySecp256k1 mocked method
+func (cs *VMCryptoStub) VerifySecp256k1(key []byte, msg []byte, sig []byte, hashType uint8) error {
+	if cs.VerifySecp256k1Called != nil {
+		return cs.VerifySecp256k1Called(key, msg, sig, hashType)
+	}
+	return nil
+}
+
+// EncodeSecp256k1DERSignature mocked method
+func (cs *VMCryptoStub) EncodeSecp256k1DERSignature(r, s []byte) []byte {
+	if cs.EncodeSecp256k1DERSignatureCalled != nil {
+		return cs.EncodeSecp256k1DERSignatureCalled(r, s)
+	}
+	return nil
+}
+
+// VerifySecp256r1 mocked method
+func (cs *VMCryptoStub) VerifySecp256r1(key []byte, msg []byte, sig []byte) error {
+	if cs.VerifySecp256r1Called != nil {
+		return cs.VerifySecp256r1Called(key, msg, sig)
+	}
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cs *VMCryptoStub) IsInterfaceNil() bool {
+	return cs == nil
+}
@@ -0,0 +1,161 @@
+package eicontext
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/contexts"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/config"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/crypto/factory"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/executor"
+	"github.com/stretchr/testify/require"
+)
+
+// MemoryRuntime is a runtime context mock backed by an actual memory, which
+// also records the error with which an EI function failed the execution
+type MemoryRuntime struct {
+	*contextmock.RuntimeContextMock
+	Memory []byte
+	Err    error
+}
+
+// MemLoad loads bytes from the memory
+func (runtime *MemoryRuntime) MemLoad(offset int32, length int32) ([]byte, error) {
+	if offset < 0 || length < 0 || int(offset)+int(length) > len(runtime.Memory) {
+		return nil, arwen.ErrBadBounds
+	}
+
+	result := make([]byte, length)
+	copy(result, runtime.Memory[offset:offset+length])
+	return result, nil
+}
+
+// MemLoadMultiple loads consecutive byte slices from the memory
+func (runtime *MemoryRuntime) MemLoadMultiple(offset int32, lengths []int32) ([][]byte, error) {
+	results := make([][]byte, len(lengths))
+	for i, length := range lengths {
+		result, err := runtime.MemLoad(offset, length)
+		if err != nil {
+			return nil, err
+		}
+
+		results[i] = result
+		offset += length
+	}
+
+	return results, nil
+}
+
+// MemStore stores bytes in the memory
+func (runtime *MemoryRuntime) MemStore(offset int32, data []byte) error {
+	if offset < 0 || int(offset)+len(data) > len(runtime.Memory) {
+		return arwen.ErrBadBounds
+	}
+
+	copy(runtime.Memory[offset:], data)
+	return nil
+}
+
+// Store writes the data at the end of the memory and returns its offset
+func (runtime *MemoryRuntime) Store(data []byte) int32 {
+	offset := int32(len(runtime.Memory))
+	runtime.Memory = append(runtime.Memory, data...)
+	return offset
+}
+
+// FailExecution records the error
+func (runtime *MemoryRuntime) FailExecution(err error) {
+	runtime.Err = err
+}
+
+// EIContext provides the context pointer with which EI functions are called
+// in unit tests, backed by a mocked host with the actual VM crypto and real
+// managed types
+type EIContext struct {
+	Pointer      unsafe.Pointer
+	Host         *contextmock.VMHostMock
+	Runtime      *MemoryRuntime
+	ManagedTypes arwen.ManagedTypesContext
+
+	vmHost arwen.VMHost
+}
+
+// NewEIContext creates an EIContext whose runtime fails the execution on
+// every EI error; its context pointer is released when the test ends
+func NewEIContext(tb testing.TB) *EIContext {
+	metering := &contextmock.MeteringContextMock{}
+	metering.SetGasSchedule(config.MakeGasMapForTests())
+
+	runtime := &MemoryRuntime{
+		RuntimeContextMock: &contextmock.RuntimeContextMock{
+			FailBigIntAPI:         true,
+			FailCryptoAPI:         true,
+			FailElrondAPI:         true,
+			FailManagedBuffersAPI: true,
+		},
+		Memory: make([]byte, 0),
+	}
+
+	cryptoHook, err := factory.NewVMCrypto()
+	require.Nil(tb, err)
+
+	host := &contextmock.VMHostMock{
+		RuntimeContext:  runtime,
+		MeteringContext: metering,
+		CryptoHook:      cryptoHook,
+	}
+	managedTypes, err := contexts.NewManagedTypesContext(host)
+	require.Nil(tb, err)
+	host.ManagedTypesContext = managedTypes
+
+	context := &EIContext{
+		Host:         host,
+		Runtime:      runtime,
+		ManagedTypes: managedTypes,
+		vmHost:       host,
+	}
+
+	// the host reference must point to the heap, like the one set by the runtime context
+	hostReference := uintptr(unsafe.Pointer(&context.vmHost))
+	context.Pointer = executor.NewInstanceContext(nil, unsafe.Pointer(&hostReference))
+	tb.Cleanup(func() {
+		executor.ReleaseInstanceContext(context.Pointer)
+	})
+
+	return context
+}
+
+// NewBigInt creates a big int and returns its handle
+func (context *EIContext) NewBigInt(value int64) int32 {
+	return context.ManagedTypes.NewBigIntFromInt64(value)
+}
+
+// NewBigIntFromString creates a big int from its decimal representation and returns its handle
+func (context *EIContext) NewBigIntFromString(tb testing.TB, value string) int32 {
+	bigValue, ok := big.NewInt(0).SetString(value, 10)
+	require.True(tb, ok)
+	return context.ManagedTypes.NewBigInt(bigValue)
+}
+
+// MustGetBigInt returns the big int under the given handle
+func (context *EIContext) MustGetBigInt(tb testing.TB, handle int32) *big.Int {
+	value, err := context.ManagedTypes.GetBigInt(handle)
+	require.Nil(tb, err)
+	return value
+}
+
+// RequireBigInt checks the decimal representation of the big int under the given handle
+func (context *EIContext) RequireBigInt(tb testing.TB, expected string, handle int32) {
+	require.Equal(tb, expected, context.MustGetBigInt(tb, handle).String())
+}
+
+// RequireBufferHex checks the hex representation of the managed buffer under the given handle
+func (context *EIContext) RequireBufferHex(tb testing.TB, expected string, handle int32) {
+	data, err := context.ManagedTypes.GetBytes(handle)
+	require.Nil(tb, err)
+	require.Equal(tb, expected, hex.EncodeToString(data))
+}
@@ -0,0 +1,94 @@
+package mock
+
+import "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+
+var _ arwen.ExecutionObserver = (*ExecutionObserverStub)(nil)
+
+// ExecutionObserverStub is used in tests to check the events emitted to an ExecutionObserver
+type ExecutionObserverStub struct {
+	OnCallEnterCalled     func(event *arwen.CallEnterEvent)
+	OnCallExitCalled      func(event *arwen.CallExitEvent)
+	OnStorageAccessCalled func(event *arwen.StorageAccessEvent)
+	OnTransferCalled      func(event *arwen.TransferEvent)
+	OnLogCalled           func(event *arwen.LogEvent)
+	OnAsyncCallCalled     func(event *arwen.AsyncCallEvent)
+	OnCallbackCalled      func(event *arwen.CallbackEvent)
+	OnGasUsedCalled       func(event *arwen.GasUsedEvent)
+	OnFunctionEnterCalled func(event *arwen.FunctionCallEvent)
+	OnFunctionExitCalled  func(event *arwen.FunctionCallEvent)
+}
+
+// OnCallEnter mocked method
+func (eos *ExecutionObserverStub) OnCallEnter(event *arwen.CallEnterEvent) {
+	if eos.OnCallEnterCalled != nil {
+		eos.OnCallEnterCalled(event)
+	}
+}
+
+// OnCallExit mocked method
+func (eos *ExecutionObserverStub) OnCallExit(event *arwen.CallExitEvent) {
+	if eos.OnCallExitCalled != nil {
+		eos.OnCallExitCalled(event)
+	}
+}
+
+// OnStorageAccess mocked method
+func (eos *ExecutionObserverStub) OnStorageAccess(event *arwen.StorageAccessEvent) {
+	if eos.OnStorageAccessCalled != nil {
+		eos.OnStorageAccessCalled(event)
+	}
+}
+
+// OnTransfer mocked method
+func (eos *ExecutionObserverStub) OnTransfer(event *arwen.TransferEvent) {
+	if eos.OnTransferCalled != nil {
+		eos.OnTransferCalled(event)
+	}
+}
+
+// OnLog mocked method
+func (eos *ExecutionObserverStub) OnLog(event *arwen.LogEvent) {
+	if eos.OnLogCalled != nil {
+		eos.OnLogCalled(event)
+	}
+}
+
+// OnAsyncCall mocked method
+func (eos *ExecutionObserverStub) OnAsyncCall(event *arwen.AsyncCallEvent) {
+	if eos.OnAsyncCallCalled != nil {
+		eos.OnAsyncCallCalled(event)
+	}
+}
+
+// OnCallback mocked method
+func (eos *ExecutionObserverStub) OnCallback(event *arwen.CallbackEvent) {
+	if eos.OnCallbackCalled != nil {
+		eos.OnCallbackCalled(event)
+	}
+}
+
+// OnGasUsed mocked method
+func (eos *ExecutionObserverStub) OnGasUsed(event *arwen.GasUsedEvent) {
+	if eos.OnGasUsedCalled != nil {
+		eos.OnGasUsedCalled(event)
+	}
+}
+
+// OnFunctionEnter mocked method
+func (eos *ExecutionObserverStub) OnFunctionEnter(event *arwen.FunctionCallEvent) {
+	if eos.OnFunctionEnterCalled != nil {
+		eos.OnFunctionEnterCalled(event)
+	}
+}
+
+// OnFunctionExit mocked method
+func (eos *ExecutionObserverStub) OnFunctionExit(event *arwen.FunctionCallEvent) {
+	if eos.OnFunctionExitCalled != nil {
+		eos.OnFunctionExitCalled(event)
+	}
+}
+
+// IsInterfaceNil mocked method
+func (eos *ExecutionObserverStub) IsInterfaceNil() bool {
+	return eos == nil
+}
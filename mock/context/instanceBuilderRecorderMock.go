@@ -1,19 +1,22 @@
 package mock
 
 import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer"
 )
 
 // InstanceBuilderRecorderMock can be passed to RuntimeContext as an InstanceBuilder to
-// create mocked Wasmer instances.
+// record the instances created by the given InstanceBuilder.
 type InstanceBuilderRecorderMock struct {
-	InstanceMap map[string][]wasmer.InstanceHandler
+	InstanceBuilder arwen.InstanceBuilder
+	InstanceMap     map[string][]wasmer.InstanceHandler
 }
 
 // NewInstanceBuilderRecorderMock constructs a new InstanceBuilderRecorderMock
-func NewInstanceBuilderRecorderMock() *InstanceBuilderRecorderMock {
+func NewInstanceBuilderRecorderMock(instanceBuilder arwen.InstanceBuilder) *InstanceBuilderRecorderMock {
 	return &InstanceBuilderRecorderMock{
-		InstanceMap: make(map[string][]wasmer.InstanceHandler),
+		InstanceBuilder: instanceBuilder,
+		InstanceMap:     make(map[string][]wasmer.InstanceHandler),
 	}
 }
 
@@ -22,7 +25,7 @@ func (builder *InstanceBuilderRecorderMock) NewInstanceWithOptions(
 	contractCode []byte,
 	options wasmer.CompilationOptions,
 ) (wasmer.InstanceHandler, error) {
-	instance, err := wasmer.NewInstanceWithOptions(contractCode, options)
+	instance, err := builder.InstanceBuilder.NewInstanceWithOptions(contractCode, options)
 	if err == nil {
 		builder.addContractInstanceToInstanceMap(contractCode, instance)
 	}
@@ -34,7 +37,7 @@ func (builder *InstanceBuilderRecorderMock) NewInstanceFromCompiledCodeWithOptio
 	compiledCode []byte,
 	options wasmer.CompilationOptions,
 ) (wasmer.InstanceHandler, error) {
-	instance, err := wasmer.NewInstanceFromCompiledCodeWithOptions(compiledCode, options)
+	instance, err := builder.InstanceBuilder.NewInstanceFromCompiledCodeWithOptions(compiledCode, options)
 	if err == nil {
 		builder.addContractInstanceToInstanceMap(compiledCode, instance)
 	}
@@ -109,6 +109,15 @@ func (m *MeteringContextMock) GasSpentByContract() uint64 {
 	return 0
 }
 
+// GasRequired mocked method
+func (m *MeteringContextMock) GasRequired() uint64 {
+	return 0
+}
+
+// TrackGasRequiredByNestedCall mocked method
+func (m *MeteringContextMock) TrackGasRequiredByNestedCall(_ uint64, _ uint64) {
+}
+
 // GetGasForExecution mocked method
 func (m *MeteringContextMock) GetGasForExecution() uint64 {
 	return 0
@@ -183,6 +183,15 @@ func (o *OutputContextMock) TransferESDT(_ []byte, _ []byte, _ []*vmcommon.ESDTT
 	return 0, nil
 }
 
+// GetBackTransfers mocked method
+func (o *OutputContextMock) GetBackTransfers() ([]*vmcommon.ESDTTransfer, *big.Int) {
+	return make([]*vmcommon.ESDTTransfer, 0), big.NewInt(0)
+}
+
+// ClearBackTransfers mocked method
+func (o *OutputContextMock) ClearBackTransfers() {
+}
+
 // AddTxValueToAccount mocked method
 func (o *OutputContextMock) AddTxValueToAccount(_ []byte, _ *big.Int) {
 }
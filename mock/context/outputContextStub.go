@@ -26,6 +26,8 @@ type OutputContextStub struct {
 	WriteLogCalled                    func(address []byte, topics [][]byte, data []byte)
 	TransferCalled                    func(destination []byte, sender []byte, gasLimit uint64, gasLocked uint64, value *big.Int, input []byte) error
 	TransferESDTCalled                func(destination []byte, sender []byte, transfers []*vmcommon.ESDTTransfer, input *vmcommon.ContractCallInput) (uint64, error)
+	GetBackTransfersCalled            func() ([]*vmcommon.ESDTTransfer, *big.Int)
+	ClearBackTransfersCalled          func()
 	SelfDestructCalled                func(address []byte, beneficiary []byte)
 	GetRefundCalled                   func() uint64
 	SetRefundCalled                   func(refund uint64)
@@ -167,6 +169,21 @@ func (o *OutputContextStub) TransferESDT(destination []byte, sender []byte, tran
 	return 0, nil
 }
 
+// GetBackTransfers mocked method
+func (o *OutputContextStub) GetBackTransfers() ([]*vmcommon.ESDTTransfer, *big.Int) {
+	if o.GetBackTransfersCalled != nil {
+		return o.GetBackTransfersCalled()
+	}
+	return make([]*vmcommon.ESDTTransfer, 0), big.NewInt(0)
+}
+
+// ClearBackTransfers mocked method
+func (o *OutputContextStub) ClearBackTransfers() {
+	if o.ClearBackTransfersCalled != nil {
+		o.ClearBackTransfersCalled()
+	}
+}
+
 // SelfDestruct mocked method
 func (o *OutputContextStub) SelfDestruct(address []byte, beneficiary []byte) {
 	if o.SelfDestructCalled != nil {
@@ -24,6 +24,7 @@ type VMHostMock struct {
 	MeteringContext     arwen.MeteringContext
 	StorageContext      arwen.StorageContext
 	ManagedTypesContext arwen.ManagedTypesContext
+	ExecutionObserver   arwen.ExecutionObserver
 
 	SCAPIMethods  *wasmer.Imports
 	IsBuiltinFunc bool
@@ -64,6 +65,14 @@ func (host *VMHostMock) Storage() arwen.StorageContext {
 	return host.StorageContext
 }
 
+// Observer mocked method
+func (host *VMHostMock) Observer() arwen.ExecutionObserver {
+	if host.ExecutionObserver == nil {
+		return &ExecutionObserverStub{}
+	}
+	return host.ExecutionObserver
+}
+
 // BigInt mocked method
 func (host *VMHostMock) ManagedTypes() arwen.ManagedTypesContext {
 	return host.ManagedTypesContext
@@ -119,6 +128,16 @@ func (host *VMHostMock) ExecuteOnDestContext(_ *vmcommon.ContractCallInput) (*vm
 	return nil, nil, nil
 }
 
+// EstimateGas mocked method
+func (host *VMHostMock) EstimateGas(_ *vmcommon.ContractCallInput) (uint64, error) {
+	return 0, nil
+}
+
+// EstimateGasForCreate mocked method
+func (host *VMHostMock) EstimateGasForCreate(_ *vmcommon.ContractCreateInput) (uint64, error) {
+	return 0, nil
+}
+
 // InitState mocked method
 func (host *VMHostMock) InitState() {
 }
@@ -26,10 +26,13 @@ type VMHostStub struct {
 	OutputCalled                func() arwen.OutputContext
 	MeteringCalled              func() arwen.MeteringContext
 	StorageCalled               func() arwen.StorageContext
+	ObserverCalled              func() arwen.ExecutionObserver
 	ExecuteESDTTransferCalled   func(destination []byte, sender []byte, transfers []*vmcommon.ESDTTransfer, callType vm.CallType) (*vmcommon.VMOutput, uint64, error)
 	CreateNewContractCalled     func(input *vmcommon.ContractCreateInput) ([]byte, error)
 	ExecuteOnSameContextCalled  func(input *vmcommon.ContractCallInput) (*arwen.AsyncContextInfo, error)
 	ExecuteOnDestContextCalled  func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *arwen.AsyncContextInfo, error)
+	EstimateGasCalled           func(input *vmcommon.ContractCallInput) (uint64, error)
+	EstimateGasForCreateCalled  func(input *vmcommon.ContractCreateInput) (uint64, error)
 	GetAPIMethodsCalled         func() *wasmer.Imports
 	IsBuiltinFunctionNameCalled func(functionName string) bool
 	AreInSameShardCalled        func(left []byte, right []byte) bool
@@ -164,6 +167,14 @@ func (vhs *VMHostStub) Storage() arwen.StorageContext {
 	return nil
 }
 
+// Observer mocked method
+func (vhs *VMHostStub) Observer() arwen.ExecutionObserver {
+	if vhs.ObserverCalled != nil {
+		return vhs.ObserverCalled()
+	}
+	return &ExecutionObserverStub{}
+}
+
 // ExecuteESDTTransfer mocked method
 func (vhs *VMHostStub) ExecuteESDTTransfer(destination []byte, sender []byte, transfers []*vmcommon.ESDTTransfer, callType vm.CallType) (*vmcommon.VMOutput, uint64, error) {
 	if vhs.ExecuteESDTTransferCalled != nil {
@@ -196,6 +207,22 @@ func (vhs *VMHostStub) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (
 	return nil, nil, nil
 }
 
+// EstimateGas mocked method
+func (vhs *VMHostStub) EstimateGas(input *vmcommon.ContractCallInput) (uint64, error) {
+	if vhs.EstimateGasCalled != nil {
+		return vhs.EstimateGasCalled(input)
+	}
+	return 0, nil
+}
+
+// EstimateGasForCreate mocked method
+func (vhs *VMHostStub) EstimateGasForCreate(input *vmcommon.ContractCreateInput) (uint64, error) {
+	if vhs.EstimateGasForCreateCalled != nil {
+		return vhs.EstimateGasForCreateCalled(input)
+	}
+	return 0, nil
+}
+
 // AreInSameShard mocked method
 func (vhs *VMHostStub) AreInSameShard(left []byte, right []byte) bool {
 	if vhs.AreInSameShardCalled != nil {
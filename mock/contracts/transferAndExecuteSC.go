@@ -47,3 +47,30 @@ func TransferAndExecute(instanceMock *mock.InstanceMock, config interface{}) {
 func GetChildAddressForTransfer(transfer int) []byte {
 	return testcommon.MakeTestSCAddress(fmt.Sprintf("childSC-%d", transfer))
 }
+
+// TransferAndExecuteOnChildFuncName -
+var TransferAndExecuteOnChildFuncName = "transferAndExecuteOnChild"
+
+// TransferAndExecuteOnChild is an exposed mock contract method, which sends
+// value to the child contract, together with a call to the function given as
+// the first argument
+func TransferAndExecuteOnChild(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(TransferAndExecuteTestConfig)
+	instanceMock.AddMockMethod(TransferAndExecuteOnChildFuncName, func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		host.Metering().UseGas(testConfig.GasUsedByParent)
+
+		arguments := host.Runtime().Arguments()
+		elrondapi.TransferValueExecuteWithTypedArgs(host,
+			testcommon.ChildAddress,
+			big.NewInt(testConfig.TransferFromParentToChild),
+			int64(testConfig.GasTransferToChild),
+			arguments[0],
+			[][]byte{},
+		)
+
+		return instance
+	})
+}
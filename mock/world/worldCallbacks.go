@@ -228,6 +228,10 @@ func (b *MockWorld) GetCode(acc vmcommon.UserAccountHandler) []byte {
 
 // GetShardOfAddress -
 func (b *MockWorld) GetShardOfAddress(address []byte) uint32 {
+	if b.MultiShard != nil {
+		return b.MultiShard.ShardOfAddress(address)
+	}
+
 	account := b.AcctMap.GetAccount(address)
 	if account == nil {
 		return 0
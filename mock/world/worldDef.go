@@ -37,6 +37,7 @@ type MockWorld struct {
 	LastCreatedContractAddress []byte
 	CompiledCode               map[string][]byte
 	BuiltinFuncs               *BuiltinFunctionsWrapper
+	MultiShard                 *MultiShardMockWorld
 }
 
 // NewMockWorld creates a new MockWorld instance
@@ -91,6 +92,10 @@ func (b *MockWorld) SetCurrentBlockHash(blockHash []byte) {
 
 // NumberOfShards -
 func (b *MockWorld) NumberOfShards() uint32 {
+	if b.MultiShard != nil {
+		return b.MultiShard.NumberOfShards()
+	}
+
 	maxShardID := uint32(0)
 	for _, account := range b.AcctMap {
 		if account.ShardID > maxShardID {
@@ -103,6 +108,10 @@ func (b *MockWorld) NumberOfShards() uint32 {
 
 // ComputeId -
 func (b *MockWorld) ComputeId(address []byte) uint32 {
+	if b.MultiShard != nil {
+		return b.MultiShard.ShardOfAddress(address)
+	}
+
 	return b.AcctMap.GetAccount(address).ShardID
 }
 
@@ -113,6 +122,10 @@ func (b *MockWorld) SelfId() uint32 {
 
 // SameShard -
 func (b *MockWorld) SameShard(firstAddress []byte, secondAddress []byte) bool {
+	if b.MultiShard != nil {
+		return b.MultiShard.ShardOfAddress(firstAddress) == b.MultiShard.ShardOfAddress(secondAddress)
+	}
+
 	firstAccount := b.AcctMap.GetAccount(firstAddress)
 	secondAccount := b.AcctMap.GetAccount(secondAddress)
 	return firstAccount.ShardID == secondAccount.ShardID
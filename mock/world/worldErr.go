@@ -8,3 +8,9 @@ var ErrInsufficientFunds = errors.New("insufficient funds")
 
 // ErrNilWorldMock signals that the WorldMock is nil but shouldn't be.
 var ErrNilWorldMock = errors.New("nil worldmock")
+
+// ErrInvalidShardID signals that the given shard ID is not simulated by the MultiShardMockWorld.
+var ErrInvalidShardID = errors.New("invalid shard ID")
+
+// ErrNilVM signals that no VM was set for the shard which must execute a call.
+var ErrNilVM = errors.New("nil VM for shard")
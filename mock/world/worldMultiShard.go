@@ -0,0 +1,435 @@
+package worldmock
+
+import (
+	"encoding/hex"
+	"math/big"
+	"sort"
+
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+)
+
+const callbackFunctionName = "callBack"
+
+// CrossShardCall is a transfer, an async call or a callback emitted by a
+// contract towards an account located in another shard, waiting to be
+// delivered by ProcessRound
+type CrossShardCall struct {
+	SenderShardID      uint32
+	DestinationShardID uint32
+	Sender             []byte
+	Destination        []byte
+	Value              *big.Int
+	Data               []byte
+	GasLimit           uint64
+	GasLocked          uint64
+	GasPrice           uint64
+	CallType           vm.CallType
+	OriginalTxHash     []byte
+}
+
+// CrossShardCallResult holds the outcome of delivering a CrossShardCall to its
+// destination shard; VMOutput is nil for plain value transfers
+type CrossShardCallResult struct {
+	Call     *CrossShardCall
+	VMOutput *vmcommon.VMOutput
+	Err      error
+}
+
+// MultiShardMockWorld simulates several shards, each one with its own MockWorld
+// and VM. Transfers and async calls towards accounts in other shards are queued
+// in the outgoing queue of the sender shard and delivered by ProcessRound, in
+// the same way the protocol delivers cross-shard smart contract results.
+type MultiShardMockWorld struct {
+	Shards         []*MockWorld
+	OutgoingQueues [][]*CrossShardCall
+	DeliveredCalls []*CrossShardCallResult
+	Round          uint64
+
+	vms                   []vmcommon.VMExecutionHandler
+	gasLockedForCallbacks map[string][]uint64
+}
+
+// NewMultiShardMockWorld creates a MultiShardMockWorld with the given number of
+// shards, each one with an empty MockWorld
+func NewMultiShardMockWorld(numShards uint32) *MultiShardMockWorld {
+	if numShards == 0 {
+		numShards = 1
+	}
+
+	multiShard := &MultiShardMockWorld{
+		Shards:                make([]*MockWorld, numShards),
+		OutgoingQueues:        make([][]*CrossShardCall, numShards),
+		DeliveredCalls:        make([]*CrossShardCallResult, 0),
+		vms:                   make([]vmcommon.VMExecutionHandler, numShards),
+		gasLockedForCallbacks: make(map[string][]uint64),
+	}
+
+	for shardID := uint32(0); shardID < numShards; shardID++ {
+		world := NewMockWorld()
+		world.SelfShardID = shardID
+		world.MultiShard = multiShard
+		multiShard.Shards[shardID] = world
+	}
+
+	return multiShard
+}
+
+// NumberOfShards returns the number of simulated shards
+func (msw *MultiShardMockWorld) NumberOfShards() uint32 {
+	return uint32(len(msw.Shards))
+}
+
+// Shard returns the MockWorld of the given shard, or nil if there is no such shard
+func (msw *MultiShardMockWorld) Shard(shardID uint32) *MockWorld {
+	if shardID >= msw.NumberOfShards() {
+		return nil
+	}
+
+	return msw.Shards[shardID]
+}
+
+// SetVM sets the VM which executes the calls received by the given shard
+func (msw *MultiShardMockWorld) SetVM(shardID uint32, vmHandler vmcommon.VMExecutionHandler) error {
+	if shardID >= msw.NumberOfShards() {
+		return ErrInvalidShardID
+	}
+
+	msw.vms[shardID] = vmHandler
+	return nil
+}
+
+// ShardOfAddress returns the shard of the account with the given address. Addresses
+// not yet present in any shard are assigned using their last byte, similar to
+// the way the protocol's shard coordinator does it.
+func (msw *MultiShardMockWorld) ShardOfAddress(address []byte) uint32 {
+	for _, world := range msw.Shards {
+		account := world.AcctMap.GetAccount(address)
+		if account != nil {
+			return account.ShardID
+		}
+	}
+
+	if len(address) == 0 {
+		return 0
+	}
+
+	return uint32(address[len(address)-1]) % msw.NumberOfShards()
+}
+
+// GetAccount returns the account with the given address from the shard it belongs to
+func (msw *MultiShardMockWorld) GetAccount(address []byte) *Account {
+	return msw.Shards[msw.ShardOfAddress(address)].AcctMap.GetAccount(address)
+}
+
+// HasPendingCalls returns true if there are cross-shard calls not yet delivered
+func (msw *MultiShardMockWorld) HasPendingCalls() bool {
+	for _, queue := range msw.OutgoingQueues {
+		if len(queue) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExecuteTransaction executes the given call in the shard of its recipient,
+// applies the resulting VMOutput to that shard and queues the outgoing
+// cross-shard calls for delivery
+func (msw *MultiShardMockWorld) ExecuteTransaction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	shardID := msw.ShardOfAddress(input.RecipientAddr)
+	vmHandler := msw.vms[shardID]
+	if vmHandler == nil {
+		return nil, ErrNilVM
+	}
+
+	// the value is taken from the sender by the protocol, before calling the VM
+	hasValue := input.CallValue != nil && input.CallValue.Sign() > 0
+	var sender *Account
+	if hasValue {
+		sender = msw.GetAccount(input.CallerAddr)
+		if sender == nil || sender.Balance.Cmp(input.CallValue) < 0 {
+			return nil, ErrInsufficientFunds
+		}
+		sender.Balance = big.NewInt(0).Sub(sender.Balance, input.CallValue)
+	}
+
+	vmOutput, err := vmHandler.RunSmartContractCall(input)
+	isFailed := err != nil || vmOutput.ReturnCode != vmcommon.Ok
+	if isFailed && hasValue {
+		// the protocol returns the value to the sender of a failed transaction
+		sender.Balance = big.NewInt(0).Add(sender.Balance, input.CallValue)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	msw.processVMOutput(shardID, vmOutput, input.GasPrice, getOriginalTxHash(&input.VMInput))
+	return vmOutput, nil
+}
+
+// ProcessRound advances all shards to the next round and delivers the
+// cross-shard calls queued so far to their destination shards. Calls emitted
+// while delivering are queued for the next round.
+func (msw *MultiShardMockWorld) ProcessRound() []*CrossShardCallResult {
+	msw.Round++
+	for _, world := range msw.Shards {
+		if world.CurrentBlockInfo == nil {
+			world.CurrentBlockInfo = &BlockInfo{}
+		}
+		world.CurrentBlockInfo.BlockRound = msw.Round
+	}
+
+	calls := make([]*CrossShardCall, 0)
+	for shardID, queue := range msw.OutgoingQueues {
+		calls = append(calls, queue...)
+		msw.OutgoingQueues[shardID] = nil
+	}
+
+	results := make([]*CrossShardCallResult, 0, len(calls))
+	for _, call := range calls {
+		results = append(results, msw.deliverCall(call))
+	}
+
+	msw.DeliveredCalls = append(msw.DeliveredCalls, results...)
+	return results
+}
+
+func (msw *MultiShardMockWorld) processVMOutput(shardID uint32, vmOutput *vmcommon.VMOutput, gasPrice uint64, originalTxHash []byte) {
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		return
+	}
+
+	world := msw.Shards[shardID]
+
+	addresses := make([]string, 0, len(vmOutput.OutputAccounts))
+	for address := range vmOutput.OutputAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		outputAccount := vmOutput.OutputAccounts[address]
+		destinationShardID := msw.ShardOfAddress(outputAccount.Address)
+		if destinationShardID == shardID {
+			world.UpdateAccountFromOutputAccount(outputAccount)
+			world.AcctMap.GetAccount(outputAccount.Address).ShardID = shardID
+			continue
+		}
+
+		for _, transfer := range outputAccount.OutputTransfers {
+			msw.OutgoingQueues[shardID] = append(msw.OutgoingQueues[shardID], &CrossShardCall{
+				SenderShardID:      shardID,
+				DestinationShardID: destinationShardID,
+				Sender:             transfer.SenderAddress,
+				Destination:        outputAccount.Address,
+				Value:              transfer.Value,
+				Data:               transfer.Data,
+				GasLimit:           transfer.GasLimit,
+				GasLocked:          transfer.GasLocked,
+				GasPrice:           gasPrice,
+				CallType:           transfer.CallType,
+				OriginalTxHash:     originalTxHash,
+			})
+		}
+	}
+
+	for _, deletedAddress := range vmOutput.DeletedAccounts {
+		if msw.ShardOfAddress(deletedAddress) == shardID {
+			world.AcctMap.DeleteAccount(deletedAddress)
+		}
+	}
+}
+
+func (msw *MultiShardMockWorld) deliverCall(call *CrossShardCall) *CrossShardCallResult {
+	result := &CrossShardCallResult{Call: call}
+	world := msw.Shards[call.DestinationShardID]
+
+	if call.CallType == vm.AsynchronousCall {
+		key := callbackKey(call.Sender, call.Destination)
+		msw.gasLockedForCallbacks[key] = append(msw.gasLockedForCallbacks[key], call.GasLocked)
+	}
+
+	destination := world.AcctMap.GetAccount(call.Destination)
+	isPlainTransfer := len(call.Data) == 0 && call.CallType != vm.AsynchronousCallBack
+	if destination == nil || !destination.IsSmartContract || isPlainTransfer {
+		msw.creditValue(world, call.Destination, call.Value)
+		return result
+	}
+
+	result.VMOutput, result.Err = msw.executeCall(call)
+	if result.Err == nil {
+		msw.processVMOutput(call.DestinationShardID, result.VMOutput, call.GasPrice, call.OriginalTxHash)
+	}
+
+	isFailed := result.Err != nil || result.VMOutput.ReturnCode != vmcommon.Ok
+	if !isFailed {
+		return result
+	}
+
+	if call.CallType == vm.AsynchronousCall {
+		msw.queueErrorCallback(call, result.VMOutput, result.Err)
+	} else {
+		msw.queueRefund(call)
+	}
+
+	return result
+}
+
+func (msw *MultiShardMockWorld) executeCall(call *CrossShardCall) (*vmcommon.VMOutput, error) {
+	vmHandler := msw.vms[call.DestinationShardID]
+	if vmHandler == nil {
+		return nil, ErrNilVM
+	}
+
+	input, err := msw.createCallInput(call)
+	if err != nil {
+		return nil, err
+	}
+
+	return vmHandler.RunSmartContractCall(input)
+}
+
+func (msw *MultiShardMockWorld) createCallInput(call *CrossShardCall) (*vmcommon.ContractCallInput, error) {
+	data := string(call.Data)
+	if call.CallType == vm.AsynchronousCallBack {
+		data = callbackFunctionName + data
+	}
+
+	function, arguments, err := parsers.NewCallArgsParser().ParseData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	gasProvided := call.GasLimit
+	if call.CallType == vm.AsynchronousCallBack {
+		// the gas locked by the original async call is returned to the callback
+		gasProvided += msw.popGasLockedForCallback(call.Destination, call.Sender)
+		if len(arguments) > 0 {
+			arguments[0] = returnCodeArgument(arguments[0])
+		}
+	}
+
+	value := big.NewInt(0)
+	if call.Value != nil {
+		value.Set(call.Value)
+	}
+
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     call.Sender,
+			Arguments:      arguments,
+			CallValue:      value,
+			CallType:       call.CallType,
+			GasPrice:       call.GasPrice,
+			GasProvided:    gasProvided,
+			OriginalTxHash: call.OriginalTxHash,
+			CurrentTxHash:  call.OriginalTxHash,
+		},
+		RecipientAddr: call.Destination,
+		Function:      function,
+	}, nil
+}
+
+// queueErrorCallback sends the callback of a failed async call back to its
+// caller, together with the call value, as the protocol does when the
+// destination shard fails to execute the call
+func (msw *MultiShardMockWorld) queueErrorCallback(call *CrossShardCall, vmOutput *vmcommon.VMOutput, err error) {
+	returnCode := vmcommon.ExecutionFailed
+	returnMessage := ""
+	gasRemaining := uint64(0)
+	if err != nil {
+		returnMessage = err.Error()
+	}
+	if vmOutput != nil {
+		returnCode = vmOutput.ReturnCode
+		returnMessage = vmOutput.ReturnMessage
+		gasRemaining = vmOutput.GasRemaining
+	}
+
+	data := "@" + hex.EncodeToString([]byte(returnCode.String())) +
+		"@" + hex.EncodeToString([]byte(returnMessage))
+
+	shardID := call.DestinationShardID
+	msw.OutgoingQueues[shardID] = append(msw.OutgoingQueues[shardID], &CrossShardCall{
+		SenderShardID:      shardID,
+		DestinationShardID: call.SenderShardID,
+		Sender:             call.Destination,
+		Destination:        call.Sender,
+		Value:              call.Value,
+		Data:               []byte(data),
+		GasLimit:           gasRemaining,
+		GasPrice:           call.GasPrice,
+		CallType:           vm.AsynchronousCallBack,
+		OriginalTxHash:     call.OriginalTxHash,
+	})
+}
+
+// queueRefund sends the value of a failed call back to its sender, as the
+// protocol does for the cross-shard calls which are not async calls
+func (msw *MultiShardMockWorld) queueRefund(call *CrossShardCall) {
+	if call.Value == nil || call.Value.Sign() == 0 {
+		return
+	}
+
+	shardID := call.DestinationShardID
+	msw.OutgoingQueues[shardID] = append(msw.OutgoingQueues[shardID], &CrossShardCall{
+		SenderShardID:      shardID,
+		DestinationShardID: call.SenderShardID,
+		Sender:             call.Destination,
+		Destination:        call.Sender,
+		Value:              call.Value,
+		GasPrice:           call.GasPrice,
+		CallType:           vm.DirectCall,
+		OriginalTxHash:     call.OriginalTxHash,
+	})
+}
+
+func (msw *MultiShardMockWorld) popGasLockedForCallback(caller []byte, callee []byte) uint64 {
+	key := callbackKey(caller, callee)
+	gasLocked := msw.gasLockedForCallbacks[key]
+	if len(gasLocked) == 0 {
+		return 0
+	}
+
+	msw.gasLockedForCallbacks[key] = gasLocked[1:]
+	return gasLocked[0]
+}
+
+func (msw *MultiShardMockWorld) creditValue(world *MockWorld, address []byte, value *big.Int) {
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+
+	account := world.AcctMap.GetAccount(address)
+	if account == nil {
+		account = world.AcctMap.CreateAccount(address, world)
+		account.ShardID = world.SelfShardID
+	}
+	account.Balance = big.NewInt(0).Add(account.Balance, value)
+}
+
+func callbackKey(caller []byte, callee []byte) string {
+	return string(caller) + "|" + string(callee)
+}
+
+func getOriginalTxHash(input *vmcommon.VMInput) []byte {
+	if len(input.OriginalTxHash) > 0 {
+		return input.OriginalTxHash
+	}
+
+	return input.CurrentTxHash
+}
+
+// returnCodeArgument converts the return code of a callback, which the VM
+// sends by name, into its numeric form expected by the callback function
+func returnCodeArgument(argument []byte) []byte {
+	for returnCode := vmcommon.Ok; returnCode <= vmcommon.SimulateFailed; returnCode++ {
+		if string(argument) == returnCode.String() {
+			return big.NewInt(int64(returnCode)).Bytes()
+		}
+	}
+
+	return argument
+}
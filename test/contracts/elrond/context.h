@@ -7,6 +7,8 @@ void getSCAddress(byte *address);
 void getOwnerAddress(byte *address);
 int getShardOfAddress(byte *address);
 int isSmartContract(byte *address);
+int getCodeMetadata(byte *address, byte *result);
+int isPayable(byte *address);
 
 // EllipticCurve-Related functions
 
@@ -43,6 +45,20 @@ int	mBufferStorageLoad(int keyHandle, int mBufferHandle);
 int	mBufferGetArgument(int id, int mBufferHandle);
 int	mBufferFinish(int mBufferHandle);
 
+// Managed Vectors and Maps
+int	mVecNew();
+int	mVecGetLength(int mVecHandle);
+int	mVecPush(int mVecHandle, int mBufferHandle);
+int	mVecGet(int mVecHandle, int index, int destinationHandle);
+int	mVecSet(int mVecHandle, int index, int mBufferHandle);
+int	mVecRemove(int mVecHandle, int index);
+int	mMapNew();
+int	mMapGetLength(int mMapHandle);
+int	mMapPut(int mMapHandle, int keyHandle, int valueHandle);
+int	mMapGet(int mMapHandle, int keyHandle, int destinationHandle);
+int	mMapRemove(int mMapHandle, int keyHandle, int destinationHandle);
+int	mMapContains(int mMapHandle, int keyHandle);
+
 // Call-related functions
 void getCaller(byte *callerAddress);
 int getFunction(byte *function);
@@ -156,5 +172,9 @@ int getESDTBalance(
 		int tokenNameLen,
 		long long nonce,
 		byte *result);
+long long getESDTLocalRoles(byte *address, byte *tokenName, int tokenNameLen);
+int isESDTFrozen(byte *address, byte *tokenName, int tokenNameLen);
+int isESDTPaused(byte *tokenName, int tokenNameLen);
+int isESDTLimitedTransfer(byte *tokenName, int tokenNameLen);
 
 #endif
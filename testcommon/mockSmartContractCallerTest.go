@@ -18,9 +18,10 @@ type testTemplateConfig struct {
 // MockInstancesTestTemplate holds the data to build a mock contract call test
 type MockInstancesTestTemplate struct {
 	testTemplateConfig
-	contracts     *[]MockTestSmartContract
-	setup         func(arwen.VMHost, *worldmock.MockWorld)
-	assertResults func(*worldmock.MockWorld, *VMOutputVerifier)
+	contracts         *[]MockTestSmartContract
+	executionObserver arwen.ExecutionObserver
+	setup             func(arwen.VMHost, *worldmock.MockWorld)
+	assertResults     func(*worldmock.MockWorld, *VMOutputVerifier)
 }
 
 // BuildMockInstanceCallTest starts the building process for a mock contract call test
@@ -46,6 +47,13 @@ func (callerTest *MockInstancesTestTemplate) WithInput(input *vmcommon.ContractC
 	return callerTest
 }
 
+// WithExecutionObserver provides the ExecutionObserver to be registered on the
+// host of the mock contract call test
+func (callerTest *MockInstancesTestTemplate) WithExecutionObserver(observer arwen.ExecutionObserver) *MockInstancesTestTemplate {
+	callerTest.executionObserver = observer
+	return callerTest
+}
+
 // WithSetup provides the setup function to be used by the mock contract call test
 func (callerTest *MockInstancesTestTemplate) WithSetup(setup func(arwen.VMHost, *worldmock.MockWorld)) *MockInstancesTestTemplate {
 	callerTest.setup = setup
@@ -59,7 +67,7 @@ func (callerTest *MockInstancesTestTemplate) AndAssertResults(assertResults func
 }
 
 func (callerTest *MockInstancesTestTemplate) runTest() {
-	host, world, imb := DefaultTestArwenForCallWithInstanceMocks(callerTest.tb)
+	host, world, imb := DefaultTestArwenForCallWithInstanceMocksAndObserver(callerTest.tb, callerTest.executionObserver)
 
 	for _, mockSC := range *callerTest.contracts {
 		mockSC.initialize(callerTest.tb, host, imb)
@@ -0,0 +1,102 @@
+package testcommon
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/world"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/stretchr/testify/require"
+)
+
+// maxRoundsForMultiShardTest bounds the number of rounds a multi-shard test may
+// process before all cross-shard calls are delivered
+const maxRoundsForMultiShardTest = 10
+
+// MockInstancesMultiShardTestTemplate holds the data to build a mock contract
+// call test which is executed on several shards, with one VM per shard
+type MockInstancesMultiShardTestTemplate struct {
+	testTemplateConfig
+	numShards     uint32
+	contracts     *[]MockTestSmartContract
+	setup         func([]arwen.VMHost, *worldmock.MultiShardMockWorld)
+	assertResults func(*worldmock.MultiShardMockWorld, *VMOutputVerifier)
+}
+
+// BuildMockInstanceMultiShardTest starts the building process for a mock
+// contract call test executed across the given number of shards
+func BuildMockInstanceMultiShardTest(tb testing.TB, numShards uint32) *MockInstancesMultiShardTestTemplate {
+	return &MockInstancesMultiShardTestTemplate{
+		testTemplateConfig: testTemplateConfig{
+			tb:       tb,
+			useMocks: true,
+		},
+		numShards: numShards,
+		setup:     func([]arwen.VMHost, *worldmock.MultiShardMockWorld) {},
+	}
+}
+
+// WithContracts provides the contracts to be used by the mock contract call
+// test; each contract is deployed on the shard it was created for
+func (callerTest *MockInstancesMultiShardTestTemplate) WithContracts(usedContracts ...MockTestSmartContract) *MockInstancesMultiShardTestTemplate {
+	callerTest.contracts = &usedContracts
+	return callerTest
+}
+
+// WithInput provides the ContractCallInput to be used by the mock contract call test
+func (callerTest *MockInstancesMultiShardTestTemplate) WithInput(input *vmcommon.ContractCallInput) *MockInstancesMultiShardTestTemplate {
+	callerTest.input = input
+	return callerTest
+}
+
+// WithSetup provides the setup function to be used by the mock contract call
+// test; the hosts are indexed by shard ID
+func (callerTest *MockInstancesMultiShardTestTemplate) WithSetup(setup func([]arwen.VMHost, *worldmock.MultiShardMockWorld)) *MockInstancesMultiShardTestTemplate {
+	callerTest.setup = setup
+	return callerTest
+}
+
+// AndAssertResults provides the function that will assert the results; the
+// VMOutputVerifier checks the output of the initial call, while the results of
+// the delivered cross-shard calls are found in MultiShardMockWorld.DeliveredCalls
+func (callerTest *MockInstancesMultiShardTestTemplate) AndAssertResults(assertResults func(world *worldmock.MultiShardMockWorld, verify *VMOutputVerifier)) {
+	callerTest.assertResults = assertResults
+	callerTest.runTest()
+}
+
+func (callerTest *MockInstancesMultiShardTestTemplate) runTest() {
+	world := worldmock.NewMultiShardMockWorld(callerTest.numShards)
+
+	hosts := make([]arwen.VMHost, 0, world.NumberOfShards())
+	instanceBuilders := make([]*mock.InstanceBuilderMock, 0, world.NumberOfShards())
+	for shardID, shard := range world.Shards {
+		host := DefaultTestArwen(callerTest.tb, shard)
+		instanceBuilder := mock.NewInstanceBuilderMock(shard)
+		host.Runtime().ReplaceInstanceBuilder(instanceBuilder)
+
+		err := world.SetVM(uint32(shardID), host)
+		require.Nil(callerTest.tb, err)
+
+		hosts = append(hosts, host)
+		instanceBuilders = append(instanceBuilders, instanceBuilder)
+	}
+
+	for _, mockSC := range *callerTest.contracts {
+		mockSC.initialize(callerTest.tb, hosts[mockSC.shardID], instanceBuilders[mockSC.shardID])
+	}
+
+	callerTest.setup(hosts, world)
+
+	recipientShardID := world.ShardOfAddress(callerTest.input.RecipientAddr)
+	vmOutput, err := world.ExecuteTransaction(callerTest.input)
+	allErrors := hosts[recipientShardID].Runtime().GetAllErrors()
+
+	for round := 0; world.HasPendingCalls(); round++ {
+		require.Less(callerTest.tb, round, maxRoundsForMultiShardTest, "cross-shard calls still pending")
+		world.ProcessRound()
+	}
+
+	verify := NewVMOutputVerifierWithAllErrors(callerTest.tb, vmOutput, err, allErrors)
+	callerTest.assertResults(world, verify)
+}
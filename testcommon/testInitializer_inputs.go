@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/contexts"
 	arwenHost "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/arwen/host"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/config"
 	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_4/mock/context"
@@ -50,6 +51,15 @@ var ChildAddress = MakeTestSCAddress("childSC")
 
 var customGasSchedule = config.GasScheduleMap(nil)
 
+// WASMBackendEnvVar names the environment variable which selects the WASM
+// backend of the hosts created by the tests; Wasmer is used when it is not set
+const WASMBackendEnvVar = "ARWEN_TEST_WASM_BACKEND"
+
+// TestWASMBackend returns the WASM backend selected for the hosts created by the tests
+func TestWASMBackend() arwen.WASMBackend {
+	return arwen.WASMBackend(os.Getenv(WASMBackendEnvVar))
+}
+
 // ESDTTransferGasCost is an exposed value to use in tests
 var ESDTTransferGasCost = uint64(1)
 
@@ -151,7 +161,10 @@ func DefaultTestArwenForCallWithInstanceRecorderMock(tb testing.TB, code []byte,
 	// this uses a Blockchain Hook Stub that does not cache the compiled code
 	host, _ := DefaultTestArwenForCall(tb, code, balance)
 
-	instanceBuilderRecorderMock := contextmock.NewInstanceBuilderRecorderMock()
+	instanceBuilder, err := contexts.NewInstanceBuilder(host, TestWASMBackend())
+	require.Nil(tb, err)
+
+	instanceBuilderRecorderMock := contextmock.NewInstanceBuilderRecorderMock(instanceBuilder)
 	host.Runtime().ReplaceInstanceBuilder(instanceBuilderRecorderMock)
 
 	return host, instanceBuilderRecorderMock
@@ -159,8 +172,14 @@ func DefaultTestArwenForCallWithInstanceRecorderMock(tb testing.TB, code []byte,
 
 // DefaultTestArwenForCallWithInstanceMocks creates an InstanceBuilderMock
 func DefaultTestArwenForCallWithInstanceMocks(tb testing.TB) (arwen.VMHost, *worldmock.MockWorld, *contextmock.InstanceBuilderMock) {
+	return DefaultTestArwenForCallWithInstanceMocksAndObserver(tb, nil)
+}
+
+// DefaultTestArwenForCallWithInstanceMocksAndObserver creates an InstanceBuilderMock
+// on a host which notifies the given ExecutionObserver
+func DefaultTestArwenForCallWithInstanceMocksAndObserver(tb testing.TB, observer arwen.ExecutionObserver) (arwen.VMHost, *worldmock.MockWorld, *contextmock.InstanceBuilderMock) {
 	world := worldmock.NewMockWorld()
-	host := DefaultTestArwen(tb, world)
+	host := DefaultTestArwenWithObserver(tb, world, observer)
 
 	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
 	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
@@ -290,6 +309,7 @@ func DefaultTestArwenWithWorldMock(tb testing.TB) (arwen.VMHost, *worldmock.Mock
 		ElrondProtectedKeyPrefix: []byte("ELROND"),
 		ESDTTransferParser:       esdtTransferParser,
 		EpochNotifier:            &worldmock.EpochNotifierStub{},
+		WASMBackend:              TestWASMBackend(),
 	})
 	require.Nil(tb, err)
 	require.NotNil(tb, host)
@@ -299,6 +319,12 @@ func DefaultTestArwenWithWorldMock(tb testing.TB) (arwen.VMHost, *worldmock.Mock
 
 // DefaultTestArwen creates a host configured with a configured blockchain hook
 func DefaultTestArwen(tb testing.TB, blockchain vmcommon.BlockchainHook) arwen.VMHost {
+	return DefaultTestArwenWithObserver(tb, blockchain, nil)
+}
+
+// DefaultTestArwenWithObserver creates a host configured with a configured
+// blockchain hook, which notifies the given ExecutionObserver
+func DefaultTestArwenWithObserver(tb testing.TB, blockchain vmcommon.BlockchainHook, observer arwen.ExecutionObserver) arwen.VMHost {
 	gasSchedule := customGasSchedule
 	if gasSchedule == nil {
 		gasSchedule = config.MakeGasMapForTests()
@@ -313,6 +339,8 @@ func DefaultTestArwen(tb testing.TB, blockchain vmcommon.BlockchainHook) arwen.V
 		ElrondProtectedKeyPrefix: []byte("ELROND"),
 		ESDTTransferParser:       esdtTransferParser,
 		EpochNotifier:            &worldmock.EpochNotifierStub{},
+		ExecutionObserver:        observer,
+		WASMBackend:              TestWASMBackend(),
 	})
 	require.Nil(tb, err)
 	require.NotNil(tb, host)
@@ -405,6 +433,12 @@ func (contractInput *ContractCallInputBuilder) WithArguments(arguments ...[]byte
 	return contractInput
 }
 
+// WithCallValue provides the CallValue for ContractCallInputBuilder
+func (contractInput *ContractCallInputBuilder) WithCallValue(value int64) *ContractCallInputBuilder {
+	contractInput.ContractCallInput.VMInput.CallValue = big.NewInt(value)
+	return contractInput
+}
+
 // WithCallType provides the arguments to be called for ContractCallInputBuilder
 func (contractInput *ContractCallInputBuilder) WithCallType(callType vm.CallType) *ContractCallInputBuilder {
 	contractInput.ContractCallInput.VMInput.CallType = callType
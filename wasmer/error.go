@@ -3,13 +3,15 @@ package wasmer
 import (
 	"errors"
 	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/executor"
 )
 
 var ErrFailedInstantiation = errors.New("could not create wasmer instance")
 
 var ErrFailedCacheImports = errors.New("could not cache imports")
 
-var ErrInvalidBytecode = errors.New("invalid bytecode")
+var ErrInvalidBytecode = executor.ErrInvalidBytecode
 
 var ErrCachingFailed = errors.New("instance caching failed")
 
@@ -0,0 +1,6 @@
+package executor
+
+import "errors"
+
+// ErrInvalidBytecode signals that the given code is not a valid WASM module
+var ErrInvalidBytecode = errors.New("invalid bytecode")
@@ -0,0 +1,28 @@
+package executor
+
+// ExportedFunctionSignature holds information about the input/output arities
+// of an exported function
+type ExportedFunctionSignature struct {
+	InputArity  int
+	OutputArity int
+}
+
+// ExportedFunctionCallback calls an exported function of an instance
+type ExportedFunctionCallback func(...interface{}) (Value, error)
+
+// ExportsMap holds the exported functions of an instance, by name
+type ExportsMap map[string]ExportedFunctionCallback
+
+// ExportSignaturesMap holds the signatures of the exported functions of an instance, by name
+type ExportSignaturesMap map[string]*ExportedFunctionSignature
+
+// CompilationOptions holds the options for compiling and instantiating a WASM module
+type CompilationOptions struct {
+	GasLimit           uint64
+	UnmeteredLocals    uint64
+	MaxMemoryGrow      uint64
+	MaxMemoryGrowDelta uint64
+	OpcodeTrace        bool
+	Metering           bool
+	RuntimeBreakpoints bool
+}
@@ -0,0 +1,58 @@
+package executor
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// InstanceContext is the context of an instance which is not executed by
+// Wasmer. Its pointer is passed as the first argument of the imported
+// functions, in place of the Wasmer instance context.
+type InstanceContext struct {
+	memory MemoryHandler
+	data   unsafe.Pointer
+}
+
+// instanceContexts holds the live contexts created by NewInstanceContext,
+// allowing imported functions to tell them apart from Wasmer instance contexts
+var instanceContexts sync.Map
+
+// NewInstanceContext creates the context of an instance which is not executed
+// by Wasmer and returns the pointer to be passed to the imported functions; it
+// must be released with ReleaseInstanceContext when the instance is cleaned
+func NewInstanceContext(memory MemoryHandler, data unsafe.Pointer) unsafe.Pointer {
+	instanceContext := &InstanceContext{
+		memory: memory,
+		data:   data,
+	}
+
+	pointer := unsafe.Pointer(instanceContext)
+	instanceContexts.Store(pointer, instanceContext)
+	return pointer
+}
+
+// ReleaseInstanceContext forgets an instance context created with NewInstanceContext
+func ReleaseInstanceContext(pointer unsafe.Pointer) {
+	instanceContexts.Delete(pointer)
+}
+
+// LookupInstanceContext returns the instance context created with
+// NewInstanceContext for the given pointer, if any
+func LookupInstanceContext(pointer unsafe.Pointer) (*InstanceContext, bool) {
+	instanceContext, ok := instanceContexts.Load(pointer)
+	if !ok {
+		return nil, false
+	}
+
+	return instanceContext.(*InstanceContext), true
+}
+
+// Memory returns the memory of the instance
+func (instanceContext *InstanceContext) Memory() MemoryHandler {
+	return instanceContext.memory
+}
+
+// Data returns the data set on the instance with SetContextData
+func (instanceContext *InstanceContext) Data() unsafe.Pointer {
+	return instanceContext.data
+}
@@ -0,0 +1,49 @@
+package executor
+
+// InstanceHandler defines the functionality of a WASM instance, regardless of
+// the backend which executes it
+type InstanceHandler interface {
+	HasMemory() bool
+	SetContextData(data uintptr)
+	GetPointsUsed() uint64
+	SetPointsUsed(points uint64)
+	SetGasLimit(gasLimit uint64)
+	SetBreakpointValue(value uint64)
+	GetBreakpointValue() uint64
+	Cache() ([]byte, error)
+	Clean()
+	GetExports() ExportsMap
+	GetSignature(functionName string) (*ExportedFunctionSignature, bool)
+	GetData() uintptr
+	GetInstanceCtxMemory() MemoryHandler
+	GetMemory() MemoryHandler
+	IsFunctionImported(name string) bool
+}
+
+// MemoryHandler defines the functionality of the memory of a WASM instance
+type MemoryHandler interface {
+	Length() uint32
+	Data() []byte
+	Grow(pages uint32) error
+	Destroy()
+}
+
+// FunctionCallObserver is notified whenever an instance enters or leaves one
+// of its functions, together with the points used at that moment
+type FunctionCallObserver interface {
+	OnFunctionEnter(functionName string, imported bool, pointsUsed uint64)
+	OnFunctionExit(functionName string, imported bool, pointsUsed uint64)
+}
+
+// FunctionCallObservable defines the instances which can notify a
+// FunctionCallObserver; the Wasmer instances do not support it
+type FunctionCallObservable interface {
+	SetFunctionCallObserver(observer FunctionCallObserver)
+}
+
+// FunctionImports provides the Go implementations of the functions which can
+// be imported by the WASM modules. Each implementation receives the instance
+// context pointer as its first argument.
+type FunctionImports interface {
+	Implementation(namespace string, importName string) (interface{}, bool)
+}
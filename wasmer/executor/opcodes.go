@@ -1,4 +1,7 @@
-package wasmer
+package executor
+
+// OPCODE_COUNT is the number of opcodes which can be assigned a cost
+const OPCODE_COUNT = 448
 
 const (
 	OpcodeUnreachable = iota
@@ -0,0 +1,678 @@
+package gowasm
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/executor"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/wasmparser"
+)
+
+// branchTarget is a resolved branch: execution continues at pc, after the
+// top arity values are moved at the given height of the operand stack
+type branchTarget struct {
+	pc     int
+	height int
+	arity  int
+}
+
+// instruction is a decoded WASM instruction, with its cost and its branch
+// targets already resolved
+type instruction struct {
+	opcode    uint16
+	cost      uint64
+	immediate uint64
+	branch    branchTarget
+	table     []branchTarget
+}
+
+// compiledFunction is a function defined by the module, ready to be interpreted
+type compiledFunction struct {
+	fType          *functionType
+	numLocals      int
+	maxStackHeight int
+	code           []instruction
+}
+
+type controlFrame struct {
+	opcode      uint16
+	startIndex  int
+	height      int
+	params      int
+	results     int
+	unreachable bool
+	fixups      []branchFixup
+}
+
+type branchFixup struct {
+	instructionIndex int
+	tableIndex       int
+}
+
+// functionCompiler decodes the body of a function and checks that the operand
+// stack cannot underflow and that all indices are in range; the types of the
+// operands are not checked
+type functionCompiler struct {
+	module    *module
+	costs     *[executor.OPCODE_COUNT]uint32
+	fType     *functionType
+	numLocals int
+	reader    *wasmparser.Reader
+	code      []instruction
+	frames    []*controlFrame
+	height    int
+	maxHeight int
+}
+
+func compileFunction(m *module, definedIndex int, costs *[executor.OPCODE_COUNT]uint32) (*compiledFunction, error) {
+	fType := &m.types[m.functions[definedIndex]]
+	body := m.bodies[definedIndex]
+
+	compiler := &functionCompiler{
+		module:    m,
+		costs:     costs,
+		fType:     fType,
+		numLocals: len(fType.params) + len(body.locals),
+		reader:    wasmparser.NewReader(body.code),
+		code:      make([]instruction, 0, len(body.code)),
+	}
+	compiler.frames = []*controlFrame{{results: len(fType.results)}}
+
+	err := compiler.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	return &compiledFunction{
+		fType:          fType,
+		numLocals:      len(body.locals),
+		maxStackHeight: compiler.maxHeight,
+		code:           compiler.code,
+	}, nil
+}
+
+func (compiler *functionCompiler) compile() error {
+	for !compiler.reader.IsAtEnd() {
+		if len(compiler.frames) == 0 {
+			return ErrInvalidModule
+		}
+
+		opcode, err := compiler.readOpcode()
+		if err != nil {
+			return err
+		}
+
+		if isFloatingPointOpcode(opcode) {
+			return ErrFloatingPointInstruction
+		}
+
+		wasmerIndex, ok := wasmerOpcode(opcode)
+		if !ok {
+			return ErrUnsupportedFeature
+		}
+
+		compiler.code = append(compiler.code, instruction{
+			opcode: opcode,
+			cost:   uint64(compiler.costs[wasmerIndex]),
+		})
+
+		err = compiler.compileInstruction(opcode)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(compiler.frames) != 0 {
+		return ErrInvalidModule
+	}
+
+	return nil
+}
+
+func (compiler *functionCompiler) readOpcode() (uint16, error) {
+	opcode, err := compiler.reader.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if opcode != miscPrefix {
+		return uint16(opcode), nil
+	}
+
+	subOpcode, err := compiler.reader.ReadU32()
+	if err != nil {
+		return 0, err
+	}
+	if subOpcode > 0xFF {
+		return 0, ErrUnsupportedFeature
+	}
+
+	return opMisc | uint16(subOpcode), nil
+}
+
+func (compiler *functionCompiler) current() *instruction {
+	return &compiler.code[len(compiler.code)-1]
+}
+
+func (compiler *functionCompiler) currentIndex() int {
+	return len(compiler.code) - 1
+}
+
+func (compiler *functionCompiler) topFrame() *controlFrame {
+	return compiler.frames[len(compiler.frames)-1]
+}
+
+func (compiler *functionCompiler) pop(count int) error {
+	frame := compiler.topFrame()
+	if compiler.height-count < frame.height {
+		if !frame.unreachable {
+			return ErrInvalidModule
+		}
+		compiler.height = frame.height
+		return nil
+	}
+
+	compiler.height -= count
+	return nil
+}
+
+func (compiler *functionCompiler) push(count int) {
+	compiler.height += count
+	if compiler.height > compiler.maxHeight {
+		compiler.maxHeight = compiler.height
+	}
+}
+
+func (compiler *functionCompiler) setUnreachable() {
+	frame := compiler.topFrame()
+	frame.unreachable = true
+	compiler.height = frame.height
+}
+
+func (compiler *functionCompiler) compileInstruction(opcode uint16) error {
+	switch opcode {
+	case opUnreachable:
+		compiler.setUnreachable()
+		return nil
+	case opNop:
+		return nil
+	case opBlock, opLoop, opIf:
+		return compiler.compileBlock(opcode)
+	case opElse:
+		return compiler.compileElse()
+	case opEnd:
+		return compiler.compileEnd()
+	case opBr:
+		return compiler.compileBranch()
+	case opBrIf:
+		return compiler.compileConditionalBranch()
+	case opBrTable:
+		return compiler.compileBranchTable()
+	case opReturn:
+		return compiler.compileReturn()
+	case opCall:
+		return compiler.compileCall()
+	case opCallIndirect:
+		return compiler.compileCallIndirect()
+	case opDrop:
+		return compiler.pop(1)
+	case opSelect:
+		return compiler.compileSelect()
+	case opTypedSelect:
+		types, err := compiler.reader.ReadValueTypes()
+		if err != nil {
+			return err
+		}
+		if len(types) != 1 {
+			return ErrInvalidModule
+		}
+		if !isSupportedValueType(types[0]) {
+			return ErrUnsupportedFeature
+		}
+		return compiler.compileSelect()
+	case opLocalGet, opLocalSet, opLocalTee:
+		return compiler.compileLocal(opcode)
+	case opGlobalGet, opGlobalSet:
+		return compiler.compileGlobal(opcode)
+	case opMemorySize, opMemoryGrow:
+		return compiler.compileMemorySizeOrGrow(opcode)
+	case opI32Const:
+		value, err := compiler.reader.ReadS32()
+		compiler.current().immediate = uint64(uint32(value))
+		compiler.push(1)
+		return err
+	case opI64Const:
+		value, err := compiler.reader.ReadS64()
+		compiler.current().immediate = uint64(value)
+		compiler.push(1)
+		return err
+	case opF32Const:
+		value, err := compiler.reader.ReadFixedU32()
+		compiler.current().immediate = uint64(value)
+		compiler.push(1)
+		return err
+	case opF64Const:
+		value, err := compiler.reader.ReadFixedU64()
+		compiler.current().immediate = value
+		compiler.push(1)
+		return err
+	case opMemoryInit, opDataDrop, opMemoryCopy, opMemoryFill:
+		return compiler.compileBulkMemory(opcode)
+	}
+
+	if opcode >= opI32Load && opcode <= opI64Store32 {
+		return compiler.compileMemoryAccess(opcode)
+	}
+
+	pops, pushes, ok := numericStackEffect(opcode)
+	if !ok {
+		return ErrUnsupportedFeature
+	}
+
+	err := compiler.pop(pops)
+	compiler.push(pushes)
+	return err
+}
+
+func (compiler *functionCompiler) readBlockType() (int, int, error) {
+	reader := compiler.reader
+	b, err := reader.PeekByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	if b == blockTypeEmpty {
+		_, err = reader.ReadByte()
+		return 0, 0, err
+	}
+	if isSupportedValueType(valueType(b)) {
+		_, err = reader.ReadByte()
+		return 0, 1, err
+	}
+
+	typeIndex, err := reader.ReadSignedLEB(33)
+	if err != nil {
+		return 0, 0, err
+	}
+	if typeIndex < 0 || typeIndex >= int64(len(compiler.module.types)) {
+		return 0, 0, ErrInvalidModule
+	}
+
+	blockType := &compiler.module.types[typeIndex]
+	return len(blockType.params), len(blockType.results), nil
+}
+
+func (compiler *functionCompiler) compileBlock(opcode uint16) error {
+	params, results, err := compiler.readBlockType()
+	if err != nil {
+		return err
+	}
+
+	if opcode == opIf {
+		err = compiler.pop(1)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = compiler.pop(params)
+	if err != nil {
+		return err
+	}
+
+	frame := &controlFrame{
+		opcode:     opcode,
+		startIndex: compiler.currentIndex(),
+		height:     compiler.height,
+		params:     params,
+		results:    results,
+	}
+	compiler.push(params)
+	compiler.frames = append(compiler.frames, frame)
+
+	if opcode == opIf {
+		frame.fixups = append(frame.fixups, branchFixup{instructionIndex: frame.startIndex, tableIndex: -1})
+	}
+
+	return nil
+}
+
+func (compiler *functionCompiler) compileElse() error {
+	frame := compiler.topFrame()
+	if frame.opcode != opIf {
+		return ErrInvalidModule
+	}
+
+	err := compiler.checkFrameResults(frame)
+	if err != nil {
+		return err
+	}
+
+	// when the condition is false, execution continues after the else
+	ifInstruction := &compiler.code[frame.startIndex]
+	ifInstruction.branch.pc = compiler.currentIndex() + 1
+	frame.fixups = frame.fixups[1:]
+
+	// at the end of the then branch, execution continues after the end
+	frame.opcode = opElse
+	frame.fixups = append(frame.fixups, branchFixup{instructionIndex: compiler.currentIndex(), tableIndex: -1})
+	frame.unreachable = false
+	compiler.height = frame.height
+	compiler.push(frame.params)
+
+	return nil
+}
+
+func (compiler *functionCompiler) compileEnd() error {
+	frame := compiler.topFrame()
+	err := compiler.checkFrameResults(frame)
+	if err != nil {
+		return err
+	}
+
+	if frame.opcode == opIf && frame.params != frame.results {
+		return ErrInvalidModule
+	}
+
+	afterEnd := compiler.currentIndex() + 1
+	for _, fixup := range frame.fixups {
+		target := &compiler.code[fixup.instructionIndex].branch
+		if fixup.tableIndex >= 0 {
+			target = &compiler.code[fixup.instructionIndex].table[fixup.tableIndex]
+		}
+		target.pc = afterEnd
+	}
+
+	compiler.frames = compiler.frames[:len(compiler.frames)-1]
+	compiler.height = frame.height
+	compiler.push(frame.results)
+
+	if len(compiler.frames) == 0 && !compiler.reader.IsAtEnd() {
+		return ErrInvalidModule
+	}
+
+	return nil
+}
+
+func (compiler *functionCompiler) checkFrameResults(frame *controlFrame) error {
+	if frame.unreachable {
+		if compiler.height > frame.height+frame.results {
+			return ErrInvalidModule
+		}
+		return nil
+	}
+
+	if compiler.height != frame.height+frame.results {
+		return ErrInvalidModule
+	}
+
+	return nil
+}
+
+// resolveBranch computes the target of a branch to the given label; branches
+// to blocks are completed when the end of the block is reached
+func (compiler *functionCompiler) resolveBranch(depth uint32, tableIndex int) (branchTarget, error) {
+	if depth >= uint32(len(compiler.frames)) {
+		return branchTarget{}, ErrInvalidModule
+	}
+
+	frameIndex := len(compiler.frames) - 1 - int(depth)
+	frame := compiler.frames[frameIndex]
+
+	target := branchTarget{height: frame.height}
+	switch {
+	case frameIndex == 0:
+		target.arity = frame.results
+		target.pc = -1
+	case frame.opcode == opLoop:
+		target.arity = frame.params
+		target.pc = frame.startIndex + 1
+	default:
+		target.arity = frame.results
+		frame.fixups = append(frame.fixups, branchFixup{instructionIndex: compiler.currentIndex(), tableIndex: tableIndex})
+	}
+
+	topFrame := compiler.topFrame()
+	if !topFrame.unreachable && compiler.height-target.arity < topFrame.height {
+		return branchTarget{}, ErrInvalidModule
+	}
+
+	return target, nil
+}
+
+func (compiler *functionCompiler) compileBranch() error {
+	depth, err := compiler.reader.ReadU32()
+	if err != nil {
+		return err
+	}
+
+	compiler.current().branch, err = compiler.resolveBranch(depth, -1)
+	if err != nil {
+		return err
+	}
+
+	compiler.setUnreachable()
+	return nil
+}
+
+func (compiler *functionCompiler) compileConditionalBranch() error {
+	depth, err := compiler.reader.ReadU32()
+	if err != nil {
+		return err
+	}
+
+	err = compiler.pop(1)
+	if err != nil {
+		return err
+	}
+
+	compiler.current().branch, err = compiler.resolveBranch(depth, -1)
+	return err
+}
+
+func (compiler *functionCompiler) compileBranchTable() error {
+	count, err := compiler.reader.ReadU32()
+	if err != nil {
+		return err
+	}
+	if count > uint32(compiler.reader.Len()) {
+		return ErrInvalidModule
+	}
+
+	err = compiler.pop(1)
+	if err != nil {
+		return err
+	}
+
+	compiler.current().table = make([]branchTarget, count+1)
+	for i := 0; i <= int(count); i++ {
+		depth, err := compiler.reader.ReadU32()
+		if err != nil {
+			return err
+		}
+
+		target, err := compiler.resolveBranch(depth, i)
+		if err != nil {
+			return err
+		}
+		compiler.current().table[i] = target
+	}
+
+	compiler.setUnreachable()
+	return nil
+}
+
+func (compiler *functionCompiler) compileReturn() error {
+	var err error
+	compiler.current().branch, err = compiler.resolveBranch(uint32(len(compiler.frames)-1), -1)
+	if err != nil {
+		return err
+	}
+
+	compiler.setUnreachable()
+	return nil
+}
+
+func (compiler *functionCompiler) compileCall() error {
+	functionIndex, err := compiler.reader.ReadU32()
+	if err != nil {
+		return err
+	}
+	if functionIndex >= compiler.module.numFunctions() {
+		return ErrInvalidModule
+	}
+
+	compiler.current().immediate = uint64(functionIndex)
+	calledType := compiler.module.functionType(functionIndex)
+
+	err = compiler.pop(len(calledType.params))
+	compiler.push(len(calledType.results))
+	return err
+}
+
+func (compiler *functionCompiler) compileCallIndirect() error {
+	typeIndex, err := compiler.reader.ReadU32()
+	if err != nil {
+		return err
+	}
+	tableIndex, err := compiler.reader.ReadU32()
+	if err != nil {
+		return err
+	}
+	if typeIndex >= uint32(len(compiler.module.types)) || tableIndex != 0 || !compiler.module.hasTable {
+		return ErrInvalidModule
+	}
+
+	compiler.current().immediate = uint64(typeIndex)
+	calledType := &compiler.module.types[typeIndex]
+
+	err = compiler.pop(len(calledType.params) + 1)
+	compiler.push(len(calledType.results))
+	return err
+}
+
+func (compiler *functionCompiler) compileSelect() error {
+	err := compiler.pop(3)
+	compiler.push(1)
+	return err
+}
+
+func (compiler *functionCompiler) compileLocal(opcode uint16) error {
+	localIndex, err := compiler.reader.ReadU32()
+	if err != nil {
+		return err
+	}
+	if localIndex >= uint32(compiler.numLocals) {
+		return ErrInvalidModule
+	}
+
+	compiler.current().immediate = uint64(localIndex)
+	switch opcode {
+	case opLocalGet:
+		compiler.push(1)
+	case opLocalSet:
+		err = compiler.pop(1)
+	case opLocalTee:
+		err = compiler.pop(1)
+		compiler.push(1)
+	}
+
+	return err
+}
+
+func (compiler *functionCompiler) compileGlobal(opcode uint16) error {
+	globalIndex, err := compiler.reader.ReadU32()
+	if err != nil {
+		return err
+	}
+	if globalIndex >= uint32(len(compiler.module.globals)) {
+		return ErrInvalidModule
+	}
+
+	compiler.current().immediate = uint64(globalIndex)
+	if opcode == opGlobalGet {
+		compiler.push(1)
+		return nil
+	}
+
+	if !compiler.module.globals[globalIndex].mutable {
+		return ErrInvalidModule
+	}
+	return compiler.pop(1)
+}
+
+func (compiler *functionCompiler) compileMemorySizeOrGrow(opcode uint16) error {
+	memoryIndex, err := compiler.reader.ReadByte()
+	if err != nil {
+		return err
+	}
+	if memoryIndex != 0 || !compiler.module.hasMemory {
+		return ErrInvalidModule
+	}
+
+	if opcode == opMemoryGrow {
+		err = compiler.pop(1)
+	}
+	compiler.push(1)
+	return err
+}
+
+func (compiler *functionCompiler) compileMemoryAccess(opcode uint16) error {
+	if !compiler.module.hasMemory {
+		return ErrInvalidModule
+	}
+
+	_, err := compiler.reader.ReadU32()
+	if err != nil {
+		return err
+	}
+	offset, err := compiler.reader.ReadU32()
+	if err != nil {
+		return err
+	}
+
+	compiler.current().immediate = uint64(offset)
+	if isStoreOpcode(opcode) {
+		return compiler.pop(2)
+	}
+
+	err = compiler.pop(1)
+	compiler.push(1)
+	return err
+}
+
+func (compiler *functionCompiler) compileBulkMemory(opcode uint16) error {
+	if opcode != opDataDrop && !compiler.module.hasMemory {
+		return ErrInvalidModule
+	}
+
+	switch opcode {
+	case opMemoryInit, opDataDrop:
+		dataIndex, err := compiler.reader.ReadU32()
+		if err != nil {
+			return err
+		}
+		if dataIndex >= uint32(len(compiler.module.data)) {
+			return ErrInvalidModule
+		}
+		compiler.current().immediate = uint64(dataIndex)
+	}
+
+	numMemoryIndices := 0
+	switch opcode {
+	case opMemoryInit, opMemoryFill:
+		numMemoryIndices = 1
+	case opMemoryCopy:
+		numMemoryIndices = 2
+	}
+
+	for i := 0; i < numMemoryIndices; i++ {
+		memoryIndex, err := compiler.reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if memoryIndex != 0 {
+			return ErrInvalidModule
+		}
+	}
+
+	if opcode == opDataDrop {
+		return nil
+	}
+	return compiler.pop(3)
+}
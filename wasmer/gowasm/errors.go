@@ -0,0 +1,65 @@
+package gowasm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/wasmparser"
+)
+
+// ErrInvalidModule signals that the WASM module is malformed
+var ErrInvalidModule = wasmparser.ErrInvalidModule
+
+// ErrUnsupportedFeature signals that the WASM module uses a feature which is not supported by the Go backend
+var ErrUnsupportedFeature = wasmparser.ErrUnsupportedFeature
+
+// ErrFloatingPointInstruction signals that a function of the WASM module uses a floating point instruction, which is rejected like in Wasmer
+var ErrFloatingPointInstruction = fmt.Errorf("%w (floating point instruction)", ErrUnsupportedFeature)
+
+// ErrTooManyLocals signals that a function of the WASM module declares more locals than allowed
+var ErrTooManyLocals = fmt.Errorf("%w (too many locals)", ErrUnsupportedFeature)
+
+// ErrUnknownImport signals that the WASM module imports a function which was not registered
+var ErrUnknownImport = errors.New("unknown imported function")
+
+// ErrInvalidImport signals that a registered imported function cannot be called by the Go backend
+var ErrInvalidImport = errors.New("invalid imported function")
+
+// ErrImportSignatureMismatch signals that an imported function does not have the signature expected by the WASM module
+var ErrImportSignatureMismatch = fmt.Errorf("%w (signature mismatch)", ErrInvalidImport)
+
+// ErrInvalidArguments signals that an exported function was called with invalid arguments
+var ErrInvalidArguments = errors.New("invalid arguments for exported function")
+
+// ErrTrap signals that the execution of the WASM code was aborted
+var ErrTrap = errors.New("WASM execution trapped")
+
+// ErrTrapUnreachable signals that an unreachable instruction was executed
+var ErrTrapUnreachable = fmt.Errorf("%w (unreachable)", ErrTrap)
+
+// ErrTrapMemoryOutOfBounds signals an access outside the memory of the instance
+var ErrTrapMemoryOutOfBounds = fmt.Errorf("%w (memory access out of bounds)", ErrTrap)
+
+// ErrTrapDivisionByZero signals an integer division by zero
+var ErrTrapDivisionByZero = fmt.Errorf("%w (integer division by zero)", ErrTrap)
+
+// ErrTrapIntegerOverflow signals an integer overflow during a division or a conversion
+var ErrTrapIntegerOverflow = fmt.Errorf("%w (integer overflow)", ErrTrap)
+
+// ErrTrapInvalidConversion signals the conversion of NaN to an integer
+var ErrTrapInvalidConversion = fmt.Errorf("%w (invalid conversion to integer)", ErrTrap)
+
+// ErrTrapIndirectCall signals an indirect call to a missing table element or with a wrong signature
+var ErrTrapIndirectCall = fmt.Errorf("%w (invalid indirect call)", ErrTrap)
+
+// ErrTrapMemoryGrowLimit signals a memory.grow instruction which exceeds the limits given in the compilation options
+var ErrTrapMemoryGrowLimit = fmt.Errorf("%w (memory.grow limit exceeded)", ErrTrap)
+
+// ErrTrapCallStackExhausted signals that the maximum call depth was reached
+var ErrTrapCallStackExhausted = fmt.Errorf("%w (call stack exhausted)", ErrTrap)
+
+// ErrTrapOutOfGas signals that the gas limit of the instance was exceeded
+var ErrTrapOutOfGas = fmt.Errorf("%w (out of gas)", ErrTrap)
+
+// ErrTrapBreakpoint signals that execution was stopped by a runtime breakpoint
+var ErrTrapBreakpoint = fmt.Errorf("%w (runtime breakpoint)", ErrTrap)
@@ -0,0 +1,427 @@
+package gowasm
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/executor"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/wasmparser"
+)
+
+// breakpointOutOfGas is the breakpoint value set by Wasmer when the gas limit
+// is exceeded, which is arwen.BreakpointOutOfGas
+const breakpointOutOfGas = 4
+
+var _ executor.InstanceHandler = (*Instance)(nil)
+var _ executor.FunctionCallObservable = (*Instance)(nil)
+
+// Instance is a WASM instance executed by the pure-Go interpreter, usable
+// wherever a Wasmer instance is expected
+type Instance struct {
+	code    []byte
+	module  *module
+	options executor.CompilationOptions
+
+	functions     []*compiledFunction
+	hostFunctions []*hostFunction
+	globals       []uint64
+	table         []int64
+	memory        *Memory
+	droppedData   []bool
+	exports       executor.ExportsMap
+	signatures    executor.ExportSignaturesMap
+
+	pointsUsed        uint64
+	gasLimit          uint64
+	breakpointValue   uint64
+	memoryGrowCount   uint64
+	localAllocateCost uint64
+
+	data           uintptr
+	contextPointer unsafe.Pointer
+
+	stack     []uint64
+	sp        int
+	callDepth int
+
+	observer          executor.FunctionCallObserver
+	observedFunctions []uint32
+}
+
+// NewInstanceWithOptions decodes, validates and instantiates a WASM module;
+// its imports are resolved among the given imported functions, and its
+// instructions are metered with the given opcode costs
+func NewInstanceWithOptions(
+	code []byte,
+	options executor.CompilationOptions,
+	imports executor.FunctionImports,
+	costs *[executor.OPCODE_COUNT]uint32,
+) (*Instance, error) {
+	if len(code) == 0 {
+		return nil, executor.ErrInvalidBytecode
+	}
+
+	m, err := decodeModule(code)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := &Instance{
+		code:              code,
+		module:            m,
+		options:           options,
+		gasLimit:          options.GasLimit,
+		localAllocateCost: uint64(costs[executor.OpcodeLocalAllocate]),
+		stack:             make([]uint64, 1024),
+	}
+
+	err = instance.compileFunctions(costs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = instance.resolveImports(imports)
+	if err != nil {
+		return nil, err
+	}
+
+	err = instance.initialize()
+	if err != nil {
+		return nil, err
+	}
+
+	instance.createExports()
+
+	var memory executor.MemoryHandler
+	if instance.memory != nil {
+		memory = instance.memory
+	}
+	instance.contextPointer = executor.NewInstanceContext(memory, unsafe.Pointer(&instance.data))
+
+	if m.hasStart {
+		_, err = instance.callExport(m.startFunction, nil)
+		if err != nil {
+			instance.Clean()
+			return nil, err
+		}
+	}
+
+	return instance, nil
+}
+
+// NewInstanceFromCompiledCodeWithOptions instantiates a module from the bytes
+// returned by Cache, which are the original WASM bytecode
+func NewInstanceFromCompiledCodeWithOptions(
+	compiledCode []byte,
+	options executor.CompilationOptions,
+	imports executor.FunctionImports,
+	costs *[executor.OPCODE_COUNT]uint32,
+) (*Instance, error) {
+	return NewInstanceWithOptions(compiledCode, options, imports, costs)
+}
+
+func (instance *Instance) compileFunctions(costs *[executor.OPCODE_COUNT]uint32) error {
+	instance.functions = make([]*compiledFunction, len(instance.module.functions))
+	for i := range instance.module.functions {
+		function, err := compileFunction(instance.module, i, costs)
+		if err != nil {
+			return err
+		}
+		instance.functions[i] = function
+	}
+
+	return nil
+}
+
+func (instance *Instance) resolveImports(registeredImports executor.FunctionImports) error {
+	for _, imported := range instance.module.imports {
+		if registeredImports == nil {
+			return fmt.Errorf("%w: %s.%s", ErrUnknownImport, imported.module, imported.name)
+		}
+
+		implementation, ok := registeredImports.Implementation(imported.module, imported.name)
+		if !ok {
+			return fmt.Errorf("%w: %s.%s", ErrUnknownImport, imported.module, imported.name)
+		}
+
+		fType := &instance.module.types[imported.typeIndex]
+		function := reflect.ValueOf(implementation)
+		err := checkHostFunctionSignature(function.Type(), fType)
+		if err != nil {
+			return fmt.Errorf("%w: %s.%s", err, imported.module, imported.name)
+		}
+
+		instance.hostFunctions = append(instance.hostFunctions, &hostFunction{
+			name:           imported.name,
+			implementation: function,
+			fType:          fType,
+		})
+	}
+
+	return nil
+}
+
+// checkHostFunctionSignature verifies that an imported function takes the
+// instance context followed by int32 or int64 values, as declared by the module
+func checkHostFunctionSignature(goType reflect.Type, fType *functionType) error {
+	if goType.Kind() != reflect.Func {
+		return ErrInvalidImport
+	}
+	if goType.NumIn() != len(fType.params)+1 || goType.In(0) != contextPointerType {
+		return ErrImportSignatureMismatch
+	}
+	if goType.NumOut() != len(fType.results) {
+		return ErrImportSignatureMismatch
+	}
+
+	for i, paramType := range fType.params {
+		if !matchesValueType(goType.In(i+1).Kind(), paramType) {
+			return ErrImportSignatureMismatch
+		}
+	}
+	for i, resultType := range fType.results {
+		if !matchesValueType(goType.Out(i).Kind(), resultType) {
+			return ErrImportSignatureMismatch
+		}
+	}
+
+	return nil
+}
+
+func matchesValueType(kind reflect.Kind, vType valueType) bool {
+	switch vType {
+	case valueTypeI32:
+		return kind == reflect.Int32
+	case valueTypeI64:
+		return kind == reflect.Int64
+	default:
+		return false
+	}
+}
+
+// initialize sets up the globals, the table and the memory of the instance
+func (instance *Instance) initialize() error {
+	m := instance.module
+
+	instance.globals = make([]uint64, len(m.globals))
+	for i, global := range m.globals {
+		instance.globals[i] = global.value
+	}
+
+	instance.table = make([]int64, m.tableSize)
+	for i := range instance.table {
+		instance.table[i] = -1
+	}
+	for _, segment := range m.elements {
+		if uint64(segment.offset)+uint64(len(segment.functions)) > uint64(len(instance.table)) {
+			return fmt.Errorf("%w (element segment out of bounds)", ErrInvalidModule)
+		}
+		for i, functionIndex := range segment.functions {
+			instance.table[segment.offset+uint32(i)] = int64(functionIndex)
+		}
+	}
+
+	instance.droppedData = make([]bool, len(m.data))
+	if !m.hasMemory {
+		if len(m.data) > 0 {
+			return fmt.Errorf("%w (data segments without memory)", ErrInvalidModule)
+		}
+		return nil
+	}
+
+	instance.memory = newMemory(m.memoryMinPages, m.memoryMaxPages)
+	for i, segment := range m.data {
+		if segment.passive {
+			continue
+		}
+		if uint64(segment.offset)+uint64(len(segment.data)) > uint64(len(instance.memory.data)) {
+			return fmt.Errorf("%w (data segment out of bounds)", ErrInvalidModule)
+		}
+		copy(instance.memory.data[segment.offset:], segment.data)
+		instance.droppedData[i] = true
+	}
+
+	return nil
+}
+
+func (instance *Instance) createExports() {
+	instance.exports = make(executor.ExportsMap)
+	instance.signatures = make(executor.ExportSignaturesMap)
+
+	for name, exported := range instance.module.exports {
+		if exported.kind != wasmparser.ExternalFunction {
+			continue
+		}
+
+		fType := instance.module.functionType(exported.index)
+		instance.exports[name] = instance.exportedFunction(name, exported.index, fType)
+		instance.signatures[name] = &executor.ExportedFunctionSignature{
+			InputArity:  len(fType.params),
+			OutputArity: len(fType.results),
+		}
+	}
+}
+
+func (instance *Instance) exportedFunction(name string, functionIndex uint32, fType *functionType) executor.ExportedFunctionCallback {
+	return func(arguments ...interface{}) (executor.Value, error) {
+		if len(arguments) != len(fType.params) {
+			return executor.Void(), fmt.Errorf("%w: %s expects %d arguments", ErrInvalidArguments, name, len(fType.params))
+		}
+
+		values := make([]uint64, len(arguments))
+		for i, argument := range arguments {
+			value, err := convertArgument(argument, fType.params[i])
+			if err != nil {
+				return executor.Void(), fmt.Errorf("%w: argument #%d of %s", err, i+1, name)
+			}
+			values[i] = value
+		}
+
+		results, err := instance.callExport(functionIndex, values)
+		if err != nil {
+			return executor.Void(), err
+		}
+		if len(results) == 0 {
+			return executor.Void(), nil
+		}
+
+		switch fType.results[0] {
+		case valueTypeI32:
+			return executor.I32(int32(results[0])), nil
+		case valueTypeI64:
+			return executor.I64(int64(results[0])), nil
+		default:
+			return executor.Void(), fmt.Errorf("%w (floating point result of %s)", ErrUnsupportedFeature, name)
+		}
+	}
+}
+
+func convertArgument(argument interface{}, vType valueType) (uint64, error) {
+	var value int64
+	switch typedValue := argument.(type) {
+	case int:
+		value = int64(typedValue)
+	case int32:
+		value = int64(typedValue)
+	case int64:
+		value = typedValue
+	case uint32:
+		value = int64(typedValue)
+	case uint64:
+		value = int64(typedValue)
+	case executor.Value:
+		if typedValue.GetType() == executor.TypeI32 {
+			value = int64(typedValue.ToI32())
+		} else {
+			value = typedValue.ToI64()
+		}
+	default:
+		return 0, ErrInvalidArguments
+	}
+
+	switch vType {
+	case valueTypeI32:
+		return uint64(uint32(value)), nil
+	case valueTypeI64:
+		return uint64(value), nil
+	default:
+		return 0, ErrUnsupportedFeature
+	}
+}
+
+// HasMemory returns true if the module exports its memory
+func (instance *Instance) HasMemory() bool {
+	return instance.memory != nil && instance.module.hasMemoryExport
+}
+
+// SetContextData sets the value returned by the Data() of the instance context
+func (instance *Instance) SetContextData(data uintptr) {
+	instance.data = data
+}
+
+// GetPointsUsed returns the gas used so far
+func (instance *Instance) GetPointsUsed() uint64 {
+	return instance.pointsUsed
+}
+
+// SetPointsUsed sets the gas used so far
+func (instance *Instance) SetPointsUsed(points uint64) {
+	instance.pointsUsed = points
+}
+
+// SetGasLimit sets the gas limit of the instance
+func (instance *Instance) SetGasLimit(gasLimit uint64) {
+	instance.gasLimit = gasLimit
+}
+
+// SetBreakpointValue sets the runtime breakpoint value
+func (instance *Instance) SetBreakpointValue(value uint64) {
+	instance.breakpointValue = value
+}
+
+// GetBreakpointValue returns the runtime breakpoint value
+func (instance *Instance) GetBreakpointValue() uint64 {
+	return instance.breakpointValue
+}
+
+// Cache returns the bytecode of the instance; the Go backend has no compiled form
+func (instance *Instance) Cache() ([]byte, error) {
+	return instance.code, nil
+}
+
+// Clean releases the instance context and the memory
+func (instance *Instance) Clean() {
+	if instance.contextPointer != nil {
+		executor.ReleaseInstanceContext(instance.contextPointer)
+		instance.contextPointer = nil
+	}
+	if instance.memory != nil {
+		instance.memory.Destroy()
+	}
+}
+
+// GetExports returns the exported functions
+func (instance *Instance) GetExports() executor.ExportsMap {
+	return instance.exports
+}
+
+// GetSignature returns the signature of an exported function
+func (instance *Instance) GetSignature(functionName string) (*executor.ExportedFunctionSignature, bool) {
+	signature, ok := instance.signatures[functionName]
+	return signature, ok
+}
+
+// GetData returns the value set with SetContextData
+func (instance *Instance) GetData() uintptr {
+	return instance.data
+}
+
+// GetInstanceCtxMemory returns the memory of the instance
+func (instance *Instance) GetInstanceCtxMemory() executor.MemoryHandler {
+	return instance.GetMemory()
+}
+
+// GetMemory returns the memory of the instance
+func (instance *Instance) GetMemory() executor.MemoryHandler {
+	if instance.memory == nil {
+		return nil
+	}
+	return instance.memory
+}
+
+// SetFunctionCallObserver sets the observer notified on each function call;
+// a nil observer disables the notifications
+func (instance *Instance) SetFunctionCallObserver(observer executor.FunctionCallObserver) {
+	instance.observer = observer
+}
+
+// IsFunctionImported returns true if the module imports the given function
+func (instance *Instance) IsFunctionImported(name string) bool {
+	for _, imported := range instance.module.imports {
+		if imported.name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,480 @@
+package gowasm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/executor"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/wasmparser"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	i32     = byte(valueTypeI32)
+	i64     = byte(valueTypeI64)
+	f64Type = byte(valueTypeF64)
+)
+
+const (
+	opI32Store = 0x36
+	opI32Add   = 0x6A
+	opI32Sub   = 0x6B
+	opI64DivS  = 0x7F
+	opF64Add   = 0xA0
+)
+
+type testFunction struct {
+	params  []byte
+	results []byte
+	locals  []byte
+	code    []byte
+	export  string
+}
+
+type testModule struct {
+	imports     []testFunction
+	functions   []testFunction
+	memoryPages uint32
+	data        []byte
+}
+
+func uleb(value uint64) []byte {
+	var result []byte
+	for {
+		b := byte(value & 0x7F)
+		value >>= 7
+		if value != 0 {
+			result = append(result, b|0x80)
+			continue
+		}
+		return append(result, b)
+	}
+}
+
+func sleb(value int64) []byte {
+	var result []byte
+	for {
+		b := byte(value & 0x7F)
+		value >>= 7
+		if (value == 0 && b&0x40 == 0) || (value == -1 && b&0x40 != 0) {
+			return append(result, b)
+		}
+		result = append(result, b|0x80)
+	}
+}
+
+func vector(items ...[]byte) []byte {
+	result := uleb(uint64(len(items)))
+	for _, item := range items {
+		result = append(result, item...)
+	}
+	return result
+}
+
+func name(value string) []byte {
+	return append(uleb(uint64(len(value))), value...)
+}
+
+func appendSection(code []byte, sectionID byte, contents []byte) []byte {
+	code = append(code, sectionID)
+	code = append(code, uleb(uint64(len(contents)))...)
+	return append(code, contents...)
+}
+
+func (tm *testModule) bytecode() []byte {
+	code := []byte{0x00, 0x61, 0x73, 0x6D, 0x01, 0x00, 0x00, 0x00}
+
+	allFunctions := append(append([]testFunction{}, tm.imports...), tm.functions...)
+	var types, imports, functions, exports, bodies [][]byte
+	for i, function := range allFunctions {
+		fType := append([]byte{0x60}, vector(splitBytes(function.params)...)...)
+		fType = append(fType, vector(splitBytes(function.results)...)...)
+		types = append(types, fType)
+
+		if i < len(tm.imports) {
+			imported := append(name("env"), name(function.export)...)
+			imported = append(imported, wasmparser.ExternalFunction)
+			imports = append(imports, append(imported, uleb(uint64(i))...))
+			continue
+		}
+
+		functions = append(functions, uleb(uint64(i)))
+		if function.export != "" {
+			exported := append(name(function.export), wasmparser.ExternalFunction)
+			exports = append(exports, append(exported, uleb(uint64(i))...))
+		}
+
+		var locals [][]byte
+		for _, local := range function.locals {
+			locals = append(locals, []byte{1, local})
+		}
+		body := append(vector(locals...), function.code...)
+		body = append(body, opEnd)
+		bodies = append(bodies, append(uleb(uint64(len(body))), body...))
+	}
+
+	if tm.memoryPages > 0 {
+		exports = append(exports, append(name("memory"), wasmparser.ExternalMemory, 0))
+	}
+
+	code = appendSection(code, wasmparser.SectionType, vector(types...))
+	code = appendSection(code, wasmparser.SectionImport, vector(imports...))
+	code = appendSection(code, wasmparser.SectionFunction, vector(functions...))
+	if tm.memoryPages > 0 {
+		code = appendSection(code, wasmparser.SectionMemory, vector(append([]byte{0}, uleb(uint64(tm.memoryPages))...)))
+	}
+	code = appendSection(code, wasmparser.SectionExport, vector(exports...))
+	code = appendSection(code, wasmparser.SectionCode, vector(bodies...))
+	if len(tm.data) > 0 {
+		segment := append([]byte{0, opI32Const, 0, opEnd}, uleb(uint64(len(tm.data)))...)
+		code = appendSection(code, wasmparser.SectionData, vector(append(segment, tm.data...)))
+	}
+
+	return code
+}
+
+func splitBytes(values []byte) [][]byte {
+	result := make([][]byte, len(values))
+	for i, value := range values {
+		result[i] = []byte{value}
+	}
+	return result
+}
+
+func i32Const(value int32) []byte {
+	return append([]byte{opI32Const}, sleb(int64(value))...)
+}
+
+// testImports holds the implementations of the imported functions, by
+// namespace and by name
+type testImports map[string]map[string]interface{}
+
+func (imports testImports) Implementation(namespace string, importName string) (interface{}, bool) {
+	implementation, ok := imports[namespace][importName]
+	return implementation, ok
+}
+
+func uniformCosts(cost uint32) *[executor.OPCODE_COUNT]uint32 {
+	costs := &[executor.OPCODE_COUNT]uint32{}
+	for i := range costs {
+		costs[i] = cost
+	}
+	return costs
+}
+
+func instantiate(t *testing.T, tm *testModule, options executor.CompilationOptions, imports executor.FunctionImports) *Instance {
+	instance, err := NewInstanceWithOptions(tm.bytecode(), options, imports, uniformCosts(1))
+	require.Nil(t, err)
+	t.Cleanup(instance.Clean)
+	return instance
+}
+
+func callExport(t *testing.T, instance *Instance, name string, arguments ...interface{}) (executor.Value, error) {
+	function, ok := instance.GetExports()[name]
+	require.True(t, ok)
+	return function(arguments...)
+}
+
+func TestInstance_InvalidModule(t *testing.T) {
+	_, err := NewInstanceWithOptions(nil, executor.CompilationOptions{}, nil, uniformCosts(1))
+	require.Equal(t, executor.ErrInvalidBytecode, err)
+
+	_, err = NewInstanceWithOptions([]byte{0x00, 0x61, 0x73, 0x6D, 0x02}, executor.CompilationOptions{}, nil, uniformCosts(1))
+	require.ErrorIs(t, err, ErrInvalidModule)
+
+	tm := &testModule{functions: []testFunction{{
+		results: []byte{i32},
+		code:    []byte{opI32Add},
+		export:  "underflow",
+	}}}
+	_, err = NewInstanceWithOptions(tm.bytecode(), executor.CompilationOptions{}, nil, uniformCosts(1))
+	require.ErrorIs(t, err, ErrInvalidModule)
+}
+
+func TestInstance_CompilationLimits(t *testing.T) {
+	tm := &testModule{functions: []testFunction{{
+		locals: bytes.Repeat([]byte{i32}, maxFunctionLocals),
+		export: "locals",
+	}}}
+	_, err := NewInstanceWithOptions(tm.bytecode(), executor.CompilationOptions{}, nil, uniformCosts(1))
+	require.Nil(t, err)
+
+	tm.functions[0].locals = append(tm.functions[0].locals, i32)
+	_, err = NewInstanceWithOptions(tm.bytecode(), executor.CompilationOptions{}, nil, uniformCosts(1))
+	require.ErrorIs(t, err, ErrTooManyLocals)
+
+	tm = &testModule{functions: []testFunction{{
+		params:  []byte{f64Type, f64Type},
+		results: []byte{f64Type},
+		code:    []byte{opLocalGet, 0, opLocalGet, 1, opF64Add},
+		export:  "add",
+	}}}
+	_, err = NewInstanceWithOptions(tm.bytecode(), executor.CompilationOptions{}, nil, uniformCosts(1))
+	require.ErrorIs(t, err, ErrFloatingPointInstruction)
+}
+
+func TestInstance_Arithmetic(t *testing.T) {
+	tm := &testModule{functions: []testFunction{
+		{
+			params:  []byte{i32, i32},
+			results: []byte{i32},
+			code:    []byte{opLocalGet, 0, opLocalGet, 1, opI32Add},
+			export:  "add",
+		},
+		{
+			params:  []byte{i64, i64},
+			results: []byte{i64},
+			code:    []byte{opLocalGet, 0, opLocalGet, 1, opI64DivS},
+			export:  "div",
+		},
+	}}
+	instance := instantiate(t, tm, executor.CompilationOptions{}, nil)
+
+	signature, ok := instance.GetSignature("add")
+	require.True(t, ok)
+	require.Equal(t, &executor.ExportedFunctionSignature{InputArity: 2, OutputArity: 1}, signature)
+
+	result, err := callExport(t, instance, "add", 2, int32(-7))
+	require.Nil(t, err)
+	require.Equal(t, int32(-5), result.ToI32())
+
+	result, err = callExport(t, instance, "div", int64(-21), int64(4))
+	require.Nil(t, err)
+	require.Equal(t, int64(-5), result.ToI64())
+
+	_, err = callExport(t, instance, "div", int64(1), int64(0))
+	require.ErrorIs(t, err, ErrTrapDivisionByZero)
+
+	_, err = callExport(t, instance, "add", 1)
+	require.ErrorIs(t, err, ErrInvalidArguments)
+}
+
+func TestInstance_LoopsAndCalls(t *testing.T) {
+	// sum(n) adds n, n-1, ..., 1 in a loop; triple(n) calls sum three times
+	sumCode := []byte{
+		opBlock, blockTypeEmpty,
+		opLoop, blockTypeEmpty,
+		opLocalGet, 0, opI32Eqz, opBrIf, 1,
+		opLocalGet, 1, opLocalGet, 0, opI32Add, opLocalSet, 1,
+		opLocalGet, 0,
+	}
+	sumCode = append(sumCode, i32Const(1)...)
+	sumCode = append(sumCode, opI32Sub, opLocalSet, 0, opBr, 0, opEnd, opEnd, opLocalGet, 1)
+
+	tm := &testModule{functions: []testFunction{
+		{
+			params:  []byte{i32},
+			results: []byte{i32},
+			locals:  []byte{i32},
+			code:    sumCode,
+			export:  "sum",
+		},
+		{
+			params:  []byte{i32},
+			results: []byte{i32},
+			code: []byte{
+				opLocalGet, 0, opCall, 0,
+				opLocalGet, 0, opCall, 0, opI32Add,
+				opLocalGet, 0, opCall, 0, opI32Add,
+			},
+			export: "triple",
+		},
+	}}
+	instance := instantiate(t, tm, executor.CompilationOptions{}, nil)
+
+	result, err := callExport(t, instance, "sum", 100)
+	require.Nil(t, err)
+	require.Equal(t, int32(5050), result.ToI32())
+
+	result, err = callExport(t, instance, "triple", 10)
+	require.Nil(t, err)
+	require.Equal(t, int32(165), result.ToI32())
+}
+
+func TestInstance_Memory(t *testing.T) {
+	tm := &testModule{
+		memoryPages: 1,
+		data:        []byte("abcd"),
+		functions: []testFunction{
+			{
+				params: []byte{i32, i32},
+				code:   []byte{opLocalGet, 0, opLocalGet, 1, opI32Store, 2, 0},
+				export: "store",
+			},
+			{
+				params:  []byte{i32},
+				results: []byte{i32},
+				code:    []byte{opLocalGet, 0, opI32Load, 2, 0},
+				export:  "load",
+			},
+			{
+				params:  []byte{i32},
+				results: []byte{i32},
+				code:    []byte{opLocalGet, 0, opMemoryGrow, 0},
+				export:  "grow",
+			},
+		},
+	}
+	instance := instantiate(t, tm, executor.CompilationOptions{MaxMemoryGrowDelta: 2}, nil)
+	require.True(t, instance.HasMemory())
+
+	result, err := callExport(t, instance, "load", 0)
+	require.Nil(t, err)
+	require.Equal(t, int32(binary.LittleEndian.Uint32([]byte("abcd"))), result.ToI32())
+
+	_, err = callExport(t, instance, "store", 8, int32(0x01020304))
+	require.Nil(t, err)
+	require.Equal(t, []byte{4, 3, 2, 1}, instance.GetMemory().Data()[8:12])
+
+	_, err = callExport(t, instance, "load", PageSize-2)
+	require.ErrorIs(t, err, ErrTrapMemoryOutOfBounds)
+
+	_, err = callExport(t, instance, "grow", 3)
+	require.ErrorIs(t, err, ErrTrapMemoryGrowLimit)
+
+	result, err = callExport(t, instance, "grow", 1)
+	require.Nil(t, err)
+	require.Equal(t, int32(1), result.ToI32())
+	require.Equal(t, uint32(2*PageSize), instance.GetMemory().Length())
+}
+
+func TestInstance_Metering(t *testing.T) {
+	tm := &testModule{functions: []testFunction{
+		{
+			results: []byte{i32},
+			locals:  []byte{i32, i32, i32},
+			code:    []byte{opNop, opNop, opI32Const, 1},
+			export:  "constant",
+		},
+		{
+			code:   []byte{opLoop, blockTypeEmpty, opBr, 0, opEnd},
+			export: "infinite",
+		},
+	}}
+	options := executor.CompilationOptions{
+		GasLimit:        1000,
+		UnmeteredLocals: 1,
+		Metering:        true,
+	}
+	instance := instantiate(t, tm, options, nil)
+
+	// two nops, a const and the final end, plus two metered locals
+	_, err := callExport(t, instance, "constant")
+	require.Nil(t, err)
+	require.Equal(t, uint64(6), instance.GetPointsUsed())
+
+	instance.SetPointsUsed(0)
+	instance.SetGasLimit(100)
+	_, err = callExport(t, instance, "infinite")
+	require.ErrorIs(t, err, ErrTrapOutOfGas)
+	require.Equal(t, uint64(breakpointOutOfGas), instance.GetBreakpointValue())
+	require.Equal(t, uint64(101), instance.GetPointsUsed())
+}
+
+func TestInstance_ImportedFunctions(t *testing.T) {
+	var instance *Instance
+	hostAdd := func(context unsafe.Pointer, a int32, b int64) int64 {
+		instanceContext, ok := executor.LookupInstanceContext(context)
+		require.True(t, ok)
+		data := *(*uintptr)(instanceContext.Data())
+		return int64(a) + b + int64(data)
+	}
+	hostStop := func(context unsafe.Pointer) {
+		instance.SetBreakpointValue(1)
+	}
+
+	imports := testImports{"env": {"hostAdd": hostAdd, "hostStop": hostStop}}
+
+	tm := &testModule{
+		imports: []testFunction{
+			{params: []byte{i32, i64}, results: []byte{i64}, export: "hostAdd"},
+			{export: "hostStop"},
+		},
+		functions: []testFunction{
+			{
+				params:  []byte{i32, i64},
+				results: []byte{i64},
+				code:    []byte{opLocalGet, 0, opLocalGet, 1, opCall, 0},
+				export:  "add",
+			},
+			{
+				code:   []byte{opCall, 1, opUnreachable},
+				export: "stop",
+			},
+		},
+	}
+	instance = instantiate(t, tm, executor.CompilationOptions{RuntimeBreakpoints: true}, imports)
+	instance.SetContextData(100)
+	require.True(t, instance.IsFunctionImported("hostAdd"))
+	require.False(t, instance.IsFunctionImported("add"))
+
+	result, err := callExport(t, instance, "add", 1, int64(2))
+	require.Nil(t, err)
+	require.Equal(t, int64(103), result.ToI64())
+
+	_, err = callExport(t, instance, "stop")
+	require.ErrorIs(t, err, ErrTrapBreakpoint)
+	require.Equal(t, uint64(1), instance.GetBreakpointValue())
+
+	_, err = NewInstanceWithOptions(tm.bytecode(), executor.CompilationOptions{}, testImports{}, uniformCosts(1))
+	require.ErrorIs(t, err, ErrUnknownImport)
+
+	mismatched := testImports{"env": {"hostAdd": hostStop, "hostStop": hostStop}}
+	_, err = NewInstanceWithOptions(tm.bytecode(), executor.CompilationOptions{}, mismatched, uniformCosts(1))
+	require.ErrorIs(t, err, ErrImportSignatureMismatch)
+}
+
+func TestExecuteNumeric(t *testing.T) {
+	testCases := []struct {
+		opcode   uint16
+		operands []uint64
+		expected uint64
+	}{
+		{0x6A, []uint64{0xFFFFFFFF, 2}, 1},
+		{0x6D, []uint64{uint64(uint32(0xFFFFFFF9)), 2}, uint64(uint32(0xFFFFFFFD))},
+		{0x6F, []uint64{0x80000000, 0xFFFFFFFF}, 0},
+		{0x74, []uint64{1, 33}, 2},
+		{0x77, []uint64{0x80000001, 1}, 3},
+		{0x67, []uint64{1}, 31},
+		{0x7B, []uint64{0xFF00}, 8},
+		{0xAC, []uint64{0xFFFFFFFF}, 0xFFFFFFFFFFFFFFFF},
+		{0xC0, []uint64{0x80}, 0xFFFFFF80},
+		{0xA0, []uint64{fromF64(1.5), fromF64(2.25)}, fromF64(3.75)},
+		{0xA4, []uint64{fromF64(1), canonicalNaN64}, canonicalNaN64},
+		{0xA4, []uint64{fromF64(0), signBit64}, signBit64},
+		{0x9E, []uint64{fromF64(2.5)}, fromF64(2)},
+		{0xAA, []uint64{fromF64(-3.9)}, uint64(uint32(0xFFFFFFFD))},
+		{opMisc | 2, []uint64{fromF64(1e20)}, 0x7FFFFFFF},
+		{opMisc | 1, []uint64{fromF32(-5)}, 0},
+	}
+
+	for _, testCase := range testCases {
+		stack := append([]uint64{}, testCase.operands...)
+		sp := executeNumeric(testCase.opcode, stack, len(stack))
+		require.Equal(t, 1, sp, "opcode %#x", testCase.opcode)
+		require.Equal(t, testCase.expected, stack[0], "opcode %#x", testCase.opcode)
+	}
+}
+
+func TestExecuteNumeric_Traps(t *testing.T) {
+	testCases := []struct {
+		opcode   uint16
+		operands []uint64
+		expected error
+	}{
+		{0x6D, []uint64{0x80000000, 0xFFFFFFFF}, ErrTrapIntegerOverflow},
+		{0x80, []uint64{1, 0}, ErrTrapDivisionByZero},
+		{0xAA, []uint64{canonicalNaN64}, ErrTrapInvalidConversion},
+		{0xAA, []uint64{fromF64(3e9)}, ErrTrapIntegerOverflow},
+		{0xB1, []uint64{fromF64(-1)}, ErrTrapIntegerOverflow},
+	}
+
+	for _, testCase := range testCases {
+		stack := append([]uint64{}, testCase.operands...)
+		require.PanicsWithValue(t, trap{err: testCase.expected}, func() {
+			executeNumeric(testCase.opcode, stack, len(stack))
+		}, "opcode %#x", testCase.opcode)
+	}
+}
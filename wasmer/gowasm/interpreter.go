@@ -0,0 +1,422 @@
+package gowasm
+
+import (
+	"encoding/binary"
+	"reflect"
+	"unsafe"
+)
+
+// maxCallDepth bounds the nesting of WASM function calls
+const maxCallDepth = 8192
+
+// trap carries an error out of the interpreter loop; it is raised with panic
+// and recovered at the boundary of the exported functions
+type trap struct {
+	err error
+}
+
+func raiseTrap(err error) {
+	panic(trap{err: err})
+}
+
+// hostFunction is an imported function, implemented in Go
+type hostFunction struct {
+	name           string
+	implementation reflect.Value
+	fType          *functionType
+}
+
+func (instance *Instance) useGas(cost uint64) {
+	if !instance.options.Metering {
+		return
+	}
+
+	instance.pointsUsed += cost
+	if instance.pointsUsed > instance.gasLimit {
+		instance.breakpointValue = breakpointOutOfGas
+		raiseTrap(ErrTrapOutOfGas)
+	}
+}
+
+func (instance *Instance) ensureStackCapacity(size int) {
+	if size <= len(instance.stack) {
+		return
+	}
+
+	grown := make([]uint64, 2*size)
+	copy(grown, instance.stack[:instance.sp])
+	instance.stack = grown
+}
+
+func (instance *Instance) push(value uint64) {
+	instance.ensureStackCapacity(instance.sp + 1)
+	instance.stack[instance.sp] = value
+	instance.sp++
+}
+
+// callFunction calls a function with its arguments on top of the stack; the
+// arguments are replaced by the results
+func (instance *Instance) callFunction(functionIndex uint32) {
+	if instance.observer != nil {
+		instance.enterObservedFunction(functionIndex)
+		defer instance.exitObservedFunction()
+	}
+
+	numImports := uint32(len(instance.hostFunctions))
+	if functionIndex < numImports {
+		instance.callHostFunction(instance.hostFunctions[functionIndex])
+		return
+	}
+
+	instance.callDepth++
+	if instance.callDepth > maxCallDepth {
+		raiseTrap(ErrTrapCallStackExhausted)
+	}
+
+	instance.execute(instance.functions[functionIndex-numImports])
+	instance.callDepth--
+}
+
+func (instance *Instance) enterObservedFunction(functionIndex uint32) {
+	instance.observedFunctions = append(instance.observedFunctions, functionIndex)
+
+	imported := functionIndex < uint32(len(instance.hostFunctions))
+	name := instance.module.functionName(functionIndex)
+	instance.observer.OnFunctionEnter(name, imported, instance.pointsUsed)
+}
+
+// exitObservedFunction notifies the observer that the innermost observed
+// function was left, either by returning or by a trap
+func (instance *Instance) exitObservedFunction() {
+	last := len(instance.observedFunctions) - 1
+	functionIndex := instance.observedFunctions[last]
+	instance.observedFunctions = instance.observedFunctions[:last]
+
+	imported := functionIndex < uint32(len(instance.hostFunctions))
+	name := instance.module.functionName(functionIndex)
+	instance.observer.OnFunctionExit(name, imported, instance.pointsUsed)
+}
+
+func (instance *Instance) callHostFunction(function *hostFunction) {
+	numParams := len(function.fType.params)
+	arguments := make([]reflect.Value, numParams+1)
+	arguments[0] = reflect.ValueOf(instance.contextPointer)
+
+	base := instance.sp - numParams
+	for i, paramType := range function.fType.params {
+		value := instance.stack[base+i]
+		if paramType == valueTypeI32 {
+			arguments[i+1] = reflect.ValueOf(int32(value))
+		} else {
+			arguments[i+1] = reflect.ValueOf(int64(value))
+		}
+	}
+	instance.sp = base
+
+	results := function.implementation.Call(arguments)
+	if len(results) == 1 {
+		if function.fType.results[0] == valueTypeI32 {
+			instance.push(uint64(uint32(results[0].Int())))
+		} else {
+			instance.push(uint64(results[0].Int()))
+		}
+	}
+
+	if instance.options.RuntimeBreakpoints && instance.breakpointValue != 0 {
+		raiseTrap(ErrTrapBreakpoint)
+	}
+}
+
+// execute interprets a function defined by the module; the stack holds the
+// arguments, followed by the locals and the operands of the function
+func (instance *Instance) execute(function *compiledFunction) {
+	numParams := len(function.fType.params)
+	numResults := len(function.fType.results)
+	base := instance.sp - numParams
+	operandBase := instance.sp + function.numLocals
+
+	instance.ensureStackCapacity(operandBase + function.maxStackHeight)
+	stack := instance.stack
+	for i := instance.sp; i < operandBase; i++ {
+		stack[i] = 0
+	}
+	sp := operandBase
+
+	if uint64(function.numLocals) > instance.options.UnmeteredLocals {
+		instance.useGas((uint64(function.numLocals) - instance.options.UnmeteredLocals) * instance.localAllocateCost)
+	}
+
+	code := function.code
+	for pc := 0; pc < len(code); pc++ {
+		instr := &code[pc]
+		instance.useGas(instr.cost)
+
+		switch instr.opcode {
+		case opUnreachable:
+			raiseTrap(ErrTrapUnreachable)
+		case opNop, opBlock, opLoop, opEnd:
+		case opIf:
+			sp--
+			if uint32(stack[sp]) == 0 {
+				pc = instr.branch.pc - 1
+			}
+		case opElse:
+			pc = instr.branch.pc - 1
+		case opBr:
+			sp, pc = branch(stack, sp, operandBase, instr.branch, len(code))
+		case opBrIf:
+			sp--
+			if uint32(stack[sp]) != 0 {
+				sp, pc = branch(stack, sp, operandBase, instr.branch, len(code))
+			}
+		case opBrTable:
+			sp--
+			index := uint64(uint32(stack[sp]))
+			if index >= uint64(len(instr.table)) {
+				index = uint64(len(instr.table) - 1)
+			}
+			sp, pc = branch(stack, sp, operandBase, instr.table[index], len(code))
+		case opReturn:
+			sp, pc = branch(stack, sp, operandBase, instr.branch, len(code))
+		case opCall:
+			instance.sp = sp
+			instance.callFunction(uint32(instr.immediate))
+			stack, sp = instance.stack, instance.sp
+		case opCallIndirect:
+			sp--
+			functionIndex := instance.resolveIndirectCall(uint32(stack[sp]), uint32(instr.immediate))
+			instance.sp = sp
+			instance.callFunction(functionIndex)
+			stack, sp = instance.stack, instance.sp
+		case opDrop:
+			sp--
+		case opSelect, opTypedSelect:
+			sp -= 2
+			if uint32(stack[sp+1]) == 0 {
+				stack[sp-1] = stack[sp]
+			}
+		case opLocalGet:
+			stack[sp] = stack[base+int(instr.immediate)]
+			sp++
+		case opLocalSet:
+			sp--
+			stack[base+int(instr.immediate)] = stack[sp]
+		case opLocalTee:
+			stack[base+int(instr.immediate)] = stack[sp-1]
+		case opGlobalGet:
+			stack[sp] = instance.globals[instr.immediate]
+			sp++
+		case opGlobalSet:
+			sp--
+			instance.globals[instr.immediate] = stack[sp]
+		case opMemorySize:
+			stack[sp] = uint64(instance.memory.pages())
+			sp++
+		case opMemoryGrow:
+			stack[sp-1] = uint64(uint32(instance.growMemory(uint32(stack[sp-1]))))
+		case opI32Const, opI64Const, opF32Const, opF64Const:
+			stack[sp] = instr.immediate
+			sp++
+		case opMemoryInit:
+			sp -= 3
+			instance.initMemory(uint32(instr.immediate), uint32(stack[sp]), uint32(stack[sp+1]), uint32(stack[sp+2]))
+		case opDataDrop:
+			instance.droppedData[instr.immediate] = true
+		case opMemoryCopy:
+			sp -= 3
+			instance.copyMemory(uint32(stack[sp]), uint32(stack[sp+1]), uint32(stack[sp+2]))
+		case opMemoryFill:
+			sp -= 3
+			instance.fillMemory(uint32(stack[sp]), byte(stack[sp+1]), uint32(stack[sp+2]))
+		default:
+			if instr.opcode >= opI32Load && instr.opcode <= opI64Store32 {
+				sp = instance.accessMemory(instr, stack, sp)
+			} else {
+				sp = executeNumeric(instr.opcode, stack, sp)
+			}
+		}
+	}
+
+	copy(stack[base:base+numResults], stack[sp-numResults:sp])
+	instance.sp = base + numResults
+}
+
+// branch moves the values carried by the branch to the height of the target
+// label and returns the new stack pointer and the instruction preceding the
+// target; a negative target means returning from the function
+func branch(stack []uint64, sp int, operandBase int, target branchTarget, codeLength int) (int, int) {
+	newSp := operandBase + target.height + target.arity
+	copy(stack[operandBase+target.height:newSp], stack[sp-target.arity:sp])
+
+	if target.pc < 0 {
+		return newSp, codeLength
+	}
+
+	return newSp, target.pc - 1
+}
+
+func (instance *Instance) resolveIndirectCall(tableIndex uint32, typeIndex uint32) uint32 {
+	if tableIndex >= uint32(len(instance.table)) || instance.table[tableIndex] < 0 {
+		raiseTrap(ErrTrapIndirectCall)
+	}
+
+	functionIndex := uint32(instance.table[tableIndex])
+	expectedType := &instance.module.types[typeIndex]
+	if !instance.module.functionType(functionIndex).equals(expectedType) {
+		raiseTrap(ErrTrapIndirectCall)
+	}
+
+	return functionIndex
+}
+
+// growMemory implements memory.grow, which traps like in Wasmer if the number
+// of pages or the number of calls exceed the limits given in the compilation
+// options, and fails if the memory cannot grow beyond its maximum
+func (instance *Instance) growMemory(pages uint32) int32 {
+	const growFailed = -1
+
+	options := instance.options
+	if options.MaxMemoryGrowDelta > 0 && uint64(pages) > options.MaxMemoryGrowDelta {
+		raiseTrap(ErrTrapMemoryGrowLimit)
+	}
+	if options.MaxMemoryGrow > 0 && instance.memoryGrowCount >= options.MaxMemoryGrow {
+		raiseTrap(ErrTrapMemoryGrowLimit)
+	}
+
+	previousPages := instance.memory.pages()
+	err := instance.memory.Grow(pages)
+	if err != nil {
+		return growFailed
+	}
+
+	instance.memoryGrowCount++
+	return int32(previousPages)
+}
+
+func (instance *Instance) checkMemoryRange(offset uint64, length uint64) {
+	if offset+length > uint64(len(instance.memory.data)) {
+		raiseTrap(ErrTrapMemoryOutOfBounds)
+	}
+}
+
+func (instance *Instance) initMemory(dataIndex uint32, destination uint32, source uint32, length uint32) {
+	data := instance.module.data[dataIndex].data
+	if instance.droppedData[dataIndex] {
+		data = nil
+	}
+
+	if uint64(source)+uint64(length) > uint64(len(data)) {
+		raiseTrap(ErrTrapMemoryOutOfBounds)
+	}
+	instance.checkMemoryRange(uint64(destination), uint64(length))
+
+	copy(instance.memory.data[destination:], data[source:source+length])
+}
+
+func (instance *Instance) copyMemory(destination uint32, source uint32, length uint32) {
+	instance.checkMemoryRange(uint64(source), uint64(length))
+	instance.checkMemoryRange(uint64(destination), uint64(length))
+
+	copy(instance.memory.data[destination:destination+length], instance.memory.data[source:source+length])
+}
+
+func (instance *Instance) fillMemory(destination uint32, value byte, length uint32) {
+	instance.checkMemoryRange(uint64(destination), uint64(length))
+
+	region := instance.memory.data[destination : destination+length]
+	for i := range region {
+		region[i] = value
+	}
+}
+
+func (instance *Instance) accessMemory(instr *instruction, stack []uint64, sp int) int {
+	size := memoryAccessSize(instr.opcode)
+
+	if isStoreOpcode(instr.opcode) {
+		address := uint64(uint32(stack[sp-2])) + instr.immediate
+		instance.checkMemoryRange(address, size)
+		storeValue(instance.memory.data[address:address+size], stack[sp-1])
+		return sp - 2
+	}
+
+	address := uint64(uint32(stack[sp-1])) + instr.immediate
+	instance.checkMemoryRange(address, size)
+	stack[sp-1] = loadValue(instr.opcode, instance.memory.data[address:address+size])
+	return sp
+}
+
+func storeValue(destination []byte, value uint64) {
+	switch len(destination) {
+	case 1:
+		destination[0] = byte(value)
+	case 2:
+		binary.LittleEndian.PutUint16(destination, uint16(value))
+	case 4:
+		binary.LittleEndian.PutUint32(destination, uint32(value))
+	default:
+		binary.LittleEndian.PutUint64(destination, value)
+	}
+}
+
+func loadValue(opcode uint16, source []byte) uint64 {
+	switch opcode {
+	case 0x28, 0x2A, 0x35:
+		return uint64(binary.LittleEndian.Uint32(source))
+	case 0x29, 0x2B:
+		return binary.LittleEndian.Uint64(source)
+	case 0x2C:
+		return uint64(uint32(int32(int8(source[0]))))
+	case 0x2D, 0x31:
+		return uint64(source[0])
+	case 0x2E:
+		return uint64(uint32(int32(int16(binary.LittleEndian.Uint16(source)))))
+	case 0x2F, 0x33:
+		return uint64(binary.LittleEndian.Uint16(source))
+	case 0x30:
+		return uint64(int64(int8(source[0])))
+	case 0x32:
+		return uint64(int64(int16(binary.LittleEndian.Uint16(source))))
+	default:
+		return uint64(int64(int32(binary.LittleEndian.Uint32(source))))
+	}
+}
+
+// callExport calls an exported function with the given arguments, converting
+// the traps raised during execution into errors
+func (instance *Instance) callExport(functionIndex uint32, arguments []uint64) (results []uint64, err error) {
+	savedSp := instance.sp
+	savedCallDepth := instance.callDepth
+
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		trapped, ok := recovered.(trap)
+		if !ok {
+			panic(recovered)
+		}
+
+		instance.sp = savedSp
+		instance.callDepth = savedCallDepth
+		results = nil
+		err = trapped.err
+	}()
+
+	for _, argument := range arguments {
+		instance.push(argument)
+	}
+
+	instance.callFunction(functionIndex)
+
+	numResults := len(instance.module.functionType(functionIndex).results)
+	results = make([]uint64, numResults)
+	copy(results, instance.stack[instance.sp-numResults:instance.sp])
+	instance.sp = savedSp
+
+	return results, nil
+}
+
+// contextPointerType is the type of the first argument of imported functions
+var contextPointerType = reflect.TypeOf(unsafe.Pointer(nil))
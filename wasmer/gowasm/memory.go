@@ -0,0 +1,59 @@
+package gowasm
+
+import (
+	"errors"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/executor"
+)
+
+// PageSize is the size of a WASM memory page
+const PageSize = 65536
+
+var errMemoryGrowLimit = errors.New("memory cannot grow beyond its maximum size")
+
+var _ executor.MemoryHandler = (*Memory)(nil)
+
+// Memory is the linear memory of a Go backend instance
+type Memory struct {
+	data     []byte
+	maxPages uint32
+}
+
+func newMemory(initialPages uint32, maxPages uint32) *Memory {
+	return &Memory{
+		data:     make([]byte, uint64(initialPages)*PageSize),
+		maxPages: maxPages,
+	}
+}
+
+// Length returns the size of the memory, in bytes
+func (memory *Memory) Length() uint32 {
+	return uint32(len(memory.data))
+}
+
+// Data returns the contents of the memory; the returned slice is invalidated by Grow
+func (memory *Memory) Data() []byte {
+	return memory.data
+}
+
+// Grow extends the memory by the given number of pages
+func (memory *Memory) Grow(pages uint32) error {
+	currentPages := uint64(len(memory.data)) / PageSize
+	if currentPages+uint64(pages) > uint64(memory.maxPages) {
+		return errMemoryGrowLimit
+	}
+
+	grown := make([]byte, (currentPages+uint64(pages))*PageSize)
+	copy(grown, memory.data)
+	memory.data = grown
+	return nil
+}
+
+// Destroy releases the contents of the memory
+func (memory *Memory) Destroy() {
+	memory.data = nil
+}
+
+func (memory *Memory) pages() uint32 {
+	return uint32(len(memory.data) / PageSize)
+}
@@ -0,0 +1,589 @@
+package gowasm
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/wasmparser"
+)
+
+type valueType = wasmparser.ValueType
+
+const (
+	valueTypeI32 = wasmparser.ValueTypeI32
+	valueTypeI64 = wasmparser.ValueTypeI64
+	valueTypeF32 = wasmparser.ValueTypeF32
+	valueTypeF64 = wasmparser.ValueTypeF64
+)
+
+func isSupportedValueType(vType valueType) bool {
+	switch vType {
+	case valueTypeI32, valueTypeI64, valueTypeF32, valueTypeF64:
+		return true
+	default:
+		return false
+	}
+}
+
+const nameSectionName = "name"
+const functionNamesSubsection = 1
+
+const functionReferenceType = 0x70
+
+const maxMemoryPages = 65536
+
+// maxFunctionLocals is the number of locals a function may declare, which is
+// the same limit as the one enforced by Wasmer
+const maxFunctionLocals = 4000
+
+type functionType struct {
+	params  []valueType
+	results []valueType
+}
+
+func (fType *functionType) equals(other *functionType) bool {
+	return bytes.Equal(valueTypesToBytes(fType.params), valueTypesToBytes(other.params)) &&
+		bytes.Equal(valueTypesToBytes(fType.results), valueTypesToBytes(other.results))
+}
+
+func valueTypesToBytes(types []valueType) []byte {
+	result := make([]byte, len(types))
+	for i, vType := range types {
+		result[i] = byte(vType)
+	}
+	return result
+}
+
+type importedFunction struct {
+	module    string
+	name      string
+	typeIndex uint32
+}
+
+type globalDefinition struct {
+	valueType valueType
+	mutable   bool
+	value     uint64
+}
+
+type export struct {
+	kind  byte
+	index uint32
+}
+
+type elementSegment struct {
+	offset    uint32
+	functions []uint32
+}
+
+type dataSegment struct {
+	passive bool
+	offset  uint32
+	data    []byte
+}
+
+type functionBody struct {
+	locals []valueType
+	code   []byte
+}
+
+// module is the decoded form of a WASM binary module
+type module struct {
+	types           []functionType
+	imports         []importedFunction
+	functions       []uint32
+	hasTable        bool
+	tableSize       uint32
+	hasMemory       bool
+	memoryMinPages  uint32
+	memoryMaxPages  uint32
+	globals         []globalDefinition
+	exports         map[string]export
+	hasStart        bool
+	startFunction   uint32
+	elements        []elementSegment
+	data            []dataSegment
+	bodies          []functionBody
+	hasMemoryExport bool
+	functionNames   map[uint32]string
+	exportNames     map[uint32]string
+}
+
+func (m *module) numFunctions() uint32 {
+	return uint32(len(m.imports) + len(m.functions))
+}
+
+func (m *module) functionType(functionIndex uint32) *functionType {
+	if functionIndex < uint32(len(m.imports)) {
+		return &m.types[m.imports[functionIndex].typeIndex]
+	}
+
+	return &m.types[m.functions[functionIndex-uint32(len(m.imports))]]
+}
+
+// decodeModule decodes a WASM binary module; only the features supported by
+// the interpreter are accepted
+func decodeModule(code []byte) (*module, error) {
+	sections, err := wasmparser.ReadSections(code)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &module{
+		exports:        make(map[string]export),
+		exportNames:    make(map[uint32]string),
+		memoryMaxPages: maxMemoryPages,
+	}
+	for _, section := range sections {
+		err = m.decodeSection(section)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(m.functions) != len(m.bodies) {
+		return nil, ErrInvalidModule
+	}
+
+	return m, nil
+}
+
+func (m *module) decodeSection(section wasmparser.Section) error {
+	switch section.ID {
+	case wasmparser.SectionCustom:
+		m.decodeCustomSection(section.Data)
+		return nil
+	case wasmparser.SectionDataCount:
+		return nil
+	case wasmparser.SectionType:
+		return m.decodeTypeSection(section.Data)
+	case wasmparser.SectionImport:
+		return m.decodeImportSection(section.Data)
+	case wasmparser.SectionFunction:
+		return m.decodeFunctionSection(section.Data)
+	case wasmparser.SectionTable:
+		return m.decodeTableSection(section.Data)
+	case wasmparser.SectionMemory:
+		return m.decodeMemorySection(section.Data)
+	case wasmparser.SectionGlobal:
+		return m.decodeGlobalSection(section.Data)
+	case wasmparser.SectionExport:
+		return m.decodeExportSection(section.Data)
+	case wasmparser.SectionStart:
+		return m.decodeStartSection(section.Data)
+	case wasmparser.SectionElement:
+		return m.decodeElementSection(section.Data)
+	case wasmparser.SectionCode:
+		return m.decodeCodeSection(section.Data)
+	case wasmparser.SectionData:
+		return m.decodeDataSection(section.Data)
+	default:
+		return ErrInvalidModule
+	}
+}
+
+// decodeCustomSection reads the function names from the name section; since
+// custom sections do not affect execution, malformed ones are ignored
+func (m *module) decodeCustomSection(data []byte) {
+	sectionName, content, err := wasmparser.DecodeCustomSection(data)
+	if err != nil || sectionName != nameSectionName {
+		return
+	}
+
+	reader := wasmparser.NewReader(content)
+	for !reader.IsAtEnd() {
+		subsectionID, err := reader.ReadByte()
+		if err != nil {
+			return
+		}
+		size, err := reader.ReadU32()
+		if err != nil {
+			return
+		}
+		subsection, err := reader.ReadBytes(int(size))
+		if err != nil {
+			return
+		}
+
+		if subsectionID == functionNamesSubsection {
+			m.functionNames = decodeNameMap(wasmparser.NewReader(subsection))
+		}
+	}
+}
+
+func decodeNameMap(reader *wasmparser.Reader) map[uint32]string {
+	names := make(map[uint32]string)
+
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil
+	}
+	for i := uint32(0); i < count; i++ {
+		index, err := reader.ReadU32()
+		if err != nil {
+			return nil
+		}
+		name, err := reader.ReadName()
+		if err != nil {
+			return nil
+		}
+		names[index] = name
+	}
+
+	return names
+}
+
+// functionName returns the name of a function given by the name section, by
+// its import or by its export, falling back to the function index
+func (m *module) functionName(functionIndex uint32) string {
+	name, ok := m.functionNames[functionIndex]
+	if ok {
+		return name
+	}
+
+	if functionIndex < uint32(len(m.imports)) {
+		return m.imports[functionIndex].name
+	}
+
+	name, ok = m.exportNames[functionIndex]
+	if ok {
+		return name
+	}
+
+	return fmt.Sprintf("wasm-function[%d]", functionIndex)
+}
+
+func (m *module) decodeTypeSection(data []byte) error {
+	types, err := wasmparser.DecodeTypeSection(data)
+	if err != nil {
+		return err
+	}
+
+	m.types = make([]functionType, 0, len(types))
+	for _, fType := range types {
+		if !areSupportedValueTypes(fType.Params) || !areSupportedValueTypes(fType.Results) {
+			return ErrUnsupportedFeature
+		}
+
+		m.types = append(m.types, functionType{params: fType.Params, results: fType.Results})
+	}
+
+	return nil
+}
+
+func areSupportedValueTypes(types []valueType) bool {
+	for _, vType := range types {
+		if !isSupportedValueType(vType) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *module) decodeImportSection(data []byte) error {
+	imports, err := wasmparser.DecodeImportSection(data)
+	if err != nil {
+		return err
+	}
+
+	for _, imported := range imports {
+		if imported.Kind != wasmparser.ExternalFunction {
+			return ErrUnsupportedFeature
+		}
+		if imported.TypeIndex >= uint32(len(m.types)) {
+			return ErrInvalidModule
+		}
+
+		m.imports = append(m.imports, importedFunction{
+			module:    imported.Module,
+			name:      imported.Name,
+			typeIndex: imported.TypeIndex,
+		})
+	}
+
+	return nil
+}
+
+func (m *module) decodeFunctionSection(data []byte) error {
+	functions, err := wasmparser.DecodeFunctionSection(data)
+	if err != nil {
+		return err
+	}
+
+	for _, typeIndex := range functions {
+		if typeIndex >= uint32(len(m.types)) {
+			return ErrInvalidModule
+		}
+	}
+
+	m.functions = functions
+	return nil
+}
+
+func (m *module) decodeTableSection(data []byte) error {
+	tables, err := wasmparser.DecodeTableSection(data)
+	if err != nil {
+		return err
+	}
+	if len(tables) > 1 {
+		return ErrUnsupportedFeature
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	table := tables[0]
+	if table.ReferenceType != functionReferenceType || table.Limits.Shared {
+		return ErrUnsupportedFeature
+	}
+
+	m.hasTable = true
+	m.tableSize = table.Limits.Min
+	return nil
+}
+
+func (m *module) decodeMemorySection(data []byte) error {
+	memories, err := wasmparser.DecodeMemorySection(data)
+	if err != nil {
+		return err
+	}
+	if len(memories) > 1 {
+		return ErrUnsupportedFeature
+	}
+	if len(memories) == 0 {
+		return nil
+	}
+
+	limits := memories[0]
+	if limits.Shared {
+		return ErrUnsupportedFeature
+	}
+	if limits.Min > maxMemoryPages || (limits.HasMax && (limits.Max > maxMemoryPages || limits.Max < limits.Min)) {
+		return ErrInvalidModule
+	}
+
+	m.hasMemory = true
+	m.memoryMinPages = limits.Min
+	if limits.HasMax {
+		m.memoryMaxPages = limits.Max
+	}
+	return nil
+}
+
+func (m *module) decodeGlobalSection(data []byte) error {
+	globals, err := wasmparser.DecodeGlobalSection(data)
+	if err != nil {
+		return err
+	}
+
+	for _, global := range globals {
+		vType := global.Type.ValueType
+		if !isSupportedValueType(vType) {
+			return ErrUnsupportedFeature
+		}
+
+		value, err := m.evaluateConstantExpression(global.Init, vType)
+		if err != nil {
+			return err
+		}
+
+		m.globals = append(m.globals, globalDefinition{
+			valueType: vType,
+			mutable:   global.Type.Mutable,
+			value:     value,
+		})
+	}
+
+	return nil
+}
+
+func (m *module) decodeExportSection(data []byte) error {
+	exports, err := wasmparser.DecodeExportSection(data)
+	if err != nil {
+		return err
+	}
+
+	for _, exported := range exports {
+		switch exported.Kind {
+		case wasmparser.ExternalFunction:
+			if exported.Index >= m.numFunctions() {
+				return ErrInvalidModule
+			}
+			if _, named := m.exportNames[exported.Index]; !named {
+				m.exportNames[exported.Index] = exported.Name
+			}
+		case wasmparser.ExternalMemory:
+			m.hasMemoryExport = true
+		case wasmparser.ExternalTable, wasmparser.ExternalGlobal:
+		default:
+			return ErrInvalidModule
+		}
+
+		if _, exists := m.exports[exported.Name]; exists {
+			return ErrInvalidModule
+		}
+		m.exports[exported.Name] = export{kind: exported.Kind, index: exported.Index}
+	}
+
+	return nil
+}
+
+func (m *module) decodeStartSection(data []byte) error {
+	functionIndex, err := wasmparser.DecodeStartSection(data)
+	if err != nil {
+		return err
+	}
+	if functionIndex >= m.numFunctions() {
+		return ErrInvalidModule
+	}
+
+	startType := m.functionType(functionIndex)
+	if len(startType.params) > 0 || len(startType.results) > 0 {
+		return ErrInvalidModule
+	}
+
+	m.hasStart = true
+	m.startFunction = functionIndex
+	return nil
+}
+
+func (m *module) decodeElementSection(data []byte) error {
+	segments, err := wasmparser.DecodeElementSection(data)
+	if err != nil {
+		return err
+	}
+
+	for _, segment := range segments {
+		offset, err := m.evaluateConstantExpression(segment.Offset, valueTypeI32)
+		if err != nil {
+			return err
+		}
+
+		for _, functionIndex := range segment.Functions {
+			if functionIndex >= m.numFunctions() {
+				return ErrInvalidModule
+			}
+		}
+
+		m.elements = append(m.elements, elementSegment{
+			offset:    uint32(offset),
+			functions: segment.Functions,
+		})
+	}
+
+	return nil
+}
+
+func (m *module) decodeCodeSection(data []byte) error {
+	bodies, err := wasmparser.DecodeCodeSection(data)
+	if err != nil {
+		return err
+	}
+
+	for _, decodedBody := range bodies {
+		body := functionBody{code: decodedBody.Code}
+		for _, entry := range decodedBody.Locals {
+			if !isSupportedValueType(entry.Type) {
+				return ErrUnsupportedFeature
+			}
+			if uint64(len(body.locals))+uint64(entry.Count) > maxFunctionLocals {
+				return ErrTooManyLocals
+			}
+
+			for k := uint32(0); k < entry.Count; k++ {
+				body.locals = append(body.locals, entry.Type)
+			}
+		}
+
+		m.bodies = append(m.bodies, body)
+	}
+
+	return nil
+}
+
+func (m *module) decodeDataSection(data []byte) error {
+	segments, err := wasmparser.DecodeDataSection(data)
+	if err != nil {
+		return err
+	}
+
+	for _, decodedSegment := range segments {
+		if decodedSegment.MemoryIndex != 0 {
+			return ErrInvalidModule
+		}
+
+		segment := dataSegment{
+			passive: decodedSegment.Passive,
+			data:    decodedSegment.Data,
+		}
+		if !segment.passive {
+			offset, err := m.evaluateConstantExpression(decodedSegment.Offset, valueTypeI32)
+			if err != nil {
+				return err
+			}
+			segment.offset = uint32(offset)
+		}
+
+		m.data = append(m.data, segment)
+	}
+
+	return nil
+}
+
+// evaluateConstantExpression evaluates the initializer of a global or the
+// offset of a segment; only constants and previously defined globals are accepted
+func (m *module) evaluateConstantExpression(expression []byte, expectedType valueType) (uint64, error) {
+	reader := wasmparser.NewReader(expression)
+	opcode, err := reader.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	var value uint64
+	var vType valueType
+	switch opcode {
+	case opI32Const:
+		var constant int32
+		constant, err = reader.ReadS32()
+		value, vType = uint64(uint32(constant)), valueTypeI32
+	case opI64Const:
+		var constant int64
+		constant, err = reader.ReadS64()
+		value, vType = uint64(constant), valueTypeI64
+	case opF32Const:
+		var constant uint32
+		constant, err = reader.ReadFixedU32()
+		value, vType = uint64(constant), valueTypeF32
+	case opF64Const:
+		value, err = reader.ReadFixedU64()
+		vType = valueTypeF64
+	case opGlobalGet:
+		var globalIndex uint32
+		globalIndex, err = reader.ReadU32()
+		if err == nil && globalIndex >= uint32(len(m.globals)) {
+			err = ErrInvalidModule
+		}
+		if err == nil {
+			value, vType = m.globals[globalIndex].value, m.globals[globalIndex].valueType
+		}
+	default:
+		err = ErrUnsupportedFeature
+	}
+	if err != nil {
+		return 0, err
+	}
+	if vType != expectedType {
+		return 0, ErrInvalidModule
+	}
+
+	end, err := reader.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if end != opEnd {
+		return 0, ErrInvalidModule
+	}
+
+	return value, nil
+}
@@ -0,0 +1,516 @@
+package gowasm
+
+import (
+	"math"
+	"math/bits"
+)
+
+const canonicalNaN32 = 0x7FC00000
+const canonicalNaN64 = 0x7FF8000000000000
+
+const signBit32 = 0x80000000
+const signBit64 = 0x8000000000000000
+
+func boolToValue(condition bool) uint64 {
+	if condition {
+		return 1
+	}
+	return 0
+}
+
+func f32(value uint64) float32 {
+	return math.Float32frombits(uint32(value))
+}
+
+func f64(value uint64) float64 {
+	return math.Float64frombits(value)
+}
+
+func fromF32(value float32) uint64 {
+	if value != value {
+		return canonicalNaN32
+	}
+	return uint64(math.Float32bits(value))
+}
+
+func fromF64(value float64) uint64 {
+	if value != value {
+		return canonicalNaN64
+	}
+	return math.Float64bits(value)
+}
+
+// executeNumeric executes a numeric instruction, which pops one or two
+// operands and pushes one result, returning the new stack pointer
+func executeNumeric(opcode uint16, stack []uint64, sp int) int {
+	pops, _, _ := numericStackEffect(opcode)
+	if pops == 1 {
+		stack[sp-1] = executeUnary(opcode, stack[sp-1])
+		return sp
+	}
+
+	stack[sp-2] = executeBinary(opcode, stack[sp-2], stack[sp-1])
+	return sp - 1
+}
+
+func executeUnary(opcode uint16, a uint64) uint64 {
+	switch opcode {
+	case 0x45:
+		return boolToValue(uint32(a) == 0)
+	case 0x50:
+		return boolToValue(a == 0)
+	case 0x67:
+		return uint64(bits.LeadingZeros32(uint32(a)))
+	case 0x68:
+		return uint64(bits.TrailingZeros32(uint32(a)))
+	case 0x69:
+		return uint64(bits.OnesCount32(uint32(a)))
+	case 0x79:
+		return uint64(bits.LeadingZeros64(a))
+	case 0x7A:
+		return uint64(bits.TrailingZeros64(a))
+	case 0x7B:
+		return uint64(bits.OnesCount64(a))
+	case 0x8B:
+		return uint64(uint32(a) &^ signBit32)
+	case 0x8C:
+		return uint64(uint32(a) ^ signBit32)
+	case 0x8D:
+		return fromF32(float32(math.Ceil(float64(f32(a)))))
+	case 0x8E:
+		return fromF32(float32(math.Floor(float64(f32(a)))))
+	case 0x8F:
+		return fromF32(float32(math.Trunc(float64(f32(a)))))
+	case 0x90:
+		return fromF32(float32(math.RoundToEven(float64(f32(a)))))
+	case 0x91:
+		return fromF32(float32(math.Sqrt(float64(f32(a)))))
+	case 0x99:
+		return a &^ signBit64
+	case 0x9A:
+		return a ^ signBit64
+	case 0x9B:
+		return fromF64(math.Ceil(f64(a)))
+	case 0x9C:
+		return fromF64(math.Floor(f64(a)))
+	case 0x9D:
+		return fromF64(math.Trunc(f64(a)))
+	case 0x9E:
+		return fromF64(math.RoundToEven(f64(a)))
+	case 0x9F:
+		return fromF64(math.Sqrt(f64(a)))
+	default:
+		return executeConversion(opcode, a)
+	}
+}
+
+func executeConversion(opcode uint16, a uint64) uint64 {
+	switch opcode {
+	case 0xA7:
+		return uint64(uint32(a))
+	case 0xA8:
+		return uint64(uint32(truncateToInt(float64(f32(a)), math.MinInt32, math.MaxInt32)))
+	case 0xA9:
+		return uint64(uint32(truncateToUint(float64(f32(a)), math.MaxUint32)))
+	case 0xAA:
+		return uint64(uint32(truncateToInt(f64(a), math.MinInt32, math.MaxInt32)))
+	case 0xAB:
+		return uint64(uint32(truncateToUint(f64(a), math.MaxUint32)))
+	case 0xAC:
+		return uint64(int64(int32(a)))
+	case 0xAD:
+		return uint64(uint32(a))
+	case 0xAE:
+		return uint64(truncateToInt(float64(f32(a)), math.MinInt64, math.MaxInt64))
+	case 0xAF:
+		return truncateToUint(float64(f32(a)), math.MaxUint64)
+	case 0xB0:
+		return uint64(truncateToInt(f64(a), math.MinInt64, math.MaxInt64))
+	case 0xB1:
+		return truncateToUint(f64(a), math.MaxUint64)
+	case 0xB2:
+		return fromF32(float32(int32(a)))
+	case 0xB3:
+		return fromF32(float32(uint32(a)))
+	case 0xB4:
+		return fromF32(float32(int64(a)))
+	case 0xB5:
+		return fromF32(float32(a))
+	case 0xB6:
+		return fromF32(float32(f64(a)))
+	case 0xB7:
+		return fromF64(float64(int32(a)))
+	case 0xB8:
+		return fromF64(float64(uint32(a)))
+	case 0xB9:
+		return fromF64(float64(int64(a)))
+	case 0xBA:
+		return fromF64(float64(a))
+	case 0xBB:
+		return fromF64(float64(f32(a)))
+	case 0xBC, 0xBE:
+		return uint64(uint32(a))
+	case 0xBD, 0xBF:
+		return a
+	case 0xC0:
+		return uint64(uint32(int32(int8(a))))
+	case 0xC1:
+		return uint64(uint32(int32(int16(a))))
+	case 0xC2:
+		return uint64(int64(int8(a)))
+	case 0xC3:
+		return uint64(int64(int16(a)))
+	case 0xC4:
+		return uint64(int64(int32(a)))
+	default:
+		return executeSaturatingTruncation(opcode, a)
+	}
+}
+
+func executeSaturatingTruncation(opcode uint16, a uint64) uint64 {
+	switch opcode {
+	case opMisc | 0:
+		return uint64(uint32(saturateToInt(float64(f32(a)), math.MinInt32, math.MaxInt32)))
+	case opMisc | 1:
+		return uint64(uint32(saturateToUint(float64(f32(a)), math.MaxUint32)))
+	case opMisc | 2:
+		return uint64(uint32(saturateToInt(f64(a), math.MinInt32, math.MaxInt32)))
+	case opMisc | 3:
+		return uint64(uint32(saturateToUint(f64(a), math.MaxUint32)))
+	case opMisc | 4:
+		return uint64(saturateToInt(float64(f32(a)), math.MinInt64, math.MaxInt64))
+	case opMisc | 5:
+		return saturateToUint(float64(f32(a)), math.MaxUint64)
+	case opMisc | 6:
+		return uint64(saturateToInt(f64(a), math.MinInt64, math.MaxInt64))
+	default:
+		return saturateToUint(f64(a), math.MaxUint64)
+	}
+}
+
+// truncateToInt truncates a float to a signed integer, trapping if the result
+// is not representable between minimum and maximum
+func truncateToInt(value float64, minimum int64, maximum int64) int64 {
+	if value != value {
+		raiseTrap(ErrTrapInvalidConversion)
+	}
+
+	truncated := math.Trunc(value)
+	if truncated < float64(minimum) || truncated >= -float64(minimum) || int64(truncated) > maximum {
+		raiseTrap(ErrTrapIntegerOverflow)
+	}
+
+	return int64(truncated)
+}
+
+// truncateToUint truncates a float to an unsigned integer, trapping if the
+// result is not representable up to maximum
+func truncateToUint(value float64, maximum uint64) uint64 {
+	if value != value {
+		raiseTrap(ErrTrapInvalidConversion)
+	}
+
+	truncated := math.Trunc(value)
+	if truncated <= -1 || truncated >= float64(maximum)+1 {
+		raiseTrap(ErrTrapIntegerOverflow)
+	}
+
+	return uint64(truncated)
+}
+
+func saturateToInt(value float64, minimum int64, maximum int64) int64 {
+	switch {
+	case value != value:
+		return 0
+	case value <= float64(minimum):
+		return minimum
+	case value >= -float64(minimum):
+		return maximum
+	}
+
+	truncated := int64(math.Trunc(value))
+	if truncated > maximum {
+		return maximum
+	}
+	return truncated
+}
+
+func saturateToUint(value float64, maximum uint64) uint64 {
+	switch {
+	case value != value, value <= 0:
+		return 0
+	case value >= float64(maximum)+1:
+		return maximum
+	}
+
+	return uint64(math.Trunc(value))
+}
+
+func executeBinary(opcode uint16, a uint64, b uint64) uint64 {
+	switch {
+	case opcode >= 0x46 && opcode <= 0x4F:
+		return compareI32(opcode, uint32(a), uint32(b))
+	case opcode >= 0x51 && opcode <= 0x5A:
+		return compareI64(opcode, a, b)
+	case opcode >= 0x5B && opcode <= 0x60:
+		return compareFloats(opcode-0x5B, float64(f32(a)), float64(f32(b)))
+	case opcode >= 0x61 && opcode <= 0x66:
+		return compareFloats(opcode-0x61, f64(a), f64(b))
+	case opcode >= 0x6A && opcode <= 0x78:
+		return uint64(arithmeticI32(opcode, uint32(a), uint32(b)))
+	case opcode >= 0x7C && opcode <= 0x8A:
+		return arithmeticI64(opcode, a, b)
+	case opcode >= 0x92 && opcode <= 0x98:
+		return arithmeticF32(opcode, a, b)
+	default:
+		return arithmeticF64(opcode, a, b)
+	}
+}
+
+func compareI32(opcode uint16, a uint32, b uint32) uint64 {
+	switch opcode {
+	case 0x46:
+		return boolToValue(a == b)
+	case 0x47:
+		return boolToValue(a != b)
+	case 0x48:
+		return boolToValue(int32(a) < int32(b))
+	case 0x49:
+		return boolToValue(a < b)
+	case 0x4A:
+		return boolToValue(int32(a) > int32(b))
+	case 0x4B:
+		return boolToValue(a > b)
+	case 0x4C:
+		return boolToValue(int32(a) <= int32(b))
+	case 0x4D:
+		return boolToValue(a <= b)
+	case 0x4E:
+		return boolToValue(int32(a) >= int32(b))
+	default:
+		return boolToValue(a >= b)
+	}
+}
+
+func compareI64(opcode uint16, a uint64, b uint64) uint64 {
+	switch opcode {
+	case 0x51:
+		return boolToValue(a == b)
+	case 0x52:
+		return boolToValue(a != b)
+	case 0x53:
+		return boolToValue(int64(a) < int64(b))
+	case 0x54:
+		return boolToValue(a < b)
+	case 0x55:
+		return boolToValue(int64(a) > int64(b))
+	case 0x56:
+		return boolToValue(a > b)
+	case 0x57:
+		return boolToValue(int64(a) <= int64(b))
+	case 0x58:
+		return boolToValue(a <= b)
+	case 0x59:
+		return boolToValue(int64(a) >= int64(b))
+	default:
+		return boolToValue(a >= b)
+	}
+}
+
+// compareFloats implements eq, ne, lt, gt, le and ge, in this order
+func compareFloats(comparison uint16, a float64, b float64) uint64 {
+	switch comparison {
+	case 0:
+		return boolToValue(a == b)
+	case 1:
+		return boolToValue(a != b)
+	case 2:
+		return boolToValue(a < b)
+	case 3:
+		return boolToValue(a > b)
+	case 4:
+		return boolToValue(a <= b)
+	default:
+		return boolToValue(a >= b)
+	}
+}
+
+func arithmeticI32(opcode uint16, a uint32, b uint32) uint32 {
+	switch opcode {
+	case 0x6A:
+		return a + b
+	case 0x6B:
+		return a - b
+	case 0x6C:
+		return a * b
+	case 0x6D:
+		if b == 0 {
+			raiseTrap(ErrTrapDivisionByZero)
+		}
+		if int32(a) == math.MinInt32 && int32(b) == -1 {
+			raiseTrap(ErrTrapIntegerOverflow)
+		}
+		return uint32(int32(a) / int32(b))
+	case 0x6E:
+		if b == 0 {
+			raiseTrap(ErrTrapDivisionByZero)
+		}
+		return a / b
+	case 0x6F:
+		if b == 0 {
+			raiseTrap(ErrTrapDivisionByZero)
+		}
+		if int32(b) == -1 {
+			return 0
+		}
+		return uint32(int32(a) % int32(b))
+	case 0x70:
+		if b == 0 {
+			raiseTrap(ErrTrapDivisionByZero)
+		}
+		return a % b
+	case 0x71:
+		return a & b
+	case 0x72:
+		return a | b
+	case 0x73:
+		return a ^ b
+	case 0x74:
+		return a << (b & 31)
+	case 0x75:
+		return uint32(int32(a) >> (b & 31))
+	case 0x76:
+		return a >> (b & 31)
+	case 0x77:
+		return bits.RotateLeft32(a, int(b&31))
+	default:
+		return bits.RotateLeft32(a, -int(b&31))
+	}
+}
+
+func arithmeticI64(opcode uint16, a uint64, b uint64) uint64 {
+	switch opcode {
+	case 0x7C:
+		return a + b
+	case 0x7D:
+		return a - b
+	case 0x7E:
+		return a * b
+	case 0x7F:
+		if b == 0 {
+			raiseTrap(ErrTrapDivisionByZero)
+		}
+		if int64(a) == math.MinInt64 && int64(b) == -1 {
+			raiseTrap(ErrTrapIntegerOverflow)
+		}
+		return uint64(int64(a) / int64(b))
+	case 0x80:
+		if b == 0 {
+			raiseTrap(ErrTrapDivisionByZero)
+		}
+		return a / b
+	case 0x81:
+		if b == 0 {
+			raiseTrap(ErrTrapDivisionByZero)
+		}
+		if int64(b) == -1 {
+			return 0
+		}
+		return uint64(int64(a) % int64(b))
+	case 0x82:
+		if b == 0 {
+			raiseTrap(ErrTrapDivisionByZero)
+		}
+		return a % b
+	case 0x83:
+		return a & b
+	case 0x84:
+		return a | b
+	case 0x85:
+		return a ^ b
+	case 0x86:
+		return a << (b & 63)
+	case 0x87:
+		return uint64(int64(a) >> (b & 63))
+	case 0x88:
+		return a >> (b & 63)
+	case 0x89:
+		return bits.RotateLeft64(a, int(b&63))
+	default:
+		return bits.RotateLeft64(a, -int(b&63))
+	}
+}
+
+func arithmeticF32(opcode uint16, a uint64, b uint64) uint64 {
+	x, y := f32(a), f32(b)
+	switch opcode {
+	case 0x92:
+		return fromF32(x + y)
+	case 0x93:
+		return fromF32(x - y)
+	case 0x94:
+		return fromF32(x * y)
+	case 0x95:
+		return fromF32(x / y)
+	case 0x96:
+		return fromF32(float32(floatMin(float64(x), float64(y))))
+	case 0x97:
+		return fromF32(float32(floatMax(float64(x), float64(y))))
+	default:
+		return uint64(uint32(a)&^signBit32 | uint32(b)&signBit32)
+	}
+}
+
+func arithmeticF64(opcode uint16, a uint64, b uint64) uint64 {
+	x, y := f64(a), f64(b)
+	switch opcode {
+	case 0xA0:
+		return fromF64(x + y)
+	case 0xA1:
+		return fromF64(x - y)
+	case 0xA2:
+		return fromF64(x * y)
+	case 0xA3:
+		return fromF64(x / y)
+	case 0xA4:
+		return fromF64(floatMin(x, y))
+	case 0xA5:
+		return fromF64(floatMax(x, y))
+	default:
+		return a&^signBit64 | b&signBit64
+	}
+}
+
+// floatMin follows the WASM semantics: NaN if any operand is NaN, and -0 is
+// smaller than +0
+func floatMin(a float64, b float64) float64 {
+	if a != a || b != b {
+		return math.NaN()
+	}
+	if a == b {
+		if math.Signbit(a) {
+			return a
+		}
+		return b
+	}
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func floatMax(a float64, b float64) float64 {
+	if a != a || b != b {
+		return math.NaN()
+	}
+	if a == b {
+		if math.Signbit(a) {
+			return b
+		}
+		return a
+	}
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,133 @@
+package gowasm
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/executor"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/wasmparser"
+)
+
+// binary opcodes of the supported WASM instructions; the instructions with the
+// 0xFC prefix are represented as miscPrefix | subOpcode
+const (
+	opUnreachable  = 0x00
+	opNop          = 0x01
+	opBlock        = 0x02
+	opLoop         = 0x03
+	opIf           = 0x04
+	opElse         = 0x05
+	opEnd          = 0x0B
+	opBr           = 0x0C
+	opBrIf         = 0x0D
+	opBrTable      = 0x0E
+	opReturn       = 0x0F
+	opCall         = 0x10
+	opCallIndirect = 0x11
+	opDrop         = 0x1A
+	opSelect       = 0x1B
+	opTypedSelect  = 0x1C
+	opLocalGet     = 0x20
+	opLocalSet     = 0x21
+	opLocalTee     = 0x22
+	opGlobalGet    = 0x23
+	opGlobalSet    = 0x24
+	opI32Load      = 0x28
+	opI64Store32   = 0x3E
+	opMemorySize   = 0x3F
+	opMemoryGrow   = 0x40
+	opI32Const     = 0x41
+	opI64Const     = 0x42
+	opF32Const     = 0x43
+	opF64Const     = 0x44
+	opI32Eqz       = 0x45
+	opI64Extend32S = 0xC4
+
+	miscPrefix       = 0xFC
+	opMisc           = 0xFC00
+	opI32TruncSatF32 = opMisc | 0
+	opI64TruncSatF64 = opMisc | 7
+	opMemoryInit     = opMisc | 8
+	opDataDrop       = opMisc | 9
+	opMemoryCopy     = opMisc | 10
+	opMemoryFill     = opMisc | 11
+)
+
+const blockTypeEmpty = 0x40
+
+// wasmerOpcode returns the index of the instruction among the opcode costs,
+// which is the same for both backends, so that they meter the same way
+func wasmerOpcode(opcode uint16) (int, bool) {
+	switch {
+	case opcode <= opElse:
+		return executor.OpcodeUnreachable + int(opcode), true
+	case opcode >= opEnd && opcode <= opCallIndirect:
+		return executor.OpcodeEnd + int(opcode-opEnd), true
+	case opcode >= opDrop && opcode <= opTypedSelect:
+		return executor.OpcodeDrop + int(opcode-opDrop), true
+	case opcode >= opLocalGet && opcode <= opGlobalSet:
+		return executor.OpcodeLocalGet + int(opcode-opLocalGet), true
+	case opcode >= opI32Load && opcode <= opF64Const:
+		return executor.OpcodeI32Load + int(opcode-opI32Load), true
+	case opcode >= opI32Eqz && opcode <= opI64Extend32S:
+		return executor.OpcodeI32Eqz + int(opcode-opI32Eqz), true
+	case opcode >= opI32TruncSatF32 && opcode <= opI64TruncSatF64:
+		return executor.OpcodeI32TruncSatF32S + int(opcode-opI32TruncSatF32), true
+	case opcode >= opMemoryInit && opcode <= opMemoryFill:
+		return executor.OpcodeMemoryInit + int(opcode-opMemoryInit), true
+	default:
+		return 0, false
+	}
+}
+
+// isFloatingPointOpcode returns true if the instruction operates on floating
+// point values; the interpreter implements them, but the compiler rejects
+// them, like Wasmer does
+func isFloatingPointOpcode(opcode uint16) bool {
+	instruction := wasmparser.Instruction{Opcode: byte(opcode)}
+	if opcode&opMisc == opMisc {
+		instruction = wasmparser.Instruction{Opcode: miscPrefix, SubOpcode: uint32(opcode &^ opMisc)}
+	}
+
+	return instruction.IsFloatingPoint()
+}
+
+// numericStackEffect returns the number of operands popped and pushed by the
+// numeric instructions, which take no immediates
+func numericStackEffect(opcode uint16) (int, int, bool) {
+	switch {
+	case opcode == 0x45 || opcode == 0x50:
+		return 1, 1, true
+	case opcode >= 0x46 && opcode <= 0x66:
+		return 2, 1, true
+	case opcode >= 0x67 && opcode <= 0x69, opcode >= 0x79 && opcode <= 0x7B:
+		return 1, 1, true
+	case opcode >= 0x6A && opcode <= 0x78, opcode >= 0x7C && opcode <= 0x8A:
+		return 2, 1, true
+	case opcode >= 0x8B && opcode <= 0x91, opcode >= 0x99 && opcode <= 0x9F:
+		return 1, 1, true
+	case opcode >= 0x92 && opcode <= 0x98, opcode >= 0xA0 && opcode <= 0xA6:
+		return 2, 1, true
+	case opcode >= 0xA7 && opcode <= opI64Extend32S:
+		return 1, 1, true
+	case opcode >= opI32TruncSatF32 && opcode <= opI64TruncSatF64:
+		return 1, 1, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// memoryAccessSize returns the number of bytes accessed by a load or store
+func memoryAccessSize(opcode uint16) uint64 {
+	switch opcode {
+	case 0x28, 0x2A, 0x34, 0x35, 0x36, 0x38, 0x3E:
+		return 4
+	case 0x29, 0x2B, 0x37, 0x39:
+		return 8
+	case 0x2C, 0x2D, 0x30, 0x31, 0x3A, 0x3C:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func isStoreOpcode(opcode uint16) bool {
+	return opcode >= 0x36 && opcode <= opI64Store32
+}
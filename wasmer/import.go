@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"unsafe"
 
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/executor"
 	"github.com/ElrondNetwork/elrond-vm-common"
 )
 
@@ -165,6 +166,22 @@ func (imports *Imports) Append(importName string, implementation interface{}, cg
 	return imports, nil
 }
 
+// Implementation returns the Go implementation of an imported function,
+// which receives the instance context as its first argument
+func (imports *Imports) Implementation(namespace string, importName string) (interface{}, bool) {
+	namespacedImports, ok := imports.imports[namespace]
+	if !ok {
+		return nil, false
+	}
+
+	imported, ok := namespacedImports[importName]
+	if !ok {
+		return nil, false
+	}
+
+	return imported.implementation, true
+}
+
 // Close closes/frees all imported functions that have been registered by Wasmer.
 func (imports *Imports) Close() {
 	for _, namespacedImports := range imports.imports {
@@ -181,6 +198,7 @@ func (imports *Imports) Close() {
 type InstanceContext struct {
 	context *cWasmerInstanceContextT
 	memory  MemoryHandler
+	data    unsafe.Pointer
 }
 
 // NewInstanceContext creates a new wasmer context given a cWasmerInstance and a memory
@@ -192,19 +210,26 @@ func NewInstanceContext(ctx *cWasmerInstanceContextT, mem Memory) *InstanceConte
 }
 
 // IntoInstanceContext casts the first `context unsafe.Pointer`
-// argument of an imported function into an `InstanceContext`.
+// argument of an imported function into an `InstanceContext`. The contexts
+// of the instances which are not executed by Wasmer are created with
+// executor.NewInstanceContext.
 func IntoInstanceContext(instanceContext unsafe.Pointer) InstanceContext {
+	goInstanceContext, ok := executor.LookupInstanceContext(instanceContext)
+	if ok {
+		return InstanceContext{memory: goInstanceContext.Memory(), data: goInstanceContext.Data()}
+	}
+
 	context := (*cWasmerInstanceContextT)(instanceContext)
 	memory := newMemory(cWasmerInstanceContextMemory(context))
 
-	return InstanceContext{context, &memory}
+	return InstanceContext{context: context, memory: &memory}
 }
 
 // IntoInstanceContextDirect retrieves the Wasmer instance context directly
 // from the Wasmer instance. This context can be stored as long as the instance itself.
 func IntoInstanceContextDirect(instanceContext *cWasmerInstanceContextT) InstanceContext {
 	memory := newMemory(cWasmerInstanceContextMemory(instanceContext))
-	return InstanceContext{instanceContext, &memory}
+	return InstanceContext{context: instanceContext, memory: &memory}
 }
 
 // Memory returns the current instance memory.
@@ -215,5 +240,9 @@ func (instanceContext *InstanceContext) Memory() MemoryHandler {
 // Data returns the instance context data as an `unsafe.Pointer`. It's
 // up to the user to cast it appropriately as a pointer to a data.
 func (instanceContext *InstanceContext) Data() unsafe.Pointer {
+	if instanceContext.context == nil {
+		return instanceContext.data
+	}
+
 	return cWasmerInstanceContextDataGet(instanceContext.context)
 }
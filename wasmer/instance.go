@@ -5,9 +5,12 @@ import "C"
 import (
 	"fmt"
 	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/executor"
 )
 
-const OPCODE_COUNT = 448
+// OPCODE_COUNT is the number of opcodes which can be assigned a cost
+const OPCODE_COUNT = executor.OPCODE_COUNT
 
 // InstanceError represents any kind of errors related to a WebAssembly instance. It
 // is returned by `Instance` functions only.
@@ -35,13 +38,6 @@ type ExportedFunctionError struct {
 	message      string
 }
 
-// ExportedFunctionSignature holds information about the input/output arities
-// of an exported function
-type ExportedFunctionSignature struct {
-	InputArity  int
-	OutputArity int
-}
-
 // NewExportedFunctionError constructs a new `ExportedFunctionError`,
 // where `functionName` is the name of the exported function, and
 // `message` is the error message. If the error message contains `%s`,
@@ -56,10 +52,6 @@ func (error *ExportedFunctionError) Error() string {
 	return error.message
 }
 
-type ExportedFunctionCallback func(...interface{}) (Value, error)
-type ExportsMap map[string]ExportedFunctionCallback
-type ExportSignaturesMap map[string]*ExportedFunctionSignature
-
 // Instance represents a WebAssembly instance.
 type Instance struct {
 	// The underlying WebAssembly instance.
@@ -91,16 +83,6 @@ type Instance struct {
 	InstanceCtx InstanceContext
 }
 
-type CompilationOptions struct {
-	GasLimit           uint64
-	UnmeteredLocals    uint64
-	MaxMemoryGrow      uint64
-	MaxMemoryGrowDelta uint64
-	OpcodeTrace        bool
-	Metering           bool
-	RuntimeBreakpoints bool
-}
-
 func newWrappedError(target error) error {
 	var lastError string
 	var err error
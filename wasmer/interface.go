@@ -1,28 +1,37 @@
 package wasmer
 
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_4/wasmer/executor"
+)
+
 // InstanceHandler defines the functionality of a Wasmer instance
-type InstanceHandler interface {
-	HasMemory() bool
-	SetContextData(data uintptr)
-	GetPointsUsed() uint64
-	SetPointsUsed(points uint64)
-	SetGasLimit(gasLimit uint64)
-	SetBreakpointValue(value uint64)
-	GetBreakpointValue() uint64
-	Cache() ([]byte, error)
-	Clean()
-	GetExports() ExportsMap
-	GetSignature(functionName string) (*ExportedFunctionSignature, bool)
-	GetData() uintptr
-	GetInstanceCtxMemory() MemoryHandler
-	GetMemory() MemoryHandler
-	IsFunctionImported(name string) bool
-}
+type InstanceHandler = executor.InstanceHandler
 
 // MemoryHandler defines the functionality of the memory of a Wasmer instance
-type MemoryHandler interface {
-	Length() uint32
-	Data() []byte
-	Grow(pages uint32) error
-	Destroy()
-}
+type MemoryHandler = executor.MemoryHandler
+
+// FunctionCallObserver is notified whenever an instance enters or leaves one
+// of its functions
+type FunctionCallObserver = executor.FunctionCallObserver
+
+// FunctionCallObservable defines the instances which can notify a
+// FunctionCallObserver; the Wasmer instances do not support it
+type FunctionCallObservable = executor.FunctionCallObservable
+
+// CompilationOptions holds the options for compiling and instantiating a WASM module
+type CompilationOptions = executor.CompilationOptions
+
+// ExportedFunctionSignature holds information about the input/output arities
+// of an exported function
+type ExportedFunctionSignature = executor.ExportedFunctionSignature
+
+// ExportedFunctionCallback calls an exported function of an instance
+type ExportedFunctionCallback = executor.ExportedFunctionCallback
+
+// ExportsMap holds the exported functions of an instance, by name
+type ExportsMap = executor.ExportsMap
+
+// ExportSignaturesMap holds the signatures of the exported functions of an instance, by name
+type ExportSignaturesMap = executor.ExportSignaturesMap
+
+var _ executor.FunctionImports = (*Imports)(nil)
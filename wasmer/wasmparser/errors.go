@@ -0,0 +1,9 @@
+package wasmparser
+
+import "errors"
+
+// ErrInvalidModule signals that the WASM module is malformed
+var ErrInvalidModule = errors.New("invalid WASM module")
+
+// ErrUnsupportedFeature signals that the WASM module uses a feature which the parser cannot decode
+var ErrUnsupportedFeature = errors.New("unsupported WASM feature")
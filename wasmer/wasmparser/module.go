@@ -0,0 +1,573 @@
+package wasmparser
+
+import (
+	"bytes"
+)
+
+// Section IDs of the WASM binary format
+const (
+	SectionCustom    = 0
+	SectionType      = 1
+	SectionImport    = 2
+	SectionFunction  = 3
+	SectionTable     = 4
+	SectionMemory    = 5
+	SectionGlobal    = 6
+	SectionExport    = 7
+	SectionStart     = 8
+	SectionElement   = 9
+	SectionCode      = 10
+	SectionData      = 11
+	SectionDataCount = 12
+)
+
+// External kinds of the imports and of the exports
+const (
+	ExternalFunction = 0
+	ExternalTable    = 1
+	ExternalMemory   = 2
+	ExternalGlobal   = 3
+)
+
+// ValueType is the binary encoding of a WASM value type
+type ValueType byte
+
+// The value types of the WASM MVP
+const (
+	ValueTypeI32 ValueType = 0x7F
+	ValueTypeI64 ValueType = 0x7E
+	ValueTypeF32 ValueType = 0x7D
+	ValueTypeF64 ValueType = 0x7C
+)
+
+const functionTypeForm = 0x60
+
+var wasmMagic = []byte{0x00, 0x61, 0x73, 0x6D}
+var wasmVersion = []byte{0x01, 0x00, 0x00, 0x00}
+
+// Section is a section of a WASM module, which is not yet decoded
+type Section struct {
+	ID   byte
+	Data []byte
+}
+
+// Limits holds the limits of a table or of a memory
+type Limits struct {
+	Min    uint32
+	Max    uint32
+	HasMax bool
+	Shared bool
+}
+
+// FunctionType is the signature of a function
+type FunctionType struct {
+	Params  []ValueType
+	Results []ValueType
+}
+
+// Table is a table declared or imported by a module
+type Table struct {
+	ReferenceType byte
+	Limits        Limits
+}
+
+// GlobalType is the type of a global declared or imported by a module
+type GlobalType struct {
+	ValueType ValueType
+	Mutable   bool
+}
+
+// Import is an import of a module; only the field matching its Kind is set
+type Import struct {
+	Module    string
+	Name      string
+	Kind      byte
+	TypeIndex uint32
+	Table     Table
+	Memory    Limits
+	Global    GlobalType
+}
+
+// Global is a global declared by a module, with its constant initializer
+type Global struct {
+	Type GlobalType
+	Init []byte
+}
+
+// Export is an export of a module
+type Export struct {
+	Name  string
+	Kind  byte
+	Index uint32
+}
+
+// ElementSegment is an active element segment of the first table
+type ElementSegment struct {
+	Offset    []byte
+	Functions []uint32
+}
+
+// LocalEntry declares Count locals of the same type
+type LocalEntry struct {
+	Count uint32
+	Type  ValueType
+}
+
+// FunctionBody holds the locals and the instructions of a function
+type FunctionBody struct {
+	Locals []LocalEntry
+	Code   []byte
+}
+
+// DataSegment is a data segment; the offset is not set for passive segments
+type DataSegment struct {
+	Passive     bool
+	MemoryIndex uint32
+	Offset      []byte
+	Data        []byte
+}
+
+// ReadSections checks the header of a WASM module and splits it into its
+// sections, without decoding them
+func ReadSections(code []byte) ([]Section, error) {
+	reader := NewReader(code)
+
+	magic, err := reader.ReadBytes(len(wasmMagic))
+	if err != nil || !bytes.Equal(magic, wasmMagic) {
+		return nil, ErrInvalidModule
+	}
+	version, err := reader.ReadBytes(len(wasmVersion))
+	if err != nil || !bytes.Equal(version, wasmVersion) {
+		return nil, ErrInvalidModule
+	}
+
+	sections := make([]Section, 0)
+	for !reader.IsAtEnd() {
+		sectionID, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		sectionSize, err := reader.ReadU32()
+		if err != nil {
+			return nil, err
+		}
+		sectionData, err := reader.ReadBytes(int(sectionSize))
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, Section{ID: sectionID, Data: sectionData})
+	}
+
+	return sections, nil
+}
+
+// DecodeCustomSection returns the name and the content of a custom section
+func DecodeCustomSection(data []byte) (string, []byte, error) {
+	reader := NewReader(data)
+	name, err := reader.ReadName()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return name, data[reader.Position():], nil
+}
+
+// DecodeTypeSection decodes the function types of a module
+func DecodeTypeSection(data []byte) ([]FunctionType, error) {
+	reader := NewReader(data)
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]FunctionType, 0, count)
+	for i := uint32(0); i < count; i++ {
+		form, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if form != functionTypeForm {
+			return nil, ErrInvalidModule
+		}
+
+		params, err := reader.ReadValueTypes()
+		if err != nil {
+			return nil, err
+		}
+		results, err := reader.ReadValueTypes()
+		if err != nil {
+			return nil, err
+		}
+
+		types = append(types, FunctionType{Params: params, Results: results})
+	}
+
+	return types, checkSectionEnd(reader)
+}
+
+// DecodeImportSection decodes the imports of a module
+func DecodeImportSection(data []byte) ([]Import, error) {
+	reader := NewReader(data)
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil, err
+	}
+
+	imports := make([]Import, 0, count)
+	for i := uint32(0); i < count; i++ {
+		imported := Import{}
+		imported.Module, err = reader.ReadName()
+		if err != nil {
+			return nil, err
+		}
+		imported.Name, err = reader.ReadName()
+		if err != nil {
+			return nil, err
+		}
+		imported.Kind, err = reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch imported.Kind {
+		case ExternalFunction:
+			imported.TypeIndex, err = reader.ReadU32()
+		case ExternalTable:
+			imported.Table, err = reader.readTable()
+		case ExternalMemory:
+			imported.Memory, err = reader.ReadLimits()
+		case ExternalGlobal:
+			imported.Global, err = reader.readGlobalType()
+		default:
+			err = ErrInvalidModule
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		imports = append(imports, imported)
+	}
+
+	return imports, checkSectionEnd(reader)
+}
+
+// DecodeFunctionSection decodes the type indices of the functions defined by a module
+func DecodeFunctionSection(data []byte) ([]uint32, error) {
+	reader := NewReader(data)
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil, err
+	}
+
+	functions := make([]uint32, 0, count)
+	for i := uint32(0); i < count; i++ {
+		typeIndex, err := reader.ReadU32()
+		if err != nil {
+			return nil, err
+		}
+
+		functions = append(functions, typeIndex)
+	}
+
+	return functions, checkSectionEnd(reader)
+}
+
+// DecodeTableSection decodes the tables defined by a module
+func DecodeTableSection(data []byte) ([]Table, error) {
+	reader := NewReader(data)
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]Table, 0, count)
+	for i := uint32(0); i < count; i++ {
+		table, err := reader.readTable()
+		if err != nil {
+			return nil, err
+		}
+
+		tables = append(tables, table)
+	}
+
+	return tables, checkSectionEnd(reader)
+}
+
+// DecodeMemorySection decodes the memories defined by a module
+func DecodeMemorySection(data []byte) ([]Limits, error) {
+	reader := NewReader(data)
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil, err
+	}
+
+	memories := make([]Limits, 0, count)
+	for i := uint32(0); i < count; i++ {
+		limits, err := reader.ReadLimits()
+		if err != nil {
+			return nil, err
+		}
+
+		memories = append(memories, limits)
+	}
+
+	return memories, checkSectionEnd(reader)
+}
+
+// DecodeGlobalSection decodes the globals defined by a module
+func DecodeGlobalSection(data []byte) ([]Global, error) {
+	reader := NewReader(data)
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil, err
+	}
+
+	globals := make([]Global, 0, count)
+	for i := uint32(0); i < count; i++ {
+		global := Global{}
+		global.Type, err = reader.readGlobalType()
+		if err != nil {
+			return nil, err
+		}
+		global.Init, err = reader.ReadConstantExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		globals = append(globals, global)
+	}
+
+	return globals, checkSectionEnd(reader)
+}
+
+// DecodeExportSection decodes the exports of a module
+func DecodeExportSection(data []byte) ([]Export, error) {
+	reader := NewReader(data)
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil, err
+	}
+
+	exports := make([]Export, 0, count)
+	for i := uint32(0); i < count; i++ {
+		export := Export{}
+		export.Name, err = reader.ReadName()
+		if err != nil {
+			return nil, err
+		}
+		export.Kind, err = reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		export.Index, err = reader.ReadU32()
+		if err != nil {
+			return nil, err
+		}
+
+		exports = append(exports, export)
+	}
+
+	return exports, checkSectionEnd(reader)
+}
+
+// DecodeStartSection decodes the index of the start function of a module
+func DecodeStartSection(data []byte) (uint32, error) {
+	reader := NewReader(data)
+	functionIndex, err := reader.ReadU32()
+	if err != nil {
+		return 0, err
+	}
+
+	return functionIndex, checkSectionEnd(reader)
+}
+
+// DecodeElementSection decodes the element segments of a module; only the
+// active segments of the first table, given by function indices, are supported
+func DecodeElementSection(data []byte) ([]ElementSegment, error) {
+	reader := NewReader(data)
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]ElementSegment, 0, count)
+	for i := uint32(0); i < count; i++ {
+		flags, err := reader.ReadU32()
+		if err != nil {
+			return nil, err
+		}
+		if flags != 0 {
+			return nil, ErrUnsupportedFeature
+		}
+
+		segment := ElementSegment{}
+		segment.Offset, err = reader.ReadConstantExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		numFunctions, err := reader.ReadU32()
+		if err != nil {
+			return nil, err
+		}
+		for j := uint32(0); j < numFunctions; j++ {
+			functionIndex, err := reader.ReadU32()
+			if err != nil {
+				return nil, err
+			}
+			segment.Functions = append(segment.Functions, functionIndex)
+		}
+
+		segments = append(segments, segment)
+	}
+
+	return segments, checkSectionEnd(reader)
+}
+
+// DecodeCodeSection decodes the bodies of the functions defined by a module;
+// the instructions are not decoded
+func DecodeCodeSection(data []byte) ([]FunctionBody, error) {
+	reader := NewReader(data)
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil, err
+	}
+
+	bodies := make([]FunctionBody, 0, count)
+	for i := uint32(0); i < count; i++ {
+		bodySize, err := reader.ReadU32()
+		if err != nil {
+			return nil, err
+		}
+		bodyData, err := reader.ReadBytes(int(bodySize))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := decodeFunctionBody(bodyData)
+		if err != nil {
+			return nil, err
+		}
+
+		bodies = append(bodies, body)
+	}
+
+	return bodies, checkSectionEnd(reader)
+}
+
+func decodeFunctionBody(data []byte) (FunctionBody, error) {
+	reader := NewReader(data)
+	numLocalEntries, err := reader.ReadU32()
+	if err != nil {
+		return FunctionBody{}, err
+	}
+
+	body := FunctionBody{}
+	for i := uint32(0); i < numLocalEntries; i++ {
+		entry := LocalEntry{}
+		entry.Count, err = reader.ReadU32()
+		if err != nil {
+			return FunctionBody{}, err
+		}
+		valueType, err := reader.ReadByte()
+		if err != nil {
+			return FunctionBody{}, err
+		}
+		entry.Type = ValueType(valueType)
+
+		body.Locals = append(body.Locals, entry)
+	}
+
+	body.Code = data[reader.Position():]
+	return body, nil
+}
+
+// DecodeDataSection decodes the data segments of a module
+func DecodeDataSection(data []byte) ([]DataSegment, error) {
+	reader := NewReader(data)
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]DataSegment, 0, count)
+	for i := uint32(0); i < count; i++ {
+		flags, err := reader.ReadU32()
+		if err != nil {
+			return nil, err
+		}
+
+		segment := DataSegment{}
+		switch flags {
+		case 0:
+		case 1:
+			segment.Passive = true
+		case 2:
+			segment.MemoryIndex, err = reader.ReadU32()
+		default:
+			err = ErrInvalidModule
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !segment.Passive {
+			segment.Offset, err = reader.ReadConstantExpression()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		size, err := reader.ReadU32()
+		if err != nil {
+			return nil, err
+		}
+		segment.Data, err = reader.ReadBytes(int(size))
+		if err != nil {
+			return nil, err
+		}
+
+		segments = append(segments, segment)
+	}
+
+	return segments, checkSectionEnd(reader)
+}
+
+func (reader *Reader) readTable() (Table, error) {
+	referenceType, err := reader.ReadByte()
+	if err != nil {
+		return Table{}, err
+	}
+
+	limits, err := reader.ReadLimits()
+	if err != nil {
+		return Table{}, err
+	}
+
+	return Table{ReferenceType: referenceType, Limits: limits}, nil
+}
+
+func (reader *Reader) readGlobalType() (GlobalType, error) {
+	valueType, err := reader.ReadByte()
+	if err != nil {
+		return GlobalType{}, err
+	}
+	mutable, err := reader.ReadByte()
+	if err != nil {
+		return GlobalType{}, err
+	}
+	if mutable > 1 {
+		return GlobalType{}, ErrInvalidModule
+	}
+
+	return GlobalType{ValueType: ValueType(valueType), Mutable: mutable == 1}, nil
+}
+
+func checkSectionEnd(reader *Reader) error {
+	if !reader.IsAtEnd() {
+		return ErrInvalidModule
+	}
+
+	return nil
+}
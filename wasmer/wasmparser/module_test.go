@@ -0,0 +1,128 @@
+package wasmparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testHeader = []byte{0x00, 0x61, 0x73, 0x6D, 0x01, 0x00, 0x00, 0x00}
+
+func TestReadSections(t *testing.T) {
+	code := append([]byte{}, testHeader...)
+	code = append(code, SectionType, 0x04, 0x01, 0x60, 0x00, 0x00)
+	code = append(code, SectionCustom, 0x03, 0x01, 'a', 0x07)
+
+	sections, err := ReadSections(code)
+	require.Nil(t, err)
+	require.Equal(t, []Section{
+		{ID: SectionType, Data: []byte{0x01, 0x60, 0x00, 0x00}},
+		{ID: SectionCustom, Data: []byte{0x01, 'a', 0x07}},
+	}, sections)
+
+	name, content, err := DecodeCustomSection(sections[1].Data)
+	require.Nil(t, err)
+	require.Equal(t, "a", name)
+	require.Equal(t, []byte{0x07}, content)
+
+	_, err = ReadSections(testHeader[:6])
+	require.Equal(t, ErrInvalidModule, err)
+
+	_, err = ReadSections(code[:len(code)-1])
+	require.Equal(t, ErrInvalidModule, err)
+}
+
+func TestDecodeSections_TrailingBytes(t *testing.T) {
+	types, err := DecodeTypeSection([]byte{0x01, 0x60, 0x01, 0x7F, 0x01, 0x7E})
+	require.Nil(t, err)
+	require.Equal(t, []FunctionType{{Params: []ValueType{ValueTypeI32}, Results: []ValueType{ValueTypeI64}}}, types)
+
+	_, err = DecodeTypeSection([]byte{0x01, 0x60, 0x00, 0x00, 0x00})
+	require.Equal(t, ErrInvalidModule, err)
+
+	_, err = DecodeFunctionSection([]byte{0x01, 0x00, 0x00})
+	require.Equal(t, ErrInvalidModule, err)
+}
+
+func TestDecodeImportSection(t *testing.T) {
+	imports, err := DecodeImportSection([]byte{
+		0x03,
+		0x03, 'e', 'n', 'v', 0x01, 'f', ExternalFunction, 0x02,
+		0x03, 'e', 'n', 'v', 0x01, 'm', ExternalMemory, 0x01, 0x01, 0x02,
+		0x03, 'e', 'n', 'v', 0x01, 'g', ExternalGlobal, 0x7F, 0x01,
+	})
+	require.Nil(t, err)
+	require.Equal(t, []Import{
+		{Module: "env", Name: "f", Kind: ExternalFunction, TypeIndex: 2},
+		{Module: "env", Name: "m", Kind: ExternalMemory, Memory: Limits{Min: 1, Max: 2, HasMax: true}},
+		{Module: "env", Name: "g", Kind: ExternalGlobal, Global: GlobalType{ValueType: ValueTypeI32, Mutable: true}},
+	}, imports)
+
+	_, err = DecodeImportSection([]byte{0x01, 0x03, 'e', 'n', 'v', 0x01, 0xFF, ExternalFunction, 0x00})
+	require.Equal(t, ErrInvalidModule, err)
+}
+
+func TestDecodeGlobalAndDataSections(t *testing.T) {
+	globals, err := DecodeGlobalSection([]byte{0x01, 0x7E, 0x00, 0x42, 0x7F, 0x0B})
+	require.Nil(t, err)
+	require.Equal(t, []Global{{Type: GlobalType{ValueType: ValueTypeI64}, Init: []byte{0x42, 0x7F, 0x0B}}}, globals)
+
+	segments, err := DecodeDataSection([]byte{
+		0x02,
+		0x00, 0x41, 0x08, 0x0B, 0x02, 'h', 'i',
+		0x01, 0x01, '!',
+	})
+	require.Nil(t, err)
+	require.Equal(t, []DataSegment{
+		{Offset: []byte{0x41, 0x08, 0x0B}, Data: []byte("hi")},
+		{Passive: true, Data: []byte("!")},
+	}, segments)
+}
+
+func TestDecodeCodeSection(t *testing.T) {
+	bodies, err := DecodeCodeSection([]byte{0x01, 0x06, 0x01, 0x02, 0x7F, 0x41, 0x00, 0x0B})
+	require.Nil(t, err)
+	require.Equal(t, []FunctionBody{{
+		Locals: []LocalEntry{{Count: 2, Type: ValueTypeI32}},
+		Code:   []byte{0x41, 0x00, 0x0B},
+	}}, bodies)
+}
+
+func TestReader_ReadInstruction(t *testing.T) {
+	reader := NewReader([]byte{
+		0x02, 0x40, // block
+		0x0E, 0x01, 0x00, 0x00, // br_table
+		0x28, 0x02, 0x10, // i32.load
+		0x44, 0, 0, 0, 0, 0, 0, 0, 0, // f64.const
+		0xFC, 0x0A, 0x00, 0x00, // memory.copy
+		0x0B, // end
+	})
+
+	var opcodes []byte
+	for !reader.IsAtEnd() {
+		instruction, err := reader.ReadInstruction()
+		require.Nil(t, err)
+		opcodes = append(opcodes, instruction.Opcode)
+	}
+	require.Equal(t, []byte{0x02, 0x0E, 0x28, 0x44, 0xFC, 0x0B}, opcodes)
+
+	instruction, err := NewReader([]byte{0xFD, 0x01}).ReadInstruction()
+	require.Equal(t, ErrUnsupportedFeature, err)
+	require.True(t, instruction.IsSIMD())
+}
+
+func TestReader_LEB(t *testing.T) {
+	value, err := NewReader([]byte{0xE5, 0x8E, 0x26}).ReadU32()
+	require.Nil(t, err)
+	require.Equal(t, uint32(624485), value)
+
+	_, err = NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x7F}).ReadU32()
+	require.Equal(t, ErrInvalidModule, err)
+
+	signed, err := NewReader([]byte{0x7F}).ReadS32()
+	require.Nil(t, err)
+	require.Equal(t, int32(-1), signed)
+
+	_, err = NewReader([]byte{0x80}).ReadS64()
+	require.Equal(t, ErrInvalidModule, err)
+}
@@ -0,0 +1,418 @@
+package wasmparser
+
+import (
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+const (
+	opcodeEnd            = 0x0B
+	opcodeMiscPrefix     = 0xFC
+	opcodeSIMDPrefix     = 0xFD
+	opcodeV128Const      = 0x0C
+	maxTruncSatSubOpcode = 7
+	blockTypeEmpty       = 0x40
+)
+
+// Instruction identifies a WASM instruction; SubOpcode is only set for the
+// instructions with the 0xFC and 0xFD prefixes
+type Instruction struct {
+	Opcode    byte
+	SubOpcode uint32
+}
+
+// IsEnd returns true if the instruction ends a block or an expression
+func (instruction Instruction) IsEnd() bool {
+	return instruction.Opcode == opcodeEnd
+}
+
+// IsMisc returns true if the instruction has the 0xFC prefix
+func (instruction Instruction) IsMisc() bool {
+	return instruction.Opcode == opcodeMiscPrefix
+}
+
+// IsSIMD returns true if the instruction has the 0xFD prefix
+func (instruction Instruction) IsSIMD() bool {
+	return instruction.Opcode == opcodeSIMDPrefix
+}
+
+// IsFloatingPoint returns true if the instruction operates on floating point
+// values; SIMD instructions are all considered floating point
+func (instruction Instruction) IsFloatingPoint() bool {
+	if instruction.IsSIMD() {
+		return true
+	}
+	if instruction.IsMisc() {
+		return instruction.SubOpcode <= maxTruncSatSubOpcode
+	}
+
+	opcode := instruction.Opcode
+	switch {
+	case opcode == 0x2A || opcode == 0x2B:
+		return true
+	case opcode == 0x38 || opcode == 0x39:
+		return true
+	case opcode == 0x43 || opcode == 0x44:
+		return true
+	case opcode >= 0x5B && opcode <= 0x66:
+		return true
+	case opcode >= 0x8B && opcode <= 0xA6:
+		return true
+	case opcode >= 0xA8 && opcode <= 0xBF:
+		return opcode != 0xAC && opcode != 0xAD
+	default:
+		return false
+	}
+}
+
+// Reader reads the primitive values and the instructions of the WASM binary format
+type Reader struct {
+	data     []byte
+	position int
+}
+
+// NewReader creates a Reader positioned at the start of the given data
+func NewReader(data []byte) *Reader {
+	return &Reader{data: data}
+}
+
+// IsAtEnd returns true if all the data was read
+func (reader *Reader) IsAtEnd() bool {
+	return reader.position >= len(reader.data)
+}
+
+// Position returns the number of bytes read so far
+func (reader *Reader) Position() int {
+	return reader.position
+}
+
+// Len returns the total length of the data
+func (reader *Reader) Len() int {
+	return len(reader.data)
+}
+
+// ReadByte reads one byte
+func (reader *Reader) ReadByte() (byte, error) {
+	b, err := reader.PeekByte()
+	if err != nil {
+		return 0, err
+	}
+
+	reader.position++
+	return b, nil
+}
+
+// PeekByte returns the next byte without reading it
+func (reader *Reader) PeekByte() (byte, error) {
+	if reader.IsAtEnd() {
+		return 0, ErrInvalidModule
+	}
+
+	return reader.data[reader.position], nil
+}
+
+// ReadBytes reads the given number of bytes
+func (reader *Reader) ReadBytes(length int) ([]byte, error) {
+	if length < 0 || length > len(reader.data)-reader.position {
+		return nil, ErrInvalidModule
+	}
+
+	result := reader.data[reader.position : reader.position+length]
+	reader.position += length
+	return result, nil
+}
+
+// ReadName reads a length-prefixed UTF-8 string
+func (reader *Reader) ReadName() (string, error) {
+	length, err := reader.ReadU32()
+	if err != nil {
+		return "", err
+	}
+
+	name, err := reader.ReadBytes(int(length))
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(name) {
+		return "", ErrInvalidModule
+	}
+
+	return string(name), nil
+}
+
+// ReadValueTypes reads a vector of value types, which are not checked
+func (reader *Reader) ReadValueTypes() ([]ValueType, error) {
+	count, err := reader.ReadU32()
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := reader.ReadBytes(int(count))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ValueType, len(types))
+	for i, vType := range types {
+		result[i] = ValueType(vType)
+	}
+
+	return result, nil
+}
+
+// ReadLimits reads the limits of a table or of a memory
+func (reader *Reader) ReadLimits() (Limits, error) {
+	flags, err := reader.ReadByte()
+	if err != nil {
+		return Limits{}, err
+	}
+	if flags > 3 {
+		return Limits{}, ErrInvalidModule
+	}
+
+	limits := Limits{Shared: flags&0x02 != 0}
+	limits.Min, err = reader.ReadU32()
+	if err != nil {
+		return Limits{}, err
+	}
+
+	limits.HasMax = flags&0x01 != 0
+	if limits.HasMax {
+		limits.Max, err = reader.ReadU32()
+		if err != nil {
+			return Limits{}, err
+		}
+	}
+
+	return limits, nil
+}
+
+// ReadU32 reads an unsigned LEB128 number of at most 32 bits
+func (reader *Reader) ReadU32() (uint32, error) {
+	value, err := reader.ReadUnsignedLEB(32)
+	return uint32(value), err
+}
+
+// ReadS32 reads a signed LEB128 number of at most 32 bits
+func (reader *Reader) ReadS32() (int32, error) {
+	value, err := reader.ReadSignedLEB(32)
+	return int32(value), err
+}
+
+// ReadS64 reads a signed LEB128 number of at most 64 bits
+func (reader *Reader) ReadS64() (int64, error) {
+	return reader.ReadSignedLEB(64)
+}
+
+// ReadFixedU32 reads a little endian 32 bits number
+func (reader *Reader) ReadFixedU32() (uint32, error) {
+	data, err := reader.ReadBytes(4)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint32(data), nil
+}
+
+// ReadFixedU64 reads a little endian 64 bits number
+func (reader *Reader) ReadFixedU64() (uint64, error) {
+	data, err := reader.ReadBytes(8)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint64(data), nil
+}
+
+// ReadUnsignedLEB reads an unsigned LEB128 number of at most maxBits bits
+func (reader *Reader) ReadUnsignedLEB(maxBits uint) (uint64, error) {
+	result := uint64(0)
+	for shift := uint(0); shift < maxBits+7; shift += 7 {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		result |= uint64(b&0x7F) << shift
+		if b&0x80 == 0 {
+			if maxBits < 64 && result>>maxBits != 0 {
+				return 0, ErrInvalidModule
+			}
+			return result, nil
+		}
+	}
+
+	return 0, ErrInvalidModule
+}
+
+// ReadSignedLEB reads a signed LEB128 number of at most maxBits bits
+func (reader *Reader) ReadSignedLEB(maxBits uint) (int64, error) {
+	result := int64(0)
+	shift := uint(0)
+	for shift < maxBits+7 {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		result |= int64(b&0x7F) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			if shift < 64 && b&0x40 != 0 {
+				result |= -1 << shift
+			}
+			if maxBits < 64 && (result < -(1<<(maxBits-1)) || result >= 1<<(maxBits-1)) {
+				return 0, ErrInvalidModule
+			}
+			return result, nil
+		}
+	}
+
+	return 0, ErrInvalidModule
+}
+
+// ReadInstruction reads one instruction and skips its immediates; among the
+// SIMD instructions, only v128.const can be skipped, the others are reported
+// with ErrUnsupportedFeature
+func (reader *Reader) ReadInstruction() (Instruction, error) {
+	opcode, err := reader.ReadByte()
+	if err != nil {
+		return Instruction{}, err
+	}
+
+	instruction := Instruction{Opcode: opcode}
+	switch {
+	case opcode >= 0x02 && opcode <= 0x04:
+		err = reader.skipBlockType()
+	case opcode == 0x0C || opcode == 0x0D:
+		_, err = reader.ReadU32()
+	case opcode == 0x0E:
+		err = reader.skipBranchTable()
+	case opcode == 0x10:
+		_, err = reader.ReadU32()
+	case opcode == 0x11:
+		err = reader.skipU32s(2)
+	case opcode == 0x1C:
+		_, err = reader.ReadValueTypes()
+	case opcode >= 0x20 && opcode <= 0x26:
+		_, err = reader.ReadU32()
+	case opcode >= 0x28 && opcode <= 0x3E:
+		err = reader.skipU32s(2)
+	case opcode == 0x3F || opcode == 0x40:
+		_, err = reader.ReadU32()
+	case opcode == 0x41 || opcode == 0x42:
+		err = reader.skipLEB()
+	case opcode == 0x43:
+		_, err = reader.ReadBytes(4)
+	case opcode == 0x44:
+		_, err = reader.ReadBytes(8)
+	case opcode == 0xD0:
+		_, err = reader.ReadByte()
+	case opcode == 0xD2:
+		_, err = reader.ReadU32()
+	case opcode == opcodeMiscPrefix:
+		instruction.SubOpcode, err = reader.skipMiscInstruction()
+	case opcode == opcodeSIMDPrefix:
+		instruction.SubOpcode, err = reader.skipSIMDInstruction()
+	}
+
+	return instruction, err
+}
+
+// ReadConstantExpression reads the instructions of a constant expression,
+// up to and including its end, and returns their bytes
+func (reader *Reader) ReadConstantExpression() ([]byte, error) {
+	start := reader.position
+	for {
+		instruction, err := reader.ReadInstruction()
+		if err != nil {
+			return nil, err
+		}
+		if instruction.IsEnd() {
+			return reader.data[start:reader.position], nil
+		}
+	}
+}
+
+func (reader *Reader) skipMiscInstruction() (uint32, error) {
+	subOpcode, err := reader.ReadU32()
+	if err != nil {
+		return 0, err
+	}
+
+	switch subOpcode {
+	case 8, 10, 12, 14:
+		err = reader.skipU32s(2)
+	case 9, 11, 13, 15, 16, 17:
+		_, err = reader.ReadU32()
+	}
+
+	return subOpcode, err
+}
+
+func (reader *Reader) skipSIMDInstruction() (uint32, error) {
+	subOpcode, err := reader.ReadU32()
+	if err != nil {
+		return 0, err
+	}
+	if subOpcode != opcodeV128Const {
+		return subOpcode, ErrUnsupportedFeature
+	}
+
+	_, err = reader.ReadBytes(16)
+	return subOpcode, err
+}
+
+func (reader *Reader) skipBlockType() error {
+	blockType, err := reader.PeekByte()
+	if err != nil {
+		return err
+	}
+	if blockType == blockTypeEmpty || isValueType(blockType) {
+		reader.position++
+		return nil
+	}
+
+	return reader.skipLEB()
+}
+
+func isValueType(valueType byte) bool {
+	return valueType >= 0x7B && valueType <= 0x7F || valueType == 0x70 || valueType == 0x6F
+}
+
+func (reader *Reader) skipBranchTable() error {
+	count, err := reader.ReadU32()
+	if err != nil {
+		return err
+	}
+
+	return reader.skipU32s(int(count) + 1)
+}
+
+func (reader *Reader) skipU32s(count int) error {
+	for i := 0; i < count; i++ {
+		_, err := reader.ReadU32()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// skipLEB skips a signed or unsigned LEB128 number, of at most 64 bits
+func (reader *Reader) skipLEB() error {
+	const maxLEBLength = 10
+
+	for i := 0; i < maxLEBLength; i++ {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b&0x80 == 0 {
+			return nil
+		}
+	}
+
+	return ErrInvalidModule
+}